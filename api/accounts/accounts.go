@@ -6,6 +6,7 @@
 package accounts
 
 import (
+	"fmt"
 	"math/big"
 	"net/http"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/transactions"
 	"github.com/vechain/thor/api/utils"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
@@ -21,18 +23,25 @@ import (
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
-	"github.com/vechain/thor/xenv"
 )
 
+// percentage added to the estimated gas by default
+const defaultGasMargin = 10
+
+// max number of storage keys proved in a request
+const maxProofKeys = 64
+
 type Accounts struct {
 	chain        *chain.Chain
 	stateCreator *state.Creator
+	forkConfig   thor.ForkConfig
 }
 
-func New(chain *chain.Chain, stateCreator *state.Creator) *Accounts {
+func New(chain *chain.Chain, stateCreator *state.Creator, forkConfig thor.ForkConfig) *Accounts {
 	return &Accounts{
 		chain,
 		stateCreator,
+		forkConfig,
 	}
 }
 
@@ -95,6 +104,28 @@ func (a *Accounts) getStorage(addr thor.Address, key thor.Bytes32, stateRoot tho
 	return storage, nil
 }
 
+// proofNodes collects proof nodes in order, without duplicates.
+type proofNodes struct {
+	seen  map[string]bool
+	nodes []string
+}
+
+func (p *proofNodes) Put(key, value []byte) error {
+	if !p.seen[string(key)] {
+		p.seen[string(key)] = true
+		p.nodes = append(p.nodes, hexutil.Encode(value))
+	}
+	return nil
+}
+
+func (a *Accounts) getProof(addr thor.Address, keys []thor.Bytes32, stateRoot thor.Bytes32) (*AccountProof, error) {
+	nodes := &proofNodes{seen: make(map[string]bool)}
+	if err := a.stateCreator.Prove(stateRoot, addr, keys, nodes); err != nil {
+		return nil, err
+	}
+	return &AccountProof{nodes.nodes}, nil
+}
+
 func (a *Accounts) sterilizeOptions(options *ContractCall) {
 	if options.Gas == 0 {
 		options.Gas = math.MaxUint64
@@ -112,6 +143,7 @@ func (a *Accounts) sterilizeOptions(options *ContractCall) {
 }
 
 //Call a contract with input
+//It's executed by the read-only runtime, so that changes of state are discarded.
 func (a *Accounts) Call(to *thor.Address, body *ContractCall, header *block.Header) (output *VMOutput, err error) {
 	a.sterilizeOptions(body)
 	state, err := a.stateCreator.NewState(header.StateRoot())
@@ -121,33 +153,179 @@ func (a *Accounts) Call(to *thor.Address, body *ContractCall, header *block.Head
 	v := big.Int(*body.Value)
 	data, err := hexutil.Decode(body.Data)
 	if err != nil {
-		return nil, err
+		return nil, utils.BadRequest(err, "data")
 	}
 	clause := tx.NewClause(to).WithData(data).WithValue(&v)
-	gp := (*big.Int)(body.GasPrice)
-	signer, _ := header.Signer()
-	rt := runtime.New(a.chain.NewSeeker(header.ParentID()), state,
-		&xenv.BlockContext{
-			Beneficiary: header.Beneficiary(),
-			Signer:      signer,
-			Number:      header.Number(),
-			Time:        header.Timestamp(),
-			GasLimit:    header.GasLimit(),
-			TotalScore:  header.TotalScore()})
+	result, err := runtime.Call(
+		a.chain.NewSeeker(header.ParentID()),
+		state,
+		header,
+		clause,
+		body.Caller,
+		body.Gas,
+		(*big.Int)(body.GasPrice),
+		a.forkConfig)
+	if err != nil {
+		return nil, err
+	}
+	return convertCallResult(result), nil
+}
 
-	vmout := rt.ExecuteClause(clause, 0, body.Gas, &xenv.TransactionContext{
-		Origin:     body.Caller,
-		GasPrice:   gp,
-		ProvedWork: &big.Int{}})
+//BatchCall executes clauses in sequence upon one state
+//The execution stops at the first reverted clause, and changes of state are discarded.
+func (a *Accounts) BatchCall(body *BatchCallData, header *block.Header) (results BatchCallResults, err error) {
+	if body.Gas == 0 {
+		body.Gas = math.MaxUint64
+	}
+	if body.GasPrice == nil {
+		body.GasPrice = &math.HexOrDecimal256{}
+	}
+	clauses, err := convertClauses(body.Clauses)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := rt.Seeker().Err(); err != nil {
+	state, err := a.stateCreator.NewState(header.StateRoot())
+	if err != nil {
 		return nil, err
 	}
-	if err := state.Err(); err != nil {
+	if err := applyStateOverrides(state, body.StateOverrides, header.Timestamp()); err != nil {
 		return nil, err
 	}
-	return convertVMOutputWithInputGas(vmout, body.Gas), nil
 
+	callResults, err := runtime.CallBatch(
+		a.chain.NewSeeker(header.ParentID()),
+		state,
+		header,
+		clauses,
+		body.Caller,
+		body.Gas,
+		(*big.Int)(body.GasPrice),
+		a.forkConfig)
+	if err != nil {
+		return nil, err
+	}
+	results = make(BatchCallResults, 0, len(callResults))
+	for _, result := range callResults {
+		results = append(results, convertCallResult(result))
+	}
+	return results, nil
+}
+
+//EstimateGas searches the minimal gas for a tx of the clauses to succeed
+func (a *Accounts) EstimateGas(body *EstimateGasData, header *block.Header) (*GasEstimation, error) {
+	gasCap := header.GasLimit()
+	if body.Gas > gasCap {
+		return nil, utils.BadRequest(errors.Errorf("exceeds block gas limit %v", gasCap), "gas")
+	}
+	if body.Gas > 0 {
+		gasCap = body.Gas
+	}
+	margin := uint64(defaultGasMargin)
+	if body.Margin != nil {
+		margin = *body.Margin
+	}
+	clauses, err := convertClauses(body.Clauses)
+	if err != nil {
+		return nil, err
+	}
+	intrinsicGas, err := tx.IntrinsicGas(clauses...)
+	if err != nil {
+		return nil, utils.BadRequest(err, "clauses")
+	}
+	if gasCap < intrinsicGas {
+		return nil, utils.BadRequest(errors.New("less than intrinsic gas"), "gas")
+	}
+
+	state, err := a.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	if err := applyStateOverrides(state, body.StateOverrides, header.Timestamp()); err != nil {
+		return nil, err
+	}
+
+	gas, failure, err := runtime.EstimateGas(
+		a.chain.NewSeeker(header.ParentID()),
+		state,
+		header,
+		clauses,
+		body.Caller,
+		(*big.Int)(body.GasPrice),
+		gasCap,
+		a.forkConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	estimation := &GasEstimation{IntrinsicGas: intrinsicGas}
+	if failure != nil {
+		estimation.Reverted = true
+		estimation.VMError = failure.VMErr.Error()
+		estimation.RevertReason = failure.RevertReason
+		return estimation, nil
+	}
+
+	// gas * (100 + margin) / 100, capped by gasCap
+	recommended := new(big.Int).SetUint64(gas)
+	recommended.Mul(recommended, new(big.Int).SetUint64(100+margin))
+	recommended.Div(recommended, big.NewInt(100))
+	if !recommended.IsUint64() || recommended.Uint64() > gasCap {
+		recommended.SetUint64(gasCap)
+	}
+
+	estimation.Gas = gas
+	estimation.RecommendedGas = recommended.Uint64()
+	return estimation, nil
+}
+
+func convertClauses(jsonClauses transactions.Clauses) ([]*tx.Clause, error) {
+	clauses := make([]*tx.Clause, 0, len(jsonClauses))
+	for i, c := range jsonClauses {
+		data, err := hexutil.Decode(c.Data)
+		if err != nil {
+			return nil, utils.BadRequest(err, fmt.Sprintf("clauses[%d].data", i))
+		}
+		v := big.Int(c.Value)
+		clauses = append(clauses, tx.NewClause(c.To).WithData(data).WithValue(&v))
+	}
+	return clauses, nil
+}
+
+func applyStateOverrides(state *state.State, overrides map[string]*AccountOverride, blockTime uint64) error {
+	for hexAddr, override := range overrides {
+		addr, err := thor.ParseAddress(hexAddr)
+		if err != nil {
+			return utils.BadRequest(err, "stateOverrides")
+		}
+		if override == nil {
+			continue
+		}
+		if override.Balance != nil {
+			state.SetBalance(addr, (*big.Int)(override.Balance))
+		}
+		if override.Energy != nil {
+			state.SetEnergy(addr, (*big.Int)(override.Energy), blockTime)
+		}
+		if override.Code != nil {
+			code, err := hexutil.Decode(*override.Code)
+			if err != nil {
+				return utils.BadRequest(err, "stateOverrides.code")
+			}
+			state.SetCode(addr, code)
+		}
+		for hexKey, value := range override.Storage {
+			key, err := thor.ParseBytes32(hexKey)
+			if err != nil {
+				return utils.BadRequest(err, "stateOverrides.storage")
+			}
+			if value == nil {
+				value = &thor.Bytes32{}
+			}
+			state.SetStorage(addr, key, *value)
+		}
+	}
+	return state.Err()
 }
 
 func (a *Accounts) handleGetAccount(w http.ResponseWriter, req *http.Request) error {
@@ -186,10 +364,38 @@ func (a *Accounts) handleGetStorage(w http.ResponseWriter, req *http.Request) er
 	return utils.WriteJSON(w, map[string]string{"value": storage.String()})
 }
 
+func (a *Accounts) handleGetProof(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	query := req.URL.Query()
+	if len(query["key"]) > maxProofKeys {
+		return utils.BadRequest(errors.Errorf("exceeds limit %v", maxProofKeys), "key")
+	}
+	keys := make([]thor.Bytes32, 0, len(query["key"]))
+	for _, s := range query["key"] {
+		key, err := thor.ParseBytes32(s)
+		if err != nil {
+			return utils.BadRequest(err, "key")
+		}
+		keys = append(keys, key)
+	}
+	h, err := a.getBlockHeader(query.Get("revision"))
+	if err != nil {
+		return err
+	}
+	proof, err := a.getProof(addr, keys, h.StateRoot())
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, proof)
+}
+
 func (a *Accounts) handleCallContract(w http.ResponseWriter, req *http.Request) error {
 	callBody := &ContractCall{}
 	if err := utils.ParseJSON(req.Body, &callBody); err != nil {
-		return err
+		return utils.BadRequest(err, "body")
 	}
 	req.Body.Close()
 	h, err := a.getBlockHeader(req.URL.Query().Get("revision"))
@@ -213,22 +419,76 @@ func (a *Accounts) handleCallContract(w http.ResponseWriter, req *http.Request)
 	return utils.WriteJSON(w, output)
 }
 
+func (a *Accounts) handleBatchCall(w http.ResponseWriter, req *http.Request) error {
+	batchCallData := &BatchCallData{}
+	if err := utils.ParseJSON(req.Body, &batchCallData); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	req.Body.Close()
+	h, err := a.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	results, err := a.BatchCall(batchCallData, h)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, results)
+}
+
+func (a *Accounts) handleEstimateGas(w http.ResponseWriter, req *http.Request) error {
+	estimateGasData := &EstimateGasData{}
+	if err := utils.ParseJSON(req.Body, &estimateGasData); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	req.Body.Close()
+	h, err := a.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	estimation, err := a.EstimateGas(estimateGasData, h)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, estimation)
+}
+
+// getBlockHeader returns the header selected by revision, which can be a block number,
+// a block ID, "best" or "finalized".
 func (a *Accounts) getBlockHeader(revision string) (*block.Header, error) {
 	if revision == "" || revision == "best" {
 		return a.chain.BestBlock().Header(), nil
 	}
-	blkID, err := thor.ParseBytes32(revision)
-	if err != nil {
-		n, err := strconv.ParseUint(revision, 0, 0)
-		if err != nil {
-			return nil, err
+	if revision == "finalized" {
+		return a.chain.FinalizedBlock(), nil
+	}
+	var (
+		header *block.Header
+		err    error
+	)
+	if blkID, e := thor.ParseBytes32(revision); e == nil {
+		header, err = a.chain.GetBlockHeader(blkID)
+	} else {
+		n, e := strconv.ParseUint(revision, 0, 0)
+		if e != nil {
+			return nil, utils.BadRequest(e, "revision")
 		}
 		if n > math.MaxUint32 {
 			return nil, utils.BadRequest(errors.New("block number exceeded"), "revision")
 		}
-		return a.chain.GetTrunkBlockHeader(uint32(n))
+		header, err = a.chain.GetTrunkBlockHeader(uint32(n))
 	}
-	return a.chain.GetBlockHeader(blkID)
+	if err != nil {
+		if a.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.New("block not found"), "revision")
+		}
+		return nil, err
+	}
+	// states of the best and finalized blocks are always retained
+	if err := utils.CheckState(a.chain, a.stateCreator, header); err != nil {
+		return nil, err
+	}
+	return header, nil
 }
 
 func (a *Accounts) Mount(root *mux.Router, pathPrefix string) {
@@ -242,9 +502,17 @@ func (a *Accounts) Mount(root *mux.Router, pathPrefix string) {
 	sub.Path("/{address}/storage/{key}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetStorage))
 	sub.Path("/{address}/storage/{key}").Queries("revision", "{revision}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetStorage))
 
+	sub.Path("/{address}/proof").Methods(http.MethodGet).HandlerFunc(utils.WrapHandlerFunc(a.handleGetProof))
+
 	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleCallContract))
 	sub.Path("").Queries("revision", "{revision}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleCallContract))
 
+	sub.Path("/*").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleBatchCall))
+	sub.Path("/*").Queries("revision", "{revision}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleBatchCall))
+
+	sub.Path("/*/estimate").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleEstimateGas))
+	sub.Path("/*/estimate").Queries("revision", "{revision}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleEstimateGas))
+
 	sub.Path("/{address}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleCallContract))
 	sub.Path("/{address}").Queries("revision", "{revision}").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleCallContract))
 
@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	ABI "github.com/vechain/thor/abi"
 	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/transactions"
+	"github.com/vechain/thor/builtin"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/genesis"
 	"github.com/vechain/thor/lvldb"
@@ -89,6 +91,8 @@ var storageValue = byte(1)
 
 var contractAddr thor.Address
 
+var bestStateRoot thor.Bytes32
+
 var bytecode = common.Hex2Bytes("608060405234801561001057600080fd5b50610125806100206000396000f3006080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806324b8ba5f14604e578063bb4e3f4d14607b575b600080fd5b348015605957600080fd5b506079600480360381019080803560ff16906020019092919050505060cf565b005b348015608657600080fd5b5060b3600480360381019080803560ff169060200190929190803560ff16906020019092919050505060ec565b604051808260ff1660ff16815260200191505060405180910390f35b806000806101000a81548160ff021916908360ff16021790555050565b60008183019050929150505600a165627a7a723058201584add23e31d36c569b468097fe01033525686b59bbb263fb3ab82e9553dae50029")
 
 var runtimeBytecode = common.Hex2Bytes("6080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806324b8ba5f14604e578063bb4e3f4d14607b575b600080fd5b348015605957600080fd5b506079600480360381019080803560ff16906020019092919050505060cf565b005b348015608657600080fd5b5060b3600480360381019080803560ff169060200190929190803560ff16906020019092919050505060ec565b604051808260ff1660ff16815260200191505060405180910390f35b806000806101000a81548160ff021916908360ff16021790555050565b60008183019050929150505600a165627a7a723058201584add23e31d36c569b468097fe01033525686b59bbb263fb3ab82e9553dae50029")
@@ -99,8 +103,13 @@ func TestAccount(t *testing.T) {
 	initAccountServer(t)
 	defer ts.Close()
 	getAccount(t)
+	getAccountWithRevision(t)
+	getAccountProof(t)
 	deployContractWithCall(t)
 	callContract(t)
+	callContractReverted(t)
+	batchCall(t)
+	estimateGas(t)
 }
 
 func getAccount(t *testing.T) {
@@ -135,6 +144,57 @@ func getAccount(t *testing.T) {
 
 }
 
+func getAccountWithRevision(t *testing.T) {
+	for revision, balance := range map[string]*big.Int{
+		"0":         big.NewInt(0),
+		"1":         value,
+		"best":      value,
+		"finalized": big.NewInt(0),
+	} {
+		res, status := httpGetWithStatus(t, ts.URL+"/accounts/"+addr.String()+"?revision="+revision)
+		assert.Equal(t, http.StatusOK, status, revision)
+		var acc accounts.Account
+		if err := json.Unmarshal(res, &acc); err != nil {
+			t.Fatal(err)
+		}
+		// compared by value, since zero decoded may differ from big.NewInt(0) in representation
+		assert.Equal(t, 0, balance.Cmp((*big.Int)(&acc.Balance)), revision)
+	}
+
+	for _, revision := range []string{"100", "bad", thor.Bytes32{}.String()} {
+		_, status := httpGetWithStatus(t, ts.URL+"/accounts/"+addr.String()+"?revision="+revision)
+		assert.Equal(t, http.StatusBadRequest, status, revision)
+	}
+}
+
+func getAccountProof(t *testing.T) {
+	res := httpGet(t, ts.URL+"/accounts/"+contractAddr.String()+"/proof?key="+storageKey.String())
+	var proof accounts.AccountProof
+	if err := json.Unmarshal(res, &proof); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, proof.Nodes)
+
+	proofDB, _ := lvldb.NewMem()
+	for _, node := range proof.Nodes {
+		data, err := hexutil.Decode(node)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := thor.Blake2b(data)
+		proofDB.Put(hash[:], data)
+	}
+	st, err := state.New(bestStateRoot, proofDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, thor.BytesToBytes32([]byte{storageValue}), st.GetStorage(contractAddr, storageKey), "storage should be proved")
+	assert.Nil(t, st.Err())
+
+	_, status := httpGetWithStatus(t, ts.URL+"/accounts/"+contractAddr.String()+"/proof?key=bad")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
 func initAccountServer(t *testing.T) {
 	db, _ := lvldb.NewMem()
 	stateC := state.NewCreator(db)
@@ -163,12 +223,46 @@ func initAccountServer(t *testing.T) {
 	claCall := tx.NewClause(&contractAddr).WithData(input)
 	transactionCall := buildTxWithClauses(t, chain.Tag(), claCall)
 	packTx(chain, stateC, transactionCall, t)
+	bestStateRoot = chain.BestBlock().Header().StateRoot()
 
 	router := mux.NewRouter()
-	accounts.New(chain, stateC).Mount(router, "/accounts")
+	accounts.New(chain, stateC, thor.NoFork).Mount(router, "/accounts")
 	ts = httptest.NewServer(router)
 }
 
+func TestAccountPruned(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, _ := genesis.NewDevnet()
+	b0, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, _ := chain.New(db, b0)
+	packTx(chain, stateC, buildTxWithClauses(t, chain.Tag(), tx.NewClause(&addr).WithValue(value)), t)
+
+	router := mux.NewRouter()
+	accounts.New(chain, stateC, thor.NoFork).Mount(router, "/accounts")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	// state of genesis block pruned
+	root := b0.Header().StateRoot()
+	if err := db.Delete(root[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.SetPrunedBelow(1); err != nil {
+		t.Fatal(err)
+	}
+
+	_, status := httpGetWithStatus(t, ts.URL+"/accounts/"+addr.String()+"?revision=0")
+	assert.Equal(t, http.StatusGone, status)
+	_, status = httpGetWithStatus(t, ts.URL+"/accounts/"+addr.String()+"/code?revision=0")
+	assert.Equal(t, http.StatusGone, status)
+	_, status = httpGetWithStatus(t, ts.URL+"/accounts/"+addr.String()+"?revision=1")
+	assert.Equal(t, http.StatusOK, status)
+}
+
 func buildTxWithClauses(t *testing.T, chaiTag byte, clauses ...*tx.Clause) *tx.Transaction {
 	builder := new(tx.Builder).
 		ChainTag(chaiTag).
@@ -188,7 +282,7 @@ func buildTxWithClauses(t *testing.T, chaiTag byte, clauses ...*tx.Clause) *tx.T
 
 func packTx(chain *chain.Chain, stateC *state.Creator, transaction *tx.Transaction, t *testing.T) {
 	b := chain.BestBlock()
-	packer := packer.New(chain, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address)
+	packer := packer.New(chain, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
 	flow, err := packer.Schedule(b.Header(), uint64(time.Now().Unix()))
 	err = flow.Adopt(transaction)
 	if err != nil {
@@ -262,6 +356,146 @@ func callContract(t *testing.T) {
 	assert.Equal(t, a+b, ret, "should be equal")
 }
 
+func callContractReverted(t *testing.T) {
+	// only the executor is allowed to set params
+	m, _ := builtin.Params.ABI.MethodByName("set")
+	input, err := m.EncodeInput(thor.BytesToBytes32([]byte("key")), big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqBodyBytes, err := json.Marshal(&accounts.ContractCall{
+		Data: hexutil.Encode(input),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := httpPost(t, ts.URL+"/accounts/"+builtin.Params.Address.String(), reqBodyBytes)
+	var output *accounts.VMOutput
+	if err = json.Unmarshal(response, &output); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, output.Reverted)
+	assert.Equal(t, "evm: execution reverted", output.VMError)
+	assert.Equal(t, "builtin: executor required", output.RevertReason)
+	assert.NotZero(t, output.GasUsed)
+}
+
+func batchCall(t *testing.T) {
+	m, _ := ABI.New([]byte(abiJSON))
+	add, _ := m.MethodByName("add")
+	input, err := add.EncodeInput(uint8(1), uint8(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caller := thor.BytesToAddress([]byte("caller"))
+	to := thor.BytesToAddress([]byte("to"))
+	clauses := transactions.Clauses{
+		{To: &to, Value: math.HexOrDecimal256(*big.NewInt(10)), Data: "0x"},
+		{To: &contractAddr, Data: hexutil.Encode(input)},
+	}
+
+	// caller has no balance
+	reqBodyBytes, err := json.Marshal(&accounts.BatchCallData{
+		Clauses: clauses,
+		Caller:  caller,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results accounts.BatchCallResults
+	if err := json.Unmarshal(httpPost(t, ts.URL+"/accounts/*", reqBodyBytes), &results); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(results))
+	assert.True(t, results[0].Reverted)
+
+	// with balance overridden
+	balance := math.HexOrDecimal256(*big.NewInt(100))
+	reqBodyBytes, err = json.Marshal(&accounts.BatchCallData{
+		Clauses: clauses,
+		Caller:  caller,
+		StateOverrides: map[string]*accounts.AccountOverride{
+			caller.String(): {Balance: &balance},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(httpPost(t, ts.URL+"/accounts/*", reqBodyBytes), &results); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(results))
+	assert.False(t, results[0].Reverted)
+	assert.Equal(t, 1, len(results[0].Transfers))
+	assert.False(t, results[1].Reverted)
+
+	data, err := hexutil.Decode(results[1].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ret uint8
+	if err := add.DecodeOutput(data, &ret); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint8(3), ret)
+}
+
+func estimateGas(t *testing.T) {
+	m, _ := ABI.New([]byte(abiJSON))
+	set, _ := m.MethodByName("set")
+	input, err := set.EncodeInput(uint8(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	margin := uint64(20)
+	reqBodyBytes, err := json.Marshal(&accounts.EstimateGasData{
+		Clauses: transactions.Clauses{{To: &contractAddr, Data: hexutil.Encode(input)}},
+		Margin:  &margin,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var estimation accounts.GasEstimation
+	if err := json.Unmarshal(httpPost(t, ts.URL+"/accounts/*/estimate", reqBodyBytes), &estimation); err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, estimation.Reverted)
+	assert.True(t, estimation.Gas > estimation.IntrinsicGas)
+	assert.Equal(t, estimation.Gas*120/100, estimation.RecommendedGas)
+
+	// reverted with any gas
+	params, _ := builtin.Params.ABI.MethodByName("set")
+	input, err = params.EncodeInput(thor.BytesToBytes32([]byte("key")), big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqBodyBytes, err = json.Marshal(&accounts.EstimateGasData{
+		Clauses: transactions.Clauses{{To: &builtin.Params.Address, Data: hexutil.Encode(input)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(httpPost(t, ts.URL+"/accounts/*/estimate", reqBodyBytes), &estimation); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, estimation.Reverted)
+	assert.Zero(t, estimation.Gas)
+	assert.Equal(t, "builtin: executor required", estimation.RevertReason)
+
+	// gas above the block gas limit
+	reqBodyBytes, err = json.Marshal(&accounts.EstimateGasData{
+		Clauses: transactions.Clauses{{To: &contractAddr}},
+		Gas:     math.MaxUint64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, status := httpPostWithStatus(t, ts.URL+"/accounts/*/estimate", reqBodyBytes)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
 func httpPost(t *testing.T, url string, data []byte) []byte {
 	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
 	if err != nil {
@@ -275,6 +509,19 @@ func httpPost(t *testing.T, url string, data []byte) []byte {
 	return r
 }
 
+func httpPostWithStatus(t *testing.T, url string, data []byte) ([]byte, int) {
+	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, res.StatusCode
+}
+
 func httpGet(t *testing.T, url string) []byte {
 	res, err := http.Get(url)
 	if err != nil {
@@ -287,3 +534,16 @@ func httpGet(t *testing.T, url string) []byte {
 	}
 	return r
 }
+
+func httpGetWithStatus(t *testing.T, url string) ([]byte, int) {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, res.StatusCode
+}
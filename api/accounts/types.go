@@ -20,6 +20,12 @@ type Account struct {
 	HasCode bool                 `json:"hasCode"`
 }
 
+//AccountProof trie nodes proving the account and requested storage values, to be verified
+//against the state root of the block, by looking up the hashed address and keys
+type AccountProof struct {
+	Nodes []string `json:"nodes"`
+}
+
 //ContractCall represents contract-call body
 type ContractCall struct {
 	Value    *math.HexOrDecimal256 `json:"value,string"`
@@ -29,31 +35,77 @@ type ContractCall struct {
 	Caller   thor.Address          `json:"caller"`
 }
 
+//BatchCallData represents batch-call body
+//Clauses are executed in sequence upon one state, which is patched by StateOverrides in advance.
+type BatchCallData struct {
+	Clauses        transactions.Clauses        `json:"clauses"`
+	Gas            uint64                      `json:"gas"`
+	GasPrice       *math.HexOrDecimal256       `json:"gasPrice,string"`
+	Caller         thor.Address                `json:"caller"`
+	StateOverrides map[string]*AccountOverride `json:"stateOverrides"`
+}
+
+//AccountOverride fields to be overridden of an account, nil fields are left unchanged
+type AccountOverride struct {
+	Balance *math.HexOrDecimal256    `json:"balance,string"`
+	Energy  *math.HexOrDecimal256    `json:"energy,string"`
+	Code    *string                  `json:"code"`
+	Storage map[string]*thor.Bytes32 `json:"storage"`
+}
+
+//BatchCallResults results of batch-call
+type BatchCallResults []*VMOutput
+
+//EstimateGasData represents estimate-gas body
+//Gas is the cap of searching, defaults to the block gas limit, and Margin is the percentage added to
+//the estimated gas for recommendation, defaults to 10.
+type EstimateGasData struct {
+	Clauses        transactions.Clauses        `json:"clauses"`
+	Gas            uint64                      `json:"gas"`
+	GasPrice       *math.HexOrDecimal256       `json:"gasPrice,string"`
+	Caller         thor.Address                `json:"caller"`
+	StateOverrides map[string]*AccountOverride `json:"stateOverrides"`
+	Margin         *uint64                     `json:"margin"`
+}
+
+//GasEstimation result of estimate-gas
+//Gas is zero if clauses fail even with the gas cap, and the failure is described by other fields.
+type GasEstimation struct {
+	Gas            uint64 `json:"gas"`
+	RecommendedGas uint64 `json:"recommendedGas"`
+	IntrinsicGas   uint64 `json:"intrinsicGas"`
+	Reverted       bool   `json:"reverted"`
+	VMError        string `json:"vmError"`
+	RevertReason   string `json:"revertReason"`
+}
+
+//VMOutput result of contract-call.
+//Data is the revert data if reverted, and RevertReason is decoded from it if in form of 'Error(string)'.
 type VMOutput struct {
-	Data      string                   `json:"data"`
-	Events    []*transactions.Event    `json:"events"`
-	Transfers []*transactions.Transfer `json:"transfers"`
-	GasUsed   uint64                   `json:"gasUsed"`
-	Reverted  bool                     `json:"reverted"`
-	VMError   string                   `json:"vmError"`
+	Data         string                   `json:"data"`
+	Events       []*transactions.Event    `json:"events"`
+	Transfers    []*transactions.Transfer `json:"transfers"`
+	GasUsed      uint64                   `json:"gasUsed"`
+	Reverted     bool                     `json:"reverted"`
+	VMError      string                   `json:"vmError"`
+	RevertReason string                   `json:"revertReason"`
 }
 
-func convertVMOutputWithInputGas(vo *runtime.Output, inputGas uint64) *VMOutput {
-	gasUsed := inputGas - vo.LeftOverGas
+func convertCallResult(result *runtime.CallResult) *VMOutput {
 	var (
 		vmError  string
 		reverted bool
 	)
 
-	if vo.VMErr != nil {
+	if result.VMErr != nil {
 		reverted = true
-		vmError = vo.VMErr.Error()
+		vmError = result.VMErr.Error()
 	}
 
-	events := make([]*transactions.Event, len(vo.Events))
-	transfers := make([]*transactions.Transfer, len(vo.Transfers))
+	events := make([]*transactions.Event, len(result.Events))
+	transfers := make([]*transactions.Transfer, len(result.Transfers))
 
-	for j, txEvent := range vo.Events {
+	for j, txEvent := range result.Events {
 		event := &transactions.Event{
 			Address: txEvent.Address,
 			Data:    hexutil.Encode(txEvent.Data),
@@ -64,7 +116,7 @@ func convertVMOutputWithInputGas(vo *runtime.Output, inputGas uint64) *VMOutput
 		}
 		events[j] = event
 	}
-	for j, txTransfer := range vo.Transfers {
+	for j, txTransfer := range result.Transfers {
 		transfer := &transactions.Transfer{
 			Sender:    txTransfer.Sender,
 			Recipient: txTransfer.Recipient,
@@ -73,19 +125,13 @@ func convertVMOutputWithInputGas(vo *runtime.Output, inputGas uint64) *VMOutput
 		transfers[j] = transfer
 	}
 
-	var data string
-	if reverted {
-		data = string(vo.Data)
-	} else {
-		data = hexutil.Encode(vo.Data)
-	}
-
 	return &VMOutput{
-		Data:      data,
-		Events:    events,
-		Transfers: transfers,
-		GasUsed:   gasUsed,
-		Reverted:  reverted,
-		VMError:   vmError,
+		Data:         hexutil.Encode(result.Data),
+		Events:       events,
+		Transfers:    transfers,
+		GasUsed:      result.GasUsed,
+		Reverted:     reverted,
+		VMError:      vmError,
+		RevertReason: result.RevertReason,
 	}
 }
@@ -0,0 +1,288 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/node"
+	"github.com/vechain/thor/api/profiling"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/logging"
+	"github.com/vechain/thor/txpool"
+)
+
+// Admin serves node management requests, which should never be exposed publicly.
+type Admin struct {
+	peers     PeerManager
+	nw        Network
+	chain     *chain.Chain
+	txPool    *txpool.TxPool
+	logDB     *logdb.LogDB
+	logFilter *logging.Filter
+	profiler  *profiling.Profiler
+	version   string
+
+	reindexLock sync.Mutex
+	reindex     *ReindexStatus // status of the last reindex job, nil if never started
+}
+
+func New(peers PeerManager, nw Network, chain *chain.Chain, txPool *txpool.TxPool, logDB *logdb.LogDB, logFilter *logging.Filter, profiler *profiling.Profiler, version string) *Admin {
+	return &Admin{
+		peers:     peers,
+		nw:        nw,
+		chain:     chain,
+		txPool:    txPool,
+		logDB:     logDB,
+		logFilter: logFilter,
+		profiler:  profiler,
+		version:   version,
+	}
+}
+
+// RequireToken wraps the handler, to reject requests without the bearer token.
+func RequireToken(token string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+func (a *Admin) handleGetPeers(w http.ResponseWriter, req *http.Request) error {
+	peers := node.ConvertPeersStats(a.nw.PeersStats())
+	if peers == nil {
+		peers = []*node.PeerStats{}
+	}
+	return utils.WriteJSON(w, peers)
+}
+
+func (a *Admin) handleGetStaticPeers(w http.ResponseWriter, req *http.Request) error {
+	peers := convertNodes(a.peers.StaticNodes())
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Enode < peers[j].Enode
+	})
+	return utils.WriteJSON(w, peers)
+}
+
+func (a *Admin) handleGetTrustedPeers(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, convertNodes(a.peers.TrustedNodes()))
+}
+
+func (a *Admin) parseNode(req *http.Request) (*discover.Node, error) {
+	var peer Peer
+	if err := utils.ParseJSON(req.Body, &peer); err != nil {
+		return nil, utils.BadRequest(err, "body")
+	}
+	node, err := discover.ParseNode(peer.Enode)
+	if err != nil {
+		return nil, utils.BadRequest(err, "enode")
+	}
+	return node, nil
+}
+
+func (a *Admin) handleAddStaticPeer(w http.ResponseWriter, req *http.Request) error {
+	node, err := a.parseNode(req)
+	if err != nil {
+		return err
+	}
+	a.peers.AddStatic(node)
+	return utils.WriteJSON(w, &Peer{node.String()})
+}
+
+func (a *Admin) handleRemoveStaticPeer(w http.ResponseWriter, req *http.Request) error {
+	node, err := a.parseNode(req)
+	if err != nil {
+		return err
+	}
+	a.peers.RemoveStatic(node)
+	return utils.WriteJSON(w, &Peer{node.String()})
+}
+
+func (a *Admin) handleGetNodeInfo(w http.ResponseWriter, req *http.Request) error {
+	var synced bool
+	select {
+	case <-a.nw.Synced():
+		synced = true
+	default:
+	}
+	return utils.WriteJSON(w, &NodeInfo{
+		Version:   a.version,
+		Enode:     a.peers.Self().String(),
+		GenesisID: a.chain.GenesisBlock().Header().ID(),
+		BestBlock: convertBlockSummary(a.chain.BestBlock().Header()),
+		Synced:    synced,
+		PeerCount: len(a.nw.PeersStats()),
+	})
+}
+
+func (a *Admin) handleGetPoolStats(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, convertPoolStats(a.txPool.Stats()))
+}
+
+func (a *Admin) handleGetTxGossip(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, &Gossip{a.nw.TxGossip()})
+}
+
+func (a *Admin) handleSetTxGossip(w http.ResponseWriter, req *http.Request) error {
+	var gossip Gossip
+	if err := utils.ParseJSON(req.Body, &gossip); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	a.nw.SetTxGossip(gossip.Enabled)
+	return utils.WriteJSON(w, &gossip)
+}
+
+func (a *Admin) reindexStatus() *ReindexStatus {
+	a.reindexLock.Lock()
+	defer a.reindexLock.Unlock()
+	if a.reindex == nil {
+		return &ReindexStatus{}
+	}
+	status := *a.reindex
+	return &status
+}
+
+func (a *Admin) handleGetReindex(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, a.reindexStatus())
+}
+
+// handleStartReindex starts a job to rebuild logs in background, while the node keeps running.
+func (a *Admin) handleStartReindex(w http.ResponseWriter, req *http.Request) error {
+	var reindex Reindex
+	if err := utils.ParseJSON(req.Body, &reindex); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if reindex.From > a.chain.BestBlock().Header().Number() {
+		return utils.BadRequest(errors.New("exceeds best block"), "from")
+	}
+
+	a.reindexLock.Lock()
+	if a.reindex != nil && a.reindex.Running {
+		a.reindexLock.Unlock()
+		return utils.HTTPError(errors.New("reindex already running"), http.StatusConflict)
+	}
+	status := &ReindexStatus{
+		Running: true,
+		From:    reindex.From,
+		Current: reindex.From,
+		Target:  a.chain.BestBlock().Header().Number(),
+	}
+	a.reindex = status
+	a.reindexLock.Unlock()
+
+	go func() {
+		err := a.logDB.Reindex(context.Background(), a.chain, reindex.From, func(num, target uint32) {
+			a.reindexLock.Lock()
+			status.Current, status.Target = num, target
+			a.reindexLock.Unlock()
+		})
+
+		a.reindexLock.Lock()
+		defer a.reindexLock.Unlock()
+		status.Running = false
+		if err != nil {
+			status.Error = err.Error()
+		}
+	}()
+	return utils.WriteJSON(w, a.reindexStatus())
+}
+
+func (a *Admin) handleGetLogLevels(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, convertLogLevels(a.logFilter))
+}
+
+// handleSetLogLevels sets the default level if given, and levels of subsystems, where
+// empty ones are cleared to follow the default level.
+func (a *Admin) handleSetLogLevels(w http.ResponseWriter, req *http.Request) error {
+	var levels LogLevels
+	if err := utils.ParseJSON(req.Body, &levels); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	var defaultLevel log15.Lvl
+	if levels.Default != "" {
+		lvl, err := logging.ParseLevel(levels.Default)
+		if err != nil {
+			return utils.BadRequest(err, "default")
+		}
+		defaultLevel = lvl
+	}
+	pkgLevels := make(map[string]log15.Lvl, len(levels.Pkgs))
+	for pkg, str := range levels.Pkgs {
+		if str == "" {
+			continue
+		}
+		lvl, err := logging.ParseLevel(str)
+		if err != nil {
+			return utils.BadRequest(err, "pkgs")
+		}
+		pkgLevels[pkg] = lvl
+	}
+
+	// applied after all parsed
+	if levels.Default != "" {
+		a.logFilter.SetLevel(defaultLevel)
+	}
+	for pkg, str := range levels.Pkgs {
+		if str == "" {
+			a.logFilter.ClearPkgLevel(pkg)
+		} else {
+			a.logFilter.SetPkgLevel(pkg, pkgLevels[pkg])
+		}
+	}
+	return utils.WriteJSON(w, convertLogLevels(a.logFilter))
+}
+
+func (a *Admin) handleGetProfiling(w http.ResponseWriter, req *http.Request) error {
+	config := a.profiler.Config()
+	return utils.WriteJSON(w, &config)
+}
+
+func (a *Admin) handleSetProfiling(w http.ResponseWriter, req *http.Request) error {
+	var config profiling.Config
+	if err := utils.ParseJSON(req.Body, &config); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if err := a.profiler.SetConfig(config); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	return utils.WriteJSON(w, &config)
+}
+
+func (a *Admin) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/node").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetNodeInfo))
+	sub.Path("/peers").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetPeers))
+	sub.Path("/peers/static").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetStaticPeers))
+	sub.Path("/peers/static").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleAddStaticPeer))
+	sub.Path("/peers/static").Methods("DELETE").HandlerFunc(utils.WrapHandlerFunc(a.handleRemoveStaticPeer))
+	sub.Path("/peers/trusted").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetTrustedPeers))
+	sub.Path("/txpool").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetPoolStats))
+	sub.Path("/gossip/tx").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetTxGossip))
+	sub.Path("/gossip/tx").Methods("PUT").HandlerFunc(utils.WrapHandlerFunc(a.handleSetTxGossip))
+	sub.Path("/logs/reindex").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetReindex))
+	sub.Path("/logs/reindex").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(a.handleStartReindex))
+	sub.Path("/log-levels").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetLogLevels))
+	sub.Path("/log-levels").Methods("PUT").HandlerFunc(utils.WrapHandlerFunc(a.handleSetLogLevels))
+	sub.Path("/profiling").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(a.handleGetProfiling))
+	sub.Path("/profiling").Methods("PUT").HandlerFunc(utils.WrapHandlerFunc(a.handleSetProfiling))
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/admin"
+	"github.com/vechain/thor/api/profiling"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/comm"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/logging"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/p2psrv"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
+)
+
+const token = "secret"
+
+const enode = "enode://a8a83b4faac13f0a05ecd383d661a85e15e2a93fb41c4b5d00976d0bb8e35aab58a6303fe6b437124888da45017b94df8ce72f6a8bb5bcfdc7bd8df51698ad01@106.75.226.133:55555"
+
+type peerManager struct {
+	static map[discover.NodeID]*discover.Node
+}
+
+func (pm *peerManager) Self() *discover.Node { return discover.MustParseNode(enode) }
+func (pm *peerManager) StaticNodes() p2psrv.Nodes {
+	var nodes p2psrv.Nodes
+	for _, node := range pm.static {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+func (pm *peerManager) TrustedNodes() p2psrv.Nodes       { return nil }
+func (pm *peerManager) AddStatic(node *discover.Node)    { pm.static[node.ID] = node }
+func (pm *peerManager) RemoveStatic(node *discover.Node) { delete(pm.static, node.ID) }
+
+type network struct {
+	synced   chan struct{}
+	txGossip bool
+}
+
+func (nw *network) PeersStats() []*comm.PeerStats { return nil }
+func (nw *network) Synced() <-chan struct{}       { return nw.synced }
+func (nw *network) TxGossip() bool                { return nw.txGossip }
+func (nw *network) SetTxGossip(enabled bool)      { nw.txGossip = enabled }
+
+var (
+	ts           *httptest.Server
+	genesisBlock *block.Block
+)
+
+func TestStaticPeers(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	body, _ := json.Marshal(&admin.Peer{Enode: enode})
+	res, status := httpDo(t, "POST", ts.URL+"/admin/peers/static", body)
+	assert.Equal(t, http.StatusOK, status, string(res))
+
+	var peers []*admin.Peer
+	res, _ = httpDo(t, "GET", ts.URL+"/admin/peers/static", nil)
+	if err := json.Unmarshal(res, &peers); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []*admin.Peer{{Enode: enode}}, peers)
+
+	_, status = httpDo(t, "DELETE", ts.URL+"/admin/peers/static", body)
+	assert.Equal(t, http.StatusOK, status)
+	res, _ = httpDo(t, "GET", ts.URL+"/admin/peers/static", nil)
+	assert.Equal(t, "[]", string(res))
+
+	_, status = httpDo(t, "POST", ts.URL+"/admin/peers/static", []byte(`{"enode":"invalid"}`))
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestNodeInfo(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	var info admin.NodeInfo
+	res, status := httpDo(t, "GET", ts.URL+"/admin/node", nil)
+	assert.Equal(t, http.StatusOK, status, string(res))
+	if err := json.Unmarshal(res, &info); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.Equal(t, enode, info.Enode)
+	assert.Equal(t, genesisBlock.Header().ID(), info.GenesisID)
+	assert.Equal(t, genesisBlock.Header().ID(), info.BestBlock.ID)
+	assert.False(t, info.Synced)
+
+	res, _ = httpDo(t, "GET", ts.URL+"/admin/peers", nil)
+	assert.Equal(t, "[]", string(res))
+
+	var stats admin.PoolStats
+	res, _ = httpDo(t, "GET", ts.URL+"/admin/txpool", nil)
+	if err := json.Unmarshal(res, &stats); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, admin.PoolStats{}, stats)
+}
+
+func TestTxGossip(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	res, _ := httpDo(t, "GET", ts.URL+"/admin/gossip/tx", nil)
+	assert.Equal(t, `{"enabled":true}`, string(res))
+
+	_, status := httpDo(t, "PUT", ts.URL+"/admin/gossip/tx", []byte(`{"enabled":false}`))
+	assert.Equal(t, http.StatusOK, status)
+	res, _ = httpDo(t, "GET", ts.URL+"/admin/gossip/tx", nil)
+	assert.Equal(t, `{"enabled":false}`, string(res))
+}
+
+func TestReindex(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	res, _ := httpDo(t, "GET", ts.URL+"/admin/logs/reindex", nil)
+	assert.Equal(t, `{"running":false,"from":0,"current":0,"target":0}`, string(res))
+
+	_, status := httpDo(t, "POST", ts.URL+"/admin/logs/reindex", []byte(`{"from":1}`))
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	res, status = httpDo(t, "POST", ts.URL+"/admin/logs/reindex", []byte(`{"from":0}`))
+	assert.Equal(t, http.StatusOK, status, string(res))
+
+	var reindex admin.ReindexStatus
+	for i := 0; i < 100; i++ {
+		res, _ = httpDo(t, "GET", ts.URL+"/admin/logs/reindex", nil)
+		if err := json.Unmarshal(res, &reindex); err != nil {
+			t.Fatal(err)
+		}
+		if !reindex.Running {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, admin.ReindexStatus{}, reindex)
+}
+
+func TestLogLevels(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	res, _ := httpDo(t, "GET", ts.URL+"/admin/log-levels", nil)
+	assert.Equal(t, `{"default":"info","pkgs":{}}`, string(res))
+
+	res, status := httpDo(t, "PUT", ts.URL+"/admin/log-levels", []byte(`{"default":"warn","pkgs":{"comm":"debug","node":"error"}}`))
+	assert.Equal(t, http.StatusOK, status, string(res))
+	assert.Equal(t, `{"default":"warn","pkgs":{"comm":"debug","node":"error"}}`, string(res))
+
+	_, status = httpDo(t, "PUT", ts.URL+"/admin/log-levels", []byte(`{"pkgs":{"comm":"verbose"}}`))
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	res, _ = httpDo(t, "PUT", ts.URL+"/admin/log-levels", []byte(`{"pkgs":{"comm":""}}`))
+	assert.Equal(t, `{"default":"warn","pkgs":{"node":"error"}}`, string(res))
+}
+
+func TestProfiling(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	res, _ := httpDo(t, "GET", ts.URL+"/admin/profiling", nil)
+	assert.Equal(t, `{"enabled":false,"blockProfileRate":0,"mutexProfileFraction":0}`, string(res))
+
+	res, status := httpDo(t, "PUT", ts.URL+"/admin/profiling", []byte(`{"enabled":true,"mutexProfileFraction":5}`))
+	assert.Equal(t, http.StatusOK, status, string(res))
+	res, _ = httpDo(t, "GET", ts.URL+"/admin/profiling", nil)
+	assert.Equal(t, `{"enabled":true,"blockProfileRate":0,"mutexProfileFraction":5}`, string(res))
+
+	_, status = httpDo(t, "PUT", ts.URL+"/admin/profiling", []byte(`{"enabled":true,"blockProfileRate":-1}`))
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	httpDo(t, "PUT", ts.URL+"/admin/profiling", []byte(`{"enabled":false}`))
+}
+
+func TestRequireToken(t *testing.T) {
+	initAdminServer(t)
+	defer ts.Close()
+
+	for _, auth := range []string{"", "token", "Bearer", "Bearer foo"} {
+		req, _ := http.NewRequest("GET", ts.URL+"/admin/node", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode, auth)
+	}
+}
+
+func initAdminServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisBlock = b
+	ch, _ := chain.New(db, b)
+	pool := txpool.New(ch, stateC, txpool.DefaultPoolConfig, thor.NoFork)
+	logDB, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profiler, err := profiling.New(profiling.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	admin.New(
+		&peerManager{map[discover.NodeID]*discover.Node{}},
+		&network{make(chan struct{}), true},
+		ch,
+		pool,
+		logDB,
+		logging.NewFilter(log15.LvlInfo, log15.DiscardHandler()),
+		profiler,
+		"1.0.0",
+	).Mount(router, "/admin")
+	ts = httptest.NewServer(admin.RequireToken(token, router))
+}
+
+func httpDo(t *testing.T, method string, url string, body []byte) ([]byte, int) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, res.StatusCode
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package admin
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/comm"
+	"github.com/vechain/thor/logging"
+	"github.com/vechain/thor/p2psrv"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
+)
+
+// PeerManager manages static and trusted peers of the P2P server.
+type PeerManager interface {
+	Self() *discover.Node
+	StaticNodes() p2psrv.Nodes
+	TrustedNodes() p2psrv.Nodes
+	AddStatic(node *discover.Node)
+	RemoveStatic(node *discover.Node)
+}
+
+// Network provides connected peers and sync status, and controls tx gossip.
+type Network interface {
+	PeersStats() []*comm.PeerStats
+	Synced() <-chan struct{}
+	TxGossip() bool
+	SetTxGossip(enabled bool)
+}
+
+// Peer identifies a peer by enode URL.
+type Peer struct {
+	Enode string `json:"enode"`
+}
+
+func convertNodes(nodes p2psrv.Nodes) []*Peer {
+	peers := make([]*Peer, 0, len(nodes))
+	for _, node := range nodes {
+		peers = append(peers, &Peer{node.String()})
+	}
+	return peers
+}
+
+// BlockSummary brief of a block.
+type BlockSummary struct {
+	ID        thor.Bytes32 `json:"id"`
+	Number    uint32       `json:"number"`
+	Timestamp uint64       `json:"timestamp"`
+}
+
+func convertBlockSummary(header *block.Header) *BlockSummary {
+	return &BlockSummary{
+		ID:        header.ID(),
+		Number:    header.Number(),
+		Timestamp: header.Timestamp(),
+	}
+}
+
+// NodeInfo status of the node.
+type NodeInfo struct {
+	Version   string        `json:"version"`
+	Enode     string        `json:"enode"`
+	GenesisID thor.Bytes32  `json:"genesisID"`
+	BestBlock *BlockSummary `json:"bestBlock"`
+	Synced    bool          `json:"synced"`
+	PeerCount int           `json:"peerCount"`
+}
+
+// PoolStats counts of txs in pool.
+type PoolStats struct {
+	Pending int `json:"pending"`
+	Queued  int `json:"queued"`
+	Local   int `json:"local"`
+	Signers int `json:"signers"`
+}
+
+func convertPoolStats(stats *txpool.Stats) *PoolStats {
+	return &PoolStats{
+		Pending: stats.Pending,
+		Queued:  stats.Queued,
+		Local:   stats.Local,
+		Signers: stats.Signers,
+	}
+}
+
+// Gossip switch of gossip.
+type Gossip struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Reindex request to rebuild logs.
+type Reindex struct {
+	From uint32 `json:"from"`
+}
+
+// ReindexStatus progress of the reindex job.
+type ReindexStatus struct {
+	Running bool   `json:"running"`
+	From    uint32 `json:"from"`
+	Current uint32 `json:"current"`
+	Target  uint32 `json:"target"`
+	Error   string `json:"error,omitempty"`
+}
+
+// LogLevels the default log level, and levels of subsystems overriding it.
+type LogLevels struct {
+	Default string            `json:"default"`
+	Pkgs    map[string]string `json:"pkgs"`
+}
+
+func convertLogLevels(filter *logging.Filter) *LogLevels {
+	levels := &LogLevels{
+		Default: logging.LevelName(filter.Level()),
+		Pkgs:    make(map[string]string),
+	}
+	for pkg, lvl := range filter.PkgLevels() {
+		levels.Pkgs[pkg] = logging.LevelName(lvl)
+	}
+	return levels
+}
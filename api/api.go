@@ -11,20 +11,36 @@ import (
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 	"github.com/gorilla/mux"
 	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/admin"
 	"github.com/vechain/thor/api/blocks"
+	"github.com/vechain/thor/api/debug"
 	"github.com/vechain/thor/api/doc"
+	"github.com/vechain/thor/api/eth"
 	"github.com/vechain/thor/api/events"
+	"github.com/vechain/thor/api/evidences"
+	"github.com/vechain/thor/api/fees"
+	"github.com/vechain/thor/api/graphql"
+	"github.com/vechain/thor/api/health"
+	"github.com/vechain/thor/api/mempool"
 	"github.com/vechain/thor/api/node"
+	"github.com/vechain/thor/api/profiling"
+	"github.com/vechain/thor/api/subscriptions"
 	"github.com/vechain/thor/api/transactions"
 	"github.com/vechain/thor/api/transfers"
 	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/evidence"
 	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/logging"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/txpool"
 )
 
-//New return api router
-func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool, logDB *logdb.LogDB, nw node.Network) http.HandlerFunc {
+//New return api router, and the function to close long-lived subscriptions.
+//Event and transfer queries return at most logsLimit results each.
+//Ethereum compatible JSON-RPC is served at /eth if enableEthRPC is true, GraphQL at /graphql if enableGraphQL is true,
+//and tx pool inspection at /txpool if enableTxPool is true.
+func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool, logDB *logdb.LogDB, nw node.Network, evidenceStore *evidence.Store, forkConfig thor.ForkConfig, allowedOrigins []string, logsLimit uint64, enableEthRPC bool, enableGraphQL bool, enableTxPool bool) (http.HandlerFunc, func()) {
 	router := mux.NewRouter()
 
 	// to serve api doc and swagger-ui
@@ -41,18 +57,56 @@ func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool,
 			http.Redirect(w, req, "doc/swagger-ui/", http.StatusTemporaryRedirect)
 		})
 
-	accounts.New(chain, stateCreator).
+	accounts.New(chain, stateCreator, forkConfig).
 		Mount(router, "/accounts")
-	events.New(logDB).
+	events.New(logDB, logsLimit).
 		Mount(router, "/events")
-	transfers.New(logDB).
+	events.New(logDB, logsLimit).
+		Mount(router, "/logs/event")
+	transfers.New(logDB, logsLimit).
 		Mount(router, "/transfers")
+	transfers.New(logDB, logsLimit).
+		Mount(router, "/logs/transfer")
 	blocks.New(chain).
 		Mount(router, "/blocks")
 	transactions.New(chain, txPool).
 		Mount(router, "/transactions")
 	node.New(nw).
 		Mount(router, "/node")
+	evidences.New(evidenceStore, chain, stateCreator).
+		Mount(router, "/evidences")
+	debug.New(chain, stateCreator, forkConfig).
+		Mount(router, "/debug")
+	fees.New(chain, stateCreator).
+		Mount(router, "/fees")
+	health.New(chain, stateCreator, nw).
+		Mount(router, "")
+	subs := subscriptions.New(chain, stateCreator, txPool, allowedOrigins)
+	subs.Mount(router, "/subscriptions")
+	if enableEthRPC {
+		eth.New(chain, stateCreator, txPool, logDB, forkConfig).
+			Mount(router, "/eth")
+	}
+	if enableGraphQL {
+		graphql.New(chain, stateCreator, logDB).
+			Mount(router, "/graphql")
+	}
+	if enableTxPool {
+		mempool.New(txPool).
+			Mount(router, "/txpool")
+	}
+
+	return instrument(router), subs.Close
+}
+
+//NewAdmin return admin api router, which should be served on a private listener
+func NewAdmin(peers admin.PeerManager, nw admin.Network, chain *chain.Chain, txPool *txpool.TxPool, logDB *logdb.LogDB, logFilter *logging.Filter, profiler *profiling.Profiler, version string) http.HandlerFunc {
+	router := mux.NewRouter()
+
+	mempool.New(txPool).
+		Mount(router, "/admin/txpool")
+	admin.New(peers, nw, chain, txPool, logDB, logFilter, profiler, version).
+		Mount(router, "/admin")
 
 	return router.ServeHTTP
 }
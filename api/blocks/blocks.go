@@ -13,7 +13,6 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/api/utils"
-	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/thor"
 )
@@ -30,45 +29,91 @@ func New(chain *chain.Chain) *Blocks {
 
 func (b *Blocks) handleGetBlock(w http.ResponseWriter, req *http.Request) error {
 	revision := mux.Vars(req)["revision"]
-	block, err := b.getBlock(revision)
+	var expanded bool
+	if s := req.URL.Query().Get("expanded"); s != "" {
+		var err error
+		if expanded, err = strconv.ParseBool(s); err != nil {
+			return utils.BadRequest(err, "expanded")
+		}
+	}
+	summary, err := b.getBlockSummary(revision)
 	if err != nil {
 		return err
 	}
-	isTrunk, err := b.isTrunk(block.Header().ID(), block.Header().Number())
+	if summary == nil {
+		return utils.WriteJSON(w, nil)
+	}
+	header := summary.Header
+	isTrunk, err := b.isTrunk(header.ID(), header.Number())
 	if err != nil {
 		return err
 	}
-	blk, err := ConvertBlock(block, isTrunk)
+	isFinalized := isTrunk && header.Number() <= b.chain.FinalizedBlock().Number()
+
+	if expanded {
+		// summary is enough unless txs expanded
+		block, err := b.chain.GetBlock(header.ID())
+		if err != nil {
+			return err
+		}
+		blk, err := ConvertExpandedBlock(block, isTrunk)
+		if err != nil {
+			return err
+		}
+		blk.IsFinalized = isFinalized
+		return utils.WriteJSON(w, blk)
+	}
+	blk, err := ConvertBlockSummary(summary, isTrunk)
 	if err != nil {
 		return err
 	}
+	blk.IsFinalized = isFinalized
 	return utils.WriteJSON(w, blk)
 }
 
-func (b *Blocks) getBlock(revision string) (*block.Block, error) {
+func (b *Blocks) handleGetRawHeader(w http.ResponseWriter, req *http.Request) error {
+	summary, err := b.getBlockSummary(mux.Vars(req)["revision"])
+	if err != nil {
+		return err
+	}
+	if summary == nil {
+		return utils.WriteJSON(w, nil)
+	}
+	raw, err := ConvertRawHeader(summary.Header)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, raw)
+}
+
+// getBlockSummary returns the cached summary of the block, or nil if not found.
+func (b *Blocks) getBlockSummary(revision string) (*chain.BlockSummary, error) {
 	if revision == "" || revision == "best" {
-		return b.chain.BestBlock(), nil
+		return b.chain.GetBlockSummary(b.chain.BestBlock().Header().ID())
+	}
+	if revision == "finalized" {
+		return b.chain.GetBlockSummary(b.chain.FinalizedBlock().ID())
 	}
 	blkID, err := thor.ParseBytes32(revision)
 	if err != nil {
 		n, err := strconv.ParseUint(revision, 0, 0)
 		if err != nil {
-			return nil, err
+			return nil, utils.BadRequest(err, "revision")
 		}
 		if n > math.MaxUint32 {
 			return nil, utils.BadRequest(errors.New("block number exceeded"), "revision")
 		}
-		blk, err := b.chain.GetTrunkBlock(uint32(n))
+		summary, err := b.chain.GetTrunkBlockSummary(uint32(n))
 		if b.chain.IsNotFound(err) {
 			return nil, nil
 		}
-		return blk, err
+		return summary, err
 	}
-	blk, err := b.chain.GetBlock(blkID)
+	summary, err := b.chain.GetBlockSummary(blkID)
 	if b.chain.IsNotFound(err) {
 		return nil, nil
 	}
-	return blk, err
+	return summary, err
 }
 
 func (b *Blocks) isTrunk(blkID thor.Bytes32, blkNum uint32) (bool, error) {
@@ -83,5 +128,5 @@ func (b *Blocks) isTrunk(blkID thor.Bytes32, blkNum uint32) (bool, error) {
 func (b *Blocks) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 	sub.Path("/{revision}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(b.handleGetBlock))
-
+	sub.Path("/{revision}/header").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(b.handleGetRawHeader))
 }
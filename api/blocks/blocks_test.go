@@ -14,7 +14,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/api/blocks"
@@ -60,6 +62,43 @@ func TestBlock(t *testing.T) {
 		t.Fatal(err)
 	}
 	checkBlock(t, raw, rb)
+	assert.True(t, rb.IsTrunk)
+	assert.False(t, rb.IsFinalized)
+
+	res = httpGet(t, ts.URL+"/blocks/finalized")
+	if err := json.Unmarshal(res, &rb); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(0), rb.Number)
+	assert.True(t, rb.IsFinalized)
+
+	res = httpGet(t, ts.URL+"/blocks/best?expanded=true")
+	var eb blocks.ExpandedBlock
+	if err := json.Unmarshal(res, &eb); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, blk.Header().ID(), eb.ID)
+	assert.Equal(t, 1, len(eb.Transactions))
+	assert.Equal(t, blk.Transactions()[0].ID(), eb.Transactions[0].ID)
+	assert.Equal(t, blk.Header().ID(), eb.Transactions[0].Block.ID)
+
+	assert.Equal(t, "null", string(httpGet(t, ts.URL+"/blocks/100")))
+
+	res = httpGet(t, ts.URL+"/blocks/best/header")
+	var rh blocks.RawHeader
+	if err := json.Unmarshal(res, &rh); err != nil {
+		t.Fatal(err)
+	}
+	data, err := hexutil.Decode(rh.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var header block.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, blk.Header().ID(), header.ID())
+	assert.Equal(t, "null", string(httpGet(t, ts.URL+"/blocks/100/header")))
 
 }
 
@@ -92,7 +131,7 @@ func initBlockServer(t *testing.T) {
 		t.Fatal(err)
 	}
 	tx = tx.WithSignature(sig)
-	packer := packer.New(chain, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address)
+	packer := packer.New(chain, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
 	flow, err := packer.Schedule(b.Header(), uint64(time.Now().Unix()))
 	if err != nil {
 		t.Fatal(err)
@@ -6,7 +6,11 @@
 package blocks
 
 import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/api/transactions"
 	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/thor"
 )
 
@@ -26,25 +30,57 @@ type Block struct {
 	ReceiptsRoot thor.Bytes32   `json:"receiptsRoot"`
 	Signer       thor.Address   `json:"signer"`
 	IsTrunk      bool           `json:"isTrunk"`
+	IsFinalized  bool           `json:"isFinalized"`
 	Transactions []thor.Bytes32 `json:"transactions,string"`
 }
 
+//ExpandedBlock block with transactions expanded
+type ExpandedBlock struct {
+	*Block
+	Transactions []*transactions.Transaction `json:"transactions"`
+}
+
+//RawHeader rlp encoded block header, for clients to verify the signature and id by themselves
+type RawHeader struct {
+	Raw string `json:"raw"`
+}
+
+//ConvertRawHeader convert a block header into raw format
+func ConvertRawHeader(h *block.Header) (*RawHeader, error) {
+	data, err := rlp.EncodeToBytes(h)
+	if err != nil {
+		return nil, err
+	}
+	return &RawHeader{hexutil.Encode(data)}, nil
+}
+
 //ConvertBlock convert a raw block into a json format block
 func ConvertBlock(b *block.Block, isTrunk bool) (*Block, error) {
 	if b == nil {
 		return nil, nil
 	}
-	signer, err := b.Header().Signer()
-	if err != nil {
-		return nil, err
-	}
 	txs := b.Transactions()
 	txIds := make([]thor.Bytes32, len(txs))
 	for i, tx := range txs {
 		txIds[i] = tx.ID()
 	}
+	return ConvertBlockSummary(&chain.BlockSummary{
+		Header: b.Header(),
+		Txs:    txIds,
+		Size:   uint32(b.Size()),
+	}, isTrunk)
+}
 
-	header := b.Header()
+//ConvertBlockSummary convert a block summary into a json format block
+func ConvertBlockSummary(s *chain.BlockSummary, isTrunk bool) (*Block, error) {
+	if s == nil {
+		return nil, nil
+	}
+	header := s.Header
+	signer, err := header.Signer()
+	if err != nil {
+		return nil, err
+	}
 	return &Block{
 		Number:       header.Number(),
 		ID:           header.ID(),
@@ -55,11 +91,36 @@ func ConvertBlock(b *block.Block, isTrunk bool) (*Block, error) {
 		GasUsed:      header.GasUsed(),
 		Beneficiary:  header.Beneficiary(),
 		Signer:       signer,
-		Size:         uint32(b.Size()),
+		Size:         s.Size,
 		StateRoot:    header.StateRoot(),
 		ReceiptsRoot: header.ReceiptsRoot(),
 		TxsRoot:      header.TxsRoot(),
 		IsTrunk:      isTrunk,
-		Transactions: txIds,
+		Transactions: s.Txs,
 	}, nil
 }
+
+//ConvertExpandedBlock convert a raw block into a json format block, with transactions expanded
+func ConvertExpandedBlock(b *block.Block, isTrunk bool) (*ExpandedBlock, error) {
+	if b == nil {
+		return nil, nil
+	}
+	blk, err := ConvertBlock(b, isTrunk)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*transactions.Transaction, 0, len(b.Transactions()))
+	for _, tx := range b.Transactions() {
+		trx, err := transactions.ConvertTransaction(tx)
+		if err != nil {
+			return nil, err
+		}
+		trx.Block = &transactions.BlockContext{
+			ID:        blk.ID,
+			Number:    blk.Number,
+			Timestamp: blk.Timestamp,
+		}
+		txs = append(txs, trx)
+	}
+	return &ExpandedBlock{blk, txs}, nil
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/consensus"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracers"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+)
+
+const maxStorageResult = 1000
+
+type Debug struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	consensus    *consensus.Consensus
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator, forkConfig thor.ForkConfig) *Debug {
+	return &Debug{
+		chain,
+		stateCreator,
+		consensus.New(chain, stateCreator, forkConfig),
+	}
+}
+
+// newTracer create the tracer by name. Config is only accepted by structLogger.
+func newTracer(name string, config json.RawMessage) (vm.Tracer, error) {
+	hasConfig := len(config) > 0 && string(config) != "null"
+	if name == tracers.StructLoggerName {
+		var cfg vm.LogConfig
+		if hasConfig {
+			decoder := json.NewDecoder(bytes.NewReader(config))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&cfg); err != nil {
+				return nil, utils.BadRequest(err, "config")
+			}
+		}
+		// never print to stderr of the node
+		cfg.Debug = false
+		return vm.NewStructLogger(&cfg), nil
+	}
+	if hasConfig {
+		return nil, utils.BadRequest(errors.New("not supported by "+name), "config")
+	}
+	tracer, err := tracers.New(name)
+	if err != nil {
+		return nil, utils.BadRequest(err, "name")
+	}
+	return tracer, nil
+}
+
+// parseTarget parses target in form of '{blockID}/{txIndex|txID}'.
+func (d *Debug) parseTarget(target string) (*block.Block, uint64, error) {
+	parts := strings.Split(target, "/")
+	if len(parts) != 2 {
+		return nil, 0, utils.BadRequest(errors.New("should be in form of '{blockID}/{txIndex|txID}'"), "target")
+	}
+	blockID, err := thor.ParseBytes32(parts[0])
+	if err != nil {
+		return nil, 0, utils.BadRequest(err, "target[0]")
+	}
+	blk, err := d.chain.GetBlock(blockID)
+	if err != nil {
+		if d.chain.IsNotFound(err) {
+			return nil, 0, utils.BadRequest(errors.New("block not found"), "target[0]")
+		}
+		return nil, 0, err
+	}
+	txs := blk.Transactions()
+	if txID, err := thor.ParseBytes32(parts[1]); err == nil {
+		for i, trx := range txs {
+			if trx.ID() == txID {
+				return blk, uint64(i), nil
+			}
+		}
+		return nil, 0, utils.BadRequest(errors.New("tx not found in block"), "target[1]")
+	}
+	txIndex, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, 0, utils.BadRequest(err, "target[1]")
+	}
+	if txIndex >= uint64(len(txs)) {
+		return nil, 0, utils.BadRequest(errors.New("tx index out of range"), "target[1]")
+	}
+	return blk, txIndex, nil
+}
+
+// trace replays txs of the block up to the target one, which is executed with the tracer attached.
+func (d *Debug) trace(ctx context.Context, tracer vm.Tracer, blk *block.Block, txIndex uint64) (*tx.Receipt, error) {
+	rt, err := d.consensus.NewRuntimeForReplay(blk.Header())
+	if err != nil {
+		return nil, err
+	}
+	for i, trx := range blk.Transactions() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if uint64(i) == txIndex {
+			rt.SetVMConfig(vm.Config{Debug: true, Tracer: tracer})
+		}
+		receipt, err := rt.ExecuteTransaction(trx)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(i) == txIndex {
+			return receipt, nil
+		}
+	}
+	return nil, errors.New("tx index out of range")
+}
+
+func (d *Debug) handleTraceTransaction(w http.ResponseWriter, req *http.Request) error {
+	var opt TraceOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	req.Body.Close()
+
+	tracer, err := newTracer(opt.Name, opt.Config)
+	if err != nil {
+		return err
+	}
+	blk, txIndex, err := d.parseTarget(opt.Target)
+	if err != nil {
+		return err
+	}
+	receipt, err := d.trace(req.Context(), tracer, blk, txIndex)
+	if err != nil {
+		return err
+	}
+
+	switch t := tracer.(type) {
+	case *vm.StructLogger:
+		return utils.WriteJSON(w, convertStructLogger(t, receipt))
+	case *tracers.CallTracer:
+		frames := make([]*CallFrame, 0, len(t.Results()))
+		for _, f := range t.Results() {
+			frames = append(frames, convertCallFrame(f))
+		}
+		return utils.WriteJSON(w, frames)
+	case *tracers.PrestateTracer:
+		return utils.WriteJSON(w, convertPrestate(t.Result()))
+	}
+	return errors.New("unexpected tracer")
+}
+
+func (d *Debug) handleStorageRange(w http.ResponseWriter, req *http.Request) error {
+	var opt StorageRangeOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	req.Body.Close()
+
+	if opt.MaxResult == 0 {
+		opt.MaxResult = maxStorageResult
+	} else if opt.MaxResult < 0 || opt.MaxResult > maxStorageResult {
+		return utils.BadRequest(errors.Errorf("should be in range [1, %v]", maxStorageResult), "maxResult")
+	}
+	var keyStart thor.Bytes32
+	if opt.KeyStart != nil {
+		keyStart = *opt.KeyStart
+	}
+
+	h, err := d.getBlockHeader(opt.Revision)
+	if err != nil {
+		return err
+	}
+	if h == nil {
+		return utils.BadRequest(errors.New("block not found"), "revision")
+	}
+	if err := utils.CheckState(d.chain, d.stateCreator, h); err != nil {
+		return err
+	}
+	st, err := d.stateCreator.NewState(h.StateRoot())
+	if err != nil {
+		return err
+	}
+	entries, nextKey, err := st.StorageRange(opt.Address, keyStart, opt.MaxResult)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertStorageRange(entries, nextKey))
+}
+
+func (d *Debug) getBlockHeader(revision string) (*block.Header, error) {
+	if revision == "" || revision == "best" {
+		return d.chain.BestBlock().Header(), nil
+	}
+	if revision == "finalized" {
+		return d.chain.FinalizedBlock(), nil
+	}
+	blkID, err := thor.ParseBytes32(revision)
+	if err != nil {
+		n, err := strconv.ParseUint(revision, 0, 0)
+		if err != nil {
+			return nil, utils.BadRequest(err, "revision")
+		}
+		if n > math.MaxUint32 {
+			return nil, utils.BadRequest(errors.New("block number exceeded"), "revision")
+		}
+		b, err := d.chain.GetTrunkBlock(uint32(n))
+		if err != nil {
+			if d.chain.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return b.Header(), nil
+	}
+	b, err := d.chain.GetBlock(blkID)
+	if err != nil {
+		if d.chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return b.Header(), nil
+}
+
+func (d *Debug) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/tracers").Methods(http.MethodPost).HandlerFunc(utils.WrapHandlerFunc(d.handleTraceTransaction))
+	sub.Path("/storage-range").Methods(http.MethodPost).HandlerFunc(utils.WrapHandlerFunc(d.handleStorageRange))
+}
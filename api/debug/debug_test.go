@@ -0,0 +1,204 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/debug"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+var (
+	ts  *httptest.Server
+	blk *block.Block
+	to  = thor.BytesToAddress([]byte("to"))
+)
+
+func TestDebug(t *testing.T) {
+	initDebugServer(t)
+	defer ts.Close()
+
+	target := blk.Header().ID().String() + "/0"
+
+	var frames []*debug.CallFrame
+	res, statusCode := httpPost(t, ts.URL+"/debug/tracers", &debug.TraceOption{Name: "callTracer", Target: target})
+	assert.Equal(t, http.StatusOK, statusCode, string(res))
+	if err := json.Unmarshal(res, &frames); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(frames)) {
+		assert.Equal(t, "CALL", frames[0].Type)
+		assert.Equal(t, genesis.DevAccounts()[0].Address, frames[0].From)
+		assert.Equal(t, &to, frames[0].To)
+		assert.Equal(t, big.NewInt(10000), (*big.Int)(frames[0].Value))
+	}
+
+	var result debug.StructLoggerResult
+	res, statusCode = httpPost(t, ts.URL+"/debug/tracers", &debug.TraceOption{
+		Name:   "structLogger",
+		Target: blk.Header().ID().String() + "/" + blk.Transactions()[0].ID().String(),
+		Config: json.RawMessage(`{"disableStack":true}`),
+	})
+	assert.Equal(t, http.StatusOK, statusCode, string(res))
+	if err := json.Unmarshal(res, &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, result.Failed)
+	assert.Equal(t, uint64(21000), result.Gas)
+	// plain transfer executes no code
+	assert.Equal(t, 0, len(result.StructLogs))
+
+	for _, opt := range []*debug.TraceOption{
+		{Name: "foo", Target: target},
+		{Name: "callTracer", Target: blk.Header().ID().String()},
+		{Name: "callTracer", Target: blk.Header().ID().String() + "/1"},
+		{Name: "callTracer", Target: target, Config: json.RawMessage(`{}`)},
+		{Name: "structLogger", Target: target, Config: json.RawMessage(`{"foo":1}`)},
+	} {
+		_, statusCode := httpPost(t, ts.URL+"/debug/tracers", opt)
+		assert.Equal(t, http.StatusBadRequest, statusCode, opt)
+	}
+}
+
+func TestStorageRange(t *testing.T) {
+	initDebugServer(t)
+	defer ts.Close()
+
+	getRange := func(opt *debug.StorageRangeOption) *debug.StorageRangeResult {
+		res, statusCode := httpPost(t, ts.URL+"/debug/storage-range", opt)
+		assert.Equal(t, http.StatusOK, statusCode, string(res))
+		var result debug.StorageRangeResult
+		if err := json.Unmarshal(res, &result); err != nil {
+			t.Fatal(err)
+		}
+		return &result
+	}
+
+	all := getRange(&debug.StorageRangeOption{Address: builtin.Params.Address, Revision: "0"})
+	assert.Nil(t, all.NextKey)
+	assert.NotEqual(t, 0, len(all.Storage))
+	for _, entry := range all.Storage {
+		assert.Nil(t, entry.Key)
+		assert.NotNil(t, entry.Value)
+	}
+
+	// page through with continuation token
+	paged := make(map[string]*debug.StorageEntry)
+	opt := &debug.StorageRangeOption{Address: builtin.Params.Address, MaxResult: 1}
+	for {
+		page := getRange(opt)
+		assert.Equal(t, 1, len(page.Storage))
+		for k, v := range page.Storage {
+			paged[k] = v
+		}
+		if page.NextKey == nil {
+			break
+		}
+		opt.KeyStart = page.NextKey
+	}
+	assert.Equal(t, all.Storage, paged)
+
+	assert.Equal(t, 0, len(getRange(&debug.StorageRangeOption{Address: to}).Storage))
+
+	for _, opt := range []*debug.StorageRangeOption{
+		{Address: to, MaxResult: -1},
+		{Address: to, MaxResult: 1001},
+		{Address: to, Revision: "foo"},
+		{Address: to, Revision: "100"},
+	} {
+		_, statusCode := httpPost(t, ts.URL+"/debug/storage-range", opt)
+		assert.Equal(t, http.StatusBadRequest, statusCode, opt)
+	}
+}
+
+func initDebugServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(db, b)
+	cla := tx.NewClause(&to).WithValue(big.NewInt(10000))
+	trx := new(tx.Builder).
+		ChainTag(ch.Tag()).
+		GasPriceCoef(1).
+		Expiration(10).
+		Gas(21000).
+		Nonce(1).
+		Clause(cla).
+		BlockRef(tx.NewBlockRef(0)).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx = trx.WithSignature(sig)
+
+	packer := packer.New(ch, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
+	flow, err := packer.Schedule(b.Header(), uint64(time.Now().Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := flow.Adopt(trx); err != nil {
+		t.Fatal(err)
+	}
+	block, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ch.AddBlock(block, receipts); err != nil {
+		t.Fatal(err)
+	}
+	blk = block
+
+	router := mux.NewRouter()
+	debug.New(ch, stateC, thor.NoFork).Mount(router, "/debug")
+	ts = httptest.NewServer(router)
+}
+
+func httpPost(t *testing.T, url string, obj interface{}) ([]byte, int) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, res.StatusCode
+}
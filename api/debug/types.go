@@ -0,0 +1,155 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package debug
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracers"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+)
+
+// TraceOption options to trace a tx.
+// Target is in form of '{blockID}/{txIndex|txID}'.
+type TraceOption struct {
+	Name   string          `json:"name"`
+	Target string          `json:"target"`
+	Config json.RawMessage `json:"config"`
+}
+
+// StructLoggerResult result of structLogger.
+type StructLoggerResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []vm.StructLog `json:"structLogs"`
+}
+
+func convertStructLogger(logger *vm.StructLogger, receipt *tx.Receipt) *StructLoggerResult {
+	logs := logger.StructLogs()
+	if logs == nil {
+		logs = []vm.StructLog{}
+	}
+	return &StructLoggerResult{
+		Gas:         receipt.GasUsed,
+		Failed:      receipt.Reverted,
+		ReturnValue: hexutil.Encode(logger.Output()),
+		StructLogs:  logs,
+	}
+}
+
+// CallFrame call frame captured by callTracer.
+type CallFrame struct {
+	Type    string                `json:"type"`
+	From    thor.Address          `json:"from"`
+	To      *thor.Address         `json:"to"`
+	Value   *math.HexOrDecimal256 `json:"value"`
+	Gas     uint64                `json:"gas"`
+	GasUsed uint64                `json:"gasUsed,omitempty"`
+	Input   string                `json:"input"`
+	Output  string                `json:"output,omitempty"`
+	Error   string                `json:"error,omitempty"`
+	Calls   []*CallFrame          `json:"calls,omitempty"`
+}
+
+func convertCallFrame(f *tracers.CallFrame) *CallFrame {
+	v := math.HexOrDecimal256(*f.Value)
+	frame := &CallFrame{
+		Type:    f.Type,
+		From:    f.From,
+		To:      f.To,
+		Value:   &v,
+		Gas:     f.Gas,
+		GasUsed: f.GasUsed,
+		Input:   hexutil.Encode(f.Input),
+		Error:   f.Error,
+	}
+	if len(f.Output) > 0 {
+		frame.Output = hexutil.Encode(f.Output)
+	}
+	for _, call := range f.Calls {
+		frame.Calls = append(frame.Calls, convertCallFrame(call))
+	}
+	return frame
+}
+
+// Account pre-execution state of an account captured by prestateTracer.
+type Account struct {
+	Balance *math.HexOrDecimal256 `json:"balance"`
+	Code    string                `json:"code,omitempty"`
+	Storage map[string]string     `json:"storage,omitempty"`
+}
+
+// convertPrestate converts captured accounts, keyed by hex address.
+func convertPrestate(prestate map[thor.Address]*tracers.Account) map[string]*Account {
+	accounts := make(map[string]*Account, len(prestate))
+	for addr, acc := range prestate {
+		balance := acc.Balance
+		if balance == nil {
+			balance = new(big.Int)
+		}
+		b := math.HexOrDecimal256(*balance)
+		a := &Account{Balance: &b}
+		if len(acc.Code) > 0 {
+			a.Code = hexutil.Encode(acc.Code)
+		}
+		if len(acc.Storage) > 0 {
+			a.Storage = make(map[string]string, len(acc.Storage))
+			for k, v := range acc.Storage {
+				a.Storage[k.String()] = v.String()
+			}
+		}
+		accounts[addr.String()] = a
+	}
+	return accounts
+}
+
+// StorageRangeOption options to query a range of account storage.
+// KeyStart is the hashed key to start from, as well as the continuation token.
+type StorageRangeOption struct {
+	Address   thor.Address  `json:"address"`
+	KeyStart  *thor.Bytes32 `json:"keyStart"`
+	MaxResult int           `json:"maxResult"`
+	Revision  string        `json:"revision"`
+}
+
+// StorageEntry an entry of account storage.
+// Key is the preimage of the hashed key, which is null if unknown.
+// Value is null if the raw value is not a 32-byte word, e.g. structured storage of builtins.
+type StorageEntry struct {
+	Key   *thor.Bytes32 `json:"key"`
+	Value *thor.Bytes32 `json:"value"`
+	Raw   string        `json:"raw"`
+}
+
+// StorageRangeResult a page of account storage, keyed by hex hashed key.
+type StorageRangeResult struct {
+	Storage map[string]*StorageEntry `json:"storage"`
+	NextKey *thor.Bytes32            `json:"nextKey"`
+}
+
+func convertStorageRange(entries []state.StorageEntry, nextKey *thor.Bytes32) *StorageRangeResult {
+	storage := make(map[string]*StorageEntry, len(entries))
+	for _, e := range entries {
+		entry := &StorageEntry{Raw: hexutil.Encode(e.Value)}
+		if kind, content, _, err := rlp.Split(e.Value); err == nil && kind != rlp.List && len(content) <= 32 {
+			v := thor.BytesToBytes32(content)
+			entry.Value = &v
+		}
+		storage[e.HashedKey.String()] = entry
+	}
+	return &StorageRangeResult{
+		Storage: storage,
+		NextKey: nextKey,
+	}
+}
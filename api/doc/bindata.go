@@ -236,7 +236,7 @@ func swaggerUiSwaggerUiCss() (*asset, error) {
 	return a, nil
 }
 
-var _thorYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5c\x5b\x8f\xdc\xb8\x72\x7e\x9f\x5f\x41\x20\x01\xb4\x0b\x78\xa6\xa9\xbb\x34\x0f\x01\x7c\xec\x4d\x30\x38\x8b\xd8\x19\x4f\xf2\x12\xe4\x81\x22\x4b\xdd\x3a\x56\x8b\x3a\x24\x35\xd3\x13\x23\xff\x3d\xa0\xee\xb7\x56\x5f\x6d\xcf\xe2\xec\xec\xcb\x5a\x4d\x16\xab\x8a\x5f\x5d\x49\x89\xe7\x90\x91\x3c\xb9\x47\xf6\x1d\xbe\x33\x6f\x92\x2c\xe6\xf7\x37\x08\x3d\x83\x90\x09\xcf\xee\x11\xbe\x33\xef\xf0\x0d\x42\x2a\x51\x29\xdc\xa3\xff\x82\x0f\x1b\x92\x64\xe8\x69\xc3\x05\x7a\xff\xf9\xe1\x06\xa1\x34\xa1\x90\x49\xd0\xb3\x10\xca\xc8\x16\xee\xd1\xef\xff\xf6\xf9\x77\x4d\xb0\x7c\x54\x88\xf4\x1e\x19\x1b\xa5\x72\x79\xbf\x5a\xbd\xbc\xbc\xdc\xad\xb3\xe2\x8e\x8b\xf5\xaa\x9e\x29\x57\xe9\x3a\x4f\x6f\x35\x03\x90\xdd\x6d\xd4\x36\x35\x6e\x10\x62\x20\xa9\x48\x72\x55\x72\xf1\xf8\xdb\x97\xa7\xb8\x48\xf5\x8a\x48\x71\x44\x28\x05\x29\x07\xcc\xdc\x48\x10\x9a\x69\xcd\xc6\x6d\xbd\xe6\xca\x28\x19\x18\x50\x4a\x39\x25\x29\x52\x9a\xfd\x8c\x33\xb8\x51\x64\x5d\xcf\xa9\x58\x7f\x4f\x29\x2f\x32\x25\xa7\x33\xdf\x57\x8b\x56\xcb\xeb\x31\x88\x47\x7f\x03\x5a\x0e\x6d\x66\x3f\x09\x92\x49\x42\xf5\x84\x45\x0a\x6a\x38\xae\x99\xfe\x97\x94\xd3\xaf\x8b\x13\xa3\x66\x44\x33\xe5\xb7\x67\x38\xc0\x2d\xe8\x11\x28\xe5\xeb\x09\xa3\x31\x88\xc3\x5c\xc6\x20\xc6\x93\xff\x5d\x2b\x6e\x61\x9e\x56\x2c\xd2\x48\xba\xc9\x89\xda\x94\xea\x35\x56\xb5\xd2\xe4\xea\x1b\x61\x4c\x80\x94\xff\x67\x54\x90\xc9\x89\x20\x5b\x50\xf5\xde\xe9\xbf\x5b\xf4\xcf\x02\xe2\x7b\x64\xfc\xd3\x8a\xf2\x6d\xce\x33\x2d\xe2\xaa\x1b\xb7\x7a\x5f\x51\x78\xc8\x3e\x13\xb5\x31\x8e\x9d\xf5\x08\xcf\x89\x06\xf5\x43\xf6\x1f\x05\x88\xd7\x6a\xde\x1a\x54\xb3\x6c\x03\x85\x86\xdc\x00\x0a\x08\xc9\x62\xbb\x25\xe2\xf5\x5e\x4f\x19\x41\x00\x31\x50\x24\x49\xeb\x81\x02\x64\xce\x35\xae\x3b\x62\x86\x85\xb1\xd1\xfd\x73\xa4\xb9\x4f\x7f\xed\xfd\x42\x79\xa6\x20\x53\xfd\xc1\x08\x91\x3c\x4f\x13\x4a\xf4\xf0\xd5\xdf\x24\xcf\x86\xbf\x22\x24\xe9\x06\xb6\x64\xfc\x14\xcd\x6a\xa4\x1a\x2b\x57\xb5\x78\x95\x1a\x72\x2e\x4f\xd6\x43\x0e\x22\xe6\x62\x5b\x72\x2c\x08\x55\x88\x92\x34\x45\x3c\x1b\x29\xa7\xd5\xca\xdf\x0b\x90\xea\x2f\x9c\xbd\x76\xc4\x07\x6a\x20\x62\x5d\x6c\x35\x8b\x88\x64\x0c\x41\xf6\x9c\x08\x9e\xe9\x07\xed\x70\x4d\x23\x11\xc0\xee\x91\x12\x05\xdc\x2c\xa8\x6c\x59\x61\xf3\xea\x5a\x52\xd6\x87\x5a\xc6\x0f\x24\x4d\x8d\x3f\xd6\x3e\xf7\x59\x7f\x04\x59\xa4\xe5\x96\x77\x06\xd9\x98\x61\x0f\x01\x53\x93\x3c\xd7\xbc\x2e\x46\x53\x8c\x18\xe4\x29\x7f\x4d\xb2\x35\x22\xed\x8f\x7f\x62\xea\x6d\x63\xaa\x73\xf2\x2b\xca\x19\xfc\x51\x3d\xbd\x00\x25\x12\x78\x06\xa4\x85\xd0\x58\xdc\xe3\xd9\xde\xcc\x9e\xe5\x82\xe7\x20\x54\xd2\xe7\xa5\xbf\x14\x83\xb9\xe7\x08\xa9\xd7\x1c\xee\x91\x54\x22\xc9\xd6\x93\x01\xb0\x23\xdb\x3c\x9d\x9d\x59\x52\x44\xff\x72\x3b\x4b\x14\xef\x3c\xac\xff\x73\xb0\x6b\x79\x18\xe3\x00\xc7\x0c\x63\x62\x7a\xae\x67\xf9\xc4\x27\xbe\x65\x63\x37\xb0\x30\xb5\x6c\x66\x13\xb0\x18\x0d\x3c\xc2\x4c\x1b\xbb\x9e\x49\xac\xc0\x0a\x59\xe0\x53\x9f\x46\x81\x63\xbb\xb6\xe7\x3a\xa1\x15\x31\xd3\x75\x02\x88\x7c\xf0\x63\x8a\x63\xdb\xb3\xad\x08\x42\x8c\xad\x70\x1f\xfa\xa4\xe2\x82\xac\x61\xf5\xed\x2b\xbc\xfe\xf0\x84\xe3\x4b\xb5\xf8\x5f\xe1\xf5\x67\xe3\xb7\x56\x03\x7a\x26\x69\x31\x03\x64\x14\x73\x81\xd6\xc9\x33\x64\xe8\x2b\xbc\xfe\xd1\x60\x5d\x0a\x75\x5d\x5c\x57\x24\xf7\x03\x1b\x5f\xf6\x67\xde\x20\xb4\x2a\xf3\x72\x39\x0d\xbe\xe3\xcd\xed\x65\xf8\xbd\xad\x8d\x93\x54\x81\x18\x26\xf7\xe8\xdc\xd0\xfd\xaf\x25\xb1\x4f\x82\x81\x18\x45\xef\xa3\x27\xb7\x16\x32\x98\x7e\x38\x40\x57\x02\xd4\xd2\x50\x91\x28\x10\x09\x79\x03\xc1\xb9\xd4\x7a\x25\xda\x1b\x8c\xcd\x15\xae\x89\x10\xe4\x75\xf2\x5b\xa2\x60\x3b\x6b\x27\x4b\xe2\x56\x92\x02\x2b\xc5\xd6\x02\xaf\x9a\xe2\xef\x08\x84\x0e\x8b\xc9\x29\x48\xc7\x75\x24\xba\x3e\x4e\x0f\x03\xad\xcf\xc4\x1b\xc4\x5b\xa3\xc3\x7f\x3c\xc8\x35\x92\x57\x19\x64\xd5\xe0\x58\x7d\x13\x75\x08\xbc\x20\x68\x77\x51\xb4\x0b\xbe\x0b\x41\xb4\xd7\x7c\xe9\x41\xd8\x68\x63\x68\xc9\x19\x8a\x5e\xd1\xc3\xc7\x77\x28\x2b\xb6\x11\x88\x77\x88\x0b\x64\x18\x11\x48\x65\x18\x65\x10\x55\x1b\x40\x29\x51\x20\x15\xe2\x19\xbc\xc1\x6d\x5c\xda\x91\x52\x03\xd5\x36\xf4\x1b\x54\xab\x6f\x09\xbb\x60\x1b\x9e\x76\x0f\x1f\x4f\xcd\x7f\xc8\xcb\xc8\xbe\xaf\x9e\x32\x4d\x3a\x75\xbd\x3d\xef\x85\xfd\x76\xf7\x7b\x0a\xd1\x18\x48\x94\x44\x09\x43\xbf\x24\x31\x12\xe4\xa5\xf4\x16\xe8\x5d\x37\x9a\xe8\xa7\x2d\x91\xde\xdc\x5f\xdf\x1e\x22\x48\x9a\x7e\x8a\xe7\x8c\x77\x5e\xe7\x03\x87\x55\x09\x65\x9c\x3c\xf9\x91\xbc\x3c\xed\xf6\x20\x6d\x25\x80\x42\x92\xab\x1f\x8b\xb8\x2b\xc2\x67\x16\x33\xb5\x50\x1a\x3b\xfd\xc7\x0f\x1f\xdf\x1e\x20\x16\x37\xae\xde\x9b\x36\x43\xa8\x75\x70\x64\x92\xb0\x47\x63\x12\x32\x56\xdb\x51\x3b\x68\x29\xb0\xff\xbc\x30\xdd\x02\xf7\x8d\xed\xd9\x72\x85\x94\xb0\xeb\x96\x47\x09\x5b\xa8\x8d\x1c\x06\xbe\x19\x5b\xcc\x0d\x02\x42\x02\x62\x02\xc1\x38\x86\xc0\x36\x2d\x16\x5a\xa1\xe7\x31\xe2\x58\x0e\x0b\x43\x3b\x24\xae\x69\xc6\x14\x47\x10\x98\xe0\xb9\x31\x61\xae\x45\xe2\x40\x43\x2b\xe3\x0c\x56\x19\xa8\x17\x2e\xbe\xae\x72\x68\x8d\x7f\xc1\x22\xdb\x43\x89\x39\x4b\xac\x49\x21\xa9\x88\x2a\xe4\xdb\xdb\xbe\xb3\x12\xa8\xcf\x00\xe2\x8b\x22\x4a\x1a\x37\xdd\xaf\x9a\x48\x3d\xa0\xa2\x57\xf7\x06\xda\xd6\xee\x0c\x50\x22\x92\x92\x8c\x0e\x76\x7a\x0f\x32\x06\xea\xd8\xc0\x0e\x95\x2d\x5b\x1e\x23\xc5\xbf\x42\xd6\x10\x6a\x27\x40\x06\x62\xfd\x7a\x09\x5d\x01\x5b\x92\x64\xc0\x10\xd9\x56\xfd\x8a\xb8\x26\xda\x4e\xde\x10\xf9\x61\xd4\xd7\xaa\x16\x89\x38\x4f\x81\x34\x7e\x64\x82\xe6\x46\x68\x64\xe0\x1d\x03\x1c\x79\x91\x4d\x7c\xcf\xd1\xe5\xb9\x31\x16\x60\x71\x4c\xc3\x00\x8a\x49\x2a\x2b\xd9\xcb\x44\xea\x83\x06\xc8\x6e\x51\xf1\x43\xbb\x3c\x46\x37\x09\x83\x4c\x25\x71\x02\xa2\xd4\xfa\xa6\x49\x4b\x7f\x89\x5e\x15\x48\xdb\xfa\xb5\x9d\x58\x65\xa8\x53\xfa\x49\xa6\x60\x0d\xa2\xf7\x5c\xeb\x9a\xa8\x7b\x54\x24\x99\xb2\xad\x7d\x2b\x57\xf4\x7e\xd9\x40\xb2\xde\xa8\x5f\x07\xab\x77\x89\x4e\xb2\x05\xa9\xc8\x36\x3f\x75\x59\xcf\xd9\xb7\x6c\x91\x25\xbb\x8e\xee\x74\xd9\xa7\xdd\x0f\xd2\xf3\x34\x34\x21\xc4\x45\xb2\x4e\xb2\x53\x69\x6b\x6a\x3c\x03\xf4\xb2\xe1\x48\x26\x6b\x8d\xee\xb9\x05\x4a\x10\x2d\x49\xf5\x33\x76\xf8\x7b\x22\x56\x26\xff\x3b\x63\xc6\xe7\x4a\xa3\xc9\x97\x24\x87\xcb\xaa\x0d\x51\x28\x91\xe8\xf1\xf7\xcf\x08\x32\xca\x19\xb0\x96\x42\x4e\x04\x64\xea\xe1\xe3\xa9\x22\x3e\x7c\xd4\x6b\x54\xb3\xf7\x4a\xf7\x13\x6c\x43\xff\xad\x89\xfc\x3d\xd9\x26\xea\x7a\xab\xae\x89\x44\xa9\x26\x39\xbf\x60\x04\x19\xc4\x09\x4d\x74\x00\x3e\x51\x8f\x75\xd7\xbe\xdf\x9f\x56\xbc\xca\x9c\xdb\xfa\x5b\xc0\x0b\x11\xac\x2f\xde\x7f\x4a\x98\x01\xe5\xd1\xd2\x29\xae\x48\xfa\x85\x72\x71\x32\xf6\xfa\x44\x76\xf2\x91\xf3\x19\x25\x2f\x0b\x2c\x38\x57\x3a\x7e\x6c\x4a\x55\xf6\x12\x64\x94\x64\xcb\xa6\xa2\x88\x82\x8b\x57\x6c\xce\x4b\x2a\x72\x33\xcb\xd4\x45\xcb\x55\x65\x6b\x89\xce\x7a\x80\x75\x36\xe7\xd1\xce\xf0\xa7\x89\x1c\x28\xcf\xc2\xdd\x2a\x89\x7c\x12\x45\xf6\xf5\x50\xc6\x30\x59\xe7\x65\x03\x6a\x03\xa2\xa6\x9b\x48\x5d\x7c\x64\xbd\xe0\x37\x29\x87\xfa\xb4\xc7\x8d\xb3\x91\x03\x91\x63\x04\xf4\x86\xce\x24\x87\x7b\x13\xf7\x19\xbf\xd4\xd7\xfd\x58\xe5\x93\xac\xa8\x8e\x29\xc8\xec\x7b\x7c\x9d\xfe\x34\x27\x17\xd4\x71\x83\xd0\x09\xc3\xc0\x25\x1e\x0b\xbc\xc8\x37\xed\xd0\x0b\x71\x14\x04\xa6\xc9\x98\x1d\x39\x9e\xe3\x53\x6c\x31\x27\x76\x4c\xca\x20\x8e\x7c\x66\x5b\xb6\xe5\x1b\x43\x37\x8f\x2c\x3b\x98\xfa\xdd\xde\x42\x16\xc1\xd4\xf7\x2d\xd3\x0f\x09\x71\x6c\x1a\x79\x51\xe4\xba\x0c\x47\xb6\x69\x7b\x61\x1c\x42\x68\x61\xd3\xa1\x41\x40\x5c\x1c\x59\x34\x0a\xc3\x38\x8c\xc0\xa4\x2e\x33\x66\x3c\x2e\x32\x5d\xcb\x36\x5d\xcf\xf2\xcd\xa9\x63\x44\x66\xbd\xe4\xac\x0b\xd3\x2c\xf9\xae\xe7\xb3\xc0\x8e\xfc\x28\x60\x01\x26\x8c\xd1\xc8\x0a\x4c\xe2\x9b\xcc\x75\x62\xea\x47\xb6\xed\x39\x71\x0c\xbd\xa5\x1b\xb7\x84\xf0\x9c\x9f\x41\x26\x36\x27\xae\x43\x2f\x64\x32\x4a\x1d\x06\x01\x03\xea\xbb\xcc\x27\x24\x0a\xdc\xc8\xf5\xfc\xc8\xa3\x94\x39\x26\x61\xb6\x69\x39\xae\x19\x85\x4e\x40\x7c\xc7\xb4\x63\x4c\x4c\xc7\x8a\x99\x83\x99\x13\xda\x4e\x5f\xc9\xad\x83\xb8\x2e\xdd\x81\x47\xb8\x32\xcb\x95\xf1\x9f\xa7\xf0\xc6\xa6\x87\x0d\x81\x7d\x26\x79\xab\x17\xb9\xb4\x4e\xad\x16\x2f\x1b\x02\x4b\x59\x9a\x20\x2f\x97\x14\x40\x75\x8e\x32\x93\x7e\x4e\x6c\x57\xaf\x34\x2c\xcb\xf1\x2e\x0e\xbc\x30\x30\x23\x12\x60\x4c\x18\x61\x61\xe8\x1c\x73\x3e\xe9\x3b\x5e\x1c\x58\x96\x6f\xe2\x00\x63\x33\xb0\x5c\x0b\x07\xfa\xff\x28\x8e\x02\xc7\x74\xfc\xd0\xa2\xa1\x63\x87\x6e\xe8\xe0\x30\xb0\x2d\x3b\xc4\x18\x3c\xc7\xc7\xbe\x63\x51\x16\xf8\x3e\xd0\x30\x0e\x43\xec\x45\x94\x60\xd7\x35\x31\x38\x96\x19\xdb\x11\x36\x6d\x60\x96\x65\xda\x96\x03\xbe\x4f\x89\x89\x99\xed\x78\x5e\x64\x5b\x91\x19\x60\x4c\x7d\x0b\x4c\xcb\x37\xc3\xc8\x32\xed\xd8\x64\x0e\xb5\x7d\x6c\x63\xd7\x0e\x43\xc6\x2c\x9f\xc4\xa1\x67\x79\x96\x2e\xbf\x4a\x11\x3f\xa4\xa4\x90\xb0\xa4\x7a\xc5\x4f\xd5\xbc\x21\x80\x26\x79\x02\x55\xa5\x49\xcb\x15\xde\xa1\xac\x48\xd3\xb2\xbb\xdf\xde\x4d\xaa\xee\x24\x6d\xf5\xb8\xd6\xa5\x76\x60\x9c\x1c\x48\x9f\x57\x4d\x2b\x8e\x22\x68\xcf\xad\x44\x2f\x51\x65\x44\x91\x93\xf3\xf0\x2c\x2f\x54\x39\xb3\x66\x79\x6f\x0c\x50\xfc\x4c\x23\xac\x4f\xcd\xb5\x57\xe8\xd5\xc7\x25\xb3\xa5\x0e\xab\x82\xad\x03\xf2\xcf\x28\xd9\xbe\x73\x91\xd1\x0f\xb6\x4b\xa5\x06\xdd\x90\x24\x7b\x22\xeb\x53\x59\x09\xf6\x71\x92\x12\xa9\x2a\x76\x78\x8c\xd6\x90\x81\x6c\x33\xa0\xb6\xc7\x8c\xaa\x07\x8f\x10\x9f\xaa\xdb\xa0\x24\x2d\x51\x2e\x20\x4e\x76\x7a\x09\xc9\xb7\x30\xa5\x0f\xbb\x3c\x11\xa4\xbf\xb7\x97\xeb\xd8\xe8\x88\x22\x01\x29\x51\xba\x18\x68\xae\x42\x3f\x42\xfc\x4e\x27\xcb\x45\x96\xd4\xa5\x57\x07\xbc\xca\x7c\x8f\xc8\xc5\x66\x12\xac\xc5\xcb\x6f\x25\xdd\x41\xb0\xff\x2c\x12\x0a\x1f\xf8\x9c\x62\xcf\xdc\x4f\xca\x21\xd6\x39\x88\x76\x31\x85\xd4\x01\x80\x23\x4a\x52\x5a\xa4\x3a\x55\xd7\x50\x8b\x93\x8c\xa4\x65\x35\x96\xeb\xd5\xfb\xec\x5c\xaf\xd8\xdb\x92\x5d\xaf\xf5\xa6\x17\xa3\x24\xd3\x6e\x89\xf2\x4c\x16\xdb\x8a\x2f\xd8\x01\x2d\x4a\xae\xca\xa4\x78\x6a\x74\x0c\x72\xc8\x98\xfc\x74\x72\xab\x64\xd4\x64\xae\x13\xda\x91\x9d\xf1\x0c\xbd\x6c\x12\xba\x29\x7f\xa0\x85\x28\xcb\xf0\xfe\x80\x7a\xf9\x01\xa9\x99\x86\x19\x3f\xa6\x07\xfa\x5d\x5b\x3e\xa8\x31\xd1\x3e\xfd\x83\x27\xb6\x75\x03\xcc\xd8\xe7\xcf\xeb\x0c\xfe\x3a\xf9\x0e\x6a\x33\x78\x33\xc0\x53\x77\xd6\x2b\x1c\x5a\x5f\xd3\x2f\x1f\x1a\xca\xc6\x9c\xcb\x40\x36\x9e\x18\x2f\xfa\xef\xff\x99\x37\x34\x64\x5a\xc1\x00\xf3\xc8\x32\xfb\x49\x7c\x87\x39\x64\xe8\xe0\x63\x8c\x36\xba\xec\xe9\x8e\x04\x37\xc6\xdb\x7c\x5e\x1c\x9c\x6c\xe1\xd5\x6b\xa8\xb9\x42\x6d\xa9\xe0\x29\xaf\xf5\x2c\x85\xdb\xba\xf5\x72\x0e\xae\x7b\x5d\x9b\x36\x3f\xaa\xec\x31\x17\x9c\x15\x14\x64\x39\xac\xba\xe8\x35\xad\xc6\x15\xcf\x13\x7a\x9e\x93\x9e\xe5\xf0\x88\xdc\x68\x62\x21\x8d\xf4\xe7\x6d\xf7\x54\x82\x2b\xd6\x17\xad\x48\x25\x5e\x59\x1c\x1b\x5d\x16\x15\x77\xbd\x92\xb9\x3d\x95\x90\xb1\xd3\xbb\x29\xcd\x76\x96\xd9\x8b\x26\x21\xab\x74\x54\xf6\x6b\xc0\x2a\x47\xbe\x88\x74\xdd\xd6\x9b\x50\xaf\xa2\xcd\xc9\xa4\xdb\x18\x35\x20\x37\xd9\xe9\x5a\x27\xe7\x6d\x74\x27\x78\x39\xdf\xb6\x23\xdf\xf2\x42\xc7\xb1\xa9\x8f\x19\x98\x5e\x14\xc5\x61\x84\x3d\xd3\xb5\xb1\x1f\x04\x4e\x44\xa9\xeb\xd9\x9e\x31\x16\x6d\xef\x69\x52\x7d\xb8\xbe\xb4\xa7\x97\xf7\x3b\xb5\x13\x25\xaf\xe7\xe3\xa2\xd7\x9c\xd5\xd1\x2c\x27\x09\xab\x12\x94\x35\xe9\x36\x51\x3f\xbd\xa4\x00\xea\xb6\xb3\xa4\x3f\x3a\xf2\xab\x7a\xc0\xd7\xa1\x3f\xea\x27\x0b\x78\x06\xa1\xe6\x14\x7c\xa0\x39\x58\xde\x00\xda\x02\xc9\xe4\x24\x3f\x79\x21\xb2\xa5\x7b\xbd\x30\x8f\x90\xda\x1d\x3b\xbf\x3d\x24\xeb\x05\xb8\x42\xe5\x85\x3a\xcf\xef\xee\xbf\x6c\xd0\x04\x80\xf7\xd3\x70\xb2\xb8\x51\x33\x0a\x9d\xbd\x5f\x50\xd5\xdd\xc0\xba\x48\x53\xc3\xf2\x1d\x4a\xaa\xc4\x90\x72\x51\x9d\xeb\xb3\x24\x5b\xd7\x59\x84\x2e\xc2\xc8\x0c\xb5\xb9\x72\xbe\x9a\x31\x1a\xdc\xbf\xaa\x3d\x95\xe6\x8a\x77\x22\xdb\xeb\xb7\x83\x55\x86\x37\x71\xbf\x2b\x03\xfd\xcb\x98\x68\xce\x81\xb6\x9d\xcd\x61\xb6\xd5\x7a\x95\xf3\x3c\x6b\xe9\x2f\xca\xa9\x96\xcd\x48\x6c\x19\x63\x5b\xdf\xf3\x5b\x6d\xac\xbd\xa3\x77\xf4\x26\xf3\x2f\x34\x31\xd7\xab\x27\xe5\x17\xe6\xac\x33\xfe\xe0\x16\x19\x63\x7b\x36\x4e\xa1\x6d\x18\xbd\xb6\x4f\xf3\x37\x6f\x4a\xb7\x17\xa6\x60\xad\x8e\xab\x54\x6c\xde\x79\x5c\xe5\x6a\xd2\xf0\xaf\xca\xcc\x7e\xc4\x6a\x7b\x9d\xc0\xed\x65\x39\x4d\xf3\x37\xca\x6d\xce\xa6\xd3\xcb\x71\x4c\xcb\xae\xb3\xd5\xfe\xeb\x82\x4b\xd9\xcd\x59\x8d\xd3\x51\xea\xf7\xfd\xda\xa6\x83\x0e\x30\x25\x3d\x60\x5f\xb5\xe5\x62\xf0\xf2\x7f\x48\xfa\x4e\x8b\x22\x73\xa0\x49\xfc\x5a\x36\x62\xd6\x44\x96\x4c\x54\xfd\x96\xc1\xc5\xdb\xa6\x34\x3e\xb9\xe1\xdd\x2d\x46\x22\xc9\xd3\x42\x41\xd7\x52\xea\xb5\xd2\x48\x9a\x9e\x9e\x32\xce\x4b\x52\x46\xe9\x92\xde\xde\x20\xd3\x35\x92\x27\x7d\x64\x03\xef\x5c\xcf\x73\x1d\xdb\x0b\x3c\xd3\x0b\x3d\xb0\xb0\xeb\x78\x81\x17\xfb\xd6\x14\x6b\xd5\xab\xa9\x4b\x88\x3b\x07\x12\x65\x33\xa7\x74\x97\xe5\xf4\x76\xd8\xd4\xb5\x5d\xa5\xdd\x38\xca\x09\x66\x1d\xc1\x55\x16\x1a\xc7\xfe\x6b\x54\x1b\x33\x77\x47\xca\x62\x81\x15\x5a\xc3\x1d\x92\xcf\x48\xc0\x9f\xb7\xbf\x09\xc1\x0f\x81\x72\x82\xad\x16\x46\x26\xb6\x5d\xd7\x23\xbe\x4d\x4d\x0c\x76\x10\xc7\x60\xc5\xd4\x21\xc4\xc5\x31\x0d\x99\xe3\x11\x86\x4d\x27\x88\xb1\x0f\x96\xe7\x98\x3e\x98\xa6\x1f\x31\x13\x28\x84\x2c\x74\x82\xc8\x35\xc6\x1b\xdf\x6f\x55\x75\xbb\x34\x6a\x60\xcd\x25\x4f\xfb\xf2\x98\x46\x42\x64\x54\x6b\x7d\xca\x07\x27\x99\x73\x78\xe6\x71\x2c\xe1\x88\xcb\x3e\xe9\xe1\x3b\x41\x8f\x24\x5b\x2f\x1e\xaf\x15\xd9\x1c\x89\x89\xed\x40\x56\x6c\x87\x20\xbc\x1d\x5d\x19\xaa\x9e\xe9\xec\xa9\x7d\x14\x0b\xbe\xbd\xe8\x52\xcf\xd9\x93\x27\x80\x29\xc5\x1c\x71\x5c\xb2\x87\x4c\xdc\x3f\xde\xaf\x2e\x12\x94\x0f\x9e\x74\x1a\xf2\x05\x16\x3d\x4f\x99\xaa\xe0\x83\xfa\x2b\x87\x99\xc7\x0d\xb3\x8e\x1b\x66\x1f\x37\xcc\x39\xd5\xb2\x6a\x89\xae\x67\x5b\xbd\x77\x30\x97\x4f\xd8\x7b\x40\x45\x07\x2f\xf0\x67\xeb\x5e\x60\xe3\xf9\xe4\x72\xc0\xd2\xec\xda\x02\x47\xbd\xbf\x2f\xf0\x1d\xdc\x7e\x83\xa1\x6a\xad\xc1\xfb\x99\x07\x61\xf5\x63\xdb\xa9\x3f\xbb\x99\x31\x0f\xc4\x69\x43\xf6\x7a\x0e\xbf\x8d\x21\xd7\x2b\xdf\xfe\xac\x59\x4f\xab\x39\xea\x8a\xf4\x90\x93\xdd\x7d\x3a\xee\xbc\xee\xc8\x5e\xf9\xb1\xad\xef\x29\x24\x1b\x46\xce\xab\xae\xae\xd9\xb6\x3e\x69\xfe\xf0\xb5\xe4\xb7\xea\x85\x3b\x30\x5c\xdf\x0f\x77\xb4\x87\x9e\xf8\x8a\x27\x30\xc7\x1f\xa8\x1c\x57\x20\xbf\x31\x77\xfc\xd3\xc0\x8b\x06\xa5\x64\x18\x93\x37\x7d\x46\x3b\x55\xfb\x5b\xf2\xb7\xed\xcb\x66\x4b\x78\x8f\x40\xaa\x12\x4f\x47\xbc\x43\x71\xca\xbd\xfb\x1c\x40\x1c\x41\x32\x83\xb2\x51\x79\x70\x5c\x92\x45\xbc\xc8\x8e\x28\x31\x59\x71\xdc\x65\xa6\x06\xf2\x68\x56\x13\xd7\xdd\xc3\xfe\x3d\x62\x3c\xd6\x50\x53\x29\xf6\xb5\xd1\x7f\xd6\x48\x3e\xbc\x26\xdb\x8a\x59\x12\x1c\xbf\x70\x3d\xf8\xf6\x51\xa3\x89\xea\x43\x90\x75\xd3\xf6\xa6\x21\x7e\x8f\x72\xa2\x36\xf5\x3f\x0f\x9c\x1e\xd6\xa3\x66\x5f\xe5\x1d\xbf\x26\xb9\x10\x61\x4f\x47\x72\xf7\x71\x81\xa1\x30\xdd\x1b\xfb\x5a\x90\xbf\xeb\x01\x73\x92\x34\x6f\x01\x0c\xdf\xf5\xef\x1f\xf8\xdd\x4d\x44\xeb\x97\xf5\xf3\xb2\xf5\x91\x37\x7a\x17\x7d\xc4\x65\xfd\xe3\x31\xac\xd6\x57\xb5\xaa\x5b\x7a\x95\x33\x42\x5c\xa0\x87\x8f\x77\x25\x3e\xbb\x17\x19\x88\xac\xee\x72\x25\x31\xe2\xdb\x44\x29\x60\x77\xc7\xee\xc4\xf0\x23\x1b\x07\x79\xdd\x87\x0f\x63\x86\xd7\x77\xe5\x7d\xaf\xd1\x07\x36\x52\x22\x55\xcb\xbb\x71\x2d\x10\xe9\x05\xca\x67\xe3\x4f\x76\xdd\x1f\xc1\xbb\xda\x00\xfa\x0a\xaf\xbf\xe4\x5c\x26\xe5\x37\x1e\x7a\x9f\xa8\x6d\x8e\xca\xeb\x0f\x70\x2d\xf1\x5b\xe9\xac\xfb\xfe\xd6\x89\x46\x70\xe9\x27\xa9\xfa\xd9\xcd\xf0\x63\x4e\x87\x6c\x7e\x2f\xfe\x8e\x30\xfa\xc3\x96\x71\x25\xab\x9f\x7e\x3a\x68\x28\x16\xd7\xbf\x1c\x23\x54\x39\x50\x8b\x54\x7d\x43\x48\x5e\x2a\xd2\xb4\x41\x76\x8b\x88\xa4\x83\x7f\x6b\x06\xc6\x1a\x68\xc6\x74\x1f\xbb\x38\x06\xab\x93\x57\x82\x0e\x23\x32\x61\xe7\xed\x4f\x18\x51\xea\xb9\x96\x47\x7c\x8f\x80\xeb\x61\xcb\x71\x62\x2f\x0c\x02\xec\x52\x8a\xb1\x19\xfa\xbe\xe5\x78\x34\x0a\x2d\x6a\x45\x4e\x6c\x82\x15\xf9\xc4\xc2\x0e\x38\x8e\xeb\xe0\x10\x88\x71\xf3\xff\x01\x00\x00\xff\xff\x4f\x7c\x98\x71\x9c\x5a\x00\x00")
+var _thorYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7d\x6b\x8f\xdb\x38\xb6\xe0\x77\xff\x0a\xe2\xee\x02\xea\x5e\xb8\xca\x92\xfc\xae\x0f\x0b\x74\x5e\x3d\xb5\x9d\xdb\xc9\x24\xd9\xc1\x02\x83\xc1\x80\x92\x8e\x6c\xde\x92\x25\xb5\x48\x57\xd9\x93\xdb\xff\x7d\x71\x48\x4a\xa2\x1e\x96\xe5\x47\x3a\x95\x9e\xa4\xea\x43\x4a\x12\xc9\xf3\xe6\xe1\xe1\xe1\x61\x92\x42\x4c\x53\x76\x47\xc6\xb7\xf6\xad\x33\x60\x71\x98\xdc\x0d\x08\x79\x84\x8c\xb3\x24\xbe\x23\xf6\xad\x73\x6b\x0f\x08\x11\x4c\x44\x70\x47\xfe\x06\x2f\xd7\x94\xc5\xe4\xd3\x3a\xc9\xc8\x4f\xef\xef\x07\x84\x44\xcc\x87\x98\x03\xb6\x22\x24\xa6\x1b\xb8\x23\x6f\x7f\x7e\xff\x16\x3b\x94\x8f\xb6\x59\x74\x47\xac\xb5\x10\x29\xbf\x1b\x8d\x9e\x9e\x9e\x6e\x57\xf1\xf6\x36\xc9\x56\x23\xdd\x92\x8f\xa2\x55\x1a\xdd\x20\x00\x10\xdf\xae\xc5\x26\xb2\x06\x84\x04\xc0\xfd\x8c\xa5\x42\x42\xf1\xe1\xf5\xc7\x4f\xe1\x36\xc2\x11\x89\x48\x08\xf5\x7d\xe0\xbc\x02\xcc\x80\x43\x86\x40\x23\x18\x37\x7a\xcc\x11\xf6\x53\xeb\x29\x4a\x7c\x1a\x11\x81\xe0\xc7\x49\x00\x03\x41\x57\xba\x8d\x02\xfd\x27\xdf\x4f\xb6\xb1\xe0\xcd\x96\x3f\xa9\x41\xd5\xf0\xf8\x0d\x49\xbc\xff\x02\x5f\x70\xa3\xf5\xa7\x8c\xc6\x9c\xfa\xd8\xa0\xb3\x07\x51\xfd\x2e\x6f\xfe\x22\x4a\xfc\x87\xce\x86\x5e\xfe\x45\xde\xe4\xf5\x23\x1c\x81\x16\xf0\x0b\x12\x25\x2b\xb3\x99\x04\x34\x84\xac\xb3\xa5\xd0\x1f\xd5\x1b\xff\x8a\x84\xeb\x68\x87\x84\x25\x28\x49\x46\x9b\x37\x00\x2d\x63\xbd\xa0\x1c\xc8\x8a\x72\x92\x66\xcc\x07\x42\xe3\x80\x84\x00\x9c\xac\x19\x17\x49\xb6\x37\xda\x7f\xda\xbd\x4f\x92\xa8\xd9\xc3\x7d\xcc\x53\x90\x04\x27\x49\x48\xc4\x8e\x13\x16\x93\x34\x49\xa2\x21\x81\x98\x7a\x11\x04\xc4\xdb\x93\x9b\x1b\x9a\xb2\x1b\xb1\xc3\x17\xe4\x07\x1a\x3d\xd1\x3d\x27\xf4\x91\xb2\x08\x3f\x21\x54\x90\x11\x0d\x36\x2c\x1e\xe9\x4f\x92\x90\xc8\xbf\x09\x4a\x15\xf3\xe1\x47\x03\x92\xbf\x00\x8d\xc4\xba\x09\xc9\x5b\xf6\x08\x31\x52\x00\xb1\xc8\x80\x06\x4c\xfe\x95\x66\x89\x07\x26\xf5\x3e\x6e\xbd\xa2\x55\x0b\x49\xf4\x6b\x0f\x50\xd0\x7d\x29\xdf\xdb\x34\xa0\x02\x38\x49\x1e\x21\x23\x4f\xe0\xf1\xc4\x7f\x00\x61\x74\xf9\x0a\xbc\xed\xaa\xd9\x95\x7c\x4c\xb6\x82\x45\x4c\xb0\x0a\x0c\xaf\xdb\x10\x78\x2d\xd6\x90\xc1\x76\x43\xfc\x64\x93\x52\xc1\x90\x32\xff\xe7\xe3\xbb\x5f\x6f\x3e\xbc\x7f\xd9\x42\x4d\x10\x6b\xa3\xc7\x9f\x33\x9a\xae\xff\xfa\xb6\xd9\xab\x7e\x41\x7e\xdb\x42\xc6\x72\x24\x24\x5e\x43\xc2\x05\x15\x8a\xeb\x28\x61\x2d\x63\xac\xb0\xf1\x6f\xd1\x20\xa5\x62\x2d\xd5\xd4\x1a\x69\xe5\xe3\xa3\xcf\x34\x08\x32\xe0\xfc\x77\x0b\x5f\x10\x92\xd2\x8c\x6e\x40\x68\x1b\x80\x4f\x6e\xc8\xff\xcc\x20\xbc\x23\xd6\xff\x18\x21\x4a\x49\x8c\xaa\x32\x2a\xbf\x1b\xfd\xa4\x7a\xb8\x8f\xdf\x53\xb1\xb6\xfa\xb6\xfa\x00\x8f\x0c\x8d\xe3\x7d\xfc\xd7\x2d\x64\x7b\xd5\x6e\x05\x22\x1f\x36\x37\x29\x79\x77\x15\x93\x42\x08\xdf\x6e\x36\x34\xdb\xdf\x91\x15\x88\x9a\x29\x21\x01\x08\xca\x22\xfd\x61\x06\x3c\x4d\xd0\x3e\x96\x9d\x59\xae\x6d\x6b\x74\x09\x69\x90\xfa\xdd\x2f\xc6\x1b\x3f\x89\x05\xc4\x05\x50\xea\x97\xa6\x69\xc4\x7c\x8a\x9c\x19\xfd\x17\x4f\xe2\xea\x5b\x42\xb8\xbf\x86\x0d\xad\x3f\x25\xad\x14\x51\xdf\xf2\x91\x46\x4f\x91\x21\x4d\xf8\xc9\x74\x48\x21\x0b\x93\x0c\xc5\x2e\x16\x19\xf5\x05\xf1\x69\x14\x91\x24\xae\x11\x47\x37\xcb\xe0\xb7\x2d\x70\xf1\x22\x09\xf6\x77\x83\x56\x32\xd0\x6c\xb5\xdd\x20\x88\x52\xb2\x20\x7e\x64\x59\x12\xe3\x83\xe2\x73\xec\x83\x65\x10\xdc\x11\x91\x6d\x61\xd0\x41\xb2\x6e\x82\xb5\x93\xab\x8b\x58\x2f\x35\x8e\x2f\x69\x14\x59\xdf\x16\x9f\x4d\xd0\x3f\x00\xdf\x46\xc2\xaa\x28\xa4\x75\xd7\x90\x80\x52\x69\xca\xa1\xce\x53\xaf\x8b\xa5\x29\x24\x01\xa4\x51\xb2\x67\xf1\x8a\xd0\xe2\xe5\x77\x99\x7a\xde\x32\x35\xfa\x5f\xcf\x4c\xaa\x28\xf1\xa8\xf0\xd7\xe8\x6b\xf8\x11\xdd\x72\x90\xfe\x06\x47\xf9\x89\x7d\x20\xdb\x34\x89\x49\x12\x83\x9a\xd9\x06\x2d\x74\xfe\x6f\xfd\x90\x90\x97\xba\x3d\x5f\xd3\x0c\x88\x58\x4b\x47\x68\xa8\xe4\x8b\xe2\x10\xd8\x0d\x3a\x43\xf8\xca\x5f\xd3\x78\x05\x9c\x6c\x68\x00\xe8\xd0\xa4\x19\x3c\xb2\x64\xcb\xf1\x2b\x7e\x5b\xf4\xf9\x69\x0d\x04\x76\xe0\x6f\x71\x30\xc2\x45\x92\x72\x74\x6e\xb0\x87\x90\x65\x5c\x90\x0c\x1e\x21\x13\x10\x68\xe8\x6f\x65\x0b\x09\x2c\xf1\x69\x4c\x3c\x20\x29\xe2\x07\x01\x79\x62\x62\x2d\x27\xeb\x8c\x05\x0a\x4b\x1a\x3c\xd2\xd8\x07\x05\x22\x2a\x55\x0e\x14\xc2\x1f\x30\xee\xd3\x2c\x80\xe0\xb6\xb7\x4e\xe5\x04\x7c\x7e\x1a\xf5\x02\x69\x80\x32\xf9\x8a\x0a\xfa\x0c\x55\x4a\xec\x53\xb8\x23\x34\xcb\xe8\xbe\xf1\x8e\x09\xd8\xf0\xbb\xc6\xe3\x4b\xf5\x70\x04\x5c\xb0\x0d\x15\xf0\x4c\x14\x32\x07\x47\x2a\xce\x86\xc5\x6c\x43\x23\x54\x20\x12\x26\x19\xa1\x44\xec\x4c\x15\x15\x09\xe1\x5b\xdf\x07\x08\x06\x2d\x7c\x2a\x55\x12\x95\x01\xfb\x60\x9c\x78\x2c\xa6\xd9\xfe\x86\x03\xcd\xa4\x3a\x6c\x53\xf4\xc7\x71\x30\x9f\xa6\x43\xc2\x62\x3f\xda\x06\x38\x99\xe0\x23\x16\x8b\x8c\xc5\x9c\xf9\xa5\x0a\x67\x10\x6e\xe3\x40\xe9\x86\x14\x4f\x08\x08\xe5\x08\x57\xa9\x9f\x41\x62\xea\xee\x7d\x09\x6f\x48\x59\x24\xd7\x6d\x4a\x0b\x8b\x51\x35\x6c\xff\x82\x2c\x91\x4a\x88\x2f\xf0\xdb\x6d\x06\x84\x71\x92\x41\x9a\x64\xe2\xcf\xa1\x82\xaf\x35\x7f\x7f\xa6\xfc\x99\x2a\x61\x17\xf4\x3f\x53\xae\x11\x60\x49\x6c\x1d\x58\xb7\x8c\xfc\x24\x28\xd4\xa9\xa1\x42\xcf\x7c\xf1\x92\x81\xc8\x18\x3c\x02\x41\x24\x50\xd7\x0e\x38\xeb\xcf\x86\x5d\x69\x96\xa4\x90\xe1\x3a\xb8\xf9\x0e\x87\x0a\x74\x04\xab\xfe\xa3\x6c\x2d\x47\x05\x5f\x35\x3e\x80\x1d\xdd\xa4\x51\x6b\x4b\xd9\x23\xf9\xdf\x37\x2d\xaf\x08\xb1\x77\x33\x1b\x7f\x26\xf6\xd4\x9d\xd9\xb6\xbd\xb0\xc3\xc0\xb6\xa9\x33\x9b\xce\xdc\x39\x9d\xd3\xb9\x3b\xb6\xa7\x0b\xd7\xf6\xdd\x71\x30\xa6\xe0\x06\xfe\x62\x46\x03\x67\x6c\x4f\x67\x0e\x75\x17\xee\x32\x58\xcc\xfd\xb9\xef\x2d\x26\xe3\xe9\x78\x36\x9d\x2c\x5d\x2f\x70\xa6\x93\x05\x78\x73\x98\x87\xbe\x1d\x8e\x67\x63\xd7\x83\xa5\x6d\xbb\xcb\x43\xd2\x87\x11\x16\xba\x82\xd1\xe7\x07\xd8\xff\xe1\x6b\xe8\x8f\x6a\xf0\x5f\x60\xff\xb5\xe5\x57\x93\x81\x3c\xd2\x68\xdb\x22\xc8\x72\x3e\x59\x61\x68\x87\x3c\xc0\xfe\x5b\x13\x6b\x89\xd4\x75\xe5\x5a\x75\x79\x58\xb0\xed\xcb\xfe\x39\x87\xc4\x35\xcd\x92\x24\x7c\x16\xd6\xb2\x0c\x7a\x95\x12\x41\x08\x8b\xef\x64\x8c\x6b\x3f\x68\x95\x00\x2b\x97\xb3\x07\xd8\x4b\x87\x04\x5d\xed\x2c\x79\x84\x60\x98\x7b\xde\x19\xa4\x40\x45\xe1\x6b\xcc\x26\x44\xb0\x0d\x70\xab\x65\x36\x0e\x69\xc4\x61\x70\x58\x36\xda\x1d\xc4\x16\xd7\xb0\x55\x0a\xb8\xd8\x63\xac\x1f\x17\x3c\xc5\x33\xd8\xa5\x91\xb4\x68\x85\x23\x70\x89\xd2\x6d\x20\x7b\x88\x24\x01\x92\xb0\x45\xe7\xd0\x1d\xa9\xe8\x25\x1f\xb4\x90\xb4\xf4\xdb\x30\x20\xad\x5c\xad\x2c\x4a\x09\xc4\x68\x7b\x03\x82\x13\x94\x8c\x45\xf3\x21\xea\xae\x8a\x04\x8b\x35\xb0\x8c\x78\x11\x7d\x00\xd7\x23\x6b\xca\xd7\xc0\x6f\xc9\xdb\x24\x79\x40\x67\x0e\x29\xbf\x86\xca\x6b\x04\x0f\xfd\x2c\x2d\x88\x24\xcc\x92\x8d\xfc\x48\xad\x9a\xb2\x24\x29\xdd\x25\xfd\xa9\x0c\xd2\x2b\x37\x50\x0e\x50\x22\x23\x99\x6f\xf4\xa0\x9e\x62\x1f\xc5\x30\x8a\x74\x43\x92\x01\xf5\xd7\x7a\xf5\x97\x93\xa7\x49\x97\x21\x49\x32\x24\xe3\xa3\xfa\x92\x65\x84\x7a\x1c\x62\x1f\x6e\xbf\x35\x53\x25\x19\xd5\xf6\xe2\x90\x34\x77\xca\xf5\x41\x09\x1f\xa1\x5b\x2d\x78\x73\x11\x53\x97\x61\x63\x63\xc5\x90\xe0\x90\x45\x02\xb2\xea\x9e\x4a\xbb\x45\xea\x61\x5d\xde\xc8\xce\xde\x65\x01\x64\x35\x03\xd3\xbb\x71\x61\xd6\x2a\xcd\x8f\x3b\xfe\x0a\x01\x8d\x8d\x9f\x31\x01\x19\xa3\x2d\x86\xe6\x0f\x77\xfb\x11\x2e\x45\x17\xeb\x2a\x12\xbc\x06\x1a\x54\xb8\x82\xbf\xff\xef\xe6\x57\xd8\x89\x9b\x97\xdb\x8c\x27\x59\x1f\xf0\x74\x2f\x23\x6c\xa6\x5a\x59\x5f\x4a\x4b\xba\x84\xfd\xa0\xa0\x77\x91\x54\x51\x13\x02\x29\xd0\x08\xf6\x08\x37\x5b\x94\x1e\x5c\x4d\x0d\xd0\xb4\x6a\x0b\x39\x24\x22\x49\x99\xcf\x87\x24\xc3\xf0\x90\x34\x83\x89\xb1\xdb\x55\xe3\x96\xe1\x48\x24\xa8\x09\x18\x69\x92\xf3\x28\x11\xf4\x01\x70\x67\x10\x7c\x08\xd0\xa4\xc9\x68\x14\x5a\x39\x0c\x7a\xe1\x67\x5a\xcc\x89\x97\x04\x7b\x69\x6e\x8b\x9e\xd4\xc8\xdb\x98\x09\x12\x40\x48\xb7\x91\x90\x73\xae\x25\x2d\x73\xce\xba\x52\x99\x4c\x84\x7b\xa9\xdd\x77\x9d\xfd\xae\xb3\x5f\x4b\x67\x47\xd2\x17\x38\x57\x73\x65\x63\x53\x71\x37\x3a\xde\xeb\xed\x75\x98\x18\x47\x1e\xb4\xb0\xc8\x54\x54\xc9\x35\xe5\x71\xb1\x55\x9c\x64\xe8\xc5\x72\x0c\x8f\x51\x21\x23\xc3\x79\xa7\x08\xb5\xfc\x4a\x0e\x5b\x84\xdf\x4a\x95\x30\x01\xfe\xae\x3c\xd7\x50\x9e\xab\x0a\x76\x17\xe4\x6f\x93\xd5\xcb\x7c\xe3\x77\x94\xa7\x8a\xf0\xe3\x72\x59\x4d\x3d\x69\x4e\x2a\xf5\xac\x93\x2f\x60\xaa\x8f\x8b\x8b\x09\xc4\x33\x94\x9a\x9c\x86\xdf\xad\xee\x97\xb0\xba\x39\x75\x4b\xc3\x9b\x8b\xc3\xe5\xd2\xfd\xb7\xd7\x9f\xaa\x12\x8e\x9e\x13\xee\x56\x64\x6c\xc5\xe2\x21\xe1\x10\x07\x90\xe1\xf2\xcf\x67\x29\x83\x58\xfc\xbb\xb9\x51\xdf\x75\xf3\xbb\x6e\x9e\xa5\x9b\x7d\xfd\xa2\x83\x1a\x2a\xdb\xb7\x28\xe8\xd7\xf0\x90\xbe\x6b\x41\x8b\x16\x5c\x55\x42\xfb\xba\x36\xd6\x48\x5a\x3b\x3e\xfa\x9c\xe9\x60\xf0\x05\x9b\x25\x65\x3c\xf9\xa4\x30\xf4\xeb\x5d\x4a\xe3\x00\x82\xbe\x9b\x1e\x46\x1e\xb1\x21\xdf\x56\x11\x7e\x95\x18\xe1\xc4\x73\xff\x6a\x48\xe2\xed\xc6\xc3\x19\xc7\xb2\x3c\xe0\xc2\xb2\xe4\x8e\x07\x2e\x05\x22\x4c\x3a\x15\x72\x96\x48\x32\x62\x59\x21\x8b\x69\xc4\xfe\x05\x41\xf3\x9b\xe2\x15\x7e\xfd\x8d\x31\xfb\x45\x3e\x99\xb5\x70\x5a\x9b\xcc\xeb\x32\xfc\x64\xc6\x15\x7c\x33\xa3\xdb\x12\x56\x3d\x31\x0c\x25\x3f\xfc\x08\xfd\x05\x8e\xb9\xfb\x2c\x94\x89\x6e\x9c\xad\x62\x2a\x30\x3b\x00\xbd\x07\x96\x9b\xb0\x0d\x87\xe8\x11\xf8\xf3\x63\x53\x77\x84\x38\xa3\x4f\x6d\x8f\x1b\x21\x5e\x6b\x64\xa6\xd7\x8f\x3e\xb3\xe0\x02\x8d\xfd\xb4\xbb\x7f\x75\xa2\xb6\x7e\xa0\x4f\x35\x9f\xe6\x68\x93\xf7\x10\x63\x32\xc9\xa9\xcd\x4a\xe9\xea\x67\x17\x1a\xc7\x13\x0c\x21\x33\x26\xaf\x42\xde\x0c\x3a\xa2\xe8\x30\xc1\x09\x0b\xc8\x0f\x2c\x24\x19\x7d\x92\x53\x0a\x19\x96\x9b\x3a\x14\x9f\x16\x9d\x18\x6d\x7f\x7c\x7e\x92\x46\xa3\xe8\x5d\xd8\x7c\x7c\x88\xe6\xb9\xad\x30\x08\x68\x0d\x2a\xed\x7a\x34\xfe\x40\x9f\x3e\xed\xac\x76\x01\x1d\xa1\x7b\xce\x52\xf1\xc7\x0a\xea\x15\xc5\xa7\x55\x66\x34\x52\x28\x3b\xe6\xe3\xfb\x57\xcf\x4f\x20\x3a\x19\xa7\x79\x53\x04\x3a\x34\x0d\x7a\xfa\x9a\x07\x28\x86\x2b\x3d\xad\x47\xc5\x47\x5d\xde\xdf\xd7\xf3\xe5\x0a\xc1\xfd\xa6\xa6\x0b\x16\xf4\x9a\x2d\x7a\x27\x3e\xb0\xa0\x62\x24\xcd\x1f\x7b\x37\x09\x60\xee\x84\x6e\x30\x5d\x2c\x28\x5d\x50\x07\xa8\x6d\x87\xb0\x18\x3b\x6e\xb0\x74\x97\xb3\x59\x40\x27\xee\x24\x58\x2e\xc7\x4b\x3a\x75\x9c\xd0\xb7\x3d\x58\x38\x30\x9b\x86\x34\x98\xba\x34\x5c\xa0\x68\xe1\x0e\xe8\x28\x06\xf1\x94\x64\x0f\xa3\x14\x0a\xe5\xef\xd0\xc8\xe2\x24\x56\x9b\x26\xea\xae\x64\x2e\xf2\x96\x3f\x3f\xf6\x9d\xb5\x0e\x7c\x0f\x90\x7d\x14\x54\xc8\xb4\x88\x11\x1e\x16\x1b\x79\x94\xc3\xcd\x8a\xf2\x1b\x79\x88\xac\x41\xb3\xd2\xe0\x95\x63\x9d\x67\x1a\x9b\xf4\x2f\x4e\xb5\x19\xf4\xc7\x63\x3d\x5e\xf5\x5c\x1b\x8b\x8d\x8c\x81\x6a\x92\xc0\xd3\x9a\xf9\x6b\x9d\x21\x2a\x83\x2b\x78\x84\x4d\x7f\x12\xc3\x4e\xa8\xef\x9e\x1f\xf3\xba\x78\x84\xa7\xfa\x7e\xa6\xfc\x3d\xe2\x5e\xb2\x49\x9f\xe9\xfb\xa2\xfc\x29\x53\x73\x24\xd9\x64\x7c\xba\x78\xd5\x96\xa3\x53\xa3\x97\xa5\x16\x43\x98\x8c\x21\x3b\xe0\x04\xc3\x71\x3a\x19\xbe\xe0\x1a\xbe\xce\x97\x08\x98\x0a\x4c\xfe\xee\x0c\x89\x63\xbb\x93\x7f\x0c\x2b\x71\x32\xc7\xb6\x06\xdd\x94\x54\xb6\x88\xc5\x02\x56\x90\x35\x70\x48\x21\xf3\x21\x16\x2c\x02\x7e\x12\x12\x7e\xb2\xd9\x50\xc2\x01\xe5\x19\xf3\x89\x28\xf7\x95\x7f\x69\xf6\x28\xe1\xb6\x11\x6e\xfb\x1f\x88\x10\x84\x21\x9e\xa3\x7c\x34\xe4\x96\xd7\xd1\x19\x4e\xed\xe1\xb2\x27\x52\x15\x03\xdb\x57\x6f\x50\x9f\xa5\x9a\x48\x1d\x40\xf7\x21\xd6\x1a\xc0\xbf\x2d\x15\x78\x03\xf0\x17\x25\xef\x48\x2d\x7d\xac\x74\x84\x98\x6d\x79\x43\x03\xea\xd4\x31\xce\xba\xd6\xe8\x23\x83\x44\xbc\x76\xd2\xf5\xdb\xa2\x8c\x42\xae\x34\xe1\x9a\x34\x4c\x9d\xe3\xbd\x8e\x75\x78\x27\x83\xe9\x47\x6c\xf7\x61\x2a\x2b\x92\xcb\xc3\xaa\x21\x49\xb5\xea\xe0\x0a\xfa\xb7\x2d\x6c\x21\x30\x69\x3f\x24\xab\x2c\xd9\xa6\x6a\xff\x34\x91\xc3\x7e\x8b\xec\x28\x4f\x51\x9b\x3c\xa9\x00\xf1\x95\x79\xf2\x6f\xc1\x06\x3c\x86\xa3\x37\xfc\xad\x9c\x09\x5f\x30\x96\x61\xb0\xf4\x54\xe5\xd8\x2b\x1b\x54\xd2\x3f\xde\x46\x11\xba\x9e\xdb\x2c\x86\x80\xb0\x90\xc4\x89\xc8\xdf\x7e\x8b\xac\xf8\xa8\x70\x96\xea\xb0\x96\x27\xfe\xef\x8e\xd1\xcc\x28\x0c\x60\xd0\xcc\x5f\x83\xff\x80\x04\x41\xf7\x01\x5d\x7c\x3c\xa9\x43\x23\xf6\x08\x43\xe5\x56\x04\x1e\xc1\x22\x1c\xe7\x12\x49\x01\xb7\xff\x1a\x94\xca\x2b\x1e\xe4\xda\x4c\x88\x35\xb5\xc7\x87\x41\xdd\xc6\xcf\x04\xd8\x11\x96\x67\xd8\x5f\x93\xa1\x7c\x1f\xfb\xa6\x9f\x98\xaf\xbd\xd0\x5c\xc9\xa5\x1c\xca\x6f\x0c\x7e\xb9\xc3\xd3\xd4\xdf\xd2\xf1\xdb\xd0\x9d\x8c\x4a\xf3\x17\xb0\x66\x71\x70\x8a\xf3\xb7\xa1\xbb\xdc\x75\x45\x38\x30\xa4\xaf\x9d\x56\x1a\x45\xc9\x13\x9a\xcd\x04\x93\xcd\x3d\xd9\xb3\x09\x6b\xcd\xd9\x73\x07\xdd\xf4\xee\x76\x5d\x37\x2c\xc6\x95\x1a\x3f\x09\x74\x16\xeb\xf4\xa4\x24\x2c\xc9\xa5\xc8\x57\x03\xee\x0c\xd8\x4e\x56\x2c\x29\x23\x5f\x43\x52\x3f\xe4\xb5\x43\xfa\xea\x15\x9a\xda\xe7\x01\xed\x88\x9b\xc5\x4d\xd4\x7e\xca\x51\x35\x6b\x16\x44\x31\xb4\x4d\x77\xa8\xca\xa1\xc4\xf0\x54\x5d\x0d\x54\xc8\x60\x04\x67\xb6\xe9\x2a\xa3\x78\x26\x40\x24\x65\xc1\x14\xcc\x10\x25\xe9\x16\x33\xfe\xc9\x06\x38\xa7\xf2\xec\x72\x94\xe8\x43\x9c\x22\xdb\xc6\x0f\x84\x86\x42\x67\x47\xa4\x09\x67\x08\x52\x9e\x49\x4f\x48\x82\xd9\x13\x19\x24\xd9\x8a\xac\x69\x9a\x42\x8c\x69\x6f\x45\x4f\xe5\x9a\x91\x3f\x31\xb5\x63\x9c\x84\xa1\xd9\x75\x06\x6a\xf8\x80\xd0\x15\x2d\x3c\x14\xa2\xac\x86\x95\x78\x3c\x89\x40\x80\x45\x38\x08\xc4\x16\xc3\x7c\x43\x42\x71\x8b\x07\x67\x5b\x44\x1e\x4f\x7b\x77\xda\x8f\x36\x4e\x95\x5f\x8e\xde\x6b\x9c\x6a\x6e\x58\x9b\x6e\x38\xb6\x73\x58\xe2\x3e\x4a\x0c\xd1\x1f\x7b\x9f\x25\x22\xf1\x93\x88\x0f\x11\xd3\xd8\x20\x6c\x81\xed\xd7\x90\x4a\x69\x3e\xff\x53\xc1\xd2\x22\x98\x46\xf2\xf3\xd5\x04\x13\xcc\x7c\xcb\xef\x82\x79\x15\xc1\x2c\x27\x14\x4c\x2e\x3f\x65\x32\xc9\x8f\xeb\xe8\x50\x5a\x51\x0d\x04\x36\x4c\x08\x14\xdc\x0a\xbb\xba\x4e\x57\xf5\x0e\x72\x94\xc0\x0a\xfb\x14\x50\x65\xba\xbc\x8d\x94\xfc\xa2\x30\x39\x27\xc3\xe4\x7c\x71\x98\xdc\x93\x61\x72\xbf\x38\x4c\xe3\x93\x61\x1a\x7f\x71\x98\x26\x27\xc3\x34\xf9\x32\x30\xfd\xf9\x66\x0a\x99\x26\x7f\x78\xa6\xc8\xd3\xac\xae\x3c\x59\x98\x59\x66\x7c\xd0\x42\xb7\xef\x73\xc6\xe5\x73\x86\xd8\xbd\x33\x43\x41\x27\xcf\x1b\x79\x56\xee\x35\x15\xa8\x84\x0e\xf7\x7f\x21\x3b\x13\xb6\x46\xe3\x2b\x02\x56\xa4\x1e\x9f\x09\x5b\x5b\xfb\xef\x86\xa7\x61\x78\xf2\x0c\xc9\xc3\xb6\x47\x1f\xfb\xbd\xa2\xe9\xc9\x2b\x36\x95\x47\xae\xf9\xa0\x85\x76\xa7\x18\x1f\x8f\x46\xaa\x2a\x14\xc4\x90\xad\xf6\x95\x33\xca\xc6\x70\x4f\x3a\xdf\xb5\x36\x2c\x29\x2a\x5a\x69\x73\xd3\xc3\x84\x15\x75\xa7\xfc\x64\x03\xea\x3c\xf6\x13\x9e\x60\xbd\xe1\x20\x4a\xcb\x55\x0c\x50\xd4\xdb\x19\x56\x60\x93\x47\xb1\xd1\x8a\xc9\x03\xd3\x22\x0f\xe8\x14\x3d\x61\x98\x0e\x17\xd6\x0f\x90\x8a\xdb\x56\x6b\x59\xa2\x70\xb6\xd5\xec\xb4\x96\x45\xff\xdf\x94\xa7\xad\x19\x8c\x58\x48\x9e\x0f\xcb\xba\x06\x98\xa4\xb8\xd9\x46\x82\xa5\x11\x90\x1f\xa8\x20\x9b\x84\x0b\xe2\x4e\x67\x3f\xb6\x5a\x8a\x4a\x9a\x4b\x97\xa1\x68\xa6\x99\xb7\xa6\x16\xfc\x5b\x58\x15\x5d\x7a\xe1\xb0\x51\xd1\x1b\x29\x37\x62\x77\x45\xbb\x82\x3b\x62\x31\x3c\x45\xf2\x10\x30\xc6\xff\x63\x5d\x2c\xcb\x08\xff\x9f\x6d\x61\xb0\x73\xa9\x7f\x2d\x7d\x17\x09\x14\x19\x06\xd7\xcb\x19\x67\xb5\xa5\x19\x8d\x05\x14\xf1\x4e\x65\x05\xb0\xbc\x2c\x56\x4c\x50\xe5\xbb\x20\x40\xc5\x8e\xe4\xf9\x9b\xfb\x57\x26\xe7\xc8\x36\x8e\x50\xa6\x43\xdc\xd5\x60\xdc\x14\xc5\x76\xa5\x53\xaa\xd3\x70\x17\x9e\x91\xbb\x71\xd1\xac\xae\x89\x1c\xef\x75\xc5\x30\x34\x8e\xd2\x6a\x8a\xe4\xcb\x40\x8b\x84\x3f\x05\xd0\xa7\x35\x60\xf5\x62\x64\x36\x72\x50\xb6\x47\xb6\x7a\x49\xc0\x80\x5f\x08\xa3\x97\x24\x11\xd0\xf8\x4f\x6b\x33\x74\xf6\xf1\xa7\x9d\x69\x35\x02\x2c\x1e\x8d\x99\xd4\x3e\x64\x3d\x32\x1d\xcb\x12\xd4\x86\x8d\x90\xad\xb1\x1a\x5f\xb9\x87\x89\xfb\xe4\x19\xdc\xe8\x49\x39\x5e\x11\x26\x54\xd5\x4c\x4c\x2e\x56\x29\x42\x0c\x2b\xb4\x9b\x05\x34\x9f\x59\x3a\xe4\x27\xc4\xea\x9d\x64\xae\xd5\x21\x13\x9d\x9b\x0b\x86\xfd\xc3\xdf\x77\xbf\xdc\x92\x37\x98\xc2\x4f\xa3\x48\x76\x9f\xa1\xb3\xa2\xce\x36\xa2\x6d\x48\xb6\x02\x32\x39\x5b\xca\xda\xc8\x21\x9a\x20\x3e\x44\x5f\x40\x66\xfe\x63\xa9\x18\xad\x99\xe5\x02\x0f\x7f\xb0\xcf\x34\x03\x49\xcc\xa2\x5f\xb2\xa1\xa9\x72\x9b\x72\xf5\x36\x6a\xda\xcb\x4f\x89\x07\x61\x92\x19\xa5\x44\x9b\xbd\x72\x91\x6d\x7d\xf1\x36\x59\xad\xb0\x4f\xf4\x9c\x3e\x1a\x4f\x54\x0d\xc9\x2f\x25\xcb\x49\x0c\x87\x92\xb7\xbb\xce\x9d\x75\xe6\x1c\x1e\x63\x3a\x56\xc6\x7c\x83\x64\xb7\x06\xcd\xb6\x37\x9d\x4d\x9b\x84\x69\xef\x43\xc1\x5e\xd4\xcf\xd3\x1a\xa8\xfd\xd5\x1b\x79\x16\xf3\x4c\x3d\x2c\x52\x42\x29\x49\xd1\xf7\x2d\xdd\xff\xc2\x1d\xc6\x63\x69\x45\x52\xdb\xa0\x5b\x62\xf3\x36\x80\x65\x2f\xb5\xf5\x92\x87\x4e\x55\x1a\x8c\xae\x79\xf4\x00\xfb\x5b\xf4\xaa\x37\x49\x56\x7e\x9a\xc1\x86\x32\x19\x9e\xc6\xe4\xc6\x5f\x60\x8f\x33\x49\x9e\x36\x50\x0c\xa0\xa6\xec\x94\x72\x8e\x2b\x06\x8e\xd5\x9b\x3e\x0a\x9a\x15\x45\x92\xb0\xad\xc4\xe4\x79\x1a\x08\x5d\xdc\xee\x03\x72\xec\x42\x3b\xf1\x75\x52\x20\x4c\x04\x4a\x89\x1d\xe9\x6a\xfc\xc7\x85\xd0\xbc\x0c\xc0\x10\xc3\xda\x55\x00\x83\x16\x7c\x0d\x29\xcb\x2f\x0c\xd0\x2b\x2a\xb9\x52\x8a\x81\x63\x42\xa3\x99\x13\xa3\xab\xad\xca\x0c\x7d\x3e\xcc\xe5\x5a\x96\x3d\xce\xc5\x59\xd5\x37\x0d\xf5\x09\xd2\x62\x00\x15\x1d\xbd\xd5\x45\xc0\x86\xb9\x35\xc4\xa2\x5a\xde\x1e\xef\x25\x40\xeb\xab\xda\x7a\x6c\x95\x6f\x27\x2b\x71\x5f\xc3\x4e\xbb\x2e\xfc\x96\xe4\x2b\x19\xc7\xb6\x35\xb4\xc5\x18\x49\x86\x09\x96\xb6\x8e\xc4\xca\xa6\x45\x92\x0c\xc5\xb3\x75\xfe\x57\x94\xe1\xae\xfc\x79\xa4\xfe\xbe\xf9\xf8\x80\xdb\x96\xff\x60\x7f\x52\x14\x7f\x45\x9f\xf3\xd4\xd6\x8f\x34\x63\xe8\xa1\xf3\xc3\x2d\x2b\xd5\x45\x3b\x53\xf6\x15\x02\xc4\xfa\xac\x58\xf2\x43\x2e\x0c\x77\xe4\x3f\x30\x29\xe2\x3f\x7e\x24\x9f\xf1\x30\x93\x4e\xf8\xad\x48\x94\x7c\x91\x9f\x5d\xf9\x8c\xf9\xb0\xff\x17\x0d\xd1\xef\xea\xe7\x9b\x3b\x08\x11\x50\xd1\xd2\xa6\x93\xa6\x9a\xb2\x59\x96\x98\xcb\x1c\xf3\xdf\x45\x13\x6d\x6d\xdc\x11\x88\xf5\x71\xa3\x92\xdf\x59\x62\x18\x94\xae\x1b\x4b\x06\x2d\x1c\x30\xe7\xb0\x6d\x8a\xc5\x92\x71\xd9\x2a\xfe\xa9\x6f\x3b\x1a\x12\x10\xeb\x7f\xca\xbb\x49\xee\x03\xf5\x87\x94\x9d\x5f\xf5\x09\x59\x7c\xb0\xd2\x79\xed\xfa\x2f\x10\x2f\x74\xfc\xab\xe8\x59\x3f\x7f\x99\x04\xe5\x47\xda\xa0\xfe\x24\x8a\x27\xc6\xc9\x20\x99\xa5\xae\xc7\xa6\x11\x5e\x5c\x23\xd6\xff\xcc\xeb\x5b\xff\x4c\x79\xd1\x46\x6e\x6a\xbf\xd8\x6b\x70\xea\x03\xea\xb7\x7f\xa1\x7c\xdd\x36\xca\xe1\x37\x1f\x94\xa4\x17\xaf\xde\xca\x23\xe9\x46\xda\x11\x3e\xc7\x80\x33\x9e\x03\x2a\x9b\xc9\xcc\x77\xce\xe2\x95\x5a\x52\xab\xba\xf8\xda\x8a\xf1\x7c\x61\x5d\x14\x9e\xd7\xeb\x46\xf4\x3c\x70\x5d\xc6\xca\x35\x19\xfa\xa7\x10\x0b\x5d\x1f\x3b\x19\xea\x4a\xac\x68\x76\x59\x9c\x6e\xc5\x6d\x0b\xc8\x92\x64\x44\xdf\xe3\xa3\x0c\x2a\x27\xf6\x10\x7d\x05\xb1\x23\xb1\x61\x56\x49\x1e\xc7\x53\x35\xfa\x05\xa3\xd1\xed\x01\x84\x70\xee\x80\x54\x70\x12\x53\xcc\x83\x8f\xf6\x65\x25\x49\x19\x81\x88\xf6\xcf\xd3\x54\xe3\xac\x9f\xa5\xfe\xdd\xe0\x90\xa2\x1d\x30\xb7\xed\x07\xa4\xda\xf3\xba\xf2\x7f\x1b\x10\xeb\x24\x38\x79\x28\x19\x34\xe1\x87\x9b\x1d\xb2\x23\xca\x8a\x90\xcf\xbf\xf7\xb1\xf8\x39\x1d\x88\xe5\xde\x56\x0e\x29\xe4\xa7\xb7\x9c\x41\x2b\x32\x75\x45\x1f\xb4\xc2\x4e\xfe\xfe\x8f\x6f\xcd\xf0\x77\x08\xc6\x11\x7e\x1d\x3b\x3d\x77\x48\x3c\x50\x37\xd0\x5d\x6c\xb0\x4c\x33\x0e\x27\x94\xf6\x7e\xbb\x31\xe9\x2e\xd1\xdd\x07\x2e\x23\x4e\x7f\xac\x93\x56\x9a\x1c\x90\xb9\x23\x52\xd7\x2e\x77\x25\x95\x2c\x7b\xe7\x58\x83\xd2\x03\x47\xd0\xb4\x13\x8e\xff\x25\x79\x25\xdd\xbb\xc1\x61\x2a\xe9\x0d\x98\xbb\xc1\x51\x3c\x2a\x42\x89\x1e\x2c\x56\xf8\x95\x6b\xaa\xe4\x01\xe2\xbc\xa3\xa2\x81\xda\xd0\xb9\xa4\xdf\x7c\xb5\x47\xe8\x26\xcf\xeb\x54\x9d\x16\x8d\xd7\x94\xbf\xac\xf1\xb5\x2d\xe6\xd6\xa0\x7e\x8e\x34\xb1\xec\x5d\x00\xb6\x37\xf3\xc6\x74\x3e\x9b\x60\x05\x69\xab\x8e\x40\xe7\x37\x39\x00\x46\x38\xf0\x05\x9a\x02\x99\x8a\xbf\xeb\x24\x3c\x0b\x4e\xa5\x0d\x0b\x70\x06\x0a\x19\x64\x95\x53\x80\xe4\x07\x5c\x6f\xf0\xb1\x5b\xee\x83\x28\xb7\xf4\x6e\x70\x5c\xc0\x91\xd6\x54\xdc\x91\x2d\x8b\xc5\xd8\x3d\x34\xb2\xea\xef\x87\x35\xb0\xd5\x5a\xfc\x58\x19\xbd\x68\x22\xab\x4d\x0b\xba\x49\x4f\x1d\x76\x36\x39\x34\xec\x36\x66\xbb\xb2\xdf\xe6\xb0\x9f\x76\x7f\x10\x9d\x0d\xff\xbe\x68\xa4\xa2\xf5\xa7\xf6\x9d\x17\xce\x7a\x5a\x27\xb2\xbe\x06\x04\xad\x03\xbc\x28\x53\x5f\xdb\xb1\xfa\x1a\x1c\xfe\x92\x12\xcb\xd9\xbf\xe0\x7a\xd8\xa0\x42\xc8\x2e\xab\xc3\xca\xf2\x49\x8c\x93\x0f\x6f\xdf\xe7\xce\x59\xd1\x43\x4a\x33\x88\xc5\xfd\xab\x53\x51\xbc\x7f\x85\x63\xa8\xd6\x07\xb1\x2b\x64\xf8\x8f\xd3\x0d\xfc\x59\x51\xfe\x96\x6d\x98\xb8\xde\xa8\x78\xb8\x33\xc2\x2e\xdb\x07\xf4\x20\x86\x90\xf9\x8c\x66\xfb\x53\xe9\x98\x07\x93\x8d\xe0\xa2\x48\x54\x64\xa6\x28\x39\x94\xc1\x13\xcd\x4a\x96\xe9\x95\xf5\x05\xd8\x89\x44\xd0\xe8\xa3\x9f\x64\x70\x49\x27\x3b\xfe\x21\x49\xc4\xa9\x08\xcb\xb2\xed\x45\x89\xf8\x52\xff\xe5\x65\x59\x9d\xaa\x82\x31\xf6\x8b\x47\xd4\x44\xe6\xfa\x58\x79\x73\x98\x3c\x28\x76\x4d\xdc\x8a\x4e\xdb\xd0\x42\x6b\x98\x9d\x3a\x52\xab\x3d\x65\xbc\x42\x3c\xd7\x2e\x47\x61\xfc\x13\xa6\x52\x1c\xf3\x18\x0e\xee\x10\x2a\xa6\xa8\x3d\xdd\xb8\x14\x7c\xc6\xdf\xe4\x35\xac\x2e\xef\xba\x28\x87\x95\x6f\x4f\xfb\x34\xb6\x04\xc6\xb4\xf3\xfb\xd7\x8a\xae\x0c\xda\xf2\xe6\xc0\xf5\x75\x51\x65\x58\x0b\xf7\xac\x6b\xa2\x27\xef\x03\x30\x1e\xe8\x20\x0b\xc7\x40\x3c\xe8\x2a\x62\xd6\xa0\xbe\xc0\x32\x1e\x1c\x64\x5a\x6d\xf0\xfb\x57\xb5\xa1\x1b\x02\xd1\xf0\xd9\xf4\x8c\x67\xb8\xc3\xe8\x1c\x5b\xc5\x95\x21\x8e\x3f\x99\x2e\x96\x93\xe5\x72\x31\xa5\xb3\x60\x31\xf3\xe6\xce\x78\x39\x5b\xda\xde\x62\xe1\x38\x41\x30\xf6\x26\xb3\xc9\xdc\xb7\xdd\x60\x12\x4e\x1c\x3f\x80\xd0\x9b\x07\x63\x77\xec\xce\x4b\x84\xe4\x24\x44\xdc\xf1\xa2\x39\x2b\x18\x03\xb9\xd4\xf6\xe7\x73\xd7\x99\x2f\x29\x9d\x8c\x7d\x6f\xe6\x79\xd3\x69\x60\x7b\x63\x67\x3c\x5b\x86\x4b\x58\xba\xb6\x33\xf1\x17\x0b\x3a\xb5\x3d\xd7\xf7\x96\xcb\x70\xe9\x81\xe3\x4f\x83\x72\xa0\xc2\x6e\xdf\x11\x67\xea\x8e\x1d\xbc\xb7\xc7\x69\x9a\x6d\x19\xfc\xc5\xdf\x56\x03\x8b\x20\xcd\xa7\xb3\x79\xb0\x18\x7b\x73\x6f\x11\x2c\x6c\x1a\x04\xbe\xe7\x2e\x1c\x3a\x77\x82\xe9\x24\xf4\xe7\xde\x78\x3c\x9b\x84\xa1\xc9\xb4\xdc\x68\x12\xbb\xcd\x0a\x12\xc7\x2e\xe1\xc8\x0d\x1b\x0e\xe4\x04\xbe\x3f\x09\x60\x11\x80\x3f\x9f\x06\x73\x4a\xbd\xc5\xd4\x9b\xce\xe6\xde\xcc\xf7\x83\x89\x43\x83\xb1\xe3\x4e\xa6\x8e\xb7\x9c\x2c\xe8\x7c\xe2\x8c\x43\x9b\x3a\x13\x37\x0c\x26\x76\x30\x59\x8e\x27\x73\xab\xc5\x7c\x5d\xb7\xdf\x8a\xbd\xba\x32\xc8\xca\x34\x9d\x47\xf0\xdc\xe2\x54\x03\x3b\xa6\xc1\xa8\xe5\x12\x1c\xd2\xe9\x1b\x62\x5d\x5e\x29\x46\xc1\x85\x91\xab\x5d\x97\x7b\x59\x2b\x96\x76\xea\xca\xad\x88\x7c\x95\xa8\x1c\x52\x6b\x1c\xc9\x8c\xac\x62\x39\x9c\x70\x31\x5b\x2e\x1c\x8f\x2e\x6c\x9b\x06\x34\x58\x2e\x27\x5a\x0f\x3a\xff\xcd\x27\xb3\x70\xe1\xba\x73\xc7\x5e\xd8\xb6\xb3\x70\xa7\xae\xbd\xc0\xff\xf9\xb6\xb7\x98\x38\x93\xf9\xd2\xf5\x97\x93\xf1\x72\xba\x9c\xd8\xcb\xc5\xd8\x1d\x2f\x6d\x1b\x66\x93\xb9\x3d\x9f\xb8\x7e\xb0\x98\xcf\xc1\x5f\x86\xcb\xa5\x3d\xf3\x7c\x6a\x4f\xa7\x8e\x0d\x13\xd7\x09\xc7\x9e\xed\x8c\x21\x70\x5d\x67\xec\x4e\x60\x3e\xf7\xa9\x63\x07\xe3\xc9\x6c\xe6\x8d\x5d\xcf\x59\xd8\xb6\x3f\x77\xc1\x71\xe7\xce\xd2\x73\x9d\x71\xe8\x04\x13\x7f\x3c\xb7\xc7\xf6\x74\xbc\x5c\x06\x81\x3b\xa7\xe1\x72\xe6\xce\xdc\xd9\x44\x2b\xb1\xba\xb6\xb3\x8b\xf4\x22\x39\x95\xf2\x56\x91\x9c\x53\xde\x5a\xa8\x8f\x54\xe3\xf6\x7e\x71\x78\x45\x5d\x61\x8b\xd7\xce\x96\xd6\xb6\x94\xd3\xc6\x65\x4f\xa7\x32\x5d\x85\x01\xd4\xe6\x6b\x9e\x9c\x6e\x6e\x98\xd5\x37\x12\xfa\xf4\x2f\x43\xb8\xb2\xa5\x06\xf9\xe0\xf4\x20\x92\x33\xf5\x53\xdf\x48\x85\x06\xc3\x58\xd8\xe3\x90\xb8\x2a\xd2\x79\x44\x46\x9c\xb7\x8b\x77\x5f\x6a\xad\xf9\x85\x57\x47\xc6\x90\x9d\x6b\x24\xb9\xb7\xf1\x89\xae\x4e\x05\x65\x71\x08\x92\x88\xe2\xf9\x62\x5c\xac\x25\x21\x59\xe1\x01\xeb\xc2\x75\x2b\xaa\xbc\x11\xf5\xe0\x03\x84\xa7\xd2\x76\x21\xbb\xc6\x6a\x34\x10\xb2\x1d\xd2\x97\x63\xca\x6f\xa3\x7f\xd8\xa5\x2c\xa3\x26\x6f\x2f\xa7\xb1\x55\x76\x4a\x32\x88\xe4\x96\x00\xa6\x97\xe5\xb8\xc8\xed\x0f\x59\x1a\xbb\x52\x0d\x9b\x68\xf5\xe5\x4d\x48\xea\xce\x5c\x8b\xef\xd5\xb5\x29\xaf\x0c\x4f\x39\x4e\xbe\x13\xf5\x32\x81\xf0\x54\xb4\x0f\xf2\xd3\x4f\x20\xc4\xf5\x1f\x9a\x98\x2d\x6e\x7a\x62\xd2\x38\x8d\xfc\x6d\x94\xdf\xb5\x2a\x7d\xdb\xb2\x46\x50\xd1\xd1\x8a\xf2\x53\xa1\x98\x4d\x0e\x81\x81\xa7\xd8\xcb\x98\x21\x0e\xa6\xef\x48\xf3\x93\x98\x6f\x37\x0a\x2e\x95\x9d\x84\x57\x27\x33\x6e\x6a\x40\xd1\x69\x00\x98\xec\xca\xdf\xc5\xa7\xca\x5d\x65\x36\x23\x3a\x42\x50\xd7\xb3\x24\xd6\xce\x3d\xbe\xf0\xb7\x19\xfa\x99\x95\x0f\xf4\xf0\x95\xae\xea\x4e\x32\x51\x9b\x55\x47\x01\xfc\xa2\xb1\xaa\x42\x45\xef\x06\xfd\x44\xd1\x8c\x90\x5a\x87\xec\xb9\x76\xee\xaf\xe3\xef\x94\xce\xbd\xb3\xb0\x9b\xe6\x8c\x38\x4d\x5b\x63\xae\x2c\xf2\x9e\xad\x36\x93\x41\xc6\x76\x43\x79\xcb\xdd\x9e\x9a\xa2\x11\xc7\x2d\x95\x07\x65\x9e\xb8\x8e\xe9\xdf\x97\x32\x47\x2c\x9c\x7c\xca\x11\x15\xa3\x11\xaa\x3a\xe2\x56\x9d\xcd\xe7\xcd\x83\x0d\x16\x5e\x7d\x79\xd5\xb6\x86\xeb\x5a\x0b\xbd\x2e\x8f\x59\xb7\x4f\xb7\x3a\x66\x74\x8e\x5c\x1b\xe1\xa6\xc2\x3f\x52\xfa\x98\x66\x49\xb0\xf5\xf5\xd5\x79\xf0\x58\x7a\x4b\x66\x18\x41\x1e\x92\x3c\xcf\x48\xb7\x42\xd8\xc3\x37\x6a\x68\x48\x8e\xfd\x79\xec\x6e\x62\x70\xc5\xf5\x45\xe9\x41\xa1\xbc\x06\x61\x68\x95\x5e\x54\x71\x1a\xb2\x9d\xa7\xb8\xb1\x7e\x7a\x18\x28\x67\xa7\xf4\x5e\xb0\x0b\xcc\x4a\x7d\x80\xe2\xdc\x01\x29\x6f\xc4\xb8\xa8\x6b\x1d\x8f\x6c\xf4\xae\x66\x9b\x93\xbb\x2e\xe6\xa8\x4a\x77\x0d\x4e\x6b\x9a\x9c\xc7\xe8\x12\x71\xd9\x7e\x3c\xf6\xe6\xee\x6c\x39\x99\x8c\xfd\xb9\x1d\x80\x33\xf3\xbc\x70\xe9\xd9\x33\x67\x3a\xb6\xe7\x8b\xc5\xc4\xf3\xfd\xe9\x6c\x3c\xb3\xea\xa8\x1d\xdc\x06\xd3\xf9\x1f\x5d\x3c\xbd\x3c\x50\x8b\x46\x94\xee\x21\xbb\x42\x54\x19\x67\xb3\x94\xb2\x40\x39\x28\x2b\x5a\x32\x11\x9f\x5e\xb2\x00\x2a\x5d\x0e\xec\xa9\xbe\x57\xa9\x82\xd7\xd7\xe9\xbf\x16\x08\xcf\xc3\x82\x27\x87\x1e\x6b\x9e\x0a\x46\x28\xc8\x06\x68\xcc\x1b\xcb\x82\x27\xca\x8b\xe8\xa3\x8c\x12\xe2\xca\x52\xec\xd4\x09\xb4\xe2\x54\x47\x82\x8e\x6e\x12\x0f\x2b\xbd\x1a\xe7\x3e\xcc\x5e\x0e\x4f\x3c\xa7\xfa\x0e\x18\xa9\xea\xdb\xfe\xd3\xae\xd1\x38\xd9\x8a\x74\x9b\xdf\xe9\x79\xa2\x31\x6f\x93\x76\xed\x0a\xe8\x59\x45\xdf\x38\x56\x7f\x7d\x90\xfb\xc7\xb9\x94\x7f\x81\x8b\x79\x08\xca\xe5\xbd\x96\xf5\x61\x7e\x16\xd1\x4f\x32\x95\x64\x28\x0b\x10\x16\x3c\x22\x74\x50\xeb\xca\x80\x56\x77\x2b\x63\x04\xaa\x45\xed\x63\xf3\xfe\x53\x42\x8e\x51\xec\x20\xdd\x8e\x33\xaa\xb8\xbb\xce\xfc\x29\xce\xbc\xff\x01\x00\xe4\x93\x55\x1d\x86\x5c\x7e\x0f\x81\xd0\x54\xb9\xbe\x2c\xad\xa9\x5f\x8b\xce\xe0\x99\x8d\x68\x7f\x82\xee\xe9\x43\xf5\x98\xe7\x48\x28\x09\x29\x8b\x50\x62\x54\x13\x85\x88\x4a\x4d\x93\xe7\x67\x92\xa7\x38\x3f\x1c\x7b\x68\x26\x2a\xa2\xc7\x55\xb7\xb5\x30\xcf\xe7\x4d\x51\x68\x2e\xa5\xab\x30\x77\xc7\x01\x0d\x5d\xab\x6e\x34\x0f\xbc\xd3\x8c\xa8\xc5\x4f\x9f\x9f\x23\xdb\x34\x51\x57\x5f\xdd\x5c\xe8\xfc\xb7\xd8\xc0\x1b\x62\xd5\x6d\x98\x75\x4a\xdf\x96\x65\xc4\xcf\xba\xcd\xc7\xcd\x85\xbe\x6c\xcd\xa7\x6d\xd7\xae\xcb\xa9\xdd\xe8\x54\xb9\xb8\x7f\xc4\x68\x07\x0d\xdf\x4d\xee\x30\x5f\x46\xb9\x9a\x93\x78\x76\x3f\x86\xb3\xe8\xb8\x63\xed\xf6\xbf\xd4\x62\xf4\x92\x46\xd1\xdd\xe0\xf0\xc4\x79\x56\x04\xba\x74\x8a\xbe\x70\xfc\xb9\x12\x4a\xc7\x73\x74\x5f\x26\x76\x65\xa9\xbb\xbf\x68\x84\x17\x21\x13\xac\xab\xcb\xc2\xbd\x8c\x68\x61\x1c\x0b\x81\x28\x8e\xd5\x59\x8d\x18\xc3\xa9\xe8\x19\x83\x51\x2c\x22\xb0\x15\x46\xfd\xee\xb2\x7b\xc4\x16\xb2\x0b\x3a\x37\x30\x91\x9e\x89\xec\xcf\x3a\x34\xc9\x94\x11\xf9\x46\x40\xde\xb2\x77\xd3\xd9\x6c\x3a\x19\xcf\x16\x33\x67\xb6\x9c\x81\x6b\x4f\x27\xb3\xc5\x2c\x9c\xeb\x89\xe1\x05\xe6\x91\xa3\xa0\xbd\x32\xb8\xdd\x26\x6c\x7f\x60\x9c\xf5\x8f\x12\x0e\xbe\xa6\xfa\x0c\x9d\x46\xee\x4f\x22\x20\x7a\xcf\xf8\xdd\x23\x64\x19\xab\x5d\xf3\x7f\xe0\x0c\xca\x41\x24\xf4\x22\x50\xcf\x37\x48\xc5\x90\x41\x14\x70\x6d\x37\xf0\x72\xcb\x8c\x05\x01\xc4\xe5\xe0\x32\xce\x24\x8b\x5e\xd0\xe8\x7d\x8b\x24\xf5\xac\xa9\x90\x83\x7f\x50\xec\x5b\x24\xf2\xe6\xfc\x6d\xad\x83\x8a\x54\x51\xa6\x06\x0b\x4f\x1f\xac\x86\x5e\x97\xce\xe5\x69\xb7\x0d\xfe\xd5\xe4\xa4\x67\xea\x70\x3d\xa5\xbb\xf5\x23\x7d\xda\xf4\x54\x99\xc9\x0f\xa9\x3e\xc0\x1e\x45\x43\x52\xf2\x24\x89\x68\x00\xf3\xba\x3c\x92\xf3\xa7\xb7\x4d\x52\x85\x53\x9c\x94\x39\xd0\x4c\x56\x3c\xa9\x96\xe0\xc5\xe3\x39\x71\xa2\x0e\xf7\x8a\x35\x35\x13\xde\x8a\x14\xc3\xef\xd6\xeb\x19\x5b\x2f\xac\xbc\x94\xb5\xee\x29\x35\xe5\xe7\x10\x3a\xfa\x3a\x11\x54\x33\x55\x9b\x45\x57\x66\xc9\x0f\xaf\x05\x85\xcb\x93\x01\x5e\x4a\x02\x71\xa0\x96\xb8\xd5\x6a\xce\xf6\x57\xb1\xa8\xce\x97\xb3\xa8\x06\x75\x89\xa3\x16\xd9\x3f\x53\xae\xed\xc7\x91\x0c\x84\xeb\xaa\x34\x6a\xe5\x86\xc5\x6c\x43\x23\xe4\x85\x0c\xfc\xfd\x0b\xb2\x04\x43\x4c\x2a\x98\x50\x42\x5c\xf0\x08\x82\x9f\xaf\x09\x04\xc2\x80\x72\x20\xa3\x1d\x8a\x2c\x68\x7e\x0d\xc7\x9e\x61\x21\x81\x98\x33\xff\xb2\x71\x8b\x78\xc2\xe0\x58\x48\xe7\x71\xf3\xba\x7e\x14\xa9\xd5\x62\xa8\x1e\x3f\x00\xad\x9d\xc9\x6a\xf9\xb8\x21\xbb\x2d\x9b\x83\x35\x0a\x13\x77\xec\xd8\x76\x3b\x15\x1a\x2d\xdb\x43\x25\x39\x22\xc4\xb2\xda\x81\x26\x56\x73\x09\xa7\x8e\xf4\x77\x09\xe1\x39\x2b\x2d\x0b\xf9\xac\xc2\x10\xd2\xd7\x97\xb2\x86\xcf\x14\x3c\xf2\x19\x8a\x5d\x8e\x8a\x35\x38\x1c\x53\xb8\xca\x64\x59\x0b\x40\xb6\xae\xc0\xaf\x32\x50\x33\xd0\x78\xf9\x7e\x49\x8d\xb8\xa8\x3f\x72\xbb\x23\xd8\x22\x0f\xca\x25\x64\x53\x42\xbe\x86\xf0\x37\x85\x21\x93\xcd\x48\x00\xe8\xe3\x05\xaa\xdc\x4c\x4d\x18\x86\x28\x0d\x4c\xe6\x2a\xb1\xb8\xd8\x23\x91\xf2\xfc\x83\x02\xe5\x47\xeb\x90\x72\x15\xd6\xda\xb1\xc7\xd3\xe9\x8c\xce\xc7\xbe\x63\xc3\x78\x11\x86\xe0\x86\xfe\x84\xd2\xa9\x1d\xfa\xcb\x60\x32\xa3\x81\xed\x4c\x16\xa1\x3d\x07\x77\x36\x71\xe6\xe0\x38\x73\x2f\x70\xc0\x87\x65\xb0\x9c\x2c\xbc\x69\x43\x0a\xcd\x9d\xff\x52\x64\x6a\xf9\x00\x6d\x21\xd4\x8b\x55\x54\x95\x0a\xe1\x5d\x7a\x99\x84\x21\x07\xd1\x64\x46\x5d\xac\xa2\xbe\x87\x46\xaa\x4c\x33\x67\x65\x64\x16\xae\x4b\x8b\xc3\x22\xf2\xaa\x8b\x1f\x6e\x6e\x68\xca\x6e\xf0\x5e\xf4\x1b\xf9\xe6\x47\x23\xc5\x1d\x2b\xb0\xa1\x17\x03\x3b\x1f\xa0\x92\x68\xee\x37\xee\xa8\xef\x25\x44\x98\x7c\x94\xc4\x82\xc5\x5b\x30\x0a\x41\xca\x9c\x33\x75\x82\x12\x1d\xd4\x14\x2b\x3b\x24\x5b\x2e\x0b\xc2\xc8\x23\xe0\x45\x85\x0d\x16\x57\x2f\xc9\xd7\xd7\x25\xe7\x29\xb4\x45\x41\x9d\x76\x62\x63\x72\x57\x0f\x90\x21\xde\x6e\xcc\xcf\x30\x1a\x5a\x3d\x54\xa3\xdd\x16\xb6\x29\x85\x02\xf5\xa1\x0f\x7f\x0e\x58\x07\x91\x9c\xdd\xb8\xa1\x4a\x12\xcd\x1a\xc4\x12\x3c\x62\x4e\x4e\xb8\x8c\x2d\xc4\xfd\x13\xdb\x40\x85\x7c\x15\xb6\x19\xe1\x54\x59\xb4\xa8\x28\x8b\x59\x06\xd8\x0b\xa1\x49\x62\x2e\x32\xca\xe2\xbc\xf0\xa7\xf4\x11\x64\xab\x0e\xc6\x3c\x23\xe2\x69\x42\x4d\xc7\xb6\xed\x4c\x26\x75\x7a\x4d\xc7\xb6\x63\x63\x32\xb3\x7c\x9c\xdf\x2a\x7f\xd7\x81\x9a\xdf\x9e\x85\xd0\x0b\xbc\x4f\x98\x10\xf2\x11\xc4\x31\xa6\xa8\x20\xbb\x5e\x71\x6c\xf2\xba\xac\x42\x55\x11\xcb\xab\xac\x0e\xf3\xcf\x12\x2c\x98\x8f\x85\x4c\x33\xb9\x19\xa0\x1a\x10\x1a\xef\x3b\xb0\x90\x4d\xed\x26\x1a\x35\xdd\x91\x9f\x39\xfd\x3e\x73\xfb\x7d\x36\xee\xf7\xd9\xe4\xc8\x67\x0d\x3e\x6b\x8c\xae\x37\xe3\x48\xe7\xe4\x8d\x2c\x49\x74\xd7\x41\x49\xbd\x50\x3c\x02\x6e\x83\xdb\xf9\xfa\x52\xa7\x2d\x1e\xbd\xfd\x40\x7f\x0f\x2d\x03\xd5\x7d\xa3\xea\x38\xa5\xd2\xea\x2e\x86\xba\x6b\x35\x94\x0a\x66\x62\xfe\x40\x0d\x12\x55\x45\x49\x0b\x5f\xb7\xe7\xd5\x8a\xaf\x2c\x37\x76\x37\x38\xf2\x55\xbb\x71\xa6\xdc\xaf\x3d\x09\xc0\x78\x64\x14\x5a\x3b\xee\xfa\x49\x2b\x58\xce\x73\xa2\x6e\x18\x8f\xba\x8e\x79\x83\xb2\x8f\xa4\xea\x06\x1c\xeb\x41\x7b\x0d\x06\x0c\xda\x0c\x9c\xca\x4b\xcd\x37\xc9\x13\x14\x11\x83\x6b\x52\xfa\xb1\x36\xf1\x09\x6c\xeb\xc4\x5a\x83\xa8\x80\x7e\xa3\x0b\x73\x5d\x2d\x5b\xb0\x99\x19\xd7\x8e\x7e\x5f\x61\xeb\xb5\x1a\xfa\xba\x49\x30\x0d\x83\x95\xd3\xea\xbc\xe0\x45\x93\x82\x37\xd7\x75\xb6\x0b\xff\xfd\xd2\x4d\x56\xeb\x30\x03\xbe\x67\x0d\x74\x66\x0d\x98\x57\x24\xe5\x30\xd2\x28\x7a\x17\x1e\x2b\x26\x5e\x11\xe2\x12\x94\x9b\x56\x9d\xc5\xdf\xbc\xbc\x79\xf5\x69\xdb\xf2\xd4\xbc\x8c\xe3\x2c\x98\x2a\xb6\xe4\xba\xb0\xd5\xab\xe4\xe6\x2d\xce\x3c\x46\x54\x15\x8c\x8a\x25\xd6\x15\xb1\x74\x5e\x4f\xa8\x8a\x19\x7b\x49\x80\x95\x73\x65\xe5\xa9\x8a\xed\xad\xd1\xe7\x38\x8d\x8c\xa3\x50\xd5\xac\xde\x6b\x10\xbe\x19\x0b\xb9\x06\xed\x75\x0c\xbb\x07\xe5\xfb\xce\x11\x49\x14\xe8\xca\x6d\x47\x3f\xed\x48\xe8\xd4\xfb\x61\x79\xbd\xdc\x62\x0f\xa4\x68\x1b\xc3\xd3\x15\x87\x29\xd7\xc0\xd5\x51\x92\x28\x78\xdd\x6f\xc7\xad\x63\x10\x7d\x7f\x43\x1d\x95\x21\xa1\xa2\xfc\xab\xba\x8a\x8d\xe1\xe9\x6a\xe3\x1e\xc2\x4d\xef\xe3\xfd\x02\xfb\x1e\x33\x7a\x65\x10\x79\xc3\x43\x12\x16\x3b\x81\x32\xf4\xcf\xe5\x0d\x0f\x02\xe2\xa1\x71\xf1\x43\x8c\x87\x64\x92\x8c\x6c\xe3\x87\x38\x79\x8a\xcf\x70\x11\xae\x30\xfb\xb7\x69\x43\x53\x13\x8c\xe2\xd3\x5d\x6a\x10\xd7\xea\x6f\x9e\xe0\x1e\x9b\x95\x9d\x6b\xaf\xca\xf2\xd4\xb5\x17\xd5\x1a\xd3\xc5\x4b\x41\x33\xe3\x7a\x81\xbe\xc2\x61\x04\x03\xad\xcf\x92\xb0\xf7\xaf\x7e\x1f\x7d\x16\xbb\xfb\x38\x80\xdd\x7f\x8b\xdd\xfd\xab\xdf\x4b\xb2\xf9\x49\x1c\xb2\xd5\xa9\x7b\x72\xc6\x72\x18\x7f\xa5\xa5\x55\xe5\xff\xd4\x92\xa5\x5a\xde\x5a\x46\x25\xf2\x6d\xaf\x9c\x1d\xf9\xce\x5d\xc0\x38\x16\x2d\xfd\x4f\xd8\x24\xd9\xbe\x9a\xbc\xa9\x5f\x7d\x14\x14\xf5\xa8\xf8\x4b\x57\x5c\x96\x05\x0e\xe5\xba\x5e\x75\x45\x64\x40\xed\x90\x43\x27\x39\x6a\x14\x08\xaf\x13\xf9\x1a\xae\xce\x48\xef\xc4\x15\xc5\xae\xbb\x44\x4c\xb2\xb2\x41\xf6\x1a\x6b\xdb\x43\x35\xb5\x8f\x44\x72\xf4\x93\x7e\x99\x65\xbd\xf6\xce\x7a\xc7\xe6\x65\xea\xd8\xd1\x31\xd5\x8e\xc7\xd1\xcf\xa0\x57\xc8\x1d\xd9\xdb\xc3\xcc\xb5\x58\xa5\x13\x6a\x97\x37\xeb\x91\x77\xb1\xb9\x17\x4d\xd5\x56\x62\xb7\xe5\xc2\x1f\x15\x99\xfd\x5b\x2f\x66\x16\x2a\x78\x1e\x41\x1a\x56\x20\xbf\xad\xba\x0b\x59\x7d\x3f\xf5\xdd\xa0\xde\x4f\x1d\x11\x2c\x21\xfc\xa2\x2c\x19\x79\x9c\x42\xc7\xf9\x5f\x5c\xa4\xdb\x05\xa0\x71\x71\xf5\x55\xc1\xd3\x77\x40\xbf\x38\xb5\x5d\xcd\xac\xe2\xf4\xbd\x66\xab\x35\xde\x3a\x4d\x37\x18\x1b\xc2\x88\x3b\x6f\xb9\xd0\xb9\xe8\x01\xff\xaa\x84\x45\x0f\x0f\x96\x9d\xb5\x15\xf5\xb4\xde\xd7\x6e\x48\x7e\x41\x39\xfc\x5c\xcb\x48\x69\x23\xb6\xd7\xf2\xdd\xc1\x51\xf5\x44\xd5\xef\xbb\x7e\xe4\x6d\xcc\x01\x15\x80\xd0\xe0\x8f\x17\xc1\x1c\xe8\xc4\x9f\x2d\x2a\x45\x0d\x73\x58\xf4\x9c\x30\x09\x67\xbe\xbf\x58\x78\xde\x64\xe6\xce\xe8\xd2\x5d\xda\xf3\xb9\xb3\x80\x85\x1b\xba\xd3\xa9\xb7\x08\x71\x91\x39\x99\x8e\xe9\x7c\x01\x8b\xf9\x72\x0e\xde\xc2\x07\x3a\x1e\x2f\xc7\x9e\xeb\x4c\xad\x56\xc8\xc9\xd8\x9d\x8e\xdd\x49\xb9\x90\x7c\x03\xc0\xbb\x68\xd9\x63\x45\xa4\x17\xd7\x47\x88\x72\x22\x5b\x5a\x04\x14\xdb\x97\x59\x44\xaa\x1c\xaf\xce\x5d\xd9\x15\x21\xd3\x73\xeb\xb0\xf5\x9e\x5d\xf4\x87\x1f\x30\x21\xa6\xf9\x75\x5c\xad\x44\x2b\x76\x3d\x55\x24\x4f\xb4\xe2\xcd\x4f\xeb\x36\xb3\x42\x17\x0b\xc2\x10\x7c\x59\xe3\xa0\xa0\x0c\xee\xa8\x31\xe9\x0d\x89\x9d\xae\xa7\xaf\x57\x9f\x79\xe2\x0f\x8b\xb0\x58\xfe\x93\x2c\x39\xa8\xfc\xa6\x7c\x9f\xda\xea\x63\x9c\x0d\x56\xbd\x01\xf8\x8b\xbc\xfc\x65\xdf\x25\x44\x49\x14\xe4\x06\xea\x6e\x50\xef\xad\x4e\x0a\x03\xc6\xe3\xc4\x38\x08\x63\x8d\x11\x52\x2c\x7a\xf4\x57\x21\x2e\xe6\xbb\x70\x5f\x85\x0f\x54\xe4\xba\x7b\xe4\xa3\x6b\x07\x54\x36\xbd\x74\xdf\xbd\x4f\x92\xe8\xa3\xa0\x82\x77\x11\x4e\x5f\xfa\xd5\x84\xbb\x4e\xb4\xdf\xb6\xb0\xed\x23\xbd\x51\xe2\xd3\xe8\xf8\x67\x35\x42\x14\xb7\xfb\xa3\x40\xf1\xad\xa7\xb7\x08\x1e\x19\x55\x45\x1c\x70\x8b\xb9\x68\x2b\x0b\x16\x64\xbc\x7b\x10\x8d\xbf\xbe\x94\xac\x83\x02\x3d\xec\x8f\x8e\xa4\x5d\xc5\xc9\xc4\x44\x3e\x1a\x45\xe7\x9a\xa9\x16\x6d\xd4\x77\x96\x61\x64\x3e\xcd\x92\x47\x08\xc8\x53\x92\x3d\x0c\x55\x36\x17\x9e\x2a\x50\xac\xc3\x5a\xe5\xcd\x5a\x08\x47\xe1\xad\x8d\xaf\x6f\x45\x90\x97\x79\x86\x90\x41\xec\x43\x50\x33\x89\x46\x01\x85\xa3\xbd\xcb\x6f\x81\xff\x24\x2e\x00\xa4\xd8\xf9\xd7\x71\x08\xdc\x96\xd8\xe5\x65\x41\x52\xea\x3f\xc8\xfd\xfe\x63\x02\x5a\x77\x94\x68\x96\xb1\x47\x1a\xe1\x86\xf6\xc9\xb0\x15\x05\x3c\xb1\x00\x60\x8c\xd0\x60\x19\x7b\x4c\xe0\xaf\xdd\x7c\x87\x41\x0c\x2a\xb6\xbc\x87\x14\xb4\x2d\xc8\xb5\xf2\xd6\x9e\x2a\x76\x17\x0f\xd5\x9f\xe7\x25\xeb\xa0\x87\x84\x20\x8b\x5d\x5e\x2c\xdf\xb8\x2d\x6f\x0f\x62\x58\x6c\x02\xa3\x9c\x69\x70\x0c\x41\x6b\xf8\x29\x3a\xcc\xbd\xf4\x7c\x7f\x36\x75\x67\x74\x3e\xa3\x30\x9d\xd9\xee\x64\x12\xce\x96\x8b\x85\x3d\xf5\x7d\xdb\x76\x96\xf3\xb9\x3b\x99\xf9\xde\xd2\xf5\x5d\x6f\x12\x3a\xe0\x7a\x73\xea\xda\x13\x98\x4c\xa6\x13\x7b\x09\xd4\xaa\xab\xe6\x79\x9b\x19\x2d\x89\x78\x75\xf5\xac\x65\xd8\x17\x7a\x63\xba\x39\x35\xa1\x27\x73\xd7\x6e\x11\x70\x32\x76\x67\xf6\xa4\x6c\xa1\x0c\x65\x2d\x25\xc8\x94\x3a\x3c\x6a\x88\xc5\xc5\x4c\xf0\xb4\xb8\x68\x95\x6e\xe7\x31\x1e\xf4\x95\x99\x84\x28\x78\xe8\xdf\x6a\x1d\xd8\x83\x30\x2c\xe3\x7d\x8c\x67\x4c\x4c\x2d\x3d\x69\x7a\xe8\x0e\xa2\xa8\x7b\x38\xf0\x8e\x9e\x07\xd8\xa3\x81\xd8\x6b\x46\x9d\x94\xbb\xdc\x3e\x81\xb6\x4e\x8c\xdd\x53\x63\x65\x2e\xb0\x8e\x4e\x69\xdf\x3a\x6e\x0a\xdd\x97\xd5\xeb\x04\xae\xc8\x5d\xb1\x7b\x2e\xb8\xd7\xf7\x2b\xce\xe7\xea\xf3\xc5\xa9\x79\x67\x58\x17\x4b\xfb\xee\x72\xe4\x17\xa7\x1d\xfd\xb0\x46\xa7\xf2\x0a\x37\x5c\x16\x71\xec\x42\xa6\x70\x56\x13\xf7\x71\xce\xf0\x60\xc5\xe2\xd8\xec\x6a\x43\x77\xd5\xa8\x52\xef\x09\x15\xd3\x0d\xf3\x8b\xe2\x74\x09\x65\x95\xd3\xc7\x62\x95\x95\x41\xfe\xee\x0c\x31\x2b\xcd\xfe\x47\x15\x90\x8a\x6d\x2f\x2e\x7a\x3a\x11\x67\xed\x4a\x48\xdb\xaf\xfd\x8e\x21\xb9\x7f\x35\x24\x16\x46\x53\x2c\x4c\x80\xb2\xc2\xbc\xe4\xa7\x55\x05\x00\xbf\x38\x34\x19\xe6\xbc\x32\x02\xb4\xad\x3f\x53\x7b\xe6\xcc\xdd\x99\x33\x0b\xe6\x63\xab\x85\x9a\xc4\x69\xc1\xb1\x1c\xd9\x14\xa0\x2a\xfd\xdb\x04\x48\xef\x89\x34\x69\xd4\xad\x3f\xc5\xcd\x91\xb8\x81\x53\x17\x92\xf2\x52\xc0\xfd\x49\x4a\xd5\x06\x5f\x21\xbe\xf5\x47\x07\x79\xd9\x02\x6d\x9a\x01\xdb\xe8\xfb\x0e\x51\x56\x4b\x80\x75\x71\x4d\x16\xb6\x6c\xf9\xb4\x86\x9b\x4f\x1d\x1a\x87\xd3\xf7\x18\x71\x32\x76\x6f\xb0\xec\x1b\x3a\xec\x41\x39\x32\x13\x16\xd7\xfb\x0c\x5b\xf3\x94\x73\x6b\xfd\xd6\x53\xc7\xcf\xa2\xb4\xa8\xe1\x9a\xd1\xa7\xda\xf1\x37\x7d\xf7\xe2\xdd\xe0\x68\xd7\x95\x4e\x0d\x86\x6b\x8a\x62\x47\x8a\xe3\x25\x5e\xfa\x54\x98\xec\x44\xd7\x1d\xf8\x08\x9d\xc2\x28\x76\xef\xfa\x2d\xbd\x7a\x96\x96\x2a\xcf\xc2\x77\x7f\xd9\x50\xd5\x02\x90\xf3\x1c\x4d\x0d\xde\x79\x8d\x2f\x39\xc0\x9f\x6f\xba\x1f\xcf\x27\xc4\xcd\xb3\x23\x54\x69\xb0\x9d\x05\xb9\x02\x99\xf5\x84\x8a\xbf\x43\xbc\x13\xd1\x03\x99\x09\x28\x92\xef\x19\x7a\xc7\x33\xf4\x4a\xad\xa8\xf4\xd1\xee\x5c\xb4\xb8\x16\x5d\x83\x95\x7d\x57\x93\xeb\x72\x11\xe9\x12\x8e\x2b\xab\x57\xdf\x5d\xbb\x2b\xec\x9d\x8b\x5d\xdf\xf6\xc7\x33\xe7\xbe\x9a\x16\x97\x14\x43\x0b\xb0\x0c\x69\x60\x1d\xa6\x91\x5e\x62\x7f\x4f\x6e\x6b\x4f\x6e\x7b\x0f\x90\x1d\x8d\x8a\x16\xbb\x64\x3d\x6c\xe2\x29\x17\x8d\xe0\x96\x56\x8f\x2e\x63\x10\xa8\xad\x47\xbf\x63\xb1\x97\x6c\xe3\xe0\x78\x30\x2b\xd8\xf6\x0d\xc8\xf1\xbe\x37\xa6\x54\x26\x82\x2d\x87\x70\x1b\xe1\x1e\xaa\xea\x20\x9f\x16\x10\xdf\x21\x5e\xe4\xfe\xc4\xa2\x08\xc3\x71\x1e\x8d\x63\x0c\x50\x62\x58\x2c\xc8\x92\x14\x8f\xf9\x26\x24\x4a\x9e\x6a\x3a\x47\x5a\x59\x71\x5d\x21\x32\xef\x46\xb0\xeb\x2c\x32\xcf\x64\xe5\xec\x30\x9f\xe5\xa4\xaf\x96\xfe\x2f\xe8\x6c\x74\xc8\xcb\x11\x70\xcb\xb3\x38\x66\xf8\x2b\xec\xc4\xcb\xca\xe9\xa7\x0a\x49\xd5\xc1\x28\x24\x64\x71\xa7\xf5\x30\xbf\x11\x33\x2f\x03\x87\x6b\x20\x0c\xcc\x85\x65\x2d\xa6\xfa\x7d\x80\x35\xb9\x91\xd7\x2d\x82\x28\x80\xd0\x93\xc2\x7d\xfc\x9e\xe6\x37\xad\xe6\x09\x25\x7a\x75\xa2\x9f\xa1\xeb\x93\x52\xb1\x6e\x03\xb5\x59\x89\x71\xd0\x71\xf3\xe5\x11\x08\x2b\x52\x70\xb2\x76\x7f\xa0\x4f\xf7\xf1\x5f\xcd\x1b\x8a\x15\x32\x19\xcd\x25\x0c\x11\x91\x37\x00\xb7\x61\x92\xdf\xd7\x62\x5c\x8b\x8e\x3e\xb2\xe1\xdd\xdc\x36\x50\x33\x03\x78\xed\xb8\xb5\xe4\x8a\xb6\x02\xa9\xc3\x31\x67\x02\xaa\x5b\x57\x4a\x3b\x16\x87\x2b\x31\xe6\x8c\x52\x83\xe1\xc0\x10\x6d\x06\xd6\x28\x97\x45\x92\xf1\xc0\x55\xc2\xf3\xd5\x2d\x46\x79\xb7\x51\x74\x29\x96\xaf\xf5\x85\x32\xad\x68\x82\x7e\x79\x16\x9e\x06\x76\x1c\x4f\xf4\x15\xb7\xd8\xc4\x5c\x00\x95\xfe\xe8\xfd\x2b\x7e\x29\xfc\x1f\xf4\x2a\xba\x15\xfe\x7c\x89\xdd\x07\x7e\xeb\x70\xec\x40\x05\x0f\x54\xf4\xc0\x08\x1f\x58\xb7\xd2\xe4\x95\xfc\xa0\x5c\xd5\x35\x67\x61\x1e\x6c\xbf\xb5\x3a\x11\x31\x54\xa9\xc4\xa3\xa9\xdf\x2d\x68\x1c\x52\xf0\x1e\x58\x60\xfc\x1f\x37\x61\x4a\xd0\xeb\x78\x61\xea\x1e\xc1\x62\xf1\x5c\x94\xd7\x20\x55\x4b\xe4\x5f\x6c\x2f\xea\x31\x8f\x5f\x60\x5f\x45\xbd\x0b\x4b\xb4\xa8\x0f\xb0\xff\x21\x3f\xe8\xf6\x23\x06\x2f\x30\xf5\x8f\xf3\xa2\x3c\x87\x8e\x65\x74\xc1\xab\xa8\xfb\x00\xfb\x33\xe0\x3f\x12\x08\xea\xf1\xa3\x6b\x57\xbc\xd7\x38\xb4\x0a\x70\x9a\xf0\x3e\xb2\x6b\x64\x42\x96\x0b\x3d\xc9\x2f\x7d\xe4\x56\x9d\xd4\xd4\x77\xa7\xaa\x03\x40\xe9\x16\x03\x01\x43\xb9\x55\x84\x7b\xf2\x98\xb7\x4b\x63\x19\x99\xab\x5f\x80\xef\xc1\x9a\xc5\x81\x0e\x15\xe6\x52\x73\x5b\x09\x9e\xe1\x3b\x7d\xfd\x5e\x12\x1a\x5f\x35\xc8\x7f\x5c\xbb\x0d\x7a\xab\x85\x4f\x31\xf3\xb5\x10\xa8\x39\xf5\x1d\x24\x52\x8f\xb9\xef\x24\xe0\x2e\x99\xfc\x14\x62\xef\x70\x79\xdd\x8a\x96\x99\x60\xd0\x89\x94\xfc\x10\x39\x1e\xca\x1e\xf9\xa5\x28\x35\xd7\xf2\xf5\x95\x7c\x65\x1d\x5f\x50\x20\xff\xe6\xd3\xee\xfe\x55\x7f\x3d\x6e\x5c\x40\xd6\x00\xbf\xa1\xad\x2c\xe8\x8f\x8c\xc9\x9f\xeb\xec\x63\xaa\xd0\xd2\x01\x96\x19\x3b\x11\x9d\x3c\x93\x29\xc2\xbc\x96\xab\x54\x69\x7e\x36\xfb\xce\x93\xc8\xff\x3f\x00\xe7\x44\x9e\xad\x40\xe8\x00\x00")
 
 func thorYamlBytes() ([]byte, error) {
 	return bindataRead(
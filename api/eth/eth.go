@@ -0,0 +1,149 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package eth
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
+)
+
+const (
+	maxRequestSize = 1024 * 1024
+	maxBatchSize   = 100
+	maxLogs        = 10000
+	maxTopicSets   = 64
+)
+
+type method func(params json.RawMessage) (interface{}, error)
+
+// Eth serves Ethereum compatible JSON-RPC, mapped onto the native chain, state and tx pool,
+// so that Ethereum tools can talk to the node.
+type Eth struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	txPool       *txpool.TxPool
+	logDB        *logdb.LogDB
+	accounts     *accounts.Accounts
+	methods      map[string]method
+}
+
+// New create a new Eth instance.
+func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool, logDB *logdb.LogDB, forkConfig thor.ForkConfig) *Eth {
+	e := &Eth{
+		chain:        chain,
+		stateCreator: stateCreator,
+		txPool:       txPool,
+		logDB:        logDB,
+		accounts:     accounts.New(chain, stateCreator, forkConfig),
+	}
+	e.methods = map[string]method{
+		"net_version":               e.netVersion,
+		"eth_chainId":               e.chainID,
+		"eth_blockNumber":           e.blockNumber,
+		"eth_gasPrice":              e.gasPrice,
+		"eth_getBalance":            e.getBalance,
+		"eth_getCode":               e.getCode,
+		"eth_getStorageAt":          e.getStorageAt,
+		"eth_getTransactionCount":   e.getTransactionCount,
+		"eth_call":                  e.call,
+		"eth_estimateGas":           e.estimateGas,
+		"eth_getBlockByNumber":      e.getBlockByNumber,
+		"eth_getBlockByHash":        e.getBlockByHash,
+		"eth_getTransactionByHash":  e.getTransactionByHash,
+		"eth_getTransactionReceipt": e.getTransactionReceipt,
+		"eth_getLogs":               e.getLogs,
+		"eth_sendRawTransaction":    e.sendRawTransaction,
+	}
+	return e
+}
+
+func (e *Eth) serve(req *rpcRequest) *rpcResponse {
+	resp := &rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: errCodeInvalidRequest, Message: "invalid request"}
+		return resp
+	}
+	m, ok := e.methods[req.Method]
+	if !ok {
+		resp.Error = &rpcError{Code: errCodeMethodNotFound, Message: "the method " + req.Method + " does not exist/is not available"}
+		return resp
+	}
+	result, err := m(req.Params)
+	if err != nil {
+		if re, ok := err.(*rpcError); ok {
+			resp.Error = re
+		} else {
+			resp.Error = &rpcError{Code: errCodeServer, Message: err.Error()}
+		}
+		return resp
+	}
+	if result == nil {
+		// JSON-RPC requires result member on success
+		result = json.RawMessage("null")
+	}
+	resp.Result = result
+	return resp
+}
+
+func (e *Eth) handleRPC(w http.ResponseWriter, req *http.Request) error {
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxRequestSize))
+	if err != nil {
+		return err
+	}
+	req.Body.Close()
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var reqs []*rpcRequest
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			return utils.WriteJSON(w, parseErrorResponse())
+		}
+		if len(reqs) == 0 || len(reqs) > maxBatchSize {
+			return utils.WriteJSON(w, &rpcResponse{
+				JSONRPC: "2.0",
+				ID:      json.RawMessage("null"),
+				Error:   &rpcError{Code: errCodeInvalidRequest, Message: "invalid batch size"},
+			})
+		}
+		resps := make([]*rpcResponse, 0, len(reqs))
+		for _, r := range reqs {
+			resps = append(resps, e.serve(r))
+		}
+		return utils.WriteJSON(w, resps)
+	}
+
+	var r rpcRequest
+	if err := json.Unmarshal(body, &r); err != nil {
+		return utils.WriteJSON(w, parseErrorResponse())
+	}
+	return utils.WriteJSON(w, e.serve(&r))
+}
+
+func parseErrorResponse() *rpcResponse {
+	return &rpcResponse{
+		JSONRPC: "2.0",
+		ID:      json.RawMessage("null"),
+		Error:   &rpcError{Code: errCodeParse, Message: "parse error"},
+	}
+}
+
+func (e *Eth) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(e.handleRPC))
+}
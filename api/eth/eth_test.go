@@ -0,0 +1,241 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package eth_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/eth"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
+)
+
+var (
+	ts   *httptest.Server
+	blk  *block.Block
+	to   = thor.BytesToAddress([]byte("to"))
+	ch   *chain.Chain
+	pool *txpool.TxPool
+)
+
+type response struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func TestEth(t *testing.T) {
+	initEthServer(t)
+	defer ts.Close()
+	defer pool.Close()
+
+	var num hexutil.Uint64
+	call(t, "eth_blockNumber", nil, &num)
+	assert.Equal(t, hexutil.Uint64(1), num)
+
+	var chainID hexutil.Uint64
+	call(t, "eth_chainId", nil, &chainID)
+	assert.Equal(t, hexutil.Uint64(ch.Tag()), chainID)
+
+	var balance hexutil.Big
+	call(t, "eth_getBalance", []interface{}{&to, "latest"}, &balance)
+	assert.Equal(t, big.NewInt(10000), (*big.Int)(&balance))
+	call(t, "eth_getBalance", []interface{}{&to, "earliest"}, &balance)
+	assert.Equal(t, 0, (*big.Int)(&balance).Sign())
+
+	var b struct {
+		Hash         thor.Bytes32   `json:"hash"`
+		Transactions []thor.Bytes32 `json:"transactions"`
+	}
+	call(t, "eth_getBlockByNumber", []interface{}{"0x1", false}, &b)
+	assert.Equal(t, blk.Header().ID(), b.Hash)
+	assert.Equal(t, []thor.Bytes32{blk.Transactions()[0].ID()}, b.Transactions)
+
+	var trx struct {
+		Hash  thor.Bytes32  `json:"hash"`
+		To    *thor.Address `json:"to"`
+		Value hexutil.Big   `json:"value"`
+	}
+	txID := blk.Transactions()[0].ID()
+	call(t, "eth_getTransactionByHash", []interface{}{&txID}, &trx)
+	assert.Equal(t, txID, trx.Hash)
+	assert.Equal(t, &to, trx.To)
+
+	var receipt struct {
+		BlockHash thor.Bytes32   `json:"blockHash"`
+		Status    hexutil.Uint64 `json:"status"`
+		GasUsed   hexutil.Uint64 `json:"gasUsed"`
+	}
+	call(t, "eth_getTransactionReceipt", []interface{}{&txID}, &receipt)
+	assert.Equal(t, blk.Header().ID(), receipt.BlockHash)
+	assert.Equal(t, hexutil.Uint64(1), receipt.Status)
+	assert.Equal(t, hexutil.Uint64(21000), receipt.GasUsed)
+
+	var gas hexutil.Uint64
+	from := genesis.DevAccounts()[0].Address
+	call(t, "eth_estimateGas", []interface{}{map[string]interface{}{"from": &from, "to": &to, "value": "0x1"}}, &gas)
+	assert.Equal(t, hexutil.Uint64(21000), gas)
+
+	var logs []interface{}
+	call(t, "eth_getLogs", []interface{}{map[string]interface{}{"fromBlock": "0x0", "address": []thor.Address{to}}}, &logs)
+	assert.Equal(t, 0, len(logs))
+
+	var missing interface{}
+	call(t, "eth_getBlockByNumber", []interface{}{"0x100", false}, &missing)
+	assert.Nil(t, missing)
+}
+
+func TestEthErrors(t *testing.T) {
+	initEthServer(t)
+	defer ts.Close()
+	defer pool.Close()
+
+	res := post(t, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_unknown"}`))
+	var resp response
+	if err := json.Unmarshal(res, &resp); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, -32601, resp.Error.Code)
+
+	res = post(t, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":[]}`))
+	if err := json.Unmarshal(res, &resp); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, -32602, resp.Error.Code)
+
+	res = post(t, []byte(`{`))
+	if err := json.Unmarshal(res, &resp); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, -32700, resp.Error.Code)
+
+	res = post(t, []byte(`[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"},{"jsonrpc":"2.0","id":2,"method":"net_version"}]`))
+	var resps []response
+	if err := json.Unmarshal(res, &resps); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 2, len(resps)) {
+		assert.Equal(t, 1, resps[0].ID)
+		assert.Equal(t, 2, resps[1].ID)
+		assert.Nil(t, resps[1].Error)
+	}
+}
+
+func call(t *testing.T, method string, params []interface{}, result interface{}) {
+	data, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp response
+	if err := json.Unmarshal(post(t, data), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		t.Fatal(method, resp.Error.Message)
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		t.Fatal(method, err)
+	}
+}
+
+func post(t *testing.T, data []byte) []byte {
+	res, err := http.Post(ts.URL+"/eth", "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func initEthServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ = chain.New(db, b)
+	cla := tx.NewClause(&to).WithValue(big.NewInt(10000))
+	trx := new(tx.Builder).
+		ChainTag(ch.Tag()).
+		GasPriceCoef(1).
+		Expiration(10).
+		Gas(21000).
+		Nonce(1).
+		Clause(cla).
+		BlockRef(tx.NewBlockRef(0)).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx = trx.WithSignature(sig)
+
+	packer := packer.New(ch, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
+	flow, err := packer.Schedule(b.Header(), uint64(time.Now().Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := flow.Adopt(trx); err != nil {
+		t.Fatal(err)
+	}
+	block, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ch.AddBlock(block, receipts); err != nil {
+		t.Fatal(err)
+	}
+	blk = block
+
+	logDB, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool = txpool.New(ch, stateC, txpool.DefaultPoolConfig, thor.NoFork)
+
+	router := mux.NewRouter()
+	eth.New(ch, stateC, pool, logDB, thor.NoFork).Mount(router, "/eth")
+	ts = httptest.NewServer(router)
+}
@@ -0,0 +1,496 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/transactions"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
+)
+
+var errHeaderNotFound = &rpcError{Code: errCodeServer, Message: "header not found"}
+
+// getBlockHeader returns the header selected by the block tag, which can be a hex number,
+// "latest", "pending", "earliest", "safe" or "finalized". nil returned if not found.
+func (e *Eth) getBlockHeader(tag string) (*block.Header, error) {
+	switch tag {
+	case "", "latest", "pending":
+		return e.chain.BestBlock().Header(), nil
+	case "earliest":
+		return e.chain.GenesisBlock().Header(), nil
+	case "safe", "finalized":
+		return e.chain.FinalizedBlock(), nil
+	}
+	n, err := hexutil.DecodeUint64(tag)
+	if err != nil {
+		return nil, invalidParams("invalid block tag %v: %v", tag, err)
+	}
+	if n > math.MaxUint32 {
+		return nil, nil
+	}
+	header, err := e.chain.GetTrunkBlockHeader(uint32(n))
+	if err != nil {
+		if e.chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return header, nil
+}
+
+// mustGetBlockHeader is like getBlockHeader, but fails if the block not found.
+func (e *Eth) mustGetBlockHeader(tag string) (*block.Header, error) {
+	header, err := e.getBlockHeader(tag)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errHeaderNotFound
+	}
+	return header, nil
+}
+
+// getStateHeader is like mustGetBlockHeader, but also fails if the state of the block was pruned.
+func (e *Eth) getStateHeader(tag string) (*block.Header, error) {
+	header, err := e.mustGetBlockHeader(tag)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.CheckState(e.chain, e.stateCreator, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func (e *Eth) baseGasPrice(header *block.Header) (*big.Int, error) {
+	st, err := e.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	baseGasPrice := builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return baseGasPrice, nil
+}
+
+func (e *Eth) netVersion(params json.RawMessage) (interface{}, error) {
+	return strconv.Itoa(int(e.chain.Tag())), nil
+}
+
+// chainID returns the chain tag, which is also the chain id used by txs.
+func (e *Eth) chainID(params json.RawMessage) (interface{}, error) {
+	return hexutil.Uint64(e.chain.Tag()), nil
+}
+
+func (e *Eth) blockNumber(params json.RawMessage) (interface{}, error) {
+	return hexutil.Uint64(e.chain.BestBlock().Header().Number()), nil
+}
+
+func (e *Eth) gasPrice(params json.RawMessage) (interface{}, error) {
+	baseGasPrice, err := e.baseGasPrice(e.chain.BestBlock().Header())
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(baseGasPrice), nil
+}
+
+func (e *Eth) getBalance(params json.RawMessage) (interface{}, error) {
+	var (
+		addr thor.Address
+		tag  string
+	)
+	if err := parseParams(params, 1, &addr, &tag); err != nil {
+		return nil, err
+	}
+	header, err := e.getStateHeader(tag)
+	if err != nil {
+		return nil, err
+	}
+	st, err := e.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	balance := st.GetBalance(addr)
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+func (e *Eth) getCode(params json.RawMessage) (interface{}, error) {
+	var (
+		addr thor.Address
+		tag  string
+	)
+	if err := parseParams(params, 1, &addr, &tag); err != nil {
+		return nil, err
+	}
+	header, err := e.getStateHeader(tag)
+	if err != nil {
+		return nil, err
+	}
+	st, err := e.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	code := st.GetCode(addr)
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(code), nil
+}
+
+func (e *Eth) getStorageAt(params json.RawMessage) (interface{}, error) {
+	var (
+		addr     thor.Address
+		position hexutil.Big
+		tag      string
+	)
+	if err := parseParams(params, 2, &addr, &position, &tag); err != nil {
+		return nil, err
+	}
+	if (*big.Int)(&position).BitLen() > 256 {
+		return nil, invalidParams("storage position exceeds 32 bytes")
+	}
+	header, err := e.getStateHeader(tag)
+	if err != nil {
+		return nil, err
+	}
+	st, err := e.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	value := st.GetStorage(addr, thor.BytesToBytes32((*big.Int)(&position).Bytes()))
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(value.Bytes()), nil
+}
+
+// getTransactionCount always returns 0, since tx nonce is not sequential per account.
+func (e *Eth) getTransactionCount(params json.RawMessage) (interface{}, error) {
+	var (
+		addr thor.Address
+		tag  string
+	)
+	if err := parseParams(params, 1, &addr, &tag); err != nil {
+		return nil, err
+	}
+	return hexutil.Uint64(0), nil
+}
+
+func (e *Eth) call(params json.RawMessage) (interface{}, error) {
+	var (
+		args callArgs
+		tag  string
+	)
+	if err := parseParams(params, 1, &args, &tag); err != nil {
+		return nil, err
+	}
+	header, err := e.getStateHeader(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &accounts.ContractCall{}
+	if args.From != nil {
+		body.Caller = *args.From
+	}
+	if args.Gas != nil {
+		body.Gas = uint64(*args.Gas)
+	}
+	if args.GasPrice != nil {
+		gp := math.HexOrDecimal256(*args.GasPrice)
+		body.GasPrice = &gp
+	}
+	if args.Value != nil {
+		v := math.HexOrDecimal256(*args.Value)
+		body.Value = &v
+	}
+	// input takes precedence over data, as geth does
+	if args.Input != nil {
+		body.Data = args.Input.String()
+	} else if args.Data != nil {
+		body.Data = args.Data.String()
+	} else {
+		body.Data = "0x"
+	}
+
+	output, err := e.accounts.Call(args.To, body, header)
+	if err != nil {
+		return nil, err
+	}
+	if output.Reverted {
+		if output.VMError == "evm: execution reverted" {
+			msg := "execution reverted"
+			if output.RevertReason != "" {
+				msg += ": " + output.RevertReason
+			}
+			return nil, &rpcError{Code: errCodeReverted, Message: msg, Data: output.Data}
+		}
+		return nil, &rpcError{Code: errCodeServer, Message: output.VMError}
+	}
+	return output.Data, nil
+}
+
+func (e *Eth) estimateGas(params json.RawMessage) (interface{}, error) {
+	var (
+		args callArgs
+		tag  string
+	)
+	if err := parseParams(params, 1, &args, &tag); err != nil {
+		return nil, err
+	}
+	header, err := e.getStateHeader(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	clause := transactions.Clause{To: args.To, Data: "0x"}
+	if args.Value != nil {
+		clause.Value = math.HexOrDecimal256(*args.Value)
+	}
+	if args.Input != nil {
+		clause.Data = args.Input.String()
+	} else if args.Data != nil {
+		clause.Data = args.Data.String()
+	}
+	// the minimal gas is expected, as geth does
+	margin := uint64(0)
+	body := &accounts.EstimateGasData{
+		Clauses: transactions.Clauses{clause},
+		Margin:  &margin,
+	}
+	if args.From != nil {
+		body.Caller = *args.From
+	}
+	if args.Gas != nil {
+		body.Gas = uint64(*args.Gas)
+	}
+	if args.GasPrice != nil {
+		gp := math.HexOrDecimal256(*args.GasPrice)
+		body.GasPrice = &gp
+	}
+
+	estimation, err := e.accounts.EstimateGas(body, header)
+	if err != nil {
+		return nil, err
+	}
+	if estimation.Reverted {
+		if estimation.VMError == "evm: execution reverted" {
+			msg := "execution reverted"
+			if estimation.RevertReason != "" {
+				msg += ": " + estimation.RevertReason
+			}
+			return nil, &rpcError{Code: errCodeReverted, Message: msg}
+		}
+		return nil, &rpcError{Code: errCodeServer, Message: "gas required exceeds allowance or always failing transaction: " + estimation.VMError}
+	}
+	return hexutil.Uint64(estimation.Gas), nil
+}
+
+func (e *Eth) getBlockByNumber(params json.RawMessage) (interface{}, error) {
+	var (
+		tag    string
+		fullTx bool
+	)
+	if err := parseParams(params, 1, &tag, &fullTx); err != nil {
+		return nil, err
+	}
+	header, err := e.getBlockHeader(tag)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	return e.getBlock(header.ID(), fullTx)
+}
+
+func (e *Eth) getBlockByHash(params json.RawMessage) (interface{}, error) {
+	var (
+		id     thor.Bytes32
+		fullTx bool
+	)
+	if err := parseParams(params, 1, &id, &fullTx); err != nil {
+		return nil, err
+	}
+	return e.getBlock(id, fullTx)
+}
+
+func (e *Eth) getBlock(id thor.Bytes32, fullTx bool) (interface{}, error) {
+	b, err := e.chain.GetBlock(id)
+	if err != nil {
+		if e.chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	baseGasPrice, err := e.baseGasPrice(b.Header())
+	if err != nil {
+		return nil, err
+	}
+	return convertBlock(b, fullTx, baseGasPrice), nil
+}
+
+func (e *Eth) getTransactionByHash(params json.RawMessage) (interface{}, error) {
+	var id thor.Bytes32
+	if err := parseParams(params, 1, &id); err != nil {
+		return nil, err
+	}
+	trx, meta, err := e.chain.GetTrunkTransaction(id)
+	if err != nil {
+		if !e.chain.IsNotFound(err) {
+			return nil, err
+		}
+		// fallback to pending one
+		if trx := e.txPool.Get(id); trx != nil {
+			baseGasPrice, err := e.baseGasPrice(e.chain.BestBlock().Header())
+			if err != nil {
+				return nil, err
+			}
+			return convertTransaction(trx, nil, 0, baseGasPrice), nil
+		}
+		return nil, nil
+	}
+	header, err := e.chain.GetBlockHeader(meta.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	baseGasPrice, err := e.baseGasPrice(header)
+	if err != nil {
+		return nil, err
+	}
+	return convertTransaction(trx, header, meta.Index, baseGasPrice), nil
+}
+
+func (e *Eth) getTransactionReceipt(params json.RawMessage) (interface{}, error) {
+	var id thor.Bytes32
+	if err := parseParams(params, 1, &id); err != nil {
+		return nil, err
+	}
+	meta, err := e.chain.GetTrunkTransactionMeta(id)
+	if err != nil {
+		if e.chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	b, err := e.chain.GetBlock(meta.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := e.chain.GetBlockReceipts(meta.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	baseGasPrice, err := e.baseGasPrice(b.Header())
+	if err != nil {
+		return nil, err
+	}
+	return convertReceipt(b, receipts, meta.Index, baseGasPrice), nil
+}
+
+func (e *Eth) getLogs(params json.RawMessage) (interface{}, error) {
+	var filter logFilter
+	if err := parseParams(params, 1, &filter); err != nil {
+		return nil, err
+	}
+	addrs, err := filter.addresses()
+	if err != nil {
+		return nil, err
+	}
+	topicSets, err := filter.topicSets()
+	if err != nil {
+		return nil, err
+	}
+
+	var rng *logdb.Range
+	if filter.BlockHash != nil {
+		if filter.FromBlock != "" || filter.ToBlock != "" {
+			return nil, invalidParams("blockHash is mutually exclusive with fromBlock/toBlock")
+		}
+		header, err := e.chain.GetBlockHeader(*filter.BlockHash)
+		if err != nil {
+			if e.chain.IsNotFound(err) {
+				return nil, errHeaderNotFound
+			}
+			return nil, err
+		}
+		rng = &logdb.Range{Unit: logdb.Block, From: uint64(header.Number()), To: uint64(header.Number())}
+	} else {
+		from, err := e.mustGetBlockHeader(filter.FromBlock)
+		if err != nil {
+			return nil, err
+		}
+		to, err := e.mustGetBlockHeader(filter.ToBlock)
+		if err != nil {
+			return nil, err
+		}
+		if from.Number() > to.Number() {
+			return []*rpcLog{}, nil
+		}
+		rng = &logdb.Range{Unit: logdb.Block, From: uint64(from.Number()), To: uint64(to.Number())}
+	}
+
+	events, err := e.logDB.FilterEvents(context.Background(), &logdb.EventFilter{
+		Addresses: addrs,
+		TopicSet:  topicSets,
+		Range:     rng,
+		Options:   &logdb.Options{Offset: 0, Limit: maxLogs + 1},
+		Order:     logdb.ASC,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > maxLogs {
+		return nil, &rpcError{Code: errCodeServer, Message: "query returned more than " + strconv.Itoa(maxLogs) + " results"}
+	}
+
+	logs := make([]*rpcLog, 0, len(events))
+	for _, ev := range events {
+		meta, err := e.chain.GetTransactionMeta(ev.TxID, ev.BlockID)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, convertLog(ev, meta.Index))
+	}
+	return logs, nil
+}
+
+// sendRawTransaction accepts tx in native RLP encoding. Txs signed in Ethereum form can not be
+// translated, since signatures commit to signing hashes in different forms.
+func (e *Eth) sendRawTransaction(params json.RawMessage) (interface{}, error) {
+	var raw hexutil.Bytes
+	if err := parseParams(params, 1, &raw); err != nil {
+		return nil, err
+	}
+	var trx *tx.Transaction
+	if err := rlp.DecodeBytes(raw, &trx); err != nil {
+		return nil, invalidParams("invalid raw tx, only natively encoded tx accepted: %v", err)
+	}
+	if err := e.txPool.AddLocal(trx); err != nil {
+		if txpool.IsBadTx(err) || txpool.IsRejectedTx(err) {
+			return nil, &rpcError{Code: errCodeServer, Message: err.Error()}
+		}
+		return nil, errors.WithMessage(err, "add tx")
+	}
+	id := trx.ID()
+	return &id, nil
+}
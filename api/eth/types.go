@@ -0,0 +1,371 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// standard JSON-RPC error codes, and the one for reverted execution used by geth.
+const (
+	errCodeParse          = -32700
+	errCodeInvalidRequest = -32600
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+	errCodeServer         = -32000
+	errCodeReverted       = 3
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+func invalidParams(format string, args ...interface{}) error {
+	return &rpcError{Code: errCodeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+// parseParams decodes positional params into args, and the first n args are required.
+func parseParams(raw json.RawMessage, required int, args ...interface{}) error {
+	var params []json.RawMessage
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return invalidParams("params should be an array")
+		}
+	}
+	if len(params) < required {
+		return invalidParams("missing value for required argument %v", len(params))
+	}
+	if len(params) > len(args) {
+		return invalidParams("too many arguments, want at most %v", len(args))
+	}
+	for i, param := range params {
+		if err := json.Unmarshal(param, args[i]); err != nil {
+			return invalidParams("invalid argument %v: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// callArgs the call object of eth_call.
+type callArgs struct {
+	From     *thor.Address   `json:"from"`
+	To       *thor.Address   `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     *hexutil.Bytes  `json:"data"`
+	Input    *hexutil.Bytes  `json:"input"`
+}
+
+// logFilter the filter object of eth_getLogs.
+// Address can be a single address or an array, and each topic position can be null,
+// a single topic or an array of topics to match any of.
+type logFilter struct {
+	FromBlock string            `json:"fromBlock"`
+	ToBlock   string            `json:"toBlock"`
+	BlockHash *thor.Bytes32     `json:"blockHash"`
+	Address   json.RawMessage   `json:"address"`
+	Topics    []json.RawMessage `json:"topics"`
+}
+
+func (f *logFilter) addresses() ([]thor.Address, error) {
+	if len(f.Address) == 0 || string(f.Address) == "null" {
+		return nil, nil
+	}
+	var addr thor.Address
+	if err := json.Unmarshal(f.Address, &addr); err == nil {
+		return []thor.Address{addr}, nil
+	}
+	var addrs []thor.Address
+	if err := json.Unmarshal(f.Address, &addrs); err != nil {
+		return nil, invalidParams("invalid address: %v", err)
+	}
+	return addrs, nil
+}
+
+// topicSets expands topic positions into combinations accepted by log db.
+func (f *logFilter) topicSets() ([][5]*thor.Bytes32, error) {
+	if len(f.Topics) > 4 {
+		return nil, invalidParams("too many topics")
+	}
+	sets := [][5]*thor.Bytes32{{}}
+	for i, raw := range f.Topics {
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		var topics []*thor.Bytes32
+		var topic thor.Bytes32
+		if err := json.Unmarshal(raw, &topic); err == nil {
+			topics = []*thor.Bytes32{&topic}
+		} else if err := json.Unmarshal(raw, &topics); err != nil {
+			return nil, invalidParams("invalid topic %v: %v", i, err)
+		}
+		if len(topics) == 0 {
+			continue
+		}
+		if len(sets)*len(topics) > maxTopicSets {
+			return nil, invalidParams("too many topic combinations")
+		}
+		expanded := make([][5]*thor.Bytes32, 0, len(sets)*len(topics))
+		for _, set := range sets {
+			for _, t := range topics {
+				if t == nil {
+					// null in array matches any
+					expanded = append(expanded, set)
+					continue
+				}
+				s := set
+				s[i] = t
+				expanded = append(expanded, s)
+			}
+		}
+		sets = expanded
+	}
+	if len(sets) == 1 && sets[0] == [5]*thor.Bytes32{} {
+		return nil, nil
+	}
+	return sets, nil
+}
+
+type rpcBlock struct {
+	Number           hexutil.Uint64 `json:"number"`
+	Hash             thor.Bytes32   `json:"hash"`
+	ParentHash       thor.Bytes32   `json:"parentHash"`
+	Nonce            hexutil.Bytes  `json:"nonce"`
+	Sha3Uncles       thor.Bytes32   `json:"sha3Uncles"`
+	LogsBloom        hexutil.Bytes  `json:"logsBloom"`
+	TransactionsRoot thor.Bytes32   `json:"transactionsRoot"`
+	StateRoot        thor.Bytes32   `json:"stateRoot"`
+	ReceiptsRoot     thor.Bytes32   `json:"receiptsRoot"`
+	Miner            thor.Address   `json:"miner"`
+	Difficulty       hexutil.Uint64 `json:"difficulty"`
+	TotalDifficulty  hexutil.Uint64 `json:"totalDifficulty"`
+	ExtraData        hexutil.Bytes  `json:"extraData"`
+	Size             hexutil.Uint64 `json:"size"`
+	GasLimit         hexutil.Uint64 `json:"gasLimit"`
+	GasUsed          hexutil.Uint64 `json:"gasUsed"`
+	Timestamp        hexutil.Uint64 `json:"timestamp"`
+	Transactions     []interface{}  `json:"transactions"`
+	Uncles           []thor.Bytes32 `json:"uncles"`
+}
+
+// keccak256 of rlp encoded empty list, as no uncle exists.
+var emptyUncleHash = thor.BytesToBytes32(hexutil.MustDecode("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"))
+
+func convertBlock(b *block.Block, fullTx bool, baseGasPrice *big.Int) *rpcBlock {
+	header := b.Header()
+	txs := make([]interface{}, 0, len(b.Transactions()))
+	for i, trx := range b.Transactions() {
+		if fullTx {
+			txs = append(txs, convertTransaction(trx, header, uint64(i), baseGasPrice))
+		} else {
+			// pointer to be marshalled as hex
+			id := trx.ID()
+			txs = append(txs, &id)
+		}
+	}
+	return &rpcBlock{
+		Number:           hexutil.Uint64(header.Number()),
+		Hash:             header.ID(),
+		ParentHash:       header.ParentID(),
+		Nonce:            make([]byte, 8),
+		Sha3Uncles:       emptyUncleHash,
+		LogsBloom:        make([]byte, 256),
+		TransactionsRoot: header.TxsRoot(),
+		StateRoot:        header.StateRoot(),
+		ReceiptsRoot:     header.ReceiptsRoot(),
+		Miner:            header.Beneficiary(),
+		TotalDifficulty:  hexutil.Uint64(header.TotalScore()),
+		ExtraData:        []byte{},
+		Size:             hexutil.Uint64(b.Size()),
+		GasLimit:         hexutil.Uint64(header.GasLimit()),
+		GasUsed:          hexutil.Uint64(header.GasUsed()),
+		Timestamp:        hexutil.Uint64(header.Timestamp()),
+		Transactions:     txs,
+		Uncles:           []thor.Bytes32{},
+	}
+}
+
+// rpcTransaction a tx in Ethereum form.
+// A tx may have multiple clauses, and only the first clause is presented as to, value and input.
+type rpcTransaction struct {
+	BlockHash        *thor.Bytes32   `json:"blockHash"`
+	BlockNumber      *hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+	Hash             thor.Bytes32    `json:"hash"`
+	From             thor.Address    `json:"from"`
+	To               *thor.Address   `json:"to"`
+	Value            *hexutil.Big    `json:"value"`
+	Input            hexutil.Bytes   `json:"input"`
+	Gas              hexutil.Uint64  `json:"gas"`
+	GasPrice         *hexutil.Big    `json:"gasPrice"`
+	Nonce            hexutil.Uint64  `json:"nonce"`
+	ChainID          hexutil.Uint64  `json:"chainId"`
+	Type             hexutil.Uint64  `json:"type"`
+}
+
+// convertTransaction converts tx into Ethereum form. header is nil for pending tx.
+func convertTransaction(trx *tx.Transaction, header *block.Header, index uint64, baseGasPrice *big.Int) *rpcTransaction {
+	origin, _ := trx.Signer()
+	t := &rpcTransaction{
+		Hash:     trx.ID(),
+		From:     origin,
+		Value:    (*hexutil.Big)(new(big.Int)),
+		Input:    []byte{},
+		Gas:      hexutil.Uint64(trx.Gas()),
+		GasPrice: (*hexutil.Big)(trx.GasPrice(baseGasPrice)),
+		Nonce:    hexutil.Uint64(trx.Nonce()),
+		ChainID:  hexutil.Uint64(trx.ChainTag()),
+		Type:     hexutil.Uint64(trx.Type()),
+	}
+	if clauses := trx.Clauses(); len(clauses) > 0 {
+		t.To = clauses[0].To()
+		t.Value = (*hexutil.Big)(clauses[0].Value())
+		t.Input = clauses[0].Data()
+	}
+	if header != nil {
+		id := header.ID()
+		num := hexutil.Uint64(header.Number())
+		idx := hexutil.Uint64(index)
+		t.BlockHash, t.BlockNumber, t.TransactionIndex = &id, &num, &idx
+	}
+	return t
+}
+
+type rpcLog struct {
+	Address          thor.Address   `json:"address"`
+	Topics           []thor.Bytes32 `json:"topics"`
+	Data             hexutil.Bytes  `json:"data"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	BlockHash        thor.Bytes32   `json:"blockHash"`
+	TransactionHash  thor.Bytes32   `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	LogIndex         hexutil.Uint64 `json:"logIndex"`
+	Removed          bool           `json:"removed"`
+}
+
+func convertLog(event *logdb.Event, txIndex uint64) *rpcLog {
+	topics := make([]thor.Bytes32, 0, len(event.Topics))
+	for _, topic := range event.Topics {
+		if topic != nil {
+			topics = append(topics, *topic)
+		}
+	}
+	return &rpcLog{
+		Address:          event.Address,
+		Topics:           topics,
+		Data:             event.Data,
+		BlockNumber:      hexutil.Uint64(event.BlockNumber),
+		BlockHash:        event.BlockID,
+		TransactionHash:  event.TxID,
+		TransactionIndex: hexutil.Uint64(txIndex),
+		LogIndex:         hexutil.Uint64(event.Index),
+	}
+}
+
+type rpcReceipt struct {
+	TransactionHash   thor.Bytes32   `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64 `json:"transactionIndex"`
+	BlockHash         thor.Bytes32   `json:"blockHash"`
+	BlockNumber       hexutil.Uint64 `json:"blockNumber"`
+	From              thor.Address   `json:"from"`
+	To                *thor.Address  `json:"to"`
+	CumulativeGasUsed hexutil.Uint64 `json:"cumulativeGasUsed"`
+	GasUsed           hexutil.Uint64 `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big   `json:"effectiveGasPrice"`
+	ContractAddress   *thor.Address  `json:"contractAddress"`
+	Logs              []*rpcLog      `json:"logs"`
+	LogsBloom         hexutil.Bytes  `json:"logsBloom"`
+	Status            hexutil.Uint64 `json:"status"`
+	Type              hexutil.Uint64 `json:"type"`
+}
+
+// convertReceipt converts receipt of the tx at index of the block into Ethereum form.
+// Receipts of the whole block are required to derive cumulative gas and log index.
+func convertReceipt(b *block.Block, receipts tx.Receipts, index uint64, baseGasPrice *big.Int) *rpcReceipt {
+	header := b.Header()
+	trx := b.Transactions()[index]
+	receipt := receipts[index]
+
+	var cumulativeGas, logIndex uint64
+	for _, r := range receipts[:index] {
+		cumulativeGas += r.GasUsed
+		for _, output := range r.Outputs {
+			logIndex += uint64(len(output.Events))
+		}
+	}
+	cumulativeGas += receipt.GasUsed
+
+	origin, _ := trx.Signer()
+	r := &rpcReceipt{
+		TransactionHash:   trx.ID(),
+		TransactionIndex:  hexutil.Uint64(index),
+		BlockHash:         header.ID(),
+		BlockNumber:       hexutil.Uint64(header.Number()),
+		From:              origin,
+		CumulativeGasUsed: hexutil.Uint64(cumulativeGas),
+		GasUsed:           hexutil.Uint64(receipt.GasUsed),
+		EffectiveGasPrice: (*hexutil.Big)(trx.GasPrice(baseGasPrice)),
+		Logs:              []*rpcLog{},
+		LogsBloom:         make([]byte, 256),
+		Type:              hexutil.Uint64(trx.Type()),
+	}
+	if !receipt.Reverted {
+		r.Status = 1
+	}
+	clauses := trx.Clauses()
+	if len(clauses) > 0 {
+		r.To = clauses[0].To()
+		if clauses[0].IsCreatingContract() && !receipt.Reverted {
+			addr := thor.CreateContractAddress(trx.ID(), 0, 0)
+			r.ContractAddress = &addr
+		}
+	}
+	for _, output := range receipt.Outputs {
+		for _, event := range output.Events {
+			r.Logs = append(r.Logs, &rpcLog{
+				Address:          event.Address,
+				Topics:           event.Topics,
+				Data:             event.Data,
+				BlockNumber:      r.BlockNumber,
+				BlockHash:        r.BlockHash,
+				TransactionHash:  r.TransactionHash,
+				TransactionIndex: r.TransactionIndex,
+				LogIndex:         hexutil.Uint64(logIndex),
+			})
+			logIndex++
+		}
+	}
+	return r
+}
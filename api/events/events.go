@@ -10,64 +10,115 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 	"github.com/vechain/thor/api/utils"
 	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/thor"
 )
 
 type Events struct {
-	db *logdb.LogDB
+	db    *logdb.LogDB
+	limit uint64
 }
 
-func New(db *logdb.LogDB) *Events {
+// New creates events API, which returns at most limit events in a query.
+func New(db *logdb.LogDB, limit uint64) *Events {
 	return &Events{
 		db,
+		limit,
 	}
 }
 
-//Filter query events with option
-func (e *Events) filter(ctx context.Context, filter *Filter) ([]*FilteredEvent, error) {
+//Filter query events with option, and returns the cursor of next page if the page is full
+func (e *Events) filter(ctx context.Context, filter *Filter) ([]*FilteredEvent, *logdb.Cursor, error) {
 	f := convertFilter(filter)
 	events, err := e.db.FilterEvents(ctx, f)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	fes := make([]*FilteredEvent, len(events))
 	for i, e := range events {
 		fes[i] = convertEvent(e)
 	}
-	return fes, nil
+	var next *logdb.Cursor
+	if len(events) > 0 && uint64(len(events)) == f.Options.Limit {
+		next = events[len(events)-1].Cursor()
+	}
+	return fes, next, nil
 }
 
-func (e *Events) handleFilter(w http.ResponseWriter, req *http.Request) error {
+// parseFilter parses the filter from request body, overridden by address and order in query.
+func (e *Events) parseFilter(req *http.Request) (*Filter, error) {
 	var filter Filter
 	if err := utils.ParseJSON(req.Body, &filter); err != nil {
-		return err
+		return nil, err
 	}
 	req.Body.Close()
 	query := req.URL.Query()
 	if query.Get("address") != "" {
 		addr, err := thor.ParseAddress(query.Get("address"))
 		if err != nil {
-			return utils.BadRequest(err, "address")
+			return nil, utils.BadRequest(err, "address")
 		}
 		filter.Address = &addr
 	}
-	order := query.Get("order")
-	if order != string(logdb.DESC) {
+	if order := query.Get("order"); order != "" {
+		filter.Order = logdb.Order(order)
+	}
+	switch filter.Order {
+	case "":
 		filter.Order = logdb.ASC
-	} else {
-		filter.Order = logdb.DESC
+	case logdb.ASC, logdb.DESC:
+	default:
+		return nil, utils.BadRequest(errors.New("should be 'asc' or 'desc'"), "order")
+	}
+	if filter.Range != nil {
+		switch filter.Range.Unit {
+		case "":
+			filter.Range.Unit = logdb.Block
+		case logdb.Block, logdb.Time:
+		default:
+			return nil, utils.BadRequest(errors.New("should be 'block' or 'time'"), "range.unit")
+		}
+	}
+	if filter.Options == nil {
+		filter.Options = &logdb.Options{Limit: e.limit}
+	} else if filter.Options.Limit > e.limit {
+		return nil, utils.BadRequest(errors.Errorf("should not exceed %v", e.limit), "options.limit")
+	}
+	return &filter, nil
+}
+
+func (e *Events) handleFilter(w http.ResponseWriter, req *http.Request) error {
+	filter, err := e.parseFilter(req)
+	if err != nil {
+		return err
 	}
-	fes, err := e.filter(req.Context(), &filter)
+	fes, next, err := e.filter(req.Context(), filter)
 	if err != nil {
 		return err
 	}
+	if next != nil {
+		w.Header().Set(utils.NextCursorHeader, next.String())
+	}
 	return utils.WriteJSON(w, fes)
 }
 
+func (e *Events) handleCount(w http.ResponseWriter, req *http.Request) error {
+	filter, err := e.parseFilter(req)
+	if err != nil {
+		return err
+	}
+	count, err := e.db.CountEvents(req.Context(), convertFilter(filter))
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &Count{count})
+}
+
 func (e *Events) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 
 	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(e.handleFilter))
+	sub.Path("/count").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(e.handleCount))
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/api/events"
+	"github.com/vechain/thor/api/utils"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/thor"
@@ -29,6 +30,9 @@ func TestEvents(t *testing.T) {
 	initEventServer(t)
 	defer ts.Close()
 	getEvents(t)
+	getEventsWithBadOrder(t)
+	getEventsByCursor(t)
+	getEventsCount(t)
 }
 
 func getEvents(t *testing.T) {
@@ -60,12 +64,88 @@ func getEvents(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	res := httpPost(t, ts.URL+"/events?address="+contractAddr.String(), f)
+	res, _ := httpPost(t, ts.URL+"/logs/event?address="+contractAddr.String(), f)
 	var logs []*events.FilteredEvent
 	if err := json.Unmarshal(res, &logs); err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, limit, len(logs), "should be `limit` logs")
+	for _, log := range logs {
+		assert.Equal(t, contractAddr, log.Address)
+	}
+
+	filter.Order = logdb.DESC
+	f, err = json.Marshal(&filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, _ = httpPost(t, ts.URL+"/logs/event", f)
+	var descLogs []*events.FilteredEvent
+	if err := json.Unmarshal(res, &descLogs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, limit, len(descLogs), "should be `limit` logs")
+	assert.True(t, descLogs[0].Block.Number > logs[0].Block.Number, "should be in desc order")
+}
+
+func getEventsWithBadOrder(t *testing.T) {
+	_, statusCode := httpPost(t, ts.URL+"/logs/event?order=random", []byte("{}"))
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	_, statusCode = httpPost(t, ts.URL+"/logs/event", []byte(`{"range":{"unit":"random"}}`))
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func getEventsByCursor(t *testing.T) {
+	post := func(body string) ([]*events.FilteredEvent, string) {
+		res, err := http.Post(ts.URL+"/logs/event", "application/json", bytes.NewReader([]byte(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var logs []*events.FilteredEvent
+		if err := json.NewDecoder(res.Body).Decode(&logs); err != nil {
+			t.Fatal(err)
+		}
+		return logs, res.Header.Get(utils.NextCursorHeader)
+	}
+
+	// limited by default
+	logs, cursor := post("{}")
+	assert.Equal(t, 10, len(logs))
+	assert.NotEmpty(t, cursor)
+
+	next, _ := post(`{"options":{"limit":10,"cursor":"` + cursor + `"}}`)
+	assert.Equal(t, 10, len(next))
+	assert.Equal(t, logs[9].Block.Number+1, next[0].Block.Number)
+
+	// the last page is not full
+	_, cursor = post(`{"range":{"from":95,"to":100},"options":{"limit":10}}`)
+	assert.Empty(t, cursor)
+
+	_, statusCode := httpPost(t, ts.URL+"/logs/event", []byte(`{"options":{"limit":11}}`))
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func getEventsCount(t *testing.T) {
+	count := func(body string) uint64 {
+		res, statusCode := httpPost(t, ts.URL+"/logs/event/count", []byte(body))
+		assert.Equal(t, http.StatusOK, statusCode, string(res))
+		var c events.Count
+		if err := json.Unmarshal(res, &c); err != nil {
+			t.Fatal(err)
+		}
+		return c.Count
+	}
+
+	// not limited by options
+	assert.Equal(t, uint64(100), count(`{"options":{"limit":1}}`))
+
+	other := thor.BytesToAddress([]byte("other"))
+	// events are in blocks #1 to #100, and both bounds of range are inclusive
+	assert.Equal(t, uint64(9), count(`{"addresses":["`+other.String()+`","`+contractAddr.String()+`"],"range":{"from":0,"to":9}}`))
+	assert.Equal(t, uint64(0), count(`{"addresses":["`+other.String()+`"]}`))
+	assert.Equal(t, uint64(0), count(`{"range":{"from":0,"to":9},"timeRange":{"from":1}}`))
 }
 
 func initEventServer(t *testing.T) {
@@ -91,11 +171,11 @@ func initEventServer(t *testing.T) {
 	}
 
 	router := mux.NewRouter()
-	events.New(db).Mount(router, "/events")
+	events.New(db, 10).Mount(router, "/logs/event")
 	ts = httptest.NewServer(router)
 }
 
-func httpPost(t *testing.T, url string, data []byte) []byte {
+func httpPost(t *testing.T, url string, data []byte) ([]byte, int) {
 	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
 	if err != nil {
 		t.Fatal(err)
@@ -105,5 +185,5 @@ func httpPost(t *testing.T, url string, data []byte) []byte {
 	if err != nil {
 		t.Fatal(err)
 	}
-	return r
+	return r, res.StatusCode
 }
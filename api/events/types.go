@@ -24,18 +24,22 @@ type TopicSet struct {
 
 type Filter struct {
 	Address   *thor.Address
+	Addresses []thor.Address
 	TopicSets []*TopicSet
 	Range     *logdb.Range
+	TimeRange *logdb.Range
 	Options   *logdb.Options
 	Order     logdb.Order
 }
 
 func convertFilter(filter *Filter) *logdb.EventFilter {
 	f := &logdb.EventFilter{
-		Address: filter.Address,
-		Range:   filter.Range,
-		Options: filter.Options,
-		Order:   filter.Order,
+		Address:   filter.Address,
+		Addresses: filter.Addresses,
+		Range:     filter.Range,
+		TimeRange: filter.TimeRange,
+		Options:   filter.Options,
+		Order:     filter.Order,
 	}
 	if len(filter.TopicSets) > 0 {
 		var topicSets [][5]*thor.Bytes32
@@ -53,18 +57,25 @@ func convertFilter(filter *Filter) *logdb.EventFilter {
 	return f
 }
 
+// Count count of matched logs.
+type Count struct {
+	Count uint64 `json:"count"`
+}
+
 // FilteredEvent only comes from one contract
 type FilteredEvent struct {
-	Topics []*thor.Bytes32           `json:"topics"`
-	Data   string                    `json:"data"`
-	Block  transactions.BlockContext `json:"block"`
-	Tx     transactions.TxContext    `json:"tx"`
+	Address thor.Address              `json:"address"`
+	Topics  []*thor.Bytes32           `json:"topics"`
+	Data    string                    `json:"data"`
+	Block   transactions.BlockContext `json:"block"`
+	Tx      transactions.TxContext    `json:"tx"`
 }
 
 //convert a logdb.Event into a json format Event
 func convertEvent(event *logdb.Event) *FilteredEvent {
 	fe := FilteredEvent{
-		Data: hexutil.Encode(event.Data),
+		Address: event.Address,
+		Data:    hexutil.Encode(event.Data),
 		Block: transactions.BlockContext{
 			ID:        event.BlockID,
 			Number:    event.BlockNumber,
@@ -87,6 +98,7 @@ func convertEvent(event *logdb.Event) *FilteredEvent {
 func (e *FilteredEvent) String() string {
 	return fmt.Sprintf(`
 		Event(
+			address:       %v,
 			topics:        %v,
 			data:          %v,
 			block: (id     %v,
@@ -95,6 +107,7 @@ func (e *FilteredEvent) String() string {
 			tx:    (id     %v,
 					origin %v)
 			)`,
+		e.Address,
 		e.Topics,
 		e.Data,
 		e.Block.ID,
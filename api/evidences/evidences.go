@@ -0,0 +1,77 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package evidences
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/evidence"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+type Evidences struct {
+	store        *evidence.Store
+	chain        *chain.Chain
+	stateCreator *state.Creator
+}
+
+func New(store *evidence.Store, chain *chain.Chain, stateCreator *state.Creator) *Evidences {
+	return &Evidences{
+		store,
+		chain,
+		stateCreator,
+	}
+}
+
+func (e *Evidences) handleGetEvidences(w http.ResponseWriter, req *http.Request) error {
+	var signer *thor.Address
+	if s := req.URL.Query().Get("signer"); s != "" {
+		addr, err := thor.ParseAddress(s)
+		if err != nil {
+			return utils.BadRequest(err, "signer")
+		}
+		signer = &addr
+	}
+	evs, err := e.store.Evidences(signer)
+	if err != nil {
+		return err
+	}
+
+	st, err := e.stateCreator.NewState(e.chain.BestBlock().Header().StateRoot())
+	if err != nil {
+		return err
+	}
+	authority := builtin.Authority.Native(st)
+
+	result := make([]*Evidence, 0, len(evs))
+	for _, ev := range evs {
+		jev, err := convertEvidence(ev)
+		if err != nil {
+			return err
+		}
+		if candidate, listed := authority.Get(jev.Signer); listed {
+			jev.Listed = true
+			jev.Active = candidate.Active
+		}
+		result = append(result, jev)
+	}
+	if err := st.Err(); err != nil {
+		return errors.WithMessage(err, "state")
+	}
+	return utils.WriteJSON(w, result)
+}
+
+func (e *Evidences) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(e.handleGetEvidences))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package evidences_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/evidences"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/evidence"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+func TestEvidences(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, _ := genesis.NewDevnet()
+	b0, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, _ := chain.New(db, b0)
+
+	headers := make(map[thor.Bytes32]*block.Header)
+	store := evidence.New(db, func(id thor.Bytes32) (*block.Header, error) {
+		if h, ok := headers[id]; ok {
+			return h, nil
+		}
+		return nil, errors.New("not found")
+	})
+
+	acc := genesis.DevAccounts()[0]
+	var ids []thor.Bytes32
+	for _, gasLimit := range []uint64{1, 2} {
+		blk := new(block.Builder).ParentID(b0.Header().ID()).Timestamp(b0.Header().Timestamp() + thor.BlockInterval).GasLimit(gasLimit).Build()
+		sig, _ := crypto.Sign(blk.Header().SigningHash().Bytes(), acc.PrivateKey)
+		h := blk.WithSignature(sig).Header()
+		headers[h.ID()] = h
+		ids = append(ids, h.ID())
+		if _, err := store.Track(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := mux.NewRouter()
+	evidences.New(store, chain, stateC).Mount(router, "/evidences")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	var evs []*evidences.Evidence
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/evidences"), &evs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []*evidences.Evidence{{
+		Signer:    acc.Address,
+		Timestamp: b0.Header().Timestamp() + thor.BlockInterval,
+		Blocks:    []evidences.BlockRef{{ids[0], 1}, {ids[1], 1}},
+		Listed:    true,
+		Active:    true,
+	}}, evs)
+
+	if err := json.Unmarshal(httpGet(t, ts.URL+"/evidences?signer="+genesis.DevAccounts()[1].Address.String()), &evs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(evs))
+
+	res, err := http.Get(ts.URL + "/evidences?signer=foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func httpGet(t *testing.T, url string) []byte {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
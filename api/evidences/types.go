@@ -0,0 +1,42 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package evidences
+
+import (
+	"github.com/vechain/thor/evidence"
+	"github.com/vechain/thor/thor"
+)
+
+// Evidence of equivocation.
+// Listed and Active reflect the signer's status in the Authority built-in at best block.
+type Evidence struct {
+	Signer    thor.Address `json:"signer"`
+	Timestamp uint64       `json:"timestamp"`
+	Blocks    []BlockRef   `json:"blocks"`
+	Listed    bool         `json:"listed"`
+	Active    bool         `json:"active"`
+}
+
+// BlockRef references a block involved in the evidence.
+type BlockRef struct {
+	ID     thor.Bytes32 `json:"id"`
+	Number uint32       `json:"number"`
+}
+
+func convertEvidence(ev *evidence.Evidence) (*Evidence, error) {
+	signer, err := ev.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return &Evidence{
+		Signer:    signer,
+		Timestamp: ev.First.Timestamp(),
+		Blocks: []BlockRef{
+			{ev.First.ID(), ev.First.Number()},
+			{ev.Second.ID(), ev.Second.Number()},
+		},
+	}, nil
+}
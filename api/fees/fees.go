@@ -0,0 +1,278 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package fees
+
+import (
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+const (
+	defaultBlockCount = 10
+	maxBlockCount     = 1024
+)
+
+var defaultPercentiles = []float64{10, 50, 90}
+
+// Fees serves base gas price and fees history, for wallets to suggest gas prices.
+type Fees struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator) *Fees {
+	return &Fees{
+		chain,
+		stateCreator,
+	}
+}
+
+func (f *Fees) baseGasPrice(header *block.Header) (*big.Int, error) {
+	if err := utils.CheckState(f.chain, f.stateCreator, header); err != nil {
+		return nil, err
+	}
+	st, err := f.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	baseGasPrice := builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return baseGasPrice, nil
+}
+
+func (f *Fees) handleGetBaseGasPrice(w http.ResponseWriter, req *http.Request) error {
+	header, err := f.getBlockHeader(req.URL.Query().Get("revision"))
+	if err != nil {
+		return err
+	}
+	baseGasPrice, err := f.baseGasPrice(header)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &BaseGasPrice{
+		BaseGasPrice: (*math.HexOrDecimal256)(baseGasPrice),
+		BlockID:      header.ID(),
+		BlockNumber:  header.Number(),
+	})
+}
+
+// blockFees computes fees stats of the block.
+// Effective gas prices are derived from receipts, as paid divided by gas used.
+func (f *Fees) blockFees(blk *block.Block, percentiles []float64) (*BlockFees, error) {
+	header := blk.Header()
+	// the base gas price applied to txs is the one in the parent state
+	priceHeader := header
+	if header.Number() > 0 {
+		parent, err := f.chain.GetBlockHeader(header.ParentID())
+		if err != nil {
+			return nil, err
+		}
+		priceHeader = parent
+	}
+	baseGasPrice, err := f.baseGasPrice(priceHeader)
+	if err != nil {
+		return nil, err
+	}
+	// receipts of genesis are not saved
+	var receipts tx.Receipts
+	if len(blk.Transactions()) > 0 {
+		if receipts, err = f.chain.GetBlockReceipts(header.ID()); err != nil {
+			return nil, err
+		}
+	}
+
+	fees := &BlockFees{
+		ID:           header.ID(),
+		Number:       header.Number(),
+		BaseGasPrice: (*math.HexOrDecimal256)(baseGasPrice),
+		GasLimit:     header.GasLimit(),
+		GasUsed:      header.GasUsed(),
+		TxCount:      len(blk.Transactions()),
+		GasPrices:    make([]*math.HexOrDecimal256, 0, len(percentiles)),
+	}
+	if header.GasLimit() > 0 {
+		fees.GasUsedRatio = float64(header.GasUsed()) / float64(header.GasLimit())
+	}
+	for _, price := range weightedPrices(receipts, percentiles) {
+		fees.GasPrices = append(fees.GasPrices, (*math.HexOrDecimal256)(price))
+	}
+	return fees, nil
+}
+
+// weightedPrices returns effective gas prices at percentiles, weighted by gas used.
+// Zero prices are returned for a block without txs.
+func weightedPrices(receipts tx.Receipts, percentiles []float64) []*big.Int {
+	type item struct {
+		price   *big.Int
+		gasUsed uint64
+	}
+	var (
+		items    = make([]item, 0, len(receipts))
+		totalGas uint64
+	)
+	for _, r := range receipts {
+		if r.GasUsed == 0 {
+			continue
+		}
+		price := new(big.Int).Div(r.Paid, new(big.Int).SetUint64(r.GasUsed))
+		items = append(items, item{price, r.GasUsed})
+		totalGas += r.GasUsed
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].price.Cmp(items[j].price) < 0
+	})
+
+	prices := make([]*big.Int, 0, len(percentiles))
+	for _, p := range percentiles {
+		if len(items) == 0 {
+			prices = append(prices, &big.Int{})
+			continue
+		}
+		threshold := uint64(float64(totalGas) * p / 100)
+		var (
+			i   int
+			sum = items[0].gasUsed
+		)
+		for sum < threshold && i < len(items)-1 {
+			i++
+			sum += items[i].gasUsed
+		}
+		prices = append(prices, items[i].price)
+	}
+	return prices
+}
+
+// handleGetHistory returns fees stats of 'blockCount' blocks ended with the block of revision.
+func (f *Fees) handleGetHistory(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	blockCount := uint64(defaultBlockCount)
+	if str := query.Get("blockCount"); str != "" {
+		n, err := strconv.ParseUint(str, 10, 32)
+		if err != nil {
+			return utils.BadRequest(err, "blockCount")
+		}
+		if n == 0 || n > maxBlockCount {
+			return utils.BadRequest(errors.Errorf("should be in [1, %v]", maxBlockCount), "blockCount")
+		}
+		blockCount = n
+	}
+	percentiles, err := parsePercentiles(query.Get("percentiles"))
+	if err != nil {
+		return utils.BadRequest(err, "percentiles")
+	}
+	newest, err := f.getBlockHeader(query.Get("revision"))
+	if err != nil {
+		return err
+	}
+
+	oldest := uint32(0)
+	if uint64(newest.Number())+1 > blockCount {
+		oldest = newest.Number() + 1 - uint32(blockCount)
+	}
+	history := &FeeHistory{
+		OldestBlock: oldest,
+		Percentiles: percentiles,
+		Blocks:      make([]*BlockFees, 0, newest.Number()-oldest+1),
+	}
+	// walk back from newest, so that it works for a block not on the trunk
+	blocks := make([]*block.Block, newest.Number()-oldest+1)
+	id := newest.ID()
+	for i := len(blocks) - 1; i >= 0; i-- {
+		blk, err := f.chain.GetBlock(id)
+		if err != nil {
+			return err
+		}
+		blocks[i] = blk
+		id = blk.Header().ParentID()
+	}
+	for _, blk := range blocks {
+		fees, err := f.blockFees(blk, percentiles)
+		if err != nil {
+			return err
+		}
+		history.Blocks = append(history.Blocks, fees)
+	}
+	return utils.WriteJSON(w, history)
+}
+
+// parsePercentiles parses comma separated percentiles, which should be in [0, 100] and ascending.
+func parsePercentiles(str string) ([]float64, error) {
+	if str == "" {
+		return defaultPercentiles, nil
+	}
+	var percentiles []float64
+	for _, s := range strings.Split(str, ",") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, err
+		}
+		if p < 0 || p > 100 {
+			return nil, errors.New("out of range")
+		}
+		if len(percentiles) > 0 && p < percentiles[len(percentiles)-1] {
+			return nil, errors.New("not ascending")
+		}
+		percentiles = append(percentiles, p)
+	}
+	return percentiles, nil
+}
+
+// getBlockHeader returns the header selected by revision, which can be a block number,
+// a block ID, "best" or "finalized".
+func (f *Fees) getBlockHeader(revision string) (*block.Header, error) {
+	if revision == "" || revision == "best" {
+		return f.chain.BestBlock().Header(), nil
+	}
+	if revision == "finalized" {
+		return f.chain.FinalizedBlock(), nil
+	}
+	var (
+		header *block.Header
+		err    error
+	)
+	if blkID, e := thor.ParseBytes32(revision); e == nil {
+		header, err = f.chain.GetBlockHeader(blkID)
+	} else {
+		n, e := strconv.ParseUint(revision, 0, 0)
+		if e != nil {
+			return nil, utils.BadRequest(e, "revision")
+		}
+		if n > math.MaxUint32 {
+			return nil, utils.BadRequest(errors.New("block number exceeded"), "revision")
+		}
+		header, err = f.chain.GetTrunkBlockHeader(uint32(n))
+	}
+	if err != nil {
+		if f.chain.IsNotFound(err) {
+			return nil, utils.NotFound(errors.New("block not found"), "revision")
+		}
+		return nil, err
+	}
+	return header, nil
+}
+
+func (f *Fees) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/base-gas-price").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(f.handleGetBaseGasPrice))
+	sub.Path("/history").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(f.handleGetHistory))
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package fees_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/fees"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+var (
+	ts *httptest.Server
+	ch *chain.Chain
+)
+
+func TestFees(t *testing.T) {
+	initFeesServer(t)
+	defer ts.Close()
+
+	var price fees.BaseGasPrice
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/fees/base-gas-price", &price))
+	assert.Equal(t, thor.InitialBaseGasPrice, (*big.Int)(price.BaseGasPrice))
+	assert.Equal(t, uint32(1), price.BlockNumber)
+
+	var history fees.FeeHistory
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/fees/history?percentiles=0,100", &history))
+	assert.Equal(t, uint32(0), history.OldestBlock)
+	assert.Equal(t, []float64{0, 100}, history.Percentiles)
+	assert.Equal(t, 2, len(history.Blocks))
+
+	genesisFees := history.Blocks[0]
+	assert.Equal(t, 0, genesisFees.TxCount)
+	assert.Equal(t, 0, (*big.Int)(genesisFees.GasPrices[0]).Sign())
+
+	blockFees := history.Blocks[1]
+	assert.Equal(t, ch.BestBlock().Header().ID(), blockFees.ID)
+	assert.Equal(t, 2, blockFees.TxCount)
+	assert.Equal(t, uint64(42000), blockFees.GasUsed)
+	assert.True(t, blockFees.GasUsedRatio > 0)
+	assert.Equal(t, thor.InitialBaseGasPrice, (*big.Int)(blockFees.BaseGasPrice))
+	// coef 0 pays the base gas price, while coef 255 pays double
+	assert.Equal(t, thor.InitialBaseGasPrice, (*big.Int)(blockFees.GasPrices[0]))
+	assert.Equal(t, new(big.Int).Mul(thor.InitialBaseGasPrice, big.NewInt(2)), (*big.Int)(blockFees.GasPrices[1]))
+
+	history = fees.FeeHistory{}
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/fees/history?blockCount=1&revision=0", &history))
+	assert.Equal(t, 1, len(history.Blocks))
+	assert.Equal(t, uint32(0), history.Blocks[0].Number)
+	assert.Equal(t, 3, len(history.Blocks[0].GasPrices))
+
+	for _, query := range []string{"blockCount=0", "blockCount=x", "percentiles=50,10", "percentiles=101", "revision=x"} {
+		assert.Equal(t, http.StatusBadRequest, httpGet(t, ts.URL+"/fees/history?"+query, nil), query)
+	}
+	assert.Equal(t, http.StatusNotFound, httpGet(t, ts.URL+"/fees/history?revision=100", nil))
+}
+
+func initFeesServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ = chain.New(db, b)
+
+	to := thor.BytesToAddress([]byte("to"))
+	pk := packer.New(ch, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
+	flow, err := pk.Schedule(b.Header(), uint64(time.Now().Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, coef := range []uint8{0, 255} {
+		trx := new(tx.Builder).
+			ChainTag(ch.Tag()).
+			GasPriceCoef(coef).
+			Expiration(10).
+			Gas(21000).
+			Nonce(uint64(i)).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+			Build()
+		sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[i].PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := flow.Adopt(trx.WithSignature(sig)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	blk, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ch.AddBlock(blk, receipts); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	fees.New(ch, stateC).Mount(router, "/fees")
+	ts = httptest.NewServer(router)
+}
+
+func httpGet(t *testing.T, url string, v interface{}) int {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil && res.StatusCode == http.StatusOK {
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.StatusCode
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package fees
+
+import (
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/thor"
+)
+
+// BaseGasPrice the base gas price in the state of a block, which applies to txs of the next block.
+type BaseGasPrice struct {
+	BaseGasPrice *math.HexOrDecimal256 `json:"baseGasPrice"`
+	BlockID      thor.Bytes32          `json:"blockID"`
+	BlockNumber  uint32                `json:"blockNumber"`
+}
+
+// BlockFees fees stats of a block.
+// BaseGasPrice is the one applied to txs of the block, and GasPrices are effective gas prices
+// paid by txs at requested percentiles, weighted by gas used.
+type BlockFees struct {
+	ID           thor.Bytes32            `json:"id"`
+	Number       uint32                  `json:"number"`
+	BaseGasPrice *math.HexOrDecimal256   `json:"baseGasPrice"`
+	GasLimit     uint64                  `json:"gasLimit"`
+	GasUsed      uint64                  `json:"gasUsed"`
+	GasUsedRatio float64                 `json:"gasUsedRatio"`
+	TxCount      int                     `json:"txCount"`
+	GasPrices    []*math.HexOrDecimal256 `json:"gasPrices"`
+}
+
+// FeeHistory fees stats of consecutive blocks, in ascending order.
+type FeeHistory struct {
+	OldestBlock uint32       `json:"oldestBlock"`
+	Percentiles []float64    `json:"percentiles"`
+	Blocks      []*BlockFees `json:"blocks"`
+}
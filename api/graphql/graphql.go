@@ -0,0 +1,86 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/gorilla/mux"
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+const (
+	maxBlocks          = 100  // max number of blocks queried at once
+	defaultEventsLimit = 100  // default number of events if limit omitted
+	maxEventsLimit     = 1000 // max number of events queried at once
+)
+
+// GraphQL serves GraphQL queries over chain, state and log db.
+type GraphQL struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	logDB        *logdb.LogDB
+	schema       *gql.Schema
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator, logDB *logdb.LogDB) *GraphQL {
+	g := &GraphQL{
+		chain:        chain,
+		stateCreator: stateCreator,
+		logDB:        logDB,
+	}
+	g.schema = gql.MustParseSchema(schema, &resolver{g})
+	return g
+}
+
+// getBlockHeader returns the header selected by revision, which can be a block number,
+// a block ID, "best" or "finalized". Nil is returned if not found.
+func (g *GraphQL) getBlockHeader(revision *string) (*block.Header, error) {
+	if revision == nil || *revision == "" || *revision == "best" {
+		return g.chain.BestBlock().Header(), nil
+	}
+	if *revision == "finalized" {
+		return g.chain.FinalizedBlock(), nil
+	}
+	var (
+		header *block.Header
+		err    error
+	)
+	if blkID, e := thor.ParseBytes32(*revision); e == nil {
+		header, err = g.chain.GetBlockHeader(blkID)
+	} else {
+		n, e := strconv.ParseUint(*revision, 0, 0)
+		if e != nil {
+			return nil, errors.WithMessage(e, "revision")
+		}
+		if n > math.MaxUint32 {
+			return nil, errors.New("revision: block number exceeded")
+		}
+		header, err = g.chain.GetTrunkBlockHeader(uint32(n))
+	}
+	if err != nil {
+		if g.chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return header, nil
+}
+
+func (g *GraphQL) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").Methods(http.MethodPost).Handler(&relay.Handler{Schema: g.schema})
+}
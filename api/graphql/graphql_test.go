@@ -0,0 +1,213 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/graphql"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+var (
+	ts    *httptest.Server
+	blk   *block.Block
+	to    = thor.BytesToAddress([]byte("to"))
+	topic = thor.BytesToBytes32([]byte("topic"))
+)
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func TestGraphQL(t *testing.T) {
+	initGraphQLServer(t)
+	defer ts.Close()
+
+	var b struct {
+		Block struct {
+			ID           string
+			Number       int
+			Transactions []struct {
+				ID      string
+				Origin  string
+				Clauses []struct{ To, Value string }
+				Receipt struct {
+					GasUsed  uint64
+					Reverted bool
+				}
+			}
+			Account struct{ Balance string }
+		}
+	}
+	query(t, `{ block(revision: "1") {
+		id number
+		transactions { id origin clauses { to value } receipt { gasUsed reverted } }
+		account(address: "`+to.String()+`") { balance }
+	} }`, &b)
+	assert.Equal(t, blk.Header().ID().String(), b.Block.ID)
+	assert.Equal(t, 1, b.Block.Number)
+	assert.Equal(t, 1, len(b.Block.Transactions))
+	trx := b.Block.Transactions[0]
+	assert.Equal(t, blk.Transactions()[0].ID().String(), trx.ID)
+	assert.Equal(t, genesis.DevAccounts()[0].Address.String(), trx.Origin)
+	assert.Equal(t, to.String(), trx.Clauses[0].To)
+	assert.Equal(t, "0x2710", trx.Clauses[0].Value)
+	assert.Equal(t, uint64(21000), trx.Receipt.GasUsed)
+	assert.False(t, trx.Receipt.Reverted)
+	assert.Equal(t, "0x2710", b.Block.Account.Balance)
+
+	var a struct {
+		Account struct{ Balance string }
+	}
+	query(t, `{ account(address: "`+to.String()+`", revision: "0") { balance } }`, &a)
+	assert.Equal(t, "0x0", a.Account.Balance)
+
+	var missing struct {
+		Block       *struct{ ID string }
+		Transaction *struct{ ID string }
+	}
+	query(t, `{ block(revision: "100") { id } transaction(id: "`+thor.Bytes32{}.String()+`") { id } }`, &missing)
+	assert.Nil(t, missing.Block)
+	assert.Nil(t, missing.Transaction)
+
+	var blocks struct {
+		Blocks []struct{ Number int }
+	}
+	query(t, `{ blocks(from: 0, to: 10) { number } }`, &blocks)
+	assert.Equal(t, 2, len(blocks.Blocks))
+
+	var e struct {
+		Events []struct {
+			Address     string
+			Topics      []string
+			BlockNumber int
+			Transaction struct{ ID string }
+		}
+	}
+	query(t, `{ events(filter: { topics: ["`+topic.String()+`"] }) { address topics blockNumber transaction { id } } }`, &e)
+	assert.Equal(t, 1, len(e.Events))
+	assert.Equal(t, to.String(), e.Events[0].Address)
+	assert.Equal(t, []string{topic.String()}, e.Events[0].Topics)
+	assert.Equal(t, 1, e.Events[0].BlockNumber)
+	assert.Equal(t, blk.Transactions()[0].ID().String(), e.Events[0].Transaction.ID)
+
+	res := post(t, `{ events(filter: { limit: 100000 }) { address } }`)
+	assert.NotEmpty(t, res.Errors)
+}
+
+func initGraphQLServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(db, b)
+	trx := new(tx.Builder).
+		ChainTag(ch.Tag()).
+		Expiration(10).
+		Gas(21000).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(10000))).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx = trx.WithSignature(sig)
+
+	pk := packer.New(ch, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
+	flow, err := pk.Schedule(b.Header(), uint64(time.Now().Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := flow.Adopt(trx); err != nil {
+		t.Fatal(err)
+	}
+	block, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ch.AddBlock(block, receipts); err != nil {
+		t.Fatal(err)
+	}
+	blk = block
+
+	logDB, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := &tx.Event{Address: to, Topics: []thor.Bytes32{topic}}
+	if err := logDB.Prepare(block.Header()).
+		ForTransaction(trx.ID(), genesis.DevAccounts()[0].Address).
+		Insert(tx.Events{event}, nil).
+		Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	graphql.New(ch, stateC, logDB).Mount(router, "/graphql")
+	ts = httptest.NewServer(router)
+}
+
+func post(t *testing.T, q string) *response {
+	body, err := json.Marshal(map[string]string{"query": q})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Post(ts.URL+"/graphql", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r response
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatal(err)
+	}
+	return &r
+}
+
+func query(t *testing.T, q string, v interface{}) {
+	res := post(t, q)
+	if len(res.Errors) > 0 {
+		t.Fatal(res.Errors[0].Message)
+	}
+	if err := json.Unmarshal(res.Data, v); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,498 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// Long the scalar of uint64.
+type Long uint64
+
+// ImplementsGraphQLType maps Long to the scalar in schema.
+func (Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+// UnmarshalGraphQL accepts number or string in decimal or hex.
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		*l = Long(v)
+	case float64:
+		*l = Long(v)
+	case string:
+		n, err := strconv.ParseUint(v, 0, 64)
+		if err != nil {
+			return err
+		}
+		*l = Long(n)
+	default:
+		return errors.New("unexpected type for Long")
+	}
+	return nil
+}
+
+type resolver struct {
+	g *GraphQL
+}
+
+func (r *resolver) Block(args struct{ Revision *string }) (*blockResolver, error) {
+	header, err := r.g.getBlockHeader(args.Revision)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	return &blockResolver{r.g, header}, nil
+}
+
+func (r *resolver) Blocks(args struct{ From, To int32 }) ([]*blockResolver, error) {
+	if args.From < 0 || args.To < args.From {
+		return nil, errors.New("invalid range")
+	}
+	if args.To-args.From >= maxBlocks {
+		return nil, errors.Errorf("range exceeds %v blocks", maxBlocks)
+	}
+	best := r.g.chain.BestBlock().Header().Number()
+	var blocks []*blockResolver
+	for n := uint32(args.From); n <= uint32(args.To) && n <= best; n++ {
+		header, err := r.g.chain.GetTrunkBlockHeader(n)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, &blockResolver{r.g, header})
+	}
+	return blocks, nil
+}
+
+func (r *resolver) Transaction(args struct{ ID string }) (*txResolver, error) {
+	id, err := thor.ParseBytes32(args.ID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "id")
+	}
+	trx, meta, err := r.g.chain.GetTrunkTransaction(id)
+	if err != nil {
+		if r.g.chain.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &txResolver{r.g, trx, meta.BlockID, meta.Index}, nil
+}
+
+func (r *resolver) Account(args struct {
+	Address  string
+	Revision *string
+}) (*accountResolver, error) {
+	addr, err := thor.ParseAddress(args.Address)
+	if err != nil {
+		return nil, errors.WithMessage(err, "address")
+	}
+	header, err := r.g.getBlockHeader(args.Revision)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	if err := utils.CheckState(r.g.chain, r.g.stateCreator, header); err != nil {
+		return nil, err
+	}
+	return &accountResolver{r.g, addr, header}, nil
+}
+
+type eventFilter struct {
+	Address   *string
+	Topics    *[]*string
+	FromBlock *int32
+	ToBlock   *int32
+	Offset    *int32
+	Limit     *int32
+	Order     *string
+}
+
+func (r *resolver) Events(ctx context.Context, args struct{ Filter eventFilter }) ([]*filteredEventResolver, error) {
+	f := args.Filter
+	filter := &logdb.EventFilter{
+		Range: &logdb.Range{
+			Unit: logdb.Block,
+			To:   uint64(r.g.chain.BestBlock().Header().Number()),
+		},
+		Options: &logdb.Options{Limit: defaultEventsLimit},
+		Order:   logdb.ASC,
+	}
+	if f.Address != nil {
+		addr, err := thor.ParseAddress(*f.Address)
+		if err != nil {
+			return nil, errors.WithMessage(err, "address")
+		}
+		filter.Address = &addr
+	}
+	if f.Topics != nil {
+		if len(*f.Topics) > 5 {
+			return nil, errors.New("topics: at most 5")
+		}
+		var topics [5]*thor.Bytes32
+		for i, t := range *f.Topics {
+			if t == nil {
+				continue
+			}
+			topic, err := thor.ParseBytes32(*t)
+			if err != nil {
+				return nil, errors.WithMessage(err, "topics")
+			}
+			topics[i] = &topic
+		}
+		filter.TopicSet = [][5]*thor.Bytes32{topics}
+	}
+	if f.FromBlock != nil {
+		if *f.FromBlock < 0 {
+			return nil, errors.New("fromBlock: negative")
+		}
+		filter.Range.From = uint64(*f.FromBlock)
+	}
+	if f.ToBlock != nil {
+		if *f.ToBlock < 0 {
+			return nil, errors.New("toBlock: negative")
+		}
+		filter.Range.To = uint64(*f.ToBlock)
+	}
+	if f.Offset != nil {
+		if *f.Offset < 0 {
+			return nil, errors.New("offset: negative")
+		}
+		filter.Options.Offset = uint64(*f.Offset)
+	}
+	if f.Limit != nil {
+		if *f.Limit < 0 || *f.Limit > maxEventsLimit {
+			return nil, errors.Errorf("limit: should be in [0, %v]", maxEventsLimit)
+		}
+		filter.Options.Limit = uint64(*f.Limit)
+	}
+	if f.Order != nil {
+		switch order := logdb.Order(*f.Order); order {
+		case logdb.ASC, logdb.DESC:
+			filter.Order = order
+		default:
+			return nil, errors.New("order: should be 'asc' or 'desc'")
+		}
+	}
+
+	events, err := r.g.logDB.FilterEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*filteredEventResolver, 0, len(events))
+	for _, e := range events {
+		resolvers = append(resolvers, &filteredEventResolver{r.g, e})
+	}
+	return resolvers, nil
+}
+
+type blockResolver struct {
+	g      *GraphQL
+	header *block.Header
+}
+
+func (b *blockResolver) ID() string           { return b.header.ID().String() }
+func (b *blockResolver) Number() int32        { return int32(b.header.Number()) }
+func (b *blockResolver) ParentID() string     { return b.header.ParentID().String() }
+func (b *blockResolver) Timestamp() Long      { return Long(b.header.Timestamp()) }
+func (b *blockResolver) GasLimit() Long       { return Long(b.header.GasLimit()) }
+func (b *blockResolver) GasUsed() Long        { return Long(b.header.GasUsed()) }
+func (b *blockResolver) TotalScore() Long     { return Long(b.header.TotalScore()) }
+func (b *blockResolver) Beneficiary() string  { return b.header.Beneficiary().String() }
+func (b *blockResolver) StateRoot() string    { return b.header.StateRoot().String() }
+func (b *blockResolver) TxsRoot() string      { return b.header.TxsRoot().String() }
+func (b *blockResolver) ReceiptsRoot() string { return b.header.ReceiptsRoot().String() }
+
+func (b *blockResolver) Signer() (string, error) {
+	signer, err := b.header.Signer()
+	if err != nil {
+		return "", err
+	}
+	return signer.String(), nil
+}
+
+func (b *blockResolver) Transactions() ([]*txResolver, error) {
+	body, err := b.g.chain.GetBlockBody(b.header.ID())
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*txResolver, 0, len(body.Txs))
+	for i, trx := range body.Txs {
+		txs = append(txs, &txResolver{b.g, trx, b.header.ID(), uint64(i)})
+	}
+	return txs, nil
+}
+
+func (b *blockResolver) Account(args struct{ Address string }) (*accountResolver, error) {
+	addr, err := thor.ParseAddress(args.Address)
+	if err != nil {
+		return nil, errors.WithMessage(err, "address")
+	}
+	return &accountResolver{b.g, addr, b.header}, nil
+}
+
+type txResolver struct {
+	g       *GraphQL
+	trx     *tx.Transaction
+	blockID thor.Bytes32
+	index   uint64
+}
+
+func (t *txResolver) ID() string          { return t.trx.ID().String() }
+func (t *txResolver) ChainTag() int32     { return int32(t.trx.ChainTag()) }
+func (t *txResolver) Expiration() int32   { return int32(t.trx.Expiration()) }
+func (t *txResolver) GasPriceCoef() int32 { return int32(t.trx.GasPriceCoef()) }
+func (t *txResolver) Gas() Long           { return Long(t.trx.Gas()) }
+func (t *txResolver) Nonce() Long         { return Long(t.trx.Nonce()) }
+func (t *txResolver) Size() int32         { return int32(t.trx.Size()) }
+
+func (t *txResolver) BlockRef() string {
+	br := t.trx.BlockRef()
+	return hexutil.Encode(br[:])
+}
+
+func (t *txResolver) Clauses() []*clauseResolver {
+	clauses := t.trx.Clauses()
+	resolvers := make([]*clauseResolver, 0, len(clauses))
+	for _, c := range clauses {
+		resolvers = append(resolvers, &clauseResolver{c})
+	}
+	return resolvers
+}
+
+func (t *txResolver) Origin() (string, error) {
+	origin, err := t.trx.Signer()
+	if err != nil {
+		return "", err
+	}
+	return origin.String(), nil
+}
+
+func (t *txResolver) Delegator() (*string, error) {
+	delegator, err := t.trx.Delegator()
+	if err != nil || delegator == nil {
+		return nil, err
+	}
+	str := delegator.String()
+	return &str, nil
+}
+
+func (t *txResolver) DependsOn() *string {
+	dep := t.trx.DependsOn()
+	if dep == nil {
+		return nil
+	}
+	str := dep.String()
+	return &str
+}
+
+func (t *txResolver) Block() (*blockResolver, error) {
+	header, err := t.g.chain.GetBlockHeader(t.blockID)
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{t.g, header}, nil
+}
+
+func (t *txResolver) Receipt() (*receiptResolver, error) {
+	receipt, err := t.g.chain.GetTransactionReceipt(t.blockID, t.index)
+	if err != nil {
+		return nil, err
+	}
+	return &receiptResolver{receipt}, nil
+}
+
+type clauseResolver struct {
+	clause *tx.Clause
+}
+
+func (c *clauseResolver) To() *string {
+	to := c.clause.To()
+	if to == nil {
+		return nil
+	}
+	str := to.String()
+	return &str
+}
+
+func (c *clauseResolver) Value() string { return hexutil.EncodeBig(c.clause.Value()) }
+func (c *clauseResolver) Data() string  { return hexutil.Encode(c.clause.Data()) }
+
+type receiptResolver struct {
+	receipt *tx.Receipt
+}
+
+func (r *receiptResolver) GasUsed() Long    { return Long(r.receipt.GasUsed) }
+func (r *receiptResolver) GasPayer() string { return r.receipt.GasPayer.String() }
+func (r *receiptResolver) Paid() string     { return hexutil.EncodeBig(r.receipt.Paid) }
+func (r *receiptResolver) Reward() string   { return hexutil.EncodeBig(r.receipt.Reward) }
+func (r *receiptResolver) Reverted() bool   { return r.receipt.Reverted }
+
+func (r *receiptResolver) Outputs() []*outputResolver {
+	outputs := make([]*outputResolver, 0, len(r.receipt.Outputs))
+	for _, o := range r.receipt.Outputs {
+		outputs = append(outputs, &outputResolver{o})
+	}
+	return outputs
+}
+
+type outputResolver struct {
+	output *tx.Output
+}
+
+func (o *outputResolver) Events() []*eventResolver {
+	events := make([]*eventResolver, 0, len(o.output.Events))
+	for _, e := range o.output.Events {
+		events = append(events, &eventResolver{e})
+	}
+	return events
+}
+
+func (o *outputResolver) Transfers() []*transferResolver {
+	transfers := make([]*transferResolver, 0, len(o.output.Transfers))
+	for _, t := range o.output.Transfers {
+		transfers = append(transfers, &transferResolver{t})
+	}
+	return transfers
+}
+
+type eventResolver struct {
+	event *tx.Event
+}
+
+func (e *eventResolver) Address() string { return e.event.Address.String() }
+func (e *eventResolver) Data() string    { return hexutil.Encode(e.event.Data) }
+
+func (e *eventResolver) Topics() []string {
+	topics := make([]string, 0, len(e.event.Topics))
+	for _, t := range e.event.Topics {
+		topics = append(topics, t.String())
+	}
+	return topics
+}
+
+type transferResolver struct {
+	transfer *tx.Transfer
+}
+
+func (t *transferResolver) Sender() string    { return t.transfer.Sender.String() }
+func (t *transferResolver) Recipient() string { return t.transfer.Recipient.String() }
+func (t *transferResolver) Amount() string    { return hexutil.EncodeBig(t.transfer.Amount) }
+
+// accountResolver resolves account fields in the state of the block.
+// The state is created per field, since fields may be resolved concurrently.
+type accountResolver struct {
+	g      *GraphQL
+	addr   thor.Address
+	header *block.Header
+}
+
+func (a *accountResolver) Address() string { return a.addr.String() }
+
+func (a *accountResolver) Balance() (string, error) {
+	st, err := a.g.stateCreator.NewState(a.header.StateRoot())
+	if err != nil {
+		return "", err
+	}
+	balance := st.GetBalance(a.addr)
+	if err := st.Err(); err != nil {
+		return "", err
+	}
+	return hexutil.EncodeBig(balance), nil
+}
+
+func (a *accountResolver) Energy() (string, error) {
+	st, err := a.g.stateCreator.NewState(a.header.StateRoot())
+	if err != nil {
+		return "", err
+	}
+	energy := st.GetEnergy(a.addr, a.header.Timestamp())
+	if err := st.Err(); err != nil {
+		return "", err
+	}
+	return hexutil.EncodeBig(energy), nil
+}
+
+func (a *accountResolver) Code() (string, error) {
+	st, err := a.g.stateCreator.NewState(a.header.StateRoot())
+	if err != nil {
+		return "", err
+	}
+	code := st.GetCode(a.addr)
+	if err := st.Err(); err != nil {
+		return "", err
+	}
+	return hexutil.Encode(code), nil
+}
+
+func (a *accountResolver) HasCode() (bool, error) {
+	code, err := a.Code()
+	if err != nil {
+		return false, err
+	}
+	return code != "0x", nil
+}
+
+func (a *accountResolver) Storage(args struct{ Key string }) (string, error) {
+	key, err := thor.ParseBytes32(args.Key)
+	if err != nil {
+		return "", errors.WithMessage(err, "key")
+	}
+	st, err := a.g.stateCreator.NewState(a.header.StateRoot())
+	if err != nil {
+		return "", err
+	}
+	value := st.GetStorage(a.addr, key)
+	if err := st.Err(); err != nil {
+		return "", err
+	}
+	return value.String(), nil
+}
+
+type filteredEventResolver struct {
+	g     *GraphQL
+	event *logdb.Event
+}
+
+func (e *filteredEventResolver) Address() string      { return e.event.Address.String() }
+func (e *filteredEventResolver) Data() string         { return hexutil.Encode(e.event.Data) }
+func (e *filteredEventResolver) BlockID() string      { return e.event.BlockID.String() }
+func (e *filteredEventResolver) BlockNumber() int32   { return int32(e.event.BlockNumber) }
+func (e *filteredEventResolver) BlockTimestamp() Long { return Long(e.event.BlockTime) }
+func (e *filteredEventResolver) TxID() string         { return e.event.TxID.String() }
+func (e *filteredEventResolver) TxOrigin() string     { return e.event.TxOrigin.String() }
+
+func (e *filteredEventResolver) Topics() []string {
+	topics := make([]string, 0, len(e.event.Topics))
+	for _, t := range e.event.Topics {
+		if t != nil {
+			topics = append(topics, t.String())
+		}
+	}
+	return topics
+}
+
+func (e *filteredEventResolver) Block() (*blockResolver, error) {
+	header, err := e.g.chain.GetBlockHeader(e.event.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{e.g, header}, nil
+}
+
+func (e *filteredEventResolver) Transaction() (*txResolver, error) {
+	trx, meta, err := e.g.chain.GetTrunkTransaction(e.event.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return &txResolver{e.g, trx, meta.BlockID, meta.Index}, nil
+}
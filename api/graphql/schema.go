@@ -0,0 +1,124 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package graphql
+
+// schema of the GraphQL endpoint.
+// Hashes, addresses, byte arrays and big integers are hex strings, and revision follows the RESTful API,
+// which can be block number, ID, 'best' or 'finalized'.
+const schema = `
+	scalar Long
+
+	schema {
+		query: Query
+	}
+
+	type Query {
+		block(revision: String): Block
+		blocks(from: Int!, to: Int!): [Block!]!
+		transaction(id: String!): Transaction
+		account(address: String!, revision: String): Account
+		events(filter: EventFilter!): [FilteredEvent!]!
+	}
+
+	type Block {
+		id: String!
+		number: Int!
+		parentID: String!
+		timestamp: Long!
+		gasLimit: Long!
+		gasUsed: Long!
+		totalScore: Long!
+		beneficiary: String!
+		signer: String!
+		stateRoot: String!
+		txsRoot: String!
+		receiptsRoot: String!
+		transactions: [Transaction!]!
+		account(address: String!): Account!
+	}
+
+	type Transaction {
+		id: String!
+		chainTag: Int!
+		blockRef: String!
+		expiration: Int!
+		clauses: [Clause!]!
+		gasPriceCoef: Int!
+		gas: Long!
+		origin: String!
+		delegator: String
+		nonce: Long!
+		dependsOn: String
+		size: Int!
+		block: Block!
+		receipt: Receipt!
+	}
+
+	type Clause {
+		to: String
+		value: String!
+		data: String!
+	}
+
+	type Receipt {
+		gasUsed: Long!
+		gasPayer: String!
+		paid: String!
+		reward: String!
+		reverted: Boolean!
+		outputs: [Output!]!
+	}
+
+	type Output {
+		events: [Event!]!
+		transfers: [Transfer!]!
+	}
+
+	type Event {
+		address: String!
+		topics: [String!]!
+		data: String!
+	}
+
+	type Transfer {
+		sender: String!
+		recipient: String!
+		amount: String!
+	}
+
+	type Account {
+		address: String!
+		balance: String!
+		energy: String!
+		code: String!
+		hasCode: Boolean!
+		storage(key: String!): String!
+	}
+
+	type FilteredEvent {
+		address: String!
+		topics: [String!]!
+		data: String!
+		blockID: String!
+		blockNumber: Int!
+		blockTimestamp: Long!
+		txID: String!
+		txOrigin: String!
+		block: Block!
+		transaction: Transaction!
+	}
+
+	# topics are matched by position, and null matches any.
+	input EventFilter {
+		address: String
+		topics: [String]
+		fromBlock: Int
+		toBlock: Int
+		offset: Int
+		limit: Int
+		order: String
+	}
+`
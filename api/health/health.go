@@ -0,0 +1,121 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+)
+
+const (
+	defaultMaxBlocksBehind = 12
+	defaultMinPeers        = 1
+)
+
+// Health serves liveness and readiness probes.
+// Status 200 is responded if healthy or ready, otherwise 503.
+type Health struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	nw           Network
+}
+
+func New(chain *chain.Chain, stateCreator *state.Creator, nw Network) *Health {
+	return &Health{
+		chain,
+		stateCreator,
+		nw,
+	}
+}
+
+func writeStatus(w http.ResponseWriter, ok bool, obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", utils.JSONContentType)
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(data)
+	return nil
+}
+
+func parseUintQuery(req *http.Request, name string, def uint64) (uint64, error) {
+	str := req.URL.Query().Get(name)
+	if str == "" {
+		return def, nil
+	}
+	v, err := strconv.ParseUint(str, 10, 32)
+	if err != nil {
+		return 0, utils.BadRequest(err, name)
+	}
+	return v, nil
+}
+
+// handleHealth reports healthy if the state of the best block is readable, which implies the db open.
+func (h *Health) handleHealth(w http.ResponseWriter, req *http.Request) error {
+	best := h.chain.BestBlock().Header()
+	result := &Liveness{BestBlockNumber: best.Number()}
+	if has, err := h.stateCreator.HasRoot(best.StateRoot()); err != nil {
+		result.Error = err.Error()
+	} else if !has {
+		result.Error = "state of best block missing"
+	} else {
+		result.Healthy = true
+	}
+	return writeStatus(w, result.Healthy, result)
+}
+
+// handleReady reports ready if enough peers connected, and the best block is not too far behind the network.
+// The thresholds can be overridden by query 'maxBlocksBehind' and 'minPeers'.
+func (h *Health) handleReady(w http.ResponseWriter, req *http.Request) error {
+	maxBehind, err := parseUintQuery(req, "maxBlocksBehind", defaultMaxBlocksBehind)
+	if err != nil {
+		return err
+	}
+	minPeers, err := parseUintQuery(req, "minPeers", defaultMinPeers)
+	if err != nil {
+		return err
+	}
+
+	bestNum := h.chain.BestBlock().Header().Number()
+	result := &Readiness{
+		BestBlockNumber:        bestNum,
+		NetworkBestBlockNumber: bestNum,
+	}
+	for _, stats := range h.nw.PeersStats() {
+		if num := block.Number(stats.BestBlockID); num > result.NetworkBestBlockNumber {
+			result.NetworkBestBlockNumber = num
+		}
+		result.PeerCount++
+	}
+
+	switch {
+	case uint64(result.PeerCount) < minPeers:
+		result.Reason = fmt.Sprintf("too few peers: %v < %v", result.PeerCount, minPeers)
+	case uint64(result.NetworkBestBlockNumber-bestNum) > maxBehind:
+		result.Reason = fmt.Sprintf("too far behind the network: %v blocks", result.NetworkBestBlockNumber-bestNum)
+	default:
+		result.Ready = true
+	}
+	return writeStatus(w, result.Ready, result)
+}
+
+func (h *Health) Mount(root *mux.Router, pathPrefix string) {
+	root.Path(pathPrefix + "/health").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(h.handleHealth))
+	root.Path(pathPrefix + "/ready").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(h.handleReady))
+}
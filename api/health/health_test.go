@@ -0,0 +1,111 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package health_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/health"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/comm"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+type network struct {
+	heads []uint32
+}
+
+func (nw *network) PeersStats() []*comm.PeerStats {
+	var stats []*comm.PeerStats
+	for _, num := range nw.heads {
+		var id thor.Bytes32
+		binary.BigEndian.PutUint32(id[:], num)
+		stats = append(stats, &comm.PeerStats{BestBlockID: id})
+	}
+	return stats
+}
+
+func TestHealth(t *testing.T) {
+	nw := &network{}
+	ts := initHealthServer(t, nw)
+	defer ts.Close()
+
+	var liveness health.Liveness
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/health", &liveness))
+	assert.Equal(t, health.Liveness{Healthy: true}, liveness)
+
+	var readiness health.Readiness
+	assert.Equal(t, http.StatusServiceUnavailable, httpGet(t, ts.URL+"/ready", &readiness))
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, 0, readiness.PeerCount)
+	assert.NotEmpty(t, readiness.Reason)
+
+	// solo like, no peers required
+	readiness = health.Readiness{}
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/ready?minPeers=0", &readiness))
+	assert.True(t, readiness.Ready)
+
+	nw.heads = []uint32{5, 20}
+	readiness = health.Readiness{}
+	assert.Equal(t, http.StatusServiceUnavailable, httpGet(t, ts.URL+"/ready", &readiness))
+	assert.Equal(t, uint32(20), readiness.NetworkBestBlockNumber)
+	assert.Equal(t, 2, readiness.PeerCount)
+
+	readiness = health.Readiness{}
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/ready?maxBlocksBehind=20", &readiness))
+	assert.True(t, readiness.Ready)
+
+	res, err := http.Get(ts.URL + "/ready?minPeers=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func initHealthServer(t *testing.T, nw health.Network) *httptest.Server {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(db, b)
+
+	router := mux.NewRouter()
+	health.New(ch, stateC, nw).Mount(router, "")
+	return httptest.NewServer(router)
+}
+
+func httpGet(t *testing.T, url string, obj interface{}) int {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, obj); err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package health
+
+import (
+	"github.com/vechain/thor/comm"
+)
+
+// Network provides stats of connected peers.
+type Network interface {
+	PeersStats() []*comm.PeerStats
+}
+
+// Liveness liveness of the node.
+type Liveness struct {
+	Healthy         bool   `json:"healthy"`
+	BestBlockNumber uint32 `json:"bestBlockNumber"`
+	Error           string `json:"error,omitempty"`
+}
+
+// Readiness whether the node is usable to serve requests.
+// The network best block is the highest one among heads of connected peers.
+type Readiness struct {
+	Ready                  bool   `json:"ready"`
+	BestBlockNumber        uint32 `json:"bestBlockNumber"`
+	NetworkBestBlockNumber uint32 `json:"networkBestBlockNumber"`
+	PeerCount              int    `json:"peerCount"`
+	Reason                 string `json:"reason,omitempty"`
+}
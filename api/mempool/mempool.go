@@ -0,0 +1,129 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package mempool
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vechain/thor/api/transactions"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
+)
+
+// Mempool serves inspection of txs in pool, for users to see whether their txs are queued and why.
+type Mempool struct {
+	txPool *txpool.TxPool
+}
+
+func New(txPool *txpool.TxPool) *Mempool {
+	return &Mempool{
+		txPool,
+	}
+}
+
+// parseOrigin parses the optional origin query, which limits listings to a single signer.
+func parseOrigin(req *http.Request) (*thor.Address, error) {
+	origin := req.URL.Query().Get("origin")
+	if origin == "" {
+		return nil, nil
+	}
+	addr, err := thor.ParseAddress(origin)
+	if err != nil {
+		return nil, utils.BadRequest(err, "origin")
+	}
+	return &addr, nil
+}
+
+func (m *Mempool) handleGetStats(w http.ResponseWriter, req *http.Request) error {
+	return utils.WriteJSON(w, convertStats(m.txPool.Stats()))
+}
+
+func (m *Mempool) handleInspect(w http.ResponseWriter, req *http.Request) error {
+	origin, err := parseOrigin(req)
+	if err != nil {
+		return err
+	}
+	pending, queued := m.txPool.Inspect()
+
+	convert := func(summaries map[thor.Address][]*txpool.TxSummary, pending bool) map[string][]*TxSummary {
+		result := make(map[string][]*TxSummary)
+		for signer, list := range summaries {
+			if origin != nil && signer != *origin {
+				continue
+			}
+			converted := make([]*TxSummary, 0, len(list))
+			for _, summary := range list {
+				converted = append(converted, convertTxSummary(summary, pending))
+			}
+			result[signer.String()] = converted
+		}
+		return result
+	}
+	return utils.WriteJSON(w, &Inspection{
+		Pending: convert(pending, true),
+		Queued:  convert(queued, false),
+	})
+}
+
+func (m *Mempool) handleGetContent(w http.ResponseWriter, req *http.Request) error {
+	origin, err := parseOrigin(req)
+	if err != nil {
+		return err
+	}
+	pending, queued := m.txPool.Content()
+
+	convert := func(txs map[thor.Address]tx.Transactions) (map[string][]*transactions.Transaction, error) {
+		result := make(map[string][]*transactions.Transaction)
+		for signer, list := range txs {
+			if origin != nil && signer != *origin {
+				continue
+			}
+			converted := make([]*transactions.Transaction, 0, len(list))
+			for _, trx := range list {
+				t, err := transactions.ConvertTransaction(trx)
+				if err != nil {
+					return nil, err
+				}
+				converted = append(converted, t)
+			}
+			result[signer.String()] = converted
+		}
+		return result, nil
+	}
+
+	var content Content
+	if content.Pending, err = convert(pending); err != nil {
+		return err
+	}
+	if content.Queued, err = convert(queued); err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &content)
+}
+
+func (m *Mempool) handleGetTx(w http.ResponseWriter, req *http.Request) error {
+	id, err := thor.ParseBytes32(mux.Vars(req)["id"])
+	if err != nil {
+		return utils.BadRequest(err, "id")
+	}
+	summary, pending := m.txPool.Lookup(id)
+	if summary == nil {
+		return utils.WriteJSON(w, nil)
+	}
+	return utils.WriteJSON(w, convertTxSummary(summary, pending))
+}
+
+func (m *Mempool) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/status").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(m.handleGetStats))
+	sub.Path("/inspect").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(m.handleInspect))
+	sub.Path("/content").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(m.handleGetContent))
+	sub.Path("/transactions/{id}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(m.handleGetTx))
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package mempool_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/mempool"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
+)
+
+var (
+	ts   *httptest.Server
+	ch   *chain.Chain
+	pool *txpool.TxPool
+)
+
+func TestMempool(t *testing.T) {
+	initMempoolServer(t)
+	defer ts.Close()
+	defer pool.Close()
+
+	acc := genesis.DevAccounts()[0]
+	origin := acc.Address.String()
+	to := thor.BytesToAddress([]byte("to"))
+	build := func(nonce uint64, dependsOn *thor.Bytes32) *tx.Transaction {
+		trx, err := tx.Sign(new(tx.Builder).
+			ChainTag(ch.Tag()).
+			Expiration(10).
+			Gas(21000).
+			Nonce(nonce).
+			DependsOn(dependsOn).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+			Build(), acc.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trx
+	}
+	pendingTx := build(1, nil)
+	queuedTx := build(2, &thor.Bytes32{1})
+	if err := pool.Add(pendingTx, queuedTx); err != nil {
+		t.Fatal(err)
+	}
+
+	var stats mempool.Stats
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/txpool/status", &stats))
+	assert.Equal(t, mempool.Stats{Pending: 1, Queued: 1, Signers: 1}, stats)
+
+	var inspection mempool.Inspection
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/txpool/inspect", &inspection))
+	if assert.Equal(t, 1, len(inspection.Pending[origin])) {
+		summary := inspection.Pending[origin][0]
+		assert.Equal(t, pendingTx.ID(), summary.ID)
+		assert.Equal(t, "pending", summary.Status)
+		assert.Equal(t, uint32(10), summary.ExpiresAt)
+		assert.Equal(t, thor.InitialBaseGasPrice, (*big.Int)(summary.OverallGasPrice))
+	}
+	if assert.Equal(t, 1, len(inspection.Queued[origin])) {
+		summary := inspection.Queued[origin][0]
+		assert.Equal(t, queuedTx.ID(), summary.ID)
+		assert.Equal(t, "queued", summary.Status)
+		assert.Equal(t, "depended tx not packed yet", summary.QueuedReason)
+	}
+
+	inspection = mempool.Inspection{}
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/txpool/inspect?origin="+to.String(), &inspection))
+	assert.Equal(t, 0, len(inspection.Pending))
+	assert.Equal(t, 0, len(inspection.Queued))
+
+	var content mempool.Content
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/txpool/content?origin="+origin, &content))
+	if assert.Equal(t, 1, len(content.Queued[origin])) {
+		assert.Equal(t, queuedTx.ID(), content.Queued[origin][0].ID)
+		assert.Equal(t, acc.Address, content.Queued[origin][0].Origin)
+	}
+
+	var summary *mempool.TxSummary
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/txpool/transactions/"+queuedTx.ID().String(), &summary))
+	assert.Equal(t, "queued", summary.Status)
+	assert.Equal(t, acc.Address, summary.Origin)
+
+	summary = nil
+	assert.Equal(t, http.StatusOK, httpGet(t, ts.URL+"/txpool/transactions/"+thor.Bytes32{}.String(), &summary))
+	assert.Nil(t, summary)
+
+	assert.Equal(t, http.StatusBadRequest, httpGet(t, ts.URL+"/txpool/inspect?origin=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, httpGet(t, ts.URL+"/txpool/transactions/abc", nil))
+}
+
+func initMempoolServer(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ = chain.New(db, b)
+	pool = txpool.New(ch, stateC, txpool.DefaultPoolConfig, thor.NoFork)
+
+	router := mux.NewRouter()
+	mempool.New(pool).Mount(router, "/txpool")
+	ts = httptest.NewServer(router)
+}
+
+func httpGet(t *testing.T, url string, v interface{}) int {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil && res.StatusCode == http.StatusOK {
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.StatusCode
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package mempool
+
+import (
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/api/transactions"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
+)
+
+const (
+	statusPending = "pending"
+	statusQueued  = "queued"
+)
+
+// Stats counts of txs in pool.
+type Stats struct {
+	Pending int `json:"pending"`
+	Queued  int `json:"queued"`
+	Local   int `json:"local"`
+	Signers int `json:"signers"`
+}
+
+func convertStats(stats *txpool.Stats) *Stats {
+	return &Stats{
+		Pending: stats.Pending,
+		Queued:  stats.Queued,
+		Local:   stats.Local,
+		Signers: stats.Signers,
+	}
+}
+
+// TxSummary brief of tx in pool.
+type TxSummary struct {
+	ID              thor.Bytes32          `json:"id"`
+	Origin          thor.Address          `json:"origin"`
+	Gas             uint64                `json:"gas"`
+	OverallGasPrice *math.HexOrDecimal256 `json:"overallGasPrice"` // zero for queued txs
+	BlockRef        uint32                `json:"blockRef"`
+	Expiration      uint32                `json:"expiration"`
+	ExpiresAt       uint32                `json:"expiresAt"` // number of the last block the tx can be packed in
+	Local           bool                  `json:"local"`
+	ArrivalTime     int64                 `json:"arrivalTime"`
+	Status          string                `json:"status"`
+	QueuedReason    string                `json:"queuedReason,omitempty"`
+}
+
+func convertTxSummary(summary *txpool.TxSummary, pending bool) *TxSummary {
+	status := statusQueued
+	if pending {
+		status = statusPending
+	}
+	expiresAt := uint64(summary.BlockRef) + uint64(summary.Expiration)
+	if expiresAt > uint64(^uint32(0)) {
+		expiresAt = uint64(^uint32(0))
+	}
+	return &TxSummary{
+		ID:              summary.ID,
+		Origin:          summary.Origin,
+		Gas:             summary.Gas,
+		OverallGasPrice: (*math.HexOrDecimal256)(summary.OverallGasPrice),
+		BlockRef:        summary.BlockRef,
+		Expiration:      summary.Expiration,
+		ExpiresAt:       uint32(expiresAt),
+		Local:           summary.Local,
+		ArrivalTime:     summary.ArrivalTime,
+		Status:          status,
+		QueuedReason:    summary.QueuedReason,
+	}
+}
+
+// Inspection summaries of pending and queued txs, grouped by origin.
+type Inspection struct {
+	Pending map[string][]*TxSummary `json:"pending"`
+	Queued  map[string][]*TxSummary `json:"queued"`
+}
+
+// Content pending and queued txs, grouped by origin.
+type Content struct {
+	Pending map[string][]*transactions.Transaction `json:"pending"`
+	Queued  map[string][]*transactions.Transaction `json:"queued"`
+}
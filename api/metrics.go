@@ -0,0 +1,65 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package api
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/metric"
+)
+
+var (
+	requestCounter           = metric.NewCounterVec("api", "requests_total", "count of API requests", "route", "method", "code")
+	requestDurationHistogram = metric.NewHistogramVec("api", "request_duration_seconds", "duration of API requests", metric.DurationBuckets, "route", "method")
+)
+
+// statusRecorder records the status code written to the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Hijack implements http.Hijacker, which is required to upgrade websocket connections.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijack not supported")
+	}
+	r.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
+
+// instrument wraps the router to collect metrics of requests, labeled by route path template
+// and method of matched route, to keep the cardinality bounded.
+func instrument(router *mux.Router) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		route, method := "unmatched", ""
+		var match mux.RouteMatch
+		if router.Match(req, &match) {
+			if tpl, err := match.Route.GetPathTemplate(); err == nil {
+				route, method = tpl, req.Method
+			}
+		}
+
+		start := time.Now()
+		rec := &statusRecorder{w, http.StatusOK}
+		router.ServeHTTP(rec, req)
+
+		requestCounter.WithLabelValues(route, method, strconv.Itoa(rec.status)).Inc()
+		requestDurationHistogram.WithLabelValues(route, method).Observe(time.Since(start).Seconds())
+	}
+}
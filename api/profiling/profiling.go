@@ -0,0 +1,86 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package profiling serves pprof profiles, which can be switched on and off at runtime.
+package profiling
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Config switches of profiling.
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// BlockProfileRate see runtime.SetBlockProfileRate, 0 to disable block profile.
+	BlockProfileRate int `json:"blockProfileRate"`
+	// MutexProfileFraction see runtime.SetMutexProfileFraction, 0 to disable mutex profile.
+	MutexProfileFraction int `json:"mutexProfileFraction"`
+}
+
+// Profiler serves pprof endpoints under /debug/pprof/ if enabled.
+type Profiler struct {
+	lock   sync.RWMutex
+	config Config
+}
+
+// New create a profiler with the config applied.
+func New(config Config) (*Profiler, error) {
+	p := &Profiler{}
+	if err := p.SetConfig(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Config returns the config in effect.
+func (p *Profiler) Config() Config {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.config
+}
+
+// SetConfig applies the config. Block and mutex profiles are sampled only while enabled,
+// since sampling costs.
+func (p *Profiler) SetConfig(config Config) error {
+	if config.BlockProfileRate < 0 || config.MutexProfileFraction < 0 {
+		return errors.New("negative rate")
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if config.Enabled {
+		runtime.SetBlockProfileRate(config.BlockProfileRate)
+		runtime.SetMutexProfileFraction(config.MutexProfileFraction)
+	} else {
+		runtime.SetBlockProfileRate(0)
+		runtime.SetMutexProfileFraction(0)
+	}
+	p.config = config
+	return nil
+}
+
+// Handler returns the handler of pprof endpoints, e.g. /debug/pprof/profile for CPU profile,
+// and /debug/pprof/heap, goroutine, block, mutex for named profiles.
+func (p *Profiler) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !p.Config().Enabled {
+			http.Error(w, "profiling disabled", http.StatusServiceUnavailable)
+			return
+		}
+		mux.ServeHTTP(w, req)
+	})
+}
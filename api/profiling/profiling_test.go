@@ -0,0 +1,45 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package profiling_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/profiling"
+)
+
+func TestProfiler(t *testing.T) {
+	p, err := profiling.New(profiling.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(p.Handler())
+	defer ts.Close()
+
+	get := func(path string) int {
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, get("/debug/pprof/heap"))
+
+	assert.Nil(t, p.SetConfig(profiling.Config{Enabled: true, BlockProfileRate: 1, MutexProfileFraction: 1}))
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/goroutine", "/debug/pprof/block", "/debug/pprof/mutex"} {
+		assert.Equal(t, http.StatusOK, get(path), path)
+	}
+	assert.Equal(t, profiling.Config{Enabled: true, BlockProfileRate: 1, MutexProfileFraction: 1}, p.Config())
+
+	assert.NotNil(t, p.SetConfig(profiling.Config{Enabled: true, BlockProfileRate: -1}))
+	assert.Nil(t, p.SetConfig(profiling.Config{}))
+	assert.Equal(t, http.StatusServiceUnavailable, get("/debug/pprof/"))
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package restrict provides access control of public API, including path allowlist,
+// API key authentication and rate limiting.
+package restrict
+
+import (
+	"crypto/subtle"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/vechain/thor/metric"
+)
+
+const (
+	// KeyHeader the header to carry API key.
+	KeyHeader = "X-Api-Key"
+	// KeyQuery the query to carry API key, for clients can't set headers, e.g. websocket in browsers.
+	KeyQuery = "apikey"
+
+	maxClients = 65536 // max number of clients tracked for rate limiting
+)
+
+var rejectedCounter = metric.NewCounterVec("api", "rejected_requests_total", "count of API requests rejected by access control", "reason")
+
+// Config of access control.
+type Config struct {
+	RateLimit    float64  // requests per second allowed for each IP, 0 for no limit
+	KeyRateLimit float64  // requests per second allowed for each API key, 0 for no limit
+	Burst        int      // max requests in burst, defaults to the rate limit if 0
+	Keys         []string // API keys, requests without a valid one are rejected if not empty
+	AllowedPaths []string // path prefixes allowed, all allowed if empty
+	DeniedPaths  []string // path prefixes denied, which take precedence over allowed ones
+}
+
+// IsZero returns whether no restriction configured.
+func (c *Config) IsZero() bool {
+	return c.RateLimit == 0 &&
+		c.KeyRateLimit == 0 &&
+		len(c.Keys) == 0 &&
+		len(c.AllowedPaths) == 0 &&
+		len(c.DeniedPaths) == 0
+}
+
+// New wraps the handler to restrict access according to config.
+// Requests are checked in order of path, API key and rate limit.
+// Clients with a valid API key are limited by key, others by IP.
+func New(config Config, handler http.Handler) http.Handler {
+	ipLimiter := newLimiter(config.RateLimit, config.Burst)
+	keyLimiter := newLimiter(config.KeyRateLimit, config.Burst)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !isPathAllowed(req.URL.Path, config.AllowedPaths, config.DeniedPaths) {
+			reject(w, "path", http.StatusForbidden, "forbidden")
+			return
+		}
+
+		var key string
+		if len(config.Keys) > 0 {
+			if key = requestKey(req); key == "" {
+				reject(w, "key", http.StatusUnauthorized, "api key required")
+				return
+			}
+			if !isKeyValid(key, config.Keys) {
+				reject(w, "key", http.StatusUnauthorized, "invalid api key")
+				return
+			}
+		}
+
+		limiter, client := ipLimiter, remoteIP(req)
+		if key != "" {
+			limiter, client = keyLimiter, key
+		}
+		if wait := limiter.take(client, time.Now()); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			reject(w, "rate", http.StatusTooManyRequests, "too many requests")
+			return
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+func reject(w http.ResponseWriter, reason string, status int, msg string) {
+	rejectedCounter.WithLabelValues(reason).Inc()
+	http.Error(w, msg, status)
+}
+
+func requestKey(req *http.Request) string {
+	if key := req.Header.Get(KeyHeader); key != "" {
+		return key
+	}
+	return req.URL.Query().Get(KeyQuery)
+}
+
+func isKeyValid(key string, keys []string) bool {
+	valid := false
+	for _, k := range keys {
+		// not break early, to keep the time constant
+		if subtle.ConstantTimeCompare([]byte(key), []byte(k)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func matchPath(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+func isPathAllowed(path string, allowed, denied []string) bool {
+	for _, prefix := range denied {
+		if matchPath(path, prefix) {
+			return false
+		}
+	}
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, prefix := range allowed {
+		if matchPath(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// bucket token bucket of a client.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter limits requests of each client by token buckets.
+// Clients least recently seen are evicted if too many tracked.
+type limiter struct {
+	rate    float64
+	burst   float64
+	lock    sync.Mutex
+	buckets *lru.Cache
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	if rate <= 0 {
+		return nil
+	}
+	b := float64(burst)
+	if b <= 0 {
+		b = math.Max(1, math.Ceil(rate))
+	}
+	buckets, _ := lru.New(maxClients)
+	return &limiter{
+		rate:    rate,
+		burst:   b,
+		buckets: buckets,
+	}
+}
+
+// take takes a token of the client, and returns the duration to wait if no token left.
+func (l *limiter) take(client string, now time.Time) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var b *bucket
+	if v, ok := l.buckets.Get(client); ok {
+		b = v.(*bucket)
+		b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+		b.last = now
+	} else {
+		b = &bucket{l.burst, now}
+		l.buckets.Add(client, b)
+	}
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return 0
+}
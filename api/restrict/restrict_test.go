@@ -0,0 +1,84 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package restrict_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/restrict"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})
+
+func serve(handler http.Handler, path string, remoteAddr string, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	req.RemoteAddr = remoteAddr
+	if key != "" {
+		req.Header.Set(restrict.KeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPaths(t *testing.T) {
+	handler := restrict.New(restrict.Config{
+		AllowedPaths: []string{"/accounts", "/blocks/"},
+		DeniedPaths:  []string{"/accounts/*"},
+	}, okHandler)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/accounts/0x7567d83b7b8d80addcb281a71d54fc7b3364ffed", http.StatusOK},
+		{"/accounts", http.StatusOK},
+		{"/accounts/*", http.StatusForbidden},
+		{"/accountsx", http.StatusForbidden},
+		{"/blocks/best", http.StatusOK},
+		{"/debug/tracers", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.status, serve(handler, tt.path, "1.2.3.4:1", "").Code, tt.path)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	handler := restrict.New(restrict.Config{Keys: []string{"k1", "k2"}}, okHandler)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "/blocks/best", "1.2.3.4:1", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "/blocks/best", "1.2.3.4:1", "k3").Code)
+	assert.Equal(t, http.StatusOK, serve(handler, "/blocks/best", "1.2.3.4:1", "k2").Code)
+	assert.Equal(t, http.StatusOK, serve(handler, "/blocks/best?"+restrict.KeyQuery+"=k1", "1.2.3.4:1", "").Code)
+}
+
+func TestRateLimit(t *testing.T) {
+	handler := restrict.New(restrict.Config{
+		RateLimit:    0.01,
+		KeyRateLimit: 0.01,
+		Burst:        2,
+		Keys:         []string{"k1"},
+	}, okHandler)
+
+	// unknown keys are rejected, rather than falling back to the IP limit
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "/", "1.2.3.4:1", "k2").Code)
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusOK, serve(handler, "/", "1.2.3.4:1", "k1").Code)
+	}
+	rec := serve(handler, "/", "1.2.3.4:2", "k1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	// limited by IP if no key
+	handler = restrict.New(restrict.Config{RateLimit: 0.01, Burst: 1}, okHandler)
+	assert.Equal(t, http.StatusOK, serve(handler, "/", "1.2.3.4:1", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(handler, "/", "1.2.3.4:2", "").Code)
+	assert.Equal(t, http.StatusOK, serve(handler, "/", "5.6.7.8:1", "").Code)
+}
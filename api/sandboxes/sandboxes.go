@@ -0,0 +1,360 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package sandboxes
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/blocks"
+	"github.com/vechain/thor/api/transactions"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/sandbox"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+const (
+	maxSandboxes  = 16   // max number of sandboxes alive at the same time
+	maxMineBlocks = 1000 // max number of blocks mined by a request
+)
+
+// Sandboxes serves sandboxes forked from the chain, for development only.
+type Sandboxes struct {
+	kv           kv.Getter
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	forkConfig   thor.ForkConfig
+	pathPrefix   string
+
+	lock  sync.Mutex
+	boxes map[string]*box
+}
+
+// box a sandbox along with the router serving read APIs upon it.
+type box struct {
+	*sandbox.Sandbox
+	handler http.Handler
+}
+
+// New create sandboxes API. kv is where blocks and states of the chain are stored.
+func New(kv kv.Getter, chain *chain.Chain, stateCreator *state.Creator, forkConfig thor.ForkConfig) *Sandboxes {
+	return &Sandboxes{
+		kv:           kv,
+		chain:        chain,
+		stateCreator: stateCreator,
+		forkConfig:   forkConfig,
+		boxes:        make(map[string]*box),
+	}
+}
+
+// Close throws away all sandboxes.
+func (s *Sandboxes) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for id, b := range s.boxes {
+		b.Close()
+		delete(s.boxes, id)
+	}
+}
+
+func (s *Sandboxes) get(id string) (*box, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	b, ok := s.boxes[id]
+	if !ok {
+		return nil, utils.HTTPError(errors.New("sandbox not found"), http.StatusNotFound)
+	}
+	return b, nil
+}
+
+func (s *Sandboxes) handleCreate(w http.ResponseWriter, req *http.Request) error {
+	var create CreateSandbox
+	if err := utils.ParseJSON(req.Body, &create); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	h, err := s.getBlockHeader(create.Revision)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.boxes) >= maxSandboxes {
+		return utils.HTTPError(errors.Errorf("too many sandboxes, at most %v", maxSandboxes), http.StatusTooManyRequests)
+	}
+	sb, err := sandbox.Fork(s.kv, s.chain.GenesisBlock(), h.ID(), s.forkConfig)
+	if err != nil {
+		return err
+	}
+	id := newSandboxID()
+
+	// read APIs upon the sandbox, served as they are of the chain
+	router := mux.NewRouter()
+	accounts.New(sb.Chain(), sb.StateCreator(), s.forkConfig).
+		Mount(router, "/accounts")
+	blocks.New(sb.Chain()).
+		Mount(router, "/blocks")
+
+	s.boxes[id] = &box{sb, http.StripPrefix(s.pathPrefix+"/"+id, router)}
+	return utils.WriteJSON(w, convertSandbox(id, sb))
+}
+
+func (s *Sandboxes) handleGet(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+	b, err := s.get(id)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertSandbox(id, b.Sandbox))
+}
+
+func (s *Sandboxes) handleDelete(w http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["id"]
+
+	s.lock.Lock()
+	b, ok := s.boxes[id]
+	delete(s.boxes, id)
+	s.lock.Unlock()
+
+	if !ok {
+		return utils.HTTPError(errors.New("sandbox not found"), http.StatusNotFound)
+	}
+	if err := b.Close(); err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, nil)
+}
+
+func (s *Sandboxes) handleSubmitTransaction(w http.ResponseWriter, req *http.Request) error {
+	b, err := s.get(mux.Vars(req)["id"])
+	if err != nil {
+		return err
+	}
+	var raw transactions.RawTx
+	if err := utils.ParseJSON(req.Body, &raw); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	data, err := hexutil.Decode(raw.Raw)
+	if err != nil {
+		return utils.BadRequest(err, "raw")
+	}
+	var trx *tx.Transaction
+	if err := rlp.DecodeBytes(data, &trx); err != nil {
+		return utils.BadRequest(err, "raw")
+	}
+	if err := b.Submit(trx); err != nil {
+		return utils.BadRequest(err, "tx")
+	}
+	return utils.WriteJSON(w, map[string]string{"id": trx.ID().String()})
+}
+
+func (s *Sandboxes) handleGetReceipt(w http.ResponseWriter, req *http.Request) error {
+	b, err := s.get(mux.Vars(req)["id"])
+	if err != nil {
+		return err
+	}
+	txID, err := thor.ParseBytes32(mux.Vars(req)["txid"])
+	if err != nil {
+		return utils.BadRequest(err, "txid")
+	}
+	ch := b.Chain()
+	meta, err := ch.GetTrunkTransactionMeta(txID)
+	if err != nil {
+		if ch.IsNotFound(err) {
+			return utils.WriteJSON(w, nil)
+		}
+		return err
+	}
+	header, err := ch.GetBlockHeader(meta.BlockID)
+	if err != nil {
+		return err
+	}
+	receipt, err := ch.GetTransactionReceipt(meta.BlockID, meta.Index)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &Receipt{
+		BlockID:     meta.BlockID,
+		BlockNumber: header.Number(),
+		Receipt:     receipt,
+	})
+}
+
+func (s *Sandboxes) handleMine(w http.ResponseWriter, req *http.Request) error {
+	b, err := s.get(mux.Vars(req)["id"])
+	if err != nil {
+		return err
+	}
+	var mine Mine
+	if err := utils.ParseJSON(req.Body, &mine); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if mine.Blocks == 0 {
+		mine.Blocks = 1
+	}
+	if mine.Blocks < 0 || mine.Blocks > maxMineBlocks {
+		return utils.BadRequest(errors.Errorf("should be in range [1, %v]", maxMineBlocks), "blocks")
+	}
+	mined, err := b.Mine(mine.Blocks)
+	if err != nil {
+		return err
+	}
+	res := make([]*Block, 0, len(mined))
+	for _, blk := range mined {
+		res = append(res, convertBlock(blk))
+	}
+	return utils.WriteJSON(w, res)
+}
+
+func (s *Sandboxes) handleModifyAccount(w http.ResponseWriter, req *http.Request) error {
+	b, err := s.get(mux.Vars(req)["id"])
+	if err != nil {
+		return err
+	}
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(err, "address")
+	}
+	var mod AccountModification
+	if err := utils.ParseJSON(req.Body, &mod); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	var code []byte
+	if mod.Code != nil {
+		if code, err = hexutil.Decode(*mod.Code); err != nil {
+			return utils.BadRequest(err, "code")
+		}
+	}
+	storage := make(map[thor.Bytes32]thor.Bytes32, len(mod.Storage))
+	for hexKey, value := range mod.Storage {
+		key, err := thor.ParseBytes32(hexKey)
+		if err != nil {
+			return utils.BadRequest(err, "storage")
+		}
+		if value == nil {
+			value = &thor.Bytes32{}
+		}
+		storage[key] = *value
+	}
+
+	blk, err := b.Modify(func(st *state.State, blockTime uint64) error {
+		if mod.Balance != nil {
+			st.SetBalance(addr, (*big.Int)(mod.Balance))
+		}
+		if mod.Energy != nil {
+			st.SetEnergy(addr, (*big.Int)(mod.Energy), blockTime)
+		}
+		if mod.Code != nil {
+			st.SetCode(addr, code)
+		}
+		for key, value := range storage {
+			st.SetStorage(addr, key, value)
+		}
+		return st.Err()
+	})
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertBlock(blk))
+}
+
+// handleRead serves read APIs upon the sandbox.
+func (s *Sandboxes) handleRead(w http.ResponseWriter, req *http.Request) {
+	b, err := s.get(mux.Vars(req)["id"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	b.handler.ServeHTTP(w, req)
+}
+
+func (s *Sandboxes) getBlockHeader(revision string) (*block.Header, error) {
+	if revision == "" || revision == "best" {
+		return s.chain.BestBlock().Header(), nil
+	}
+	if revision == "finalized" {
+		return s.chain.FinalizedBlock(), nil
+	}
+	var (
+		header *block.Header
+		err    error
+	)
+	if blkID, e := thor.ParseBytes32(revision); e == nil {
+		header, err = s.chain.GetBlockHeader(blkID)
+	} else {
+		n, e := strconv.ParseUint(revision, 0, 0)
+		if e != nil {
+			return nil, utils.BadRequest(e, "revision")
+		}
+		if n > math.MaxUint32 {
+			return nil, utils.BadRequest(errors.New("block number exceeded"), "revision")
+		}
+		header, err = s.chain.GetTrunkBlockHeader(uint32(n))
+	}
+	if err != nil {
+		if s.chain.IsNotFound(err) {
+			return nil, utils.BadRequest(errors.New("block not found"), "revision")
+		}
+		return nil, err
+	}
+	if err := utils.CheckState(s.chain, s.stateCreator, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// Mount mounts sandboxes API. Besides the endpoints to create and manipulate sandboxes,
+// accounts and blocks API are served upon each sandbox, under /{id}/accounts and /{id}/blocks.
+func (s *Sandboxes) Mount(root *mux.Router, pathPrefix string) {
+	s.pathPrefix = pathPrefix
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(s.handleCreate))
+	sub.Path("/{id}").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleGet))
+	sub.Path("/{id}").Methods("DELETE").HandlerFunc(utils.WrapHandlerFunc(s.handleDelete))
+	sub.Path("/{id}/transactions").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(s.handleSubmitTransaction))
+	sub.Path("/{id}/transactions/{txid}/receipt").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleGetReceipt))
+	sub.Path("/{id}/mine").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(s.handleMine))
+	sub.Path("/{id}/accounts/{address}").Methods("PUT").HandlerFunc(utils.WrapHandlerFunc(s.handleModifyAccount))
+	sub.PathPrefix("/{id}/").HandlerFunc(s.handleRead)
+}
+
+func convertSandbox(id string, sb *sandbox.Sandbox) *Sandbox {
+	pending := sb.Pending()
+	ids := make([]thor.Bytes32, 0, len(pending))
+	for _, trx := range pending {
+		ids = append(ids, trx.ID())
+	}
+	return &Sandbox{
+		ID:        id,
+		BestBlock: convertBlock(sb.Chain().BestBlock()),
+		Pending:   ids,
+	}
+}
+
+func newSandboxID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return fmt.Sprintf("%x", b)
+}
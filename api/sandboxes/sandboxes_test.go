@@ -0,0 +1,143 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package sandboxes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/accounts"
+	"github.com/vechain/thor/api/sandboxes"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+var (
+	ts *httptest.Server
+	ch *chain.Chain
+)
+
+func TestSandboxes(t *testing.T) {
+	api := initSandboxesServer(t)
+	defer ts.Close()
+	defer api.Close()
+
+	bob := thor.BytesToAddress([]byte("bob"))
+
+	// create
+	var sb sandboxes.Sandbox
+	assert.Equal(t, http.StatusOK, httpDo(t, "POST", ts.URL+"/sandboxes", &sandboxes.CreateSandbox{Revision: "0"}, &sb))
+	assert.NotEmpty(t, sb.ID)
+	assert.Equal(t, ch.GenesisBlock().Header().ID(), sb.BestBlock.ID)
+	base := ts.URL + "/sandboxes/" + sb.ID
+
+	// modify account directly
+	var modified sandboxes.Block
+	assert.Equal(t, http.StatusOK, httpDo(t, "PUT", base+"/accounts/"+bob.String(), map[string]string{"balance": "0x64"}, &modified))
+	assert.Equal(t, uint32(1), modified.Number)
+
+	// submit and mine a tx
+	trx, _ := tx.Sign(new(tx.Builder).
+		ChainTag(ch.Tag()).
+		Gas(21000).
+		Expiration(100).
+		Clause(tx.NewClause(&bob).WithValue(big.NewInt(1))).
+		Build(), genesis.DevAccounts()[1].PrivateKey)
+	raw, _ := rlp.EncodeToBytes(trx)
+	assert.Equal(t, http.StatusOK, httpDo(t, "POST", base+"/transactions", map[string]string{"raw": hexutil.Encode(raw)}, nil))
+	assert.Equal(t, http.StatusBadRequest, httpDo(t, "POST", base+"/transactions", map[string]string{"raw": "0x00"}, nil))
+
+	var mined []*sandboxes.Block
+	assert.Equal(t, http.StatusOK, httpDo(t, "POST", base+"/mine", &sandboxes.Mine{Blocks: 2}, &mined))
+	assert.Equal(t, 2, len(mined))
+	assert.Equal(t, []thor.Bytes32{trx.ID()}, mined[0].Transactions)
+
+	var receipt sandboxes.Receipt
+	assert.Equal(t, http.StatusOK, httpDo(t, "GET", base+"/transactions/"+trx.ID().String()+"/receipt", nil, &receipt))
+	assert.Equal(t, mined[0].ID, receipt.BlockID)
+	assert.False(t, receipt.Receipt.Reverted)
+
+	// read APIs upon the sandbox
+	var acc accounts.Account
+	assert.Equal(t, http.StatusOK, httpDo(t, "GET", base+"/accounts/"+bob.String(), nil, &acc))
+	assert.Equal(t, big.NewInt(101), (*big.Int)(&acc.Balance))
+	assert.Equal(t, http.StatusOK, httpDo(t, "GET", base, nil, &sb))
+	assert.Equal(t, mined[1].ID, sb.BestBlock.ID)
+
+	// the chain forked is untouched
+	assert.Equal(t, ch.GenesisBlock().Header().ID(), ch.BestBlock().Header().ID())
+
+	// delete
+	assert.Equal(t, http.StatusOK, httpDo(t, "DELETE", base, nil, nil))
+	assert.Equal(t, http.StatusNotFound, httpDo(t, "GET", base, nil, nil))
+	assert.Equal(t, http.StatusNotFound, httpDo(t, "GET", base+"/accounts/"+bob.String(), nil, nil))
+
+	for _, revision := range []string{"x", "100"} {
+		assert.Equal(t, http.StatusBadRequest, httpDo(t, "POST", ts.URL+"/sandboxes", &sandboxes.CreateSandbox{Revision: revision}, nil), revision)
+	}
+}
+
+func initSandboxesServer(t *testing.T) *sandboxes.Sandboxes {
+	db, _ := lvldb.NewMem()
+	stateC := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := gene.Build(stateC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ = chain.New(db, b)
+
+	api := sandboxes.New(db, ch, stateC, thor.NoFork)
+	router := mux.NewRouter()
+	api.Mount(router, "/sandboxes")
+	ts = httptest.NewServer(router)
+	return api
+}
+
+func httpDo(t *testing.T, method, url string, reqObj, resObj interface{}) int {
+	var body []byte
+	if reqObj != nil {
+		var err error
+		if body, err = json.Marshal(reqObj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resObj != nil && res.StatusCode == http.StatusOK {
+		if err := json.Unmarshal(data, resObj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.StatusCode
+}
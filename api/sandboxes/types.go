@@ -0,0 +1,66 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package sandboxes
+
+import (
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// CreateSandbox request to fork a sandbox at the revision, defaults to the best block.
+type CreateSandbox struct {
+	Revision string `json:"revision"`
+}
+
+// Sandbox brief of a sandbox.
+type Sandbox struct {
+	ID        string         `json:"id"`
+	BestBlock *Block         `json:"bestBlock"`
+	Pending   []thor.Bytes32 `json:"pending"`
+}
+
+// Block brief of a block of sandbox.
+type Block struct {
+	ID           thor.Bytes32   `json:"id"`
+	Number       uint32         `json:"number"`
+	Timestamp    uint64         `json:"timestamp"`
+	Transactions []thor.Bytes32 `json:"transactions"`
+}
+
+// Mine request to mine blocks instantly.
+type Mine struct {
+	Blocks int `json:"blocks"` // defaults to 1 if 0
+}
+
+// AccountModification fields to be set of an account, nil fields are left unchanged.
+type AccountModification struct {
+	Balance *math.HexOrDecimal256    `json:"balance,string"`
+	Energy  *math.HexOrDecimal256    `json:"energy,string"`
+	Code    *string                  `json:"code"`
+	Storage map[string]*thor.Bytes32 `json:"storage"`
+}
+
+// Receipt receipt of a tx mined in sandbox, along with the block including it.
+type Receipt struct {
+	BlockID     thor.Bytes32 `json:"blockID"`
+	BlockNumber uint32       `json:"blockNumber"`
+	Receipt     *tx.Receipt  `json:"receipt"`
+}
+
+func convertBlock(b *block.Block) *Block {
+	txs := make([]thor.Bytes32, 0, len(b.Transactions()))
+	for _, trx := range b.Transactions() {
+		txs = append(txs, trx.ID())
+	}
+	return &Block{
+		ID:           b.Header().ID(),
+		Number:       b.Header().Number(),
+		Timestamp:    b.Header().Timestamp(),
+		Transactions: txs,
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/thor"
+)
+
+const maxBlocksPerRead = 64
+
+type extendedBlock struct {
+	*block.Block
+	obsolete bool
+}
+
+// blockReader reads blocks along the trunk, starting after the position.
+// Blocks read before but switched off the trunk by reorg are read out again
+// as obsolete ones, ahead of new trunk blocks.
+type blockReader struct {
+	chain    *chain.Chain
+	position thor.Bytes32
+}
+
+func newBlockReader(chain *chain.Chain, position thor.Bytes32) *blockReader {
+	return &blockReader{
+		chain,
+		position,
+	}
+}
+
+// Read reads a batch of blocks. Empty result means no more blocks for now.
+func (br *blockReader) Read() ([]*extendedBlock, error) {
+	best := br.chain.BestBlock().Header()
+	if best.ID() == br.position {
+		return nil, nil
+	}
+
+	ancestor, err := br.chain.GetBlockHeader(br.position)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []*extendedBlock
+	// backtrace to the trunk
+	for {
+		if ancestor.Number() <= best.Number() {
+			id, err := br.chain.GetAncestorBlockID(best.ID(), ancestor.Number())
+			if err != nil {
+				return nil, err
+			}
+			if id == ancestor.ID() {
+				break
+			}
+		}
+		blk, err := br.chain.GetBlock(ancestor.ID())
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, &extendedBlock{blk, true})
+		if ancestor, err = br.chain.GetBlockHeader(ancestor.ParentID()); err != nil {
+			return nil, err
+		}
+	}
+	br.position = ancestor.ID()
+
+	for num := ancestor.Number() + 1; num <= best.Number() && len(blocks) < maxBlocksPerRead; num++ {
+		id, err := br.chain.GetAncestorBlockID(best.ID(), num)
+		if err != nil {
+			return nil, err
+		}
+		blk, err := br.chain.GetBlock(id)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, &extendedBlock{blk, false})
+		br.position = id
+	}
+	return blocks, nil
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// msgReader reads messages derived from blocks.
+// hasMore is true if blocks were consumed, even no message produced.
+type msgReader interface {
+	Read() (msgs []interface{}, hasMore bool, err error)
+}
+
+type blockMsgReader struct {
+	*blockReader
+}
+
+func (r *blockMsgReader) Read() ([]interface{}, bool, error) {
+	blocks, err := r.blockReader.Read()
+	if err != nil {
+		return nil, false, err
+	}
+	msgs := make([]interface{}, 0, len(blocks))
+	for _, blk := range blocks {
+		msg, err := convertBlock(blk)
+		if err != nil {
+			return nil, false, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, len(blocks) > 0, nil
+}
+
+type eventMsgReader struct {
+	*blockReader
+	filter *EventFilter
+}
+
+func (r *eventMsgReader) Read() ([]interface{}, bool, error) {
+	blocks, err := r.blockReader.Read()
+	if err != nil {
+		return nil, false, err
+	}
+	var msgs []interface{}
+	for _, blk := range blocks {
+		header := blk.Header()
+		// skip receipts of blocks ruled out by the logs bloom, if saved
+		if bloom, err := r.chain.GetBlockBloom(header.ID()); err != nil {
+			if !r.chain.IsNotFound(err) {
+				return nil, false, err
+			}
+		} else if !r.filter.MayMatch(bloom) {
+			continue
+		}
+		if err := forEachOutput(r.chain, blk, func(txID thor.Bytes32, txOrigin thor.Address, output *tx.Output) {
+			for _, event := range output.Events {
+				if r.filter.Match(event) {
+					msgs = append(msgs, convertEvent(header, txID, txOrigin, event, blk.obsolete))
+				}
+			}
+		}); err != nil {
+			return nil, false, err
+		}
+	}
+	return msgs, len(blocks) > 0, nil
+}
+
+type transferMsgReader struct {
+	*blockReader
+	filter *TransferFilter
+}
+
+func (r *transferMsgReader) Read() ([]interface{}, bool, error) {
+	blocks, err := r.blockReader.Read()
+	if err != nil {
+		return nil, false, err
+	}
+	var msgs []interface{}
+	for _, blk := range blocks {
+		header := blk.Header()
+		if err := forEachOutput(r.chain, blk, func(txID thor.Bytes32, txOrigin thor.Address, output *tx.Output) {
+			for _, transfer := range output.Transfers {
+				if r.filter.Match(txOrigin, transfer) {
+					msgs = append(msgs, convertTransfer(header, txID, txOrigin, transfer, blk.obsolete))
+				}
+			}
+		}); err != nil {
+			return nil, false, err
+		}
+	}
+	return msgs, len(blocks) > 0, nil
+}
+
+type accountMsgReader struct {
+	*blockReader
+	stateCreator *state.Creator
+	filter       AccountFilter
+}
+
+func (r *accountMsgReader) Read() ([]interface{}, bool, error) {
+	blocks, err := r.blockReader.Read()
+	if err != nil {
+		return nil, false, err
+	}
+	var msgs []interface{}
+	for _, blk := range blocks {
+		header := blk.Header()
+		parent, err := r.chain.GetBlockHeader(header.ParentID())
+		if err != nil {
+			return nil, false, err
+		}
+		changes, err := accountChanges(r.stateCreator, parent.StateRoot(), header, r.filter)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, change := range changes {
+			msgs = append(msgs, convertAccount(header, change, blk.obsolete))
+		}
+	}
+	return msgs, len(blocks) > 0, nil
+}
+
+// accountChange changes of an account made by a block.
+type accountChange struct {
+	address                thor.Address
+	oldBalance, newBalance *big.Int
+	oldEnergy, newEnergy   *big.Int
+	storageKeys            []thor.Bytes32
+}
+
+// accountChanges returns changes of accounts in the filter made by the block, sorted by address.
+// They are taken from the write-set recorded when the block executed, or found by comparing
+// states if not recorded.
+func accountChanges(stateCreator *state.Creator, parentRoot thor.Bytes32, header *block.Header, filter AccountFilter) ([]*accountChange, error) {
+	if parentRoot == header.StateRoot() {
+		return nil, nil
+	}
+	blockTime := header.Timestamp()
+	var changes []*accountChange
+	if ws := stateCreator.WriteSet(parentRoot, header.StateRoot()); ws != nil {
+		for addr := range filter {
+			if w, ok := ws.Accounts[addr]; ok {
+				changes = append(changes, &accountChange{
+					addr,
+					w.Old.Balance, w.New.Balance,
+					w.Old.CalcEnergy(blockTime), w.New.CalcEnergy(blockTime),
+					w.StorageKeys,
+				})
+			}
+		}
+	} else {
+		oldState, err := stateCreator.NewState(parentRoot)
+		if err != nil {
+			return nil, err
+		}
+		newState, err := stateCreator.NewState(header.StateRoot())
+		if err != nil {
+			return nil, err
+		}
+		for addr := range filter {
+			changes = append(changes, &accountChange{
+				addr,
+				oldState.GetBalance(addr), newState.GetBalance(addr),
+				oldState.GetEnergy(addr, blockTime), newState.GetEnergy(addr, blockTime),
+				nil,
+			})
+		}
+		if err := oldState.Err(); err != nil {
+			return nil, err
+		}
+		if err := newState.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// accounts touched but not changed are excluded
+	n := 0
+	for _, c := range changes {
+		if len(c.storageKeys) > 0 || c.oldBalance.Cmp(c.newBalance) != 0 || c.oldEnergy.Cmp(c.newEnergy) != 0 {
+			changes[n] = c
+			n++
+		}
+	}
+	changes = changes[:n]
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].address[:], changes[j].address[:]) < 0
+	})
+	return changes, nil
+}
+
+// forEachOutput calls f for each clause output of txs in the block.
+// Outputs of reverted txs are empty.
+func forEachOutput(chain *chain.Chain, blk *extendedBlock, f func(txID thor.Bytes32, txOrigin thor.Address, output *tx.Output)) error {
+	txs := blk.Transactions()
+	if len(txs) == 0 {
+		return nil
+	}
+	receipts, err := chain.GetBlockReceipts(blk.Header().ID())
+	if err != nil {
+		return err
+	}
+	for i, trx := range txs {
+		origin, err := trx.Signer()
+		if err != nil {
+			return err
+		}
+		for _, output := range receipts[i].Outputs {
+			f(trx.ID(), origin, output)
+		}
+	}
+	return nil
+}
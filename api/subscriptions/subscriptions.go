@@ -0,0 +1,335 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/co"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
+)
+
+var log = log15.New("pkg", "subscriptions")
+
+const (
+	maxBacktraceBlocks = 1000 // max number of blocks behind the best block that a position can be
+	pingPeriod         = 30 * time.Second
+	writeTimeout       = 10 * time.Second
+	pendingTxsBuffer   = 100 // size of channel receiving pending txs from the pool
+	maxAccounts        = 256 // max number of addresses an account subscription watches
+)
+
+// Subscriptions pushes blocks, events, transfers, account changes and pending txs to subscribers over websocket.
+type Subscriptions struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	txPool       *txpool.TxPool
+	upgrader     *websocket.Upgrader
+	done         chan struct{}
+	goes         co.Goes
+}
+
+// New create a new Subscriptions instance.
+// Cross-origin requests are accepted if the origin is in allowedOrigins, or '*' is in it.
+func New(chain *chain.Chain, stateCreator *state.Creator, txPool *txpool.TxPool, allowedOrigins []string) *Subscriptions {
+	return &Subscriptions{
+		chain:        chain,
+		stateCreator: stateCreator,
+		txPool:       txPool,
+		upgrader: &websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" {
+					return true
+				}
+				for _, allowed := range allowedOrigins {
+					if allowed == "*" || allowed == origin {
+						return true
+					}
+				}
+				u, err := url.Parse(origin)
+				return err == nil && u.Host == r.Host
+			},
+		},
+		done: make(chan struct{}),
+	}
+}
+
+// Close closes all subscriptions and waits for them to exit.
+func (s *Subscriptions) Close() {
+	close(s.done)
+	s.goes.Wait()
+}
+
+// parsePosition parses the block ID after which blocks are to be read.
+// It defaults to the parent of best block, so that subscribers start with the best block.
+func (s *Subscriptions) parsePosition(posStr string) (thor.Bytes32, error) {
+	best := s.chain.BestBlock().Header()
+	if posStr == "" {
+		if best.Number() == 0 {
+			return best.ID(), nil
+		}
+		return best.ParentID(), nil
+	}
+	pos, err := thor.ParseBytes32(posStr)
+	if err != nil {
+		return thor.Bytes32{}, utils.BadRequest(err, "pos")
+	}
+	header, err := s.chain.GetBlockHeader(pos)
+	if err != nil {
+		if s.chain.IsNotFound(err) {
+			return thor.Bytes32{}, utils.BadRequest(errors.New("block not found"), "pos")
+		}
+		return thor.Bytes32{}, err
+	}
+	if best.Number() > header.Number()+maxBacktraceBlocks {
+		return thor.Bytes32{}, utils.Forbidden(errors.New("too old"), "pos")
+	}
+	return pos, nil
+}
+
+func parseAddress(query url.Values, key string) (*thor.Address, error) {
+	str := query.Get(key)
+	if str == "" {
+		return nil, nil
+	}
+	addr, err := thor.ParseAddress(str)
+	if err != nil {
+		return nil, utils.BadRequest(err, key)
+	}
+	return &addr, nil
+}
+
+func (s *Subscriptions) handleBlock(w http.ResponseWriter, req *http.Request) error {
+	pos, err := s.parsePosition(req.URL.Query().Get("pos"))
+	if err != nil {
+		return err
+	}
+	return s.pipe(w, req, &blockMsgReader{newBlockReader(s.chain, pos)})
+}
+
+func (s *Subscriptions) handleEvent(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	pos, err := s.parsePosition(query.Get("pos"))
+	if err != nil {
+		return err
+	}
+	var filter EventFilter
+	if filter.Address, err = parseAddress(query, "addr"); err != nil {
+		return err
+	}
+	for i, key := range []string{"t0", "t1", "t2", "t3", "t4"} {
+		if str := query.Get(key); str != "" {
+			topic, err := thor.ParseBytes32(str)
+			if err != nil {
+				return utils.BadRequest(err, key)
+			}
+			filter.Topics[i] = &topic
+		}
+	}
+	return s.pipe(w, req, &eventMsgReader{newBlockReader(s.chain, pos), &filter})
+}
+
+func (s *Subscriptions) handleTransfer(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	pos, err := s.parsePosition(query.Get("pos"))
+	if err != nil {
+		return err
+	}
+	var filter TransferFilter
+	if filter.TxOrigin, err = parseAddress(query, "txOrigin"); err != nil {
+		return err
+	}
+	if filter.Sender, err = parseAddress(query, "sender"); err != nil {
+		return err
+	}
+	if filter.Recipient, err = parseAddress(query, "recipient"); err != nil {
+		return err
+	}
+	return s.pipe(w, req, &transferMsgReader{newBlockReader(s.chain, pos), &filter})
+}
+
+// handleAccount pushes changes of accounts at addresses given by 'addr', which can be repeated.
+func (s *Subscriptions) handleAccount(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	pos, err := s.parsePosition(query.Get("pos"))
+	if err != nil {
+		return err
+	}
+	strs := query["addr"]
+	if len(strs) == 0 {
+		return utils.BadRequest(errors.New("required"), "addr")
+	}
+	if len(strs) > maxAccounts {
+		return utils.BadRequest(errors.Errorf("too many, max %v", maxAccounts), "addr")
+	}
+	filter := make(AccountFilter, len(strs))
+	for _, str := range strs {
+		addr, err := thor.ParseAddress(str)
+		if err != nil {
+			return utils.BadRequest(err, "addr")
+		}
+		filter[addr] = true
+	}
+	return s.pipe(w, req, &accountMsgReader{newBlockReader(s.chain, pos), s.stateCreator, filter})
+}
+
+// handlePendingTx pushes txs newly added into the pool, which can be filtered by 'origin' and 'recipient'.
+// Only tx IDs are pushed, unless 'full' is true.
+func (s *Subscriptions) handlePendingTx(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	var (
+		filter PendingTxFilter
+		err    error
+	)
+	if filter.Origin, err = parseAddress(query, "origin"); err != nil {
+		return err
+	}
+	if filter.Recipient, err = parseAddress(query, "recipient"); err != nil {
+		return err
+	}
+	full := false
+	if str := query.Get("full"); str != "" {
+		if full, err = strconv.ParseBool(str); err != nil {
+			return utils.BadRequest(err, "full")
+		}
+	}
+
+	// subscribe before upgraded, not to miss txs added once the client connected
+	txCh := make(chan *tx.Transaction, pendingTxsBuffer)
+	sub := s.txPool.SubscribeNewTransaction(txCh)
+	defer sub.Unsubscribe()
+
+	conn, closed, ok := s.upgrade(w, req)
+	if !ok {
+		return nil
+	}
+	defer conn.Close()
+
+	pingTicker := time.NewTicker(pingPeriod)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+				time.Now().Add(writeTimeout))
+			return nil
+		case <-closed:
+			return nil
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return nil
+			}
+		case trx := <-txCh:
+			if !filter.Match(trx) {
+				continue
+			}
+			msg, err := convertPendingTx(trx, full)
+			if err != nil {
+				log.Debug("failed to convert tx", "err", err)
+				continue
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(msg); err != nil {
+				log.Debug("failed to write message", "err", err)
+				return nil
+			}
+		}
+	}
+}
+
+// upgrade upgrades the request to websocket. The returned channel is closed once the
+// connection closed by the client, since the connection is read only to detect closing.
+// ok is false if failed to upgrade, and the upgrader has replied to the client.
+func (s *Subscriptions) upgrade(w http.ResponseWriter, req *http.Request) (conn *websocket.Conn, closed chan struct{}, ok bool) {
+	conn, err := s.upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		log.Debug("failed to upgrade", "err", err)
+		return nil, nil, false
+	}
+
+	closed = make(chan struct{})
+	s.goes.Go(func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	return conn, closed, true
+}
+
+// pipe upgrades the request to websocket, and writes messages from the reader until
+// the connection closed or the service closed.
+func (s *Subscriptions) pipe(w http.ResponseWriter, req *http.Request, reader msgReader) error {
+	conn, closed, ok := s.upgrade(w, req)
+	if !ok {
+		return nil
+	}
+	defer conn.Close()
+
+	pingTicker := time.NewTicker(pingPeriod)
+	defer pingTicker.Stop()
+
+	for {
+		ticker := s.chain.NewTicker()
+		msgs, hasMore, err := reader.Read()
+		if err != nil {
+			log.Warn("failed to read messages", "err", err)
+			return nil
+		}
+		for _, msg := range msgs {
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(msg); err != nil {
+				log.Debug("failed to write message", "err", err)
+				return nil
+			}
+		}
+		if hasMore {
+			continue
+		}
+
+		select {
+		case <-s.done:
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+				time.Now().Add(writeTimeout))
+			return nil
+		case <-closed:
+			return nil
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return nil
+			}
+		case <-ticker.C():
+		}
+	}
+}
+
+func (s *Subscriptions) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/block").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleBlock))
+	sub.Path("/event").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleEvent))
+	sub.Path("/transfer").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleTransfer))
+	sub.Path("/account").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handleAccount))
+	sub.Path("/pending-tx").Methods("GET").HandlerFunc(utils.WrapHandlerFunc(s.handlePendingTx))
+}
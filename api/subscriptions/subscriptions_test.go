@@ -0,0 +1,291 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/api/subscriptions"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/txpool"
+)
+
+var (
+	ts           *httptest.Server
+	pool         *txpool.TxPool
+	db           *lvldb.LevelDB
+	stateCreator *state.Creator
+)
+
+var privateKey, _ = crypto.GenerateKey()
+
+func newBlock(parent *block.Block, score uint64) *block.Block {
+	b := new(block.Builder).ParentID(parent.Header().ID()).TotalScore(parent.Header().TotalScore() + score).Build()
+	sig, _ := crypto.Sign(b.Header().SigningHash().Bytes(), privateKey)
+	return b.WithSignature(sig)
+}
+
+func TestSubscriptions(t *testing.T) {
+	ch, subs := initSubscriptionsServer(t)
+	defer ts.Close()
+	defer subs.Close()
+
+	b0 := ch.GenesisBlock()
+	b1 := newBlock(b0, 1)
+	b2 := newBlock(b1, 1)
+	b1x := newBlock(b0, 3)
+	for _, b := range []*block.Block{b1, b2} {
+		if _, err := ch.AddBlock(b, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conn := dial(t, "/subscriptions/block?pos="+b0.Header().ID().String())
+	defer conn.Close()
+
+	msg := readBlockMessage(t, conn)
+	assert.Equal(t, b1.Header().ID(), msg.ID)
+	assert.False(t, msg.Obsolete)
+	msg = readBlockMessage(t, conn)
+	assert.Equal(t, b2.Header().ID(), msg.ID)
+	assert.False(t, msg.Obsolete)
+
+	// reorg
+	if _, err := ch.AddBlock(b1x, nil); err != nil {
+		t.Fatal(err)
+	}
+	msg = readBlockMessage(t, conn)
+	assert.Equal(t, b2.Header().ID(), msg.ID)
+	assert.True(t, msg.Obsolete)
+	msg = readBlockMessage(t, conn)
+	assert.Equal(t, b1.Header().ID(), msg.ID)
+	assert.True(t, msg.Obsolete)
+	msg = readBlockMessage(t, conn)
+	assert.Equal(t, b1x.Header().ID(), msg.ID)
+	assert.False(t, msg.Obsolete)
+}
+
+func TestSubscriptionsBadRequest(t *testing.T) {
+	_, subs := initSubscriptionsServer(t)
+	defer ts.Close()
+	defer subs.Close()
+
+	for _, path := range []string{
+		"/subscriptions/block?pos=invalid",
+		"/subscriptions/block?pos=0x0000000000000000000000000000000000000000000000000000000000000001",
+		"/subscriptions/event?addr=invalid",
+		"/subscriptions/event?t0=invalid",
+		"/subscriptions/transfer?sender=invalid",
+		"/subscriptions/account",
+		"/subscriptions/account?addr=invalid",
+		"/subscriptions/pending-tx?origin=invalid",
+		"/subscriptions/pending-tx?full=invalid",
+	} {
+		_, res, err := websocket.DefaultDialer.Dial(wsURL(path), nil)
+		assert.NotNil(t, err, path)
+		if assert.NotNil(t, res, path) {
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, path)
+		}
+	}
+}
+
+func TestSubscribePendingTx(t *testing.T) {
+	ch, subs := initSubscriptionsServer(t)
+	defer ts.Close()
+	defer subs.Close()
+	defer pool.Close()
+
+	to := thor.BytesToAddress([]byte("to"))
+	newTx := func(to thor.Address) *tx.Transaction {
+		trx := new(tx.Builder).
+			ChainTag(ch.Tag()).
+			Expiration(100).
+			Gas(21000).
+			Nonce(uint64(time.Now().UnixNano())).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+			Build()
+		sig, _ := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+		return trx.WithSignature(sig)
+	}
+
+	idConn := dial(t, "/subscriptions/pending-tx")
+	defer idConn.Close()
+	fullConn := dial(t, "/subscriptions/pending-tx?full=true&recipient="+to.String())
+	defer fullConn.Close()
+
+	other := newTx(thor.BytesToAddress([]byte("other")))
+	trx := newTx(to)
+	if err := pool.Add(other, trx); err != nil {
+		t.Fatal(err)
+	}
+
+	// txs are pushed concurrently, in no particular order
+	ids := make(map[thor.Bytes32]bool)
+	for i := 0; i < 2; i++ {
+		msg := readPendingTxMessage(t, idConn)
+		assert.Nil(t, msg.Tx)
+		ids[msg.ID] = true
+	}
+	assert.Equal(t, map[thor.Bytes32]bool{other.ID(): true, trx.ID(): true}, ids)
+
+	// filtered by recipient
+	msg := readPendingTxMessage(t, fullConn)
+	assert.Equal(t, trx.ID(), msg.ID)
+	if assert.NotNil(t, msg.Tx) {
+		assert.Equal(t, genesis.DevAccounts()[0].Address, msg.Tx.Origin)
+	}
+}
+
+func TestSubscribeAccount(t *testing.T) {
+	ch, subs := initSubscriptionsServer(t)
+	defer ts.Close()
+	defer subs.Close()
+
+	proposer := genesis.DevAccounts()[0]
+	to := thor.BytesToAddress([]byte("to"))
+	// packs a block transferring to the address, with states created by the creator
+	pack := func(creator *state.Creator) *block.Block {
+		flow, err := packer.New(ch, creator, proposer.Address, proposer.Address, thor.NoFork).
+			Schedule(ch.BestBlock().Header(), uint64(time.Now().Unix()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		trx := new(tx.Builder).
+			ChainTag(ch.Tag()).
+			GasPriceCoef(1).
+			Expiration(100).
+			Gas(21000).
+			Nonce(uint64(time.Now().UnixNano())).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(10))).
+			BlockRef(tx.NewBlockRef(ch.BestBlock().Header().Number())).
+			Build()
+		sig, _ := crypto.Sign(trx.SigningHash().Bytes(), proposer.PrivateKey)
+		if err := flow.Adopt(trx.WithSignature(sig)); err != nil {
+			t.Fatal(err)
+		}
+		blk, stage, receipts, err := flow.Pack(proposer.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stage.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ch.AddBlock(blk, receipts); err != nil {
+			t.Fatal(err)
+		}
+		return blk
+	}
+	b1 := pack(stateCreator)
+	// write-set not recorded
+	b2 := pack(state.NewCreator(db))
+
+	conn := dial(t, "/subscriptions/account?addr="+to.String()+"&addr="+thor.BytesToAddress([]byte("other")).String()+"&pos="+ch.GenesisBlock().Header().ID().String())
+	defer conn.Close()
+
+	for i, b := range []*block.Block{b1, b2} {
+		msg := readAccountMessage(t, conn)
+		assert.Equal(t, b.Header().ID(), msg.Block.ID)
+		assert.Equal(t, to, msg.Address)
+		assert.Equal(t, fmt.Sprint(i*10), (*big.Int)(msg.OldBalance).String())
+		assert.Equal(t, fmt.Sprint(i*10+10), (*big.Int)(msg.NewBalance).String())
+		assert.Empty(t, msg.StorageKeys)
+		assert.False(t, msg.Obsolete)
+	}
+}
+
+func initSubscriptionsServer(t *testing.T) (*chain.Chain, *subscriptions.Subscriptions) {
+	db, _ = lvldb.NewMem()
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateCreator = state.NewCreator(db).WithWriteSets(state.NewWriteSets())
+	b0, _, err := gene.Build(stateCreator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := chain.New(db, b0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool = txpool.New(ch, stateCreator, txpool.DefaultPoolConfig, thor.NoFork)
+
+	router := mux.NewRouter()
+	subs := subscriptions.New(ch, stateCreator, pool, nil)
+	subs.Mount(router, "/subscriptions")
+	ts = httptest.NewServer(router)
+	return ch, subs
+}
+
+func wsURL(path string) string {
+	return "ws" + strings.TrimPrefix(ts.URL, "http") + path
+}
+
+func dial(t *testing.T, path string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(path), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func readPendingTxMessage(t *testing.T, conn *websocket.Conn) *subscriptions.PendingTxMessage {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg subscriptions.PendingTxMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatal(err)
+	}
+	return &msg
+}
+
+func readAccountMessage(t *testing.T, conn *websocket.Conn) *subscriptions.AccountMessage {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg subscriptions.AccountMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatal(err)
+	}
+	return &msg
+}
+
+func readBlockMessage(t *testing.T, conn *websocket.Conn) *subscriptions.BlockMessage {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg subscriptions.BlockMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatal(err)
+	}
+	return &msg
+}
+
+func TestEventFilterMayMatch(t *testing.T) {
+	addr := thor.BytesToAddress([]byte("addr"))
+	topic := thor.BytesToBytes32([]byte("topic"))
+	var bloom thor.Bloom
+	bloom.Add(addr.Bytes())
+	bloom.Add(topic.Bytes())
+
+	other := thor.BytesToBytes32([]byte("other"))
+	assert.True(t, (&subscriptions.EventFilter{}).MayMatch(&bloom))
+	assert.True(t, (&subscriptions.EventFilter{Address: &addr, Topics: [5]*thor.Bytes32{nil, &topic}}).MayMatch(&bloom))
+	assert.False(t, (&subscriptions.EventFilter{Topics: [5]*thor.Bytes32{&other}}).MayMatch(&bloom))
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package subscriptions
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/api/blocks"
+	"github.com/vechain/thor/api/transactions"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// BlockMessage block pushed to subscribers.
+// Obsolete is true if the block was pushed before but has been switched off the trunk.
+type BlockMessage struct {
+	*blocks.Block
+	Obsolete bool `json:"obsolete"`
+}
+
+func convertBlock(b *extendedBlock) (*BlockMessage, error) {
+	blk, err := blocks.ConvertBlock(b.Block, !b.obsolete)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockMessage{
+		blk,
+		b.obsolete,
+	}, nil
+}
+
+// EventMessage event pushed to subscribers.
+type EventMessage struct {
+	Address  thor.Address              `json:"address"`
+	Topics   []thor.Bytes32            `json:"topics"`
+	Data     string                    `json:"data"`
+	Block    transactions.BlockContext `json:"block"`
+	Tx       transactions.TxContext    `json:"tx"`
+	Obsolete bool                      `json:"obsolete"`
+}
+
+func convertEvent(header *block.Header, txID thor.Bytes32, txOrigin thor.Address, event *tx.Event, obsolete bool) *EventMessage {
+	return &EventMessage{
+		Address: event.Address,
+		Topics:  event.Topics,
+		Data:    hexutil.Encode(event.Data),
+		Block: transactions.BlockContext{
+			ID:        header.ID(),
+			Number:    header.Number(),
+			Timestamp: header.Timestamp(),
+		},
+		Tx: transactions.TxContext{
+			ID:     txID,
+			Origin: txOrigin,
+		},
+		Obsolete: obsolete,
+	}
+}
+
+// TransferMessage VET transfer pushed to subscribers.
+type TransferMessage struct {
+	Sender    thor.Address              `json:"sender"`
+	Recipient thor.Address              `json:"recipient"`
+	Amount    *math.HexOrDecimal256     `json:"amount"`
+	Block     transactions.BlockContext `json:"block"`
+	Tx        transactions.TxContext    `json:"tx"`
+	Obsolete  bool                      `json:"obsolete"`
+}
+
+func convertTransfer(header *block.Header, txID thor.Bytes32, txOrigin thor.Address, transfer *tx.Transfer, obsolete bool) *TransferMessage {
+	v := math.HexOrDecimal256(*transfer.Amount)
+	return &TransferMessage{
+		Sender:    transfer.Sender,
+		Recipient: transfer.Recipient,
+		Amount:    &v,
+		Block: transactions.BlockContext{
+			ID:        header.ID(),
+			Number:    header.Number(),
+			Timestamp: header.Timestamp(),
+		},
+		Tx: transactions.TxContext{
+			ID:     txID,
+			Origin: txOrigin,
+		},
+		Obsolete: obsolete,
+	}
+}
+
+// EventFilter criteria of events to be pushed.
+type EventFilter struct {
+	Address *thor.Address
+	Topics  [5]*thor.Bytes32
+}
+
+// Match returns whether the event matches the criteria.
+func (f *EventFilter) Match(event *tx.Event) bool {
+	if f.Address != nil && *f.Address != event.Address {
+		return false
+	}
+	for i, topic := range f.Topics {
+		if topic == nil {
+			continue
+		}
+		if i >= len(event.Topics) || event.Topics[i] != *topic {
+			return false
+		}
+	}
+	return true
+}
+
+// MayMatch returns false if the logs bloom of a block rules out events matching the criteria.
+func (f *EventFilter) MayMatch(bloom *thor.Bloom) bool {
+	if f.Address != nil && !bloom.Test(f.Address.Bytes()) {
+		return false
+	}
+	for _, topic := range f.Topics {
+		if topic != nil && !bloom.Test(topic.Bytes()) {
+			return false
+		}
+	}
+	return true
+}
+
+// TransferFilter criteria of transfers to be pushed.
+type TransferFilter struct {
+	TxOrigin  *thor.Address
+	Sender    *thor.Address
+	Recipient *thor.Address
+}
+
+// Match returns whether the transfer matches the criteria.
+func (f *TransferFilter) Match(txOrigin thor.Address, transfer *tx.Transfer) bool {
+	if f.TxOrigin != nil && *f.TxOrigin != txOrigin {
+		return false
+	}
+	if f.Sender != nil && *f.Sender != transfer.Sender {
+		return false
+	}
+	if f.Recipient != nil && *f.Recipient != transfer.Recipient {
+		return false
+	}
+	return true
+}
+
+// AccountMessage changes of a watched account made by a block, pushed to subscribers.
+// Energy values are both at the block time, so that only changes made by the block are shown.
+// StorageKeys are keys of storage values written, absent if none or unknown, since the write-set
+// of the block is not kept, e.g. the block was executed before the node started, when changes are
+// found by comparing states.
+type AccountMessage struct {
+	Address     thor.Address              `json:"address"`
+	OldBalance  *math.HexOrDecimal256     `json:"oldBalance"`
+	NewBalance  *math.HexOrDecimal256     `json:"newBalance"`
+	OldEnergy   *math.HexOrDecimal256     `json:"oldEnergy"`
+	NewEnergy   *math.HexOrDecimal256     `json:"newEnergy"`
+	StorageKeys []thor.Bytes32            `json:"storageKeys,omitempty"`
+	Block       transactions.BlockContext `json:"block"`
+	Obsolete    bool                      `json:"obsolete"`
+}
+
+func convertAccount(header *block.Header, change *accountChange, obsolete bool) *AccountMessage {
+	hex := func(v *big.Int) *math.HexOrDecimal256 {
+		h := math.HexOrDecimal256(*v)
+		return &h
+	}
+	return &AccountMessage{
+		Address:     change.address,
+		OldBalance:  hex(change.oldBalance),
+		NewBalance:  hex(change.newBalance),
+		OldEnergy:   hex(change.oldEnergy),
+		NewEnergy:   hex(change.newEnergy),
+		StorageKeys: change.storageKeys,
+		Block: transactions.BlockContext{
+			ID:        header.ID(),
+			Number:    header.Number(),
+			Timestamp: header.Timestamp(),
+		},
+		Obsolete: obsolete,
+	}
+}
+
+// AccountFilter addresses of accounts whose changes to be pushed.
+type AccountFilter map[thor.Address]bool
+
+// PendingTxMessage tx newly added into the pool pushed to subscribers.
+// Tx is present only if full body requested.
+type PendingTxMessage struct {
+	ID thor.Bytes32              `json:"id"`
+	Tx *transactions.Transaction `json:"tx,omitempty"`
+}
+
+func convertPendingTx(trx *tx.Transaction, full bool) (*PendingTxMessage, error) {
+	msg := &PendingTxMessage{ID: trx.ID()}
+	if full {
+		t, err := transactions.ConvertTransaction(trx)
+		if err != nil {
+			return nil, err
+		}
+		msg.Tx = t
+	}
+	return msg, nil
+}
+
+// PendingTxFilter criteria of pending txs to be pushed.
+type PendingTxFilter struct {
+	Origin    *thor.Address
+	Recipient *thor.Address // matches if any clause sent to
+}
+
+// Match returns whether the tx matches the criteria.
+func (f *PendingTxFilter) Match(trx *tx.Transaction) bool {
+	if f.Origin != nil {
+		if origin, err := trx.Signer(); err != nil || origin != *f.Origin {
+			return false
+		}
+	}
+	if f.Recipient != nil {
+		for _, c := range trx.Clauses() {
+			if to := c.To(); to != nil && *to == *f.Recipient {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
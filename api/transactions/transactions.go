@@ -6,6 +6,7 @@
 package transactions
 
 import (
+	"encoding/json"
 	"errors"
 	"math"
 	"net/http"
@@ -55,7 +56,7 @@ func (t *Transactions) getRawTransaction(txID thor.Bytes32, blockID thor.Bytes32
 		return nil, err
 	}
 	return &rawTransaction{
-		Block: BlockContext{
+		Block: &BlockContext{
 			ID:        block.Header().ID(),
 			Number:    block.Header().Number(),
 			Timestamp: block.Header().Timestamp(),
@@ -84,7 +85,7 @@ func (t *Transactions) getTransactionByID(txID thor.Bytes32, blockID thor.Bytes3
 	if err != nil {
 		return nil, err
 	}
-	tc.Block = BlockContext{
+	tc.Block = &BlockContext{
 		ID:        h.ID(),
 		Number:    h.Number(),
 		Timestamp: h.Timestamp(),
@@ -92,6 +93,27 @@ func (t *Transactions) getTransactionByID(txID thor.Bytes32, blockID thor.Bytes3
 	return tc, nil
 }
 
+// getPendingTransaction returns the tx in pool, without block context.
+func (t *Transactions) getPendingTransaction(txID thor.Bytes32) (*Transaction, error) {
+	tx := t.pool.Get(txID)
+	if tx == nil {
+		return nil, nil
+	}
+	return ConvertTransaction(tx)
+}
+
+func (t *Transactions) getPendingRawTransaction(txID thor.Bytes32) (*rawTransaction, error) {
+	tx := t.pool.Get(txID)
+	if tx == nil {
+		return nil, nil
+	}
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &rawTransaction{RawTx: RawTx{hexutil.Encode(raw)}}, nil
+}
+
 //GetTransactionReceiptByID get tx's receipt
 func (t *Transactions) getTransactionReceiptByID(txID thor.Bytes32, blockID thor.Bytes32) (*Receipt, error) {
 	txMeta, err := t.chain.GetTransactionMeta(txID, blockID)
@@ -117,21 +139,21 @@ func (t *Transactions) getTransactionReceiptByID(txID thor.Bytes32, blockID thor
 }
 
 func (t *Transactions) sendTx(tx *tx.Transaction) (thor.Bytes32, error) {
-	if err := t.pool.Add(tx); err != nil {
+	if err := t.pool.AddLocal(tx); err != nil {
 		return thor.Bytes32{}, err
 	}
 	return tx.ID(), nil
 }
 
 func (t *Transactions) handleSendTransaction(w http.ResponseWriter, req *http.Request) error {
-	var raw *RawTx
-	if err := utils.ParseJSON(req.Body, &raw); err != nil {
+	var data json.RawMessage
+	if err := utils.ParseJSON(req.Body, &data); err != nil {
 		return err
 	}
 	req.Body.Close()
-	tx, err := raw.decode()
+	tx, err := decodeSendingTx(data)
 	if err != nil {
-		return err
+		return utils.BadRequest(err, "body")
 	}
 
 	txID, err := t.sendTx(tx)
@@ -165,19 +187,32 @@ func (t *Transactions) handleGetTransactionByID(w http.ResponseWriter, req *http
 	if raw != "" && raw != "false" && raw != "true" {
 		return utils.BadRequest(errors.New("should be boolean"), "raw")
 	}
+	pending := req.URL.Query().Get("pending")
+	if pending != "" && pending != "false" && pending != "true" {
+		return utils.BadRequest(errors.New("should be boolean"), "pending")
+	}
 	if raw == "true" {
 		tx, err := t.getRawTransaction(txID, h.ID())
 		if err != nil {
 			return err
 		}
+		if tx == nil && pending == "true" {
+			if tx, err = t.getPendingRawTransaction(txID); err != nil {
+				return err
+			}
+		}
 		return utils.WriteJSON(w, tx)
 	}
 	tx, err := t.getTransactionByID(txID, h.ID())
 	if err != nil {
 		return err
 	}
+	if tx == nil && pending == "true" {
+		if tx, err = t.getPendingTransaction(txID); err != nil {
+			return err
+		}
+	}
 	return utils.WriteJSON(w, tx)
-
 }
 
 func (t *Transactions) handleGetTransactionReceiptByID(w http.ResponseWriter, req *http.Request) error {
@@ -203,11 +238,14 @@ func (t *Transactions) getBlockHeader(revision string) (*block.Header, error) {
 	if revision == "" || revision == "best" {
 		return t.chain.BestBlock().Header(), nil
 	}
+	if revision == "finalized" {
+		return t.chain.FinalizedBlock(), nil
+	}
 	blkID, err := thor.ParseBytes32(revision)
 	if err != nil {
 		n, err := strconv.ParseUint(revision, 0, 0)
 		if err != nil {
-			return nil, err
+			return nil, utils.BadRequest(err, "revision")
 		}
 		if n > math.MaxUint32 {
 			return nil, utils.BadRequest(errors.New("block number exceeded"), "revision")
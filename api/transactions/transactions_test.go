@@ -79,17 +79,17 @@ func getTxReceipt(t *testing.T) {
 }
 
 func senTx(t *testing.T) {
-	tx := new(tx.Builder).
+	trx := new(tx.Builder).
 		ChainTag(c.Tag()).
 		Expiration(10).
 		Gas(21000).
 		Build()
-	sig, err := crypto.Sign(tx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
 	if err != nil {
 		t.Fatal(err)
 	}
-	tx = tx.WithSignature(sig)
-	rlpTx, err := rlp.EncodeToBytes(tx)
+	trx = trx.WithSignature(sig)
+	rlpTx, err := rlp.EncodeToBytes(trx)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -102,7 +102,40 @@ func senTx(t *testing.T) {
 	if err = json.Unmarshal(res, &txObj); err != nil {
 		t.Fatal(err)
 	}
-	assert.Equal(t, tx.ID().String(), txObj["id"], "shoudl be the same transaction")
+	assert.Equal(t, trx.ID().String(), txObj["id"], "shoudl be the same transaction")
+
+	// canonical json form is accepted as well
+	trx = new(tx.Builder).
+		ChainTag(c.Tag()).
+		Expiration(10).
+		Gas(21000).
+		Nonce(1).
+		Build()
+	sig, err = crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trx = trx.WithSignature(sig)
+	data, err := json.Marshal(trx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res = httpPost(t, ts.URL+"/transactions", data)
+	txObj = nil
+	if err = json.Unmarshal(res, &txObj); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, trx.ID().String(), txObj["id"], "shoudl be the same transaction")
+
+	// pending tx is only visible with pending flag
+	assert.Equal(t, "null", string(httpGet(t, ts.URL+"/transactions/"+trx.ID().String())))
+	res = httpGet(t, ts.URL+"/transactions/"+trx.ID().String()+"?pending=true")
+	var pendingTx *transactions.Transaction
+	if err := json.Unmarshal(res, &pendingTx); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, trx.ID(), pendingTx.ID)
+	assert.Nil(t, pendingTx.Block)
 }
 
 func httpPost(t *testing.T, url string, data []byte) []byte {
@@ -168,7 +201,7 @@ func initTransactionServer(t *testing.T) {
 		t.Fatal(err)
 	}
 	transaction = transaction.WithSignature(sig)
-	packer := packer.New(c, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address)
+	packer := packer.New(c, stateC, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, thor.NoFork)
 	flow, err := packer.Schedule(b.Header(), uint64(time.Now().Unix()))
 	err = flow.Adopt(transaction)
 	if err != nil {
@@ -185,7 +218,7 @@ func initTransactionServer(t *testing.T) {
 		t.Fatal(err)
 	}
 	router := mux.NewRouter()
-	transactions.New(c, txpool.New(c, stateC)).Mount(router, "/transactions")
+	transactions.New(c, txpool.New(c, stateC, txpool.DefaultPoolConfig, thor.NoFork)).Mount(router, "/transactions")
 	ts = httptest.NewServer(router)
 
 }
@@ -6,11 +6,15 @@
 package transactions
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/api/utils"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
@@ -61,6 +65,30 @@ func (r *RawTx) decode() (*tx.Transaction, error) {
 	return tx, nil
 }
 
+// decodeSendingTx decodes tx to be sent, which is either wrapped as RawTx,
+// or presented in canonical json form.
+func decodeSendingTx(data json.RawMessage) (*tx.Transaction, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["raw"]; ok {
+		var raw RawTx
+		if err := utils.ParseJSON(bytes.NewReader(data), &raw); err != nil {
+			return nil, err
+		}
+		return raw.decode()
+	}
+	var tx *tx.Transaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, errors.New("tx: null")
+	}
+	return tx, nil
+}
+
 //Transaction transaction
 type Transaction struct {
 	ID           thor.Bytes32        `json:"id,string"`
@@ -74,11 +102,12 @@ type Transaction struct {
 	DependsOn    *thor.Bytes32       `json:"dependsOn,string"`
 	Nonce        math.HexOrDecimal64 `json:"nonce"`
 	Origin       thor.Address        `json:"origin,string"`
-	Block        BlockContext        `json:"block"`
+	Delegator    *thor.Address       `json:"delegator"`
+	Block        *BlockContext       `json:"block"` // nil if pending
 }
 
 type rawTransaction struct {
-	Block BlockContext `json:"block"`
+	Block *BlockContext `json:"block"` // nil if pending
 	RawTx
 }
 
@@ -89,6 +118,10 @@ func ConvertTransaction(tx *tx.Transaction) (*Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
+	delegator, err := tx.Delegator()
+	if err != nil {
+		return nil, err
+	}
 	cls := make(Clauses, len(tx.Clauses()))
 	for i, c := range tx.Clauses() {
 		cls[i] = ConvertClause(c)
@@ -98,6 +131,7 @@ func ConvertTransaction(tx *tx.Transaction) (*Transaction, error) {
 		ChainTag:     tx.ChainTag(),
 		ID:           tx.ID(),
 		Origin:       signer,
+		Delegator:    delegator,
 		BlockRef:     hexutil.Encode(br[:]),
 		Expiration:   tx.Expiration(),
 		Nonce:        math.HexOrDecimal64(tx.Nonce()),
@@ -138,6 +172,9 @@ type Output struct {
 	ContractAddress *thor.Address `json:"contractAddress"`
 	Events          []*Event      `json:"events"`
 	Transfers       []*Transfer   `json:"transfers"`
+	GasUsed         uint64        `json:"gasUsed"`
+	Data            string        `json:"data"`
+	Reverted        bool          `json:"reverted"`
 }
 
 // Event event.
@@ -189,6 +226,9 @@ func convertReceipt(txReceipt *tx.Receipt, header *block.Header, tx *tx.Transact
 		otp := &Output{contractAddr,
 			make([]*Event, len(output.Events)),
 			make([]*Transfer, len(output.Transfers)),
+			output.GasUsed,
+			hexutil.Encode(output.Data),
+			output.Reverted,
 		}
 		for j, txEvent := range output.Events {
 			event := &Event{
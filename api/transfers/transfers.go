@@ -10,54 +10,105 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 	"github.com/vechain/thor/api/utils"
 	"github.com/vechain/thor/logdb"
 )
 
 type Transfers struct {
-	db *logdb.LogDB
+	db    *logdb.LogDB
+	limit uint64
 }
 
-func New(db *logdb.LogDB) *Transfers {
+// New creates transfers API, which returns at most limit transfers in a query.
+func New(db *logdb.LogDB, limit uint64) *Transfers {
 	return &Transfers{
 		db,
+		limit,
 	}
 }
 
-//Filter query logs with option
-func (t *Transfers) filter(ctx context.Context, filter *logdb.TransferFilter) ([]*FilteredTransfer, error) {
+//Filter query logs with option, and returns the cursor of next page if the page is full
+func (t *Transfers) filter(ctx context.Context, filter *logdb.TransferFilter) ([]*FilteredTransfer, *logdb.Cursor, error) {
 	transfers, err := t.db.FilterTransfers(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	tLogs := make([]*FilteredTransfer, len(transfers))
 	for i, trans := range transfers {
 		tLogs[i] = ConvertTransfer(trans)
 	}
-	return tLogs, nil
+	var next *logdb.Cursor
+	if len(transfers) > 0 && uint64(len(transfers)) == filter.Options.Limit {
+		next = transfers[len(transfers)-1].Cursor()
+	}
+	return tLogs, next, nil
 }
 
-func (t *Transfers) handleFilterTransferLogs(w http.ResponseWriter, req *http.Request) error {
+// parseFilter parses the filter from request body, overridden by order in query.
+func (t *Transfers) parseFilter(req *http.Request) (*logdb.TransferFilter, error) {
 	var filter logdb.TransferFilter
 	if err := utils.ParseJSON(req.Body, &filter); err != nil {
-		return err
+		return nil, err
 	}
 	req.Body.Close()
-	order := req.URL.Query().Get("order")
-	if order != string(logdb.DESC) {
+	if order := req.URL.Query().Get("order"); order != "" {
+		filter.Order = logdb.Order(order)
+	}
+	switch filter.Order {
+	case "":
 		filter.Order = logdb.ASC
-	} else {
-		filter.Order = logdb.DESC
+	case logdb.ASC, logdb.DESC:
+	default:
+		return nil, utils.BadRequest(errors.New("should be 'asc' or 'desc'"), "order")
 	}
-	tLogs, err := t.filter(req.Context(), &filter)
+	if filter.Range != nil {
+		switch filter.Range.Unit {
+		case "":
+			filter.Range.Unit = logdb.Block
+		case logdb.Block, logdb.Time:
+		default:
+			return nil, utils.BadRequest(errors.New("should be 'block' or 'time'"), "range.unit")
+		}
+	}
+	if filter.Options == nil {
+		filter.Options = &logdb.Options{Limit: t.limit}
+	} else if filter.Options.Limit > t.limit {
+		return nil, utils.BadRequest(errors.Errorf("should not exceed %v", t.limit), "options.limit")
+	}
+	return &filter, nil
+}
+
+func (t *Transfers) handleFilterTransferLogs(w http.ResponseWriter, req *http.Request) error {
+	filter, err := t.parseFilter(req)
 	if err != nil {
 		return err
 	}
+	tLogs, next, err := t.filter(req.Context(), filter)
+	if err != nil {
+		return err
+	}
+	if next != nil {
+		w.Header().Set(utils.NextCursorHeader, next.String())
+	}
 	return utils.WriteJSON(w, tLogs)
 }
 
+func (t *Transfers) handleCountTransferLogs(w http.ResponseWriter, req *http.Request) error {
+	filter, err := t.parseFilter(req)
+	if err != nil {
+		return err
+	}
+	count, err := t.db.CountTransfers(req.Context(), filter)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &Count{count})
+}
+
 func (t *Transfers) Mount(root *mux.Router, pathPrefix string) {
 	sub := root.PathPrefix(pathPrefix).Subrouter()
 
 	sub.Path("").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleFilterTransferLogs))
+	sub.Path("/count").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(t.handleCountTransferLogs))
 }
@@ -29,6 +29,8 @@ func TestTransfers(t *testing.T) {
 	initLogServer(t)
 	defer ts.Close()
 	getTransfers(t)
+	getTransfersWithBadRange(t)
+	getTransfersCount(t)
 }
 
 func getTransfers(t *testing.T) {
@@ -57,12 +59,43 @@ func getTransfers(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	res := httpPost(t, ts.URL+"/transfers", f)
+	res, _ := httpPost(t, ts.URL+"/logs/transfer", f)
 	var tLogs []*transfers.FilteredTransfer
 	if err := json.Unmarshal(res, &tLogs); err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, limit, len(tLogs), "should be `limit` transfers")
+	assert.True(t, tLogs[0].Block.Number > tLogs[limit-1].Block.Number, "should be in desc order")
+	for _, tLog := range tLogs {
+		assert.Equal(t, from, tLog.Tx.Origin)
+		assert.Equal(t, to, tLog.Recipient)
+	}
+}
+
+func getTransfersWithBadRange(t *testing.T) {
+	_, statusCode := httpPost(t, ts.URL+"/logs/transfer", []byte(`{"range":{"unit":"random"}}`))
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+
+	_, statusCode = httpPost(t, ts.URL+"/logs/transfer?order=random", []byte("{}"))
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
+
+func getTransfersCount(t *testing.T) {
+	count := func(body string) uint64 {
+		res, statusCode := httpPost(t, ts.URL+"/logs/transfer/count", []byte(body))
+		assert.Equal(t, http.StatusOK, statusCode, string(res))
+		var c transfers.Count
+		if err := json.Unmarshal(res, &c); err != nil {
+			t.Fatal(err)
+		}
+		return c.Count
+	}
+
+	to := thor.BytesToAddress([]byte("to"))
+	assert.Equal(t, uint64(100), count(`{}`))
+	// transfers are in blocks #2 to #101, and both bounds of range are inclusive
+	assert.Equal(t, uint64(9), count(`{"range":{"from":1,"to":10}}`))
+	assert.Equal(t, uint64(0), count(`{"addressSets":[{"sender":"`+to.String()+`"}]}`))
 }
 
 func initLogServer(t *testing.T) {
@@ -90,11 +123,11 @@ func initLogServer(t *testing.T) {
 	}
 
 	router := mux.NewRouter()
-	transfers.New(db).Mount(router, "/transfers")
+	transfers.New(db, 1000).Mount(router, "/logs/transfer")
 	ts = httptest.NewServer(router)
 }
 
-func httpPost(t *testing.T, url string, data []byte) []byte {
+func httpPost(t *testing.T, url string, data []byte) ([]byte, int) {
 	res, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewReader(data))
 	if err != nil {
 		t.Fatal(err)
@@ -104,5 +137,5 @@ func httpPost(t *testing.T, url string, data []byte) []byte {
 	if err != nil {
 		t.Fatal(err)
 	}
-	return r
+	return r, res.StatusCode
 }
@@ -12,6 +12,11 @@ import (
 	"github.com/vechain/thor/thor"
 )
 
+// Count count of matched logs.
+type Count struct {
+	Count uint64 `json:"count"`
+}
+
 type FilteredTransfer struct {
 	Sender    thor.Address              `json:"sender"`
 	Recipient thor.Address              `json:"recipient"`
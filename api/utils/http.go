@@ -46,6 +46,14 @@ func Forbidden(cause error, msg string) error {
 	}
 }
 
+// NotFound convenience method to create http not found error.
+func NotFound(cause error, msg string) error {
+	return &httpError{
+		cause:  errors.Wrap(cause, msg),
+		status: http.StatusNotFound,
+	}
+}
+
 // HandlerFunc like http.HandlerFunc, bu it returns an error.
 // If the returned error is httpError type, httpError.status will be responded,
 // otherwise http.StatusInternalServerError responded.
@@ -75,6 +83,9 @@ const (
 	OctetStreamContentType = "application/octet-stream"
 )
 
+// NextCursorHeader the response header to carry the cursor of next page, if results are paginated.
+const NextCursorHeader = "X-Next-Cursor"
+
 // ParseJSON parse a JSON object using strict mode.
 func ParseJSON(r io.Reader, v interface{}) error {
 	decoder := json.NewDecoder(r)
@@ -0,0 +1,36 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package utils
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+)
+
+// CheckState checks whether the state of the block is available, since full nodes prune states
+// of blocks below a recent one. A 410 Gone error is returned if the state was pruned.
+func CheckState(chain *chain.Chain, stateCreator *state.Creator, header *block.Header) error {
+	prunedBelow := chain.PrunedBelow()
+	if prunedBelow == 0 {
+		return nil
+	}
+	has, err := stateCreator.HasRoot(header.StateRoot())
+	if err != nil {
+		return err
+	}
+	if has {
+		return nil
+	}
+	if header.Number() < prunedBelow {
+		return HTTPError(errors.Errorf("state of block #%v pruned, states below block #%v are not retained by the node (use an archive node instead)", header.Number(), prunedBelow), http.StatusGone)
+	}
+	// e.g. blocks of branches which were not on trunk
+	return HTTPError(errors.Errorf("state of block #%v pruned", header.Number()), http.StatusGone)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package bandwidth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+// Bandwidth tracks the speed (gas per second) of block execution, measured on recent blocks.
+// It's thread-safe.
+type Bandwidth struct {
+	value uint64
+	lock  sync.Mutex
+}
+
+// Value returns the current bandwidth in gas per second.
+func (b *Bandwidth) Value() uint64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.value
+}
+
+// Update updates the bandwidth with a newly executed block, and returns the updated value.
+// Samples from blocks used less than half of gas limit are ignored, as they are
+// too small to reflect the real capacity.
+func (b *Bandwidth) Update(header *block.Header, elapsed time.Duration) (uint64, bool) {
+	gasUsed := header.GasUsed()
+	if elapsed <= 0 || gasUsed < header.GasLimit()/2 {
+		return 0, false
+	}
+
+	sample := uint64(float64(gasUsed) * float64(time.Second) / float64(elapsed))
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.value == 0 {
+		b.value = sample
+	} else {
+		// exponential moving average
+		b.value = (b.value*7 + sample) / 8
+	}
+	return b.value, true
+}
+
+// SuggestGasLimit returns the gas limit suggested for the next block, which can be executed
+// within thor.TolerableBlockPackingTime.
+// 0 returned if no enough samples.
+func (b *Bandwidth) SuggestGasLimit() uint64 {
+	return b.Value() * uint64(thor.TolerableBlockPackingTime) / uint64(time.Second)
+}
+
+// GasLimit computes gas limit of the next block upon parent gas limit, moved toward
+// the suggested value within protocol bounds.
+func (b *Bandwidth) GasLimit(parentGasLimit uint64) uint64 {
+	suggested := b.SuggestGasLimit()
+	if suggested == 0 {
+		return parentGasLimit
+	}
+	return block.GasLimit(suggested).Qualify(parentGasLimit)
+}
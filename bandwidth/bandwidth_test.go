@@ -0,0 +1,36 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package bandwidth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/bandwidth"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+func TestBandwidth(t *testing.T) {
+	var bw bandwidth.Bandwidth
+	parentGasLimit := thor.InitialGasLimit
+	assert.Equal(t, parentGasLimit, bw.GasLimit(parentGasLimit))
+
+	// too few gas used
+	header := new(block.Builder).GasLimit(parentGasLimit).GasUsed(parentGasLimit / 4).Build().Header()
+	_, updated := bw.Update(header, time.Millisecond)
+	assert.False(t, updated)
+
+	header = new(block.Builder).GasLimit(parentGasLimit).GasUsed(parentGasLimit).Build().Header()
+	v, updated := bw.Update(header, time.Second)
+	assert.True(t, updated)
+	assert.Equal(t, parentGasLimit, v)
+
+	gl := bw.GasLimit(parentGasLimit)
+	assert.True(t, block.GasLimit(gl).IsValid(parentGasLimit))
+	assert.True(t, gl < parentGasLimit)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package bft implements a finality gadget over the PoA chain.
+//
+// The chain is divided into epochs of EpochLength blocks, and the first block of each epoch
+// is a checkpoint. A block signed by an authority node is the signer's vote for the
+// checkpoint it descends from. A checkpoint is justified once blocks of its epoch are signed
+// by a quorum (more than 2/3) of the active proposers at the checkpoint. A justified checkpoint
+// is finalized, once the checkpoint of the next epoch is justified on top of it.
+package bft
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/builtin/authority"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+// EpochLength number of blocks in an epoch.
+const EpochLength = 180
+
+// Engine tracks justified and finalized checkpoints along the trunk.
+type Engine struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	justified    thor.Bytes32
+	lock         sync.Mutex
+}
+
+// New create an Engine.
+func New(chain *chain.Chain, stateCreator *state.Creator) *Engine {
+	return &Engine{
+		chain:        chain,
+		stateCreator: stateCreator,
+		justified:    chain.FinalizedBlock().ID(),
+	}
+}
+
+// Justified returns id of the latest justified checkpoint.
+func (e *Engine) Justified() thor.Bytes32 {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.justified
+}
+
+// Process should be called for each block put on trunk, in order.
+// When the block closes an epoch, the checkpoint of the epoch is evaluated, and the
+// checkpoint before is finalized if both are justified.
+// It returns true if a new block finalized.
+func (e *Engine) Process(header *block.Header) (bool, error) {
+	num := header.Number()
+	if num == 0 || num%EpochLength != 0 {
+		return false, nil
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	checkpoint, justified, err := e.evaluate(header.ID(), num-EpochLength)
+	if err != nil || !justified {
+		return false, err
+	}
+	e.justified = checkpoint
+
+	if num < EpochLength*2 {
+		return false, nil
+	}
+	prevCheckpoint, justified, err := e.evaluate(checkpoint, num-EpochLength*2)
+	if err != nil || !justified {
+		return false, err
+	}
+	if e.chain.FinalizedBlock().Number() >= num-EpochLength*2 {
+		return false, nil
+	}
+	if err := e.chain.SetFinalized(prevCheckpoint); err != nil {
+		return false, errors.WithMessage(err, "set finalized")
+	}
+	return true, nil
+}
+
+// evaluate checks whether the checkpoint at given number is justified, by the epoch ended
+// by the head.
+func (e *Engine) evaluate(headID thor.Bytes32, checkpointNum uint32) (thor.Bytes32, bool, error) {
+	checkpointID, err := e.chain.GetAncestorBlockID(headID, checkpointNum)
+	if err != nil {
+		return thor.Bytes32{}, false, err
+	}
+	quorum, err := e.quorum(checkpointID)
+	if err != nil {
+		return thor.Bytes32{}, false, err
+	}
+
+	voters := make(map[thor.Address]bool)
+	id := headID
+	for block.Number(id) > checkpointNum {
+		header, err := e.chain.GetBlockHeader(id)
+		if err != nil {
+			return thor.Bytes32{}, false, err
+		}
+		signer, err := header.Signer()
+		if err != nil {
+			return thor.Bytes32{}, false, err
+		}
+		voters[signer] = true
+		if len(voters) >= quorum {
+			return checkpointID, true, nil
+		}
+		id = header.ParentID()
+	}
+	return checkpointID, false, nil
+}
+
+// quorum returns number of votes required to justify the checkpoint.
+func (e *Engine) quorum(checkpointID thor.Bytes32) (int, error) {
+	header, err := e.chain.GetBlockHeader(checkpointID)
+	if err != nil {
+		return 0, err
+	}
+	st, err := e.stateCreator.NewState(header.StateRoot())
+	if err != nil {
+		return 0, err
+	}
+	endorsement := builtin.Params.Native(st).Get(thor.KeyProposerEndorsement)
+	candidates := builtin.Authority.Native(st).Candidates(endorsement, thor.MaxBlockProposers)
+	if err := st.Err(); err != nil {
+		return 0, err
+	}
+	return Quorum(candidates), nil
+}
+
+// Quorum returns number of votes required for a quorum, more than 2/3 of active candidates.
+func Quorum(candidates []*authority.Candidate) int {
+	active := 0
+	for _, c := range candidates {
+		if c.Active {
+			active++
+		}
+	}
+	return active*2/3 + 1
+}
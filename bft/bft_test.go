@@ -0,0 +1,61 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package bft_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/bft"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+)
+
+func TestEngine(t *testing.T) {
+	// signers: number of distinct proposers signing blocks in round robin
+	run := func(signers int, blocks uint32) (*chain.Chain, *bft.Engine) {
+		kv, _ := lvldb.NewMem()
+		stateCreator := state.NewCreator(kv)
+		g, _ := genesis.NewDevnet()
+		b0, _, _ := g.Build(stateCreator)
+		ch, _ := chain.New(kv, b0)
+		engine := bft.New(ch, stateCreator)
+
+		parent := b0.Header()
+		for i := uint32(0); i < blocks; i++ {
+			b := new(block.Builder).
+				ParentID(parent.ID()).
+				TotalScore(parent.TotalScore() + 1).
+				StateRoot(parent.StateRoot()).
+				Build()
+			acc := genesis.DevAccounts()[int(i)%signers]
+			sig, _ := crypto.Sign(b.Header().SigningHash().Bytes(), acc.PrivateKey)
+			b = b.WithSignature(sig)
+			if _, err := ch.AddBlock(b, nil); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := engine.Process(b.Header()); err != nil {
+				t.Fatal(err)
+			}
+			parent = b.Header()
+		}
+		return ch, engine
+	}
+
+	// 10 proposers in devnet, 7 votes required
+	ch, engine := run(7, bft.EpochLength*3)
+	assert.Equal(t, uint32(bft.EpochLength), ch.FinalizedBlock().Number())
+	justified, _ := ch.GetTrunkBlockID(bft.EpochLength * 2)
+	assert.Equal(t, justified, engine.Justified())
+
+	ch, engine = run(6, bft.EpochLength*3)
+	assert.Equal(t, uint32(0), ch.FinalizedBlock().Number())
+	assert.Equal(t, ch.GenesisBlock().Header().ID(), engine.Justified())
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package blockfile defines a portable file format of blocks, for archiving and seeding nodes.
+// A file is a stream of RLP values, a header identifying the network followed by entries of
+// consecutive blocks, each along with its receipts.
+package blockfile
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// Version version of file format.
+const Version = 1
+
+// Header header of file.
+type Header struct {
+	Version   uint32
+	GenesisID thor.Bytes32
+}
+
+type entry struct {
+	Block    *block.Block
+	Receipts tx.Receipts
+}
+
+// Writer writes header and entries.
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter create a writer. The header should be written first for a new file.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w}
+}
+
+// WriteHeader writes the file header.
+func (w *Writer) WriteHeader(genesisID thor.Bytes32) error {
+	return rlp.Encode(w.w, &Header{Version, genesisID})
+}
+
+// Write writes a block along with its receipts.
+func (w *Writer) Write(blk *block.Block, receipts tx.Receipts) error {
+	return rlp.Encode(w.w, &entry{blk, receipts})
+}
+
+// countingReader counts bytes consumed. It implements io.ByteReader, so that rlp stream
+// reads no more than decoded.
+type countingReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *countingReader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err == nil {
+		r.n++
+	}
+	return b, err
+}
+
+// Reader reads entries, and checks integrity of each.
+type Reader struct {
+	cr     *countingReader
+	s      *rlp.Stream
+	header Header
+}
+
+// NewReader create a reader, and reads the file header.
+func NewReader(r io.Reader) (*Reader, error) {
+	cr := &countingReader{r: bufio.NewReader(r)}
+	s := rlp.NewStream(cr, 0)
+
+	var header Header
+	if err := s.Decode(&header); err != nil {
+		return nil, errors.Wrap(err, "read header")
+	}
+	if header.Version != Version {
+		return nil, errors.Errorf("unsupported version %v", header.Version)
+	}
+	return &Reader{cr, s, header}, nil
+}
+
+// GenesisID returns genesis id of the network blocks belong to.
+func (r *Reader) GenesisID() thor.Bytes32 {
+	return r.header.GenesisID
+}
+
+// Offset returns the number of bytes consumed, that is the end of the last entry read.
+func (r *Reader) Offset() int64 {
+	return r.cr.n
+}
+
+// Read reads the next block along with its receipts. io.EOF returned if no more,
+// and io.ErrUnexpectedEOF if the last entry is incomplete.
+func (r *Reader) Read() (*block.Block, tx.Receipts, error) {
+	var e entry
+	if err := r.s.Decode(&e); err != nil {
+		return nil, nil, err
+	}
+	header := e.Block.Header()
+	if header.TxsRoot() != e.Block.Transactions().RootHash() {
+		return nil, nil, errors.Errorf("corrupt entry of block %v: txs root mismatch", header.ID())
+	}
+	if header.ReceiptsRoot() != e.Receipts.RootHash() {
+		return nil, nil, errors.Errorf("corrupt entry of block %v: receipts root mismatch", header.ID())
+	}
+	return e.Block, e.Receipts, nil
+}
+
+// Export writes trunk blocks in range [from, to] along with their receipts.
+// Genesis is never exported, since it's built by nodes themselves. progress is called after each
+// block written.
+func Export(ctx context.Context, chain *chain.Chain, w *Writer, from, to uint32, progress func(num uint32)) error {
+	if from == 0 {
+		from = 1
+	}
+	if from > to {
+		return errors.New("from exceeds to")
+	}
+	if to > chain.BestBlock().Header().Number() {
+		return errors.New("to exceeds best block")
+	}
+	for num := from; ; num++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		blk, err := chain.GetTrunkBlock(num)
+		if err != nil {
+			return err
+		}
+		receipts, err := chain.GetBlockReceipts(blk.Header().ID())
+		if err != nil {
+			return err
+		}
+		if err := w.Write(blk, receipts); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(num)
+		}
+		if num == to {
+			return nil
+		}
+	}
+}
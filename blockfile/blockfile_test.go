@@ -0,0 +1,166 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package blockfile_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/blockfile"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/consensus"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+func newChain(t *testing.T) (*chain.Chain, *state.Creator) {
+	db, _ := lvldb.NewMem()
+	stateCreator := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b0, _, err := gene.Build(stateCreator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := chain.New(db, b0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, stateCreator
+}
+
+func packBlocks(t *testing.T, c *chain.Chain, stateCreator *state.Creator, n int) {
+	proposer := genesis.DevAccounts()[0]
+	to := thor.BytesToAddress([]byte("to"))
+	for i := 0; i < n; i++ {
+		p := packer.New(c, stateCreator, proposer.Address, proposer.Address, thor.NoFork)
+		flow, err := p.Schedule(c.BestBlock().Header(), uint64(time.Now().Unix()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		trx := new(tx.Builder).
+			ChainTag(c.Tag()).
+			GasPriceCoef(1).
+			Expiration(100).
+			Gas(21000).
+			Nonce(uint64(i)).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(10))).
+			BlockRef(tx.NewBlockRef(c.BestBlock().Header().Number())).
+			Build()
+		sig, _ := crypto.Sign(trx.SigningHash().Bytes(), proposer.PrivateKey)
+		if err := flow.Adopt(trx.WithSignature(sig)); err != nil {
+			t.Fatal(err)
+		}
+		blk, stage, receipts, err := flow.Pack(proposer.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stage.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.AddBlock(blk, receipts); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	c, stateCreator := newChain(t)
+	packBlocks(t, c, stateCreator, 3)
+
+	var buf bytes.Buffer
+	w := blockfile.NewWriter(&buf)
+	assert.Nil(t, w.WriteHeader(c.GenesisBlock().Header().ID()))
+	assert.NotNil(t, blockfile.Export(context.Background(), c, w, 1, 4, nil))
+
+	var exported []uint32
+	assert.Nil(t, blockfile.Export(context.Background(), c, w, 0, 3, func(num uint32) {
+		exported = append(exported, num)
+	}))
+	assert.Equal(t, []uint32{1, 2, 3}, exported)
+
+	// replay into a fresh chain
+	c2, stateCreator2 := newChain(t)
+	cons := consensus.New(c2, stateCreator2, thor.NoFork)
+	r, err := blockfile.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, c2.GenesisBlock().Header().ID(), r.GenesisID())
+	for {
+		blk, receipts, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		stage, replayed, err := cons.Replay(blk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, receipts.RootHash(), replayed.RootHash())
+		if _, err := stage.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c2.AddBlock(blk, replayed); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Equal(t, int64(buf.Len()), r.Offset())
+	assert.Equal(t, c.BestBlock().Header().ID(), c2.BestBlock().Header().ID())
+}
+
+func TestIncompleteEntry(t *testing.T) {
+	c, stateCreator := newChain(t)
+	packBlocks(t, c, stateCreator, 2)
+
+	var buf bytes.Buffer
+	w := blockfile.NewWriter(&buf)
+	assert.Nil(t, w.WriteHeader(c.GenesisBlock().Header().ID()))
+	assert.Nil(t, blockfile.Export(context.Background(), c, w, 1, 2, nil))
+
+	r, err := blockfile.NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk, _, err := r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), blk.Header().Number())
+	offset := r.Offset()
+
+	_, _, err = r.Read()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	// resumed by truncating the incomplete entry
+	buf.Truncate(int(offset))
+	w = blockfile.NewWriter(&buf)
+	assert.Nil(t, blockfile.Export(context.Background(), c, w, 2, 2, nil))
+
+	r, err = blockfile.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for num := uint32(1); num <= 2; num++ {
+		blk, _, err := r.Read()
+		assert.Nil(t, err)
+		assert.Equal(t, num, blk.Header().Number())
+	}
+	_, _, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
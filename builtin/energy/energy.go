@@ -90,7 +90,7 @@ func (e *Energy) Add(addr thor.Address, amount *big.Int) {
 
 		e.state.SetEnergy(addr, new(big.Int).Add(eng, amount), e.blockTime)
 	} else {
-		e.state.SetEnergy(addr, eng, e.blockTime)
+		e.state.TouchEnergy(addr, e.blockTime)
 	}
 }
 
@@ -112,7 +112,7 @@ func (e *Energy) Sub(addr thor.Address, amount *big.Int) bool {
 
 		e.state.SetEnergy(addr, new(big.Int).Sub(eng, amount), e.blockTime)
 	} else {
-		e.state.SetEnergy(addr, eng, e.blockTime)
+		e.state.TouchEnergy(addr, e.blockTime)
 	}
 	return true
 }
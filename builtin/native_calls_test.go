@@ -211,7 +211,7 @@ func TestParamsNative(t *testing.T) {
 		assert.Nil(t, seeker.Err())
 	}()
 
-	rt := runtime.New(seeker, st, &xenv.BlockContext{})
+	rt := runtime.New(seeker, st, &xenv.BlockContext{}, thor.NoFork)
 
 	test := &ctest{
 		rt:  rt,
@@ -283,7 +283,7 @@ func TestAuthorityNative(t *testing.T) {
 		assert.Nil(t, seeker.Err())
 	}()
 
-	rt := runtime.New(seeker, st, &xenv.BlockContext{})
+	rt := runtime.New(seeker, st, &xenv.BlockContext{}, thor.NoFork)
 
 	addEvent := func(signer, endorsor thor.Address, identity thor.Bytes32) *tx.Event {
 		ev, _ := builtin.Authority.ABI.EventByName("Add")
@@ -421,7 +421,7 @@ func TestEnergyNative(t *testing.T) {
 		}
 	}
 
-	rt := runtime.New(seeker, st, &xenv.BlockContext{Time: b0.Header().Timestamp()})
+	rt := runtime.New(seeker, st, &xenv.BlockContext{Time: b0.Header().Timestamp()}, thor.NoFork)
 	test := &ctest{
 		rt:     rt,
 		abi:    builtin.Energy.ABI,
@@ -534,7 +534,7 @@ func TestPrototypeNative(t *testing.T) {
 	rt := runtime.New(seeker, st, &xenv.BlockContext{
 		Time:   genesisBlock.Header().Timestamp(),
 		Number: genesisBlock.Header().Number(),
-	})
+	}, thor.NoFork)
 
 	code, _ := hex.DecodeString("60606040523415600e57600080fd5b603580601b6000396000f3006060604052600080fd00a165627a7a72305820edd8a93b651b5aac38098767f0537d9b25433278c9d155da2135efc06927fc960029")
 	out := rt.ExecuteClause(tx.NewClause(nil).WithData(code), 0, math.MaxUint64, &xenv.TransactionContext{
@@ -766,7 +766,7 @@ func TestPrototypeNativeWithLongerBlockNumber(t *testing.T) {
 	rt := runtime.New(seeker, st, &xenv.BlockContext{
 		Number: thor.MaxBackTrackingBlockNumber + 1,
 		Time:   c.BestBlock().Header().Timestamp(),
-	})
+	}, thor.NoFork)
 
 	test := &ctest{
 		rt:     rt,
@@ -834,7 +834,7 @@ func TestPrototypeNativeWithBlockNumber(t *testing.T) {
 	rt := runtime.New(seeker, st, &xenv.BlockContext{
 		Number: c.BestBlock().Header().Number(),
 		Time:   c.BestBlock().Header().Timestamp(),
-	})
+	}, thor.NoFork)
 
 	test := &ctest{
 		rt:     rt,
@@ -898,7 +898,7 @@ func TestExtensionNative(t *testing.T) {
 		assert.Nil(t, st.Err())
 		assert.Nil(t, seeker.Err())
 	}()
-	rt := runtime.New(seeker, st, &xenv.BlockContext{Number: 2, Time: b2.Header().Timestamp(), TotalScore: b2.Header().TotalScore(), Signer: b2_singer})
+	rt := runtime.New(seeker, st, &xenv.BlockContext{Number: 2, Time: b2.Header().Timestamp(), TotalScore: b2.Header().TotalScore(), Signer: b2_singer}, thor.NoFork)
 
 	test := &ctest{
 		rt:  rt,
@@ -993,4 +993,9 @@ func TestExtensionNative(t *testing.T) {
 	test.Case("blockSigner", big.NewInt(1)).
 		ShouldOutput(b1_singer).
 		Assert(t)
+
+	// genesis block has no signer
+	test.Case("blockSigner", big.NewInt(0)).
+		ShouldOutput(thor.Address{}).
+		Assert(t)
 }
@@ -38,14 +38,15 @@ func (pc *PrioCache) Len() int {
 }
 
 // Set set value and priority for given key.
-func (pc *PrioCache) Set(key, value interface{}, priority float64) {
+// The entry with the lowest priority is evicted and returned if the limit exceeded.
+func (pc *PrioCache) Set(key, value interface{}, priority float64) *PrioEntry {
 	pc.lock.Lock()
 	defer pc.lock.Unlock()
 	if ent, ok := pc.m[key]; ok {
 		ent.Value = value
 		ent.Priority = priority
 		heap.Fix(&pc.s, ent.index)
-		return
+		return nil
 	}
 	ent := &prioEntry{
 		PrioEntry: PrioEntry{
@@ -60,8 +61,9 @@ func (pc *PrioCache) Set(key, value interface{}, priority float64) {
 	pc.m[key] = ent
 
 	if len(pc.s) > pc.limit {
-		pc.popLowest()
+		return pc.popLowest()
 	}
+	return nil
 }
 
 // Get retrieves value for given key.
@@ -107,12 +109,13 @@ func (pc *PrioCache) ForEach(cb func(*PrioEntry) bool) bool {
 	return true
 }
 
-func (pc *PrioCache) popLowest() {
+func (pc *PrioCache) popLowest() *PrioEntry {
 	if len(pc.s) == 0 {
-		return
+		return nil
 	}
 	ent := heap.Pop(&pc.s).(*prioEntry)
 	delete(pc.m, ent.Key)
+	return &ent.PrioEntry
 }
 
 // PrioEntry cache entry with priority.
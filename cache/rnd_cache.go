@@ -49,13 +49,14 @@ func (rc *RandCache) Len() int {
 }
 
 // Set sets value for given key.
-func (rc *RandCache) Set(key, value interface{}) {
+// A random entry is evicted and returned if the limit exceeded.
+func (rc *RandCache) Set(key, value interface{}) *Entry {
 	rc.lock.Lock()
 	defer rc.lock.Unlock()
 
 	if ent, ok := rc.m[key]; ok {
 		ent.Value = value
-		return
+		return nil
 	}
 	ent := &randEntry{
 		Entry: Entry{
@@ -68,8 +69,9 @@ func (rc *RandCache) Set(key, value interface{}) {
 	rc.s = append(rc.s, ent)
 
 	if len(rc.s) > rc.limit {
-		rc.randDrop()
+		return rc.randDrop()
 	}
+	return nil
 }
 
 // Get get value for the given key.
@@ -137,10 +139,11 @@ func (rc *RandCache) remove(key interface{}) bool {
 	return false
 }
 
-func (rc *RandCache) randDrop() {
+func (rc *RandCache) randDrop() *Entry {
 	if len(rc.s) == 0 {
-		return
+		return nil
 	}
 	ent := rc.s[rand.Intn(len(rc.s))]
 	rc.remove(ent.Key)
+	return &ent.Entry
 }
@@ -0,0 +1,37 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+// BlockSummary is a compact digest of a block.
+// It's cheap to keep in memory, compared to the full block.
+type BlockSummary struct {
+	Header *block.Header
+	Txs    []thor.Bytes32
+	Size   uint32
+}
+
+// ReceiptsRoot returns the receipts root of the summarized block.
+func (s *BlockSummary) ReceiptsRoot() thor.Bytes32 {
+	return s.Header.ReceiptsRoot()
+}
+
+func newBlockSummary(blk *block.Block) *BlockSummary {
+	txs := blk.Transactions()
+	ids := make([]thor.Bytes32, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID()
+	}
+	return &BlockSummary{
+		Header: blk.Header(),
+		Txs:    ids,
+		Size:   uint32(blk.Size()),
+	}
+}
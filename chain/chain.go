@@ -12,7 +12,9 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/co"
 	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
 )
@@ -20,11 +22,14 @@ import (
 const (
 	blockCacheLimit    = 512
 	receiptsCacheLimit = 512
+	summaryCacheLimit  = 2048
 )
 
 var errNotFound = errors.New("not found")
 var errBlockExist = errors.New("block already exists")
 
+var bestBlockGauge = metric.NewGauge("chain", "best_block_number", "number of the best block")
+
 // Chain describes a persistent block chain.
 // It's thread-safe.
 type Chain struct {
@@ -32,18 +37,54 @@ type Chain struct {
 	ancestorTrie *ancestorTrie
 	genesisBlock *block.Block
 	bestBlock    *block.Block
+	finalized    *block.Header
+	prunedBelow  uint32
 	tag          byte
 	caches       caches
 	rw           sync.RWMutex
+	tick         co.Signal
+	metered      bool // false for forks, not to mess up metrics of the chain forked
 }
 
 type caches struct {
 	rawBlocks *cache
 	receipts  *cache
+	summaries *cache
 }
 
 // New create an instance of Chain.
 func New(kv kv.GetPutter, genesisBlock *block.Block) (*Chain, error) {
+	c, err := newChain(kv, genesisBlock)
+	if err != nil {
+		return nil, err
+	}
+	c.metered = true
+	bestBlockGauge.Set(float64(c.bestBlock.Header().Number()))
+	return c, nil
+}
+
+// NewFork create an instance of Chain upon the kv which shares blocks of an existing chain, e.g. an
+// overlay of the kv of the existing chain, with the block of given id as both the best and finalized
+// block, so that new blocks are built upon it. The kv should never be the one of the existing chain
+// itself, since its best and finalized block are overwritten.
+func NewFork(kv kv.GetPutter, genesisBlock *block.Block, headID thor.Bytes32) (*Chain, error) {
+	if _, err := loadBlockRaw(kv, headID); err != nil {
+		return nil, err
+	}
+	batch := kv.NewBatch()
+	if err := saveBestBlockID(batch, headID); err != nil {
+		return nil, err
+	}
+	if err := saveFinalizedBlockID(batch, headID); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	return newChain(kv, genesisBlock)
+}
+
+func newChain(kv kv.GetPutter, genesisBlock *block.Block) (*Chain, error) {
 	if genesisBlock.Header().Number() != 0 {
 		return nil, errors.New("genesis number != 0")
 	}
@@ -54,10 +95,16 @@ func New(kv kv.GetPutter, genesisBlock *block.Block) (*Chain, error) {
 	var bestBlock *block.Block
 
 	genesisID := genesisBlock.Header().ID()
-	if bestBlockID, err := loadBestBlockID(kv); err != nil {
-		if !kv.IsNotFound(err) {
-			return nil, err
-		}
+	bestBlockID, err := loadBestBlockID(kv)
+	if err != nil && !kv.IsNotFound(err) {
+		return nil, err
+	}
+	fresh := err != nil
+	if err := migrate(kv, fresh); err != nil {
+		return nil, err
+	}
+
+	if fresh {
 		// no genesis yet
 		raw, err := rlp.EncodeToBytes(genesisBlock)
 		if err != nil {
@@ -100,6 +147,26 @@ func New(kv kv.GetPutter, genesisBlock *block.Block) (*Chain, error) {
 		}
 	}
 
+	finalized := genesisBlock.Header()
+	if finalizedID, err := loadFinalizedBlockID(kv); err != nil {
+		if !kv.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		raw, err := loadBlockRaw(kv, finalizedID)
+		if err != nil {
+			return nil, err
+		}
+		if finalized, err = (&rawBlock{raw: raw}).Header(); err != nil {
+			return nil, err
+		}
+	}
+
+	prunedBelow, err := loadPrunedBelow(kv)
+	if err != nil && !kv.IsNotFound(err) {
+		return nil, err
+	}
+
 	rawBlocksCache := newCache(blockCacheLimit, func(key interface{}) (interface{}, error) {
 		raw, err := loadBlockRaw(kv, key.(thor.Bytes32))
 		if err != nil {
@@ -112,17 +179,27 @@ func New(kv kv.GetPutter, genesisBlock *block.Block) (*Chain, error) {
 		return loadBlockReceipts(kv, key.(thor.Bytes32))
 	})
 
-	return &Chain{
+	c := &Chain{
 		kv:           kv,
 		ancestorTrie: ancestorTrie,
 		genesisBlock: genesisBlock,
 		bestBlock:    bestBlock,
+		finalized:    finalized,
+		prunedBelow:  prunedBelow,
 		tag:          genesisBlock.Header().ID()[31],
 		caches: caches{
 			rawBlocks: rawBlocksCache,
 			receipts:  receiptsCache,
 		},
-	}, nil
+	}
+	c.caches.summaries = newCache(summaryCacheLimit, func(key interface{}) (interface{}, error) {
+		blk, err := c.getBlock(key.(thor.Bytes32))
+		if err != nil {
+			return nil, err
+		}
+		return newBlockSummary(blk), nil
+	})
+	return c, nil
 }
 
 // Tag returns chain tag, which is the last byte of genesis id.
@@ -181,6 +258,10 @@ func (c *Chain) AddBlock(newBlock *block.Block, receipts tx.Receipts) (*Fork, er
 	if err := saveBlockReceipts(c.kv, newBlockID, receipts); err != nil {
 		return nil, err
 	}
+	bloom := receipts.Bloom()
+	if err := saveBlockBloom(batch, newBlockID, &bloom); err != nil {
+		return nil, err
+	}
 
 	if err := c.ancestorTrie.Update(batch, newBlockID, newBlock.Header().ParentID()); err != nil {
 		return nil, err
@@ -204,7 +285,10 @@ func (c *Chain) AddBlock(newBlock *block.Block, receipts tx.Receipts) (*Fork, er
 	}
 
 	var fork *Fork
-	isTrunk := c.isTrunk(newBlock.Header())
+	isTrunk, err := c.isTrunk(newBlock.Header())
+	if err != nil {
+		return nil, err
+	}
 	if isTrunk {
 		if fork, err = c.buildFork(newBlock.Header(), c.bestBlock.Header()); err != nil {
 			return nil, err
@@ -222,13 +306,172 @@ func (c *Chain) AddBlock(newBlock *block.Block, receipts tx.Receipts) (*Fork, er
 
 	if isTrunk {
 		c.bestBlock = newBlock
+		if c.metered {
+			bestBlockGauge.Set(float64(newBlock.Header().Number()))
+		}
+		// blocks switched off trunk are unlikely to be queried again
+		for _, header := range fork.Branch {
+			c.caches.summaries.Remove(header.ID())
+		}
 	}
 
 	c.caches.rawBlocks.Add(newBlockID, newRawBlock(raw, newBlock))
 	c.caches.receipts.Add(newBlockID, receipts)
+	c.caches.summaries.Add(newBlockID, newBlockSummary(newBlock))
+	if isTrunk {
+		c.tick.Broadcast()
+	}
 	return fork, nil
 }
 
+// Rewind deletes trunk blocks above the trunk block with given id, which becomes the best block.
+// It's used to recover from a partially imported head. The finalized block can't be rewound.
+func (c *Chain) Rewind(id thor.Bytes32) error {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	header, err := c.getBlockHeader(id)
+	if err != nil {
+		return err
+	}
+	if header.Number() < c.finalized.Number() {
+		return errors.New("rewind below finalized block")
+	}
+	best := c.bestBlock.Header()
+	trunkID, err := c.ancestorTrie.GetAncestor(best.ID(), header.Number())
+	if err != nil {
+		return err
+	}
+	if trunkID != id {
+		return errors.New("block not on trunk")
+	}
+
+	batch := c.kv.NewBatch()
+	var deleted []thor.Bytes32
+	for h := best; h.Number() > header.Number(); {
+		summary, err := c.getBlockSummary(h.ID())
+		if err != nil {
+			return err
+		}
+		for _, txID := range summary.Txs {
+			meta, err := loadTxMeta(c.kv, txID)
+			if err != nil {
+				if !c.IsNotFound(err) {
+					return err
+				}
+			}
+			remained := meta[:0]
+			for _, m := range meta {
+				if m.BlockID != h.ID() {
+					remained = append(remained, m)
+				}
+			}
+			if len(remained) > 0 {
+				err = saveTxMeta(batch, txID, remained)
+			} else {
+				err = batch.Delete(append(txMetaPrefix, txID.Bytes()...))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		for _, prefix := range [][]byte{blockPrefix, blockReceiptsPrefix, receiptExtrasPrefix, blockBloomPrefix, indexTrieRootPrefix} {
+			if err := batch.Delete(append(append([]byte(nil), prefix...), h.ID().Bytes()...)); err != nil {
+				return err
+			}
+		}
+		deleted = append(deleted, h.ID())
+		if h, err = c.getBlockHeader(h.ParentID()); err != nil {
+			return err
+		}
+	}
+	if err := saveBestBlockID(batch, id); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	for _, id := range deleted {
+		c.caches.rawBlocks.Remove(id)
+		c.caches.receipts.Remove(id)
+		c.caches.summaries.Remove(id)
+		c.ancestorTrie.rootsCache.Remove(id)
+	}
+	if c.bestBlock, err = c.getBlock(id); err != nil {
+		return err
+	}
+	if c.metered {
+		bestBlockGauge.Set(float64(header.Number()))
+	}
+	c.tick.Broadcast()
+	return nil
+}
+
+// NewTicker create a signal Waiter to receive event that the best block changed.
+func (c *Chain) NewTicker() co.Waiter {
+	return c.tick.NewWaiter()
+}
+
+// FinalizedBlock returns header of the finalized block, which is irreversible.
+// It's the genesis block if no block finalized yet.
+func (c *Chain) FinalizedBlock() *block.Header {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.finalized
+}
+
+// SetFinalized marks the trunk block with given id as finalized.
+// Blocks finalized can't be switched off trunk any more.
+func (c *Chain) SetFinalized(id thor.Bytes32) error {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	header, err := c.getBlockHeader(id)
+	if err != nil {
+		return err
+	}
+	if header.Number() <= c.finalized.Number() {
+		return errors.New("finalized block not ascending")
+	}
+	trunkID, err := c.ancestorTrie.GetAncestor(c.bestBlock.Header().ID(), header.Number())
+	if err != nil {
+		return err
+	}
+	if trunkID != id {
+		return errors.New("block not on trunk")
+	}
+	if err := saveFinalizedBlockID(c.kv, id); err != nil {
+		return err
+	}
+	c.finalized = header
+	return nil
+}
+
+// PrunedBelow returns the number of block, states of blocks below which were pruned, except those
+// retained, e.g. the finalized block. It's 0 if states were never pruned, as archive nodes do.
+func (c *Chain) PrunedBelow() uint32 {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.prunedBelow
+}
+
+// SetPrunedBelow records that states of blocks below the number were pruned.
+// It's called by the pruner once sweeping completed.
+func (c *Chain) SetPrunedBelow(num uint32) error {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	if num < c.prunedBelow {
+		return errors.New("pruned block number not ascending")
+	}
+	if err := savePrunedBelow(c.kv, num); err != nil {
+		return err
+	}
+	c.prunedBelow = num
+	return nil
+}
+
 // GetBlockHeader get block header by block id.
 func (c *Chain) GetBlockHeader(id thor.Bytes32) (*block.Header, error) {
 	c.rw.RLock()
@@ -262,6 +505,21 @@ func (c *Chain) GetBlockRaw(id thor.Bytes32) (block.Raw, error) {
 	return raw.raw, nil
 }
 
+// GetBlockSummary get block summary by block id.
+func (c *Chain) GetBlockSummary(id thor.Bytes32) (*BlockSummary, error) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.getBlockSummary(id)
+}
+
+// GetBlockBloom get logs bloom of block by block id.
+// The bloom is built over addresses and topics of events in the block.
+func (c *Chain) GetBlockBloom(id thor.Bytes32) (*thor.Bloom, error) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.getBlockBloom(id)
+}
+
 // GetAncestorBlockID get ancestor block ID of descendant for given ancestor block.
 func (c *Chain) GetAncestorBlockID(descendantID thor.Bytes32, ancestorNum uint32) (thor.Bytes32, error) {
 	c.rw.RLock()
@@ -297,6 +555,13 @@ func (c *Chain) GetTransactionReceipt(blockID thor.Bytes32, index uint64) (*tx.R
 	return receipts[index], nil
 }
 
+// GetBlockReceipts get all tx receipts in the block for given block id.
+func (c *Chain) GetBlockReceipts(id thor.Bytes32) (tx.Receipts, error) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.getBlockReceipts(id)
+}
+
 // GetTrunkBlockID get block id on trunk by given block number.
 func (c *Chain) GetTrunkBlockID(num uint32) (thor.Bytes32, error) {
 	c.rw.RLock()
@@ -341,6 +606,17 @@ func (c *Chain) GetTrunkBlockRaw(num uint32) (block.Raw, error) {
 	return raw.raw, nil
 }
 
+// GetTrunkBlockSummary get block summary on trunk by given block number.
+func (c *Chain) GetTrunkBlockSummary(num uint32) (*BlockSummary, error) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	id, err := c.ancestorTrie.GetAncestor(c.bestBlock.Header().ID(), num)
+	if err != nil {
+		return nil, err
+	}
+	return c.getBlockSummary(id)
+}
+
 // GetTrunkTransactionMeta get transaction meta info on trunk by given tx id.
 func (c *Chain) GetTrunkTransactionMeta(txID thor.Bytes32) (*TxMeta, error) {
 	c.rw.RLock()
@@ -368,24 +644,36 @@ func (c *Chain) NewSeeker(headBlockID thor.Bytes32) *Seeker {
 	return newSeeker(c, headBlockID)
 }
 
-func (c *Chain) isTrunk(header *block.Header) bool {
+func (c *Chain) isTrunk(header *block.Header) (bool, error) {
 	bestHeader := c.bestBlock.Header()
 
 	if header.TotalScore() < bestHeader.TotalScore() {
-		return false
+		return false, nil
+	}
+
+	// a branch not containing the finalized block never becomes trunk
+	if header.Number() <= c.finalized.Number() {
+		return false, nil
+	}
+	finalizedID, err := c.ancestorTrie.GetAncestor(header.ParentID(), c.finalized.Number())
+	if err != nil {
+		return false, err
+	}
+	if finalizedID != c.finalized.ID() {
+		return false, nil
 	}
 
 	if header.TotalScore() > bestHeader.TotalScore() {
-		return true
+		return true, nil
 	}
 
 	// total scores are equal
 	if bytes.Compare(header.ID().Bytes(), bestHeader.ID().Bytes()) < 0 {
 		// smaller ID is preferred, since block with smaller ID usually has larger average score.
 		// also, it's a deterministic decision.
-		return true
+		return true, nil
 	}
-	return false
+	return false, nil
 }
 
 // Think about the example below:
@@ -475,6 +763,18 @@ func (c *Chain) getBlock(id thor.Bytes32) (*block.Block, error) {
 	return raw.Block()
 }
 
+func (c *Chain) getBlockSummary(id thor.Bytes32) (*BlockSummary, error) {
+	summary, err := c.caches.summaries.GetOrLoad(id)
+	if err != nil {
+		return nil, err
+	}
+	return summary.(*BlockSummary), nil
+}
+
+func (c *Chain) getBlockBloom(id thor.Bytes32) (*thor.Bloom, error) {
+	return loadBlockBloom(c.kv, id)
+}
+
 func (c *Chain) getBlockReceipts(blockID thor.Bytes32) (tx.Receipts, error) {
 	receipts, err := c.caches.receipts.GetOrLoad(blockID)
 	if err != nil {
@@ -514,6 +814,24 @@ func (c *Chain) getTransaction(blockID thor.Bytes32, index uint64) (*tx.Transact
 	return body.Txs[index], nil
 }
 
+// EachIndexTrieRoot calls fn with the root of block number index trie of each block stored,
+// including blocks not on trunk.
+func (c *Chain) EachIndexTrieRoot(fn func(root thor.Bytes32) error) error {
+	it := c.kv.NewIterator(*kv.NewRangeWithBytesPrefix(indexTrieRootPrefix))
+	defer it.Release()
+
+	for it.Next() {
+		// trie nodes and codes share the kv, keyed by 32 bytes hash, which may have the prefix
+		if len(it.Key()) != len(indexTrieRootPrefix)+32 || len(it.Value()) != 32 {
+			continue
+		}
+		if err := fn(thor.BytesToBytes32(it.Value())); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 // IsNotFound returns if an error means not found.
 func (c *Chain) IsNotFound(err error) bool {
 	return err == errNotFound || c.kv.IsNotFound(err)
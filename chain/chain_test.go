@@ -6,6 +6,7 @@
 package chain_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -15,6 +16,8 @@ import (
 	"github.com/vechain/thor/genesis"
 	"github.com/vechain/thor/lvldb"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
 )
 
 func initChain() *chain.Chain {
@@ -74,3 +77,252 @@ func TestAdd(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockSummary(t *testing.T) {
+	ch := initChain()
+	b0 := ch.GenesisBlock()
+	b1 := newBlock(b0, 1)
+	b2 := newBlock(b1, 1)
+	b2x := newBlock(b1, 2)
+
+	for _, b := range []*block.Block{b1, b2, b2x} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+
+	s, err := ch.GetBlockSummary(b2.Header().ID())
+	assert.Nil(t, err)
+	assert.Equal(t, b2.Header().ID(), s.Header.ID())
+	assert.Equal(t, b2.Header().ReceiptsRoot(), s.ReceiptsRoot())
+	assert.Equal(t, 0, len(s.Txs))
+
+	s, err = ch.GetTrunkBlockSummary(2)
+	assert.Nil(t, err)
+	assert.Equal(t, b2x.Header().ID(), s.Header.ID())
+
+	s, err = ch.GetTrunkBlockSummary(0)
+	assert.Nil(t, err)
+	assert.Equal(t, b0.Header().ID(), s.Header.ID())
+}
+
+func TestReceiptExtras(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(kv))
+	ch, _ := chain.New(kv, b0)
+
+	receipts := tx.Receipts{{
+		Paid:    big.NewInt(1),
+		Reward:  big.NewInt(1),
+		Outputs: []*tx.Output{{GasUsed: 100, Data: []byte{1}}},
+	}}
+	b1 := newBlock(b0, 1)
+	if _, err := ch.AddBlock(b1, receipts); err != nil {
+		t.Fatal(err)
+	}
+
+	// reopen to bypass cache
+	ch, _ = chain.New(kv, b0)
+	loaded, err := ch.GetBlockReceipts(b1.Header().ID())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(100), loaded[0].Outputs[0].GasUsed)
+	assert.Equal(t, []byte{1}, loaded[0].Outputs[0].Data)
+	assert.Equal(t, receipts.RootHash(), loaded.RootHash())
+}
+
+func TestVerifyTrunk(t *testing.T) {
+	ch := initChain()
+	b0 := ch.GenesisBlock()
+
+	build := func(parent *block.Block, receiptsRoot thor.Bytes32) *block.Block {
+		b := new(block.Builder).
+			ParentID(parent.Header().ID()).
+			TotalScore(parent.Header().TotalScore() + 1).
+			ReceiptsRoot(receiptsRoot).
+			Build()
+		sig, _ := crypto.Sign(b.Header().SigningHash().Bytes(), privateKey)
+		return b.WithSignature(sig)
+	}
+
+	b1 := build(b0, tx.Receipts(nil).RootHash())
+	b2 := build(b1, thor.Bytes32{})
+	for _, b := range []*block.Block{b1, b2} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+
+	err := ch.VerifyTrunk(0, nil, nil)
+	assert.True(t, chain.IsCorrupt(err))
+	assert.Equal(t, uint32(2), err.(*chain.CorruptError).Number)
+
+	err = ch.VerifyTrunk(0, func(thor.Bytes32) (bool, error) { return false, nil }, nil)
+	assert.True(t, chain.IsCorrupt(err))
+	assert.Equal(t, uint32(0), err.(*chain.CorruptError).Number)
+}
+
+func TestSchemaVersion(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(kv))
+
+	_, err := chain.New(kv, b0)
+	assert.Nil(t, err)
+
+	// reopen
+	_, err = chain.New(kv, b0)
+	assert.Nil(t, err)
+
+	// created before versioning
+	kv.Delete([]byte("schema"))
+	_, err = chain.New(kv, b0)
+	assert.Nil(t, err)
+	ver, _ := kv.Get([]byte("schema"))
+	assert.Equal(t, []byte{0, 0, 0, 1}, ver)
+
+	// written by newer code
+	kv.Put([]byte("schema"), []byte{0, 0, 0xff, 0xff})
+	_, err = chain.New(kv, b0)
+	assert.NotNil(t, err)
+}
+
+func TestEachIndexTrieRoot(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(kv))
+	ch, _ := chain.New(kv, b0)
+
+	b1 := newBlock(b0, 1)
+	b1x := newBlock(b0, 2)
+	for _, b := range []*block.Block{b1, b1x} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+
+	roots := func() (roots []thor.Bytes32) {
+		assert.Nil(t, ch.EachIndexTrieRoot(func(root thor.Bytes32) error {
+			roots = append(roots, root)
+			return nil
+		}))
+		return
+	}
+	expected := roots()
+	assert.Equal(t, 3, len(expected))
+
+	// a trie node keyed by hash having the same prefix
+	node := thor.Blake2b([]byte("node"))
+	node[0] = 'i'
+	kv.Put(node[:], []byte("node data"))
+	assert.Equal(t, expected, roots())
+}
+
+func TestFinalized(t *testing.T) {
+	ch := initChain()
+	b0 := ch.GenesisBlock()
+	assert.Equal(t, b0.Header().ID(), ch.FinalizedBlock().ID())
+
+	b1 := newBlock(b0, 1)
+	b2 := newBlock(b1, 1)
+	// a sibling of b1, with different timestamp to be distinct
+	b1x := new(block.Builder).ParentID(b0.Header().ID()).TotalScore(b0.Header().TotalScore() + 1).Timestamp(1).Build()
+	sig, _ := crypto.Sign(b1x.Header().SigningHash().Bytes(), privateKey)
+	b1x = b1x.WithSignature(sig)
+	for _, b := range []*block.Block{b1, b2, b1x} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, b2.Header().ID(), ch.BestBlock().Header().ID())
+
+	assert.NotNil(t, ch.SetFinalized(b1x.Header().ID()), "not on trunk")
+	assert.Nil(t, ch.SetFinalized(b1.Header().ID()))
+	assert.NotNil(t, ch.SetFinalized(b1.Header().ID()), "not ascending")
+	assert.Equal(t, b1.Header().ID(), ch.FinalizedBlock().ID())
+
+	// a heavier branch not containing the finalized block can't become trunk
+	b2x := newBlock(b1x, 10)
+	fork, err := ch.AddBlock(b2x, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(fork.Trunk))
+	assert.Equal(t, b2.Header().ID(), ch.BestBlock().Header().ID())
+
+	b3 := newBlock(b2, 1)
+	fork, err = ch.AddBlock(b3, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(fork.Trunk))
+}
+
+func TestPrunedBelow(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(kv))
+
+	ch, err := chain.New(kv, b0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), ch.PrunedBelow())
+
+	assert.Nil(t, ch.SetPrunedBelow(10))
+	assert.NotNil(t, ch.SetPrunedBelow(9), "not ascending")
+	assert.Equal(t, uint32(10), ch.PrunedBelow())
+
+	// reloaded
+	ch, err = chain.New(kv, b0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(10), ch.PrunedBelow())
+}
+
+func TestTicker(t *testing.T) {
+	ch := initChain()
+	b0 := ch.GenesisBlock()
+	b1 := newBlock(b0, 2)
+	b1x := newBlock(b0, 1)
+
+	ticker := ch.NewTicker()
+	if _, err := ch.AddBlock(b1, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ticker.C():
+	default:
+		t.Fatal("should tick when best block changed")
+	}
+
+	ticker = ch.NewTicker()
+	if _, err := ch.AddBlock(b1x, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ticker.C():
+		t.Fatal("should not tick when branch block added")
+	default:
+	}
+}
+
+func TestRewind(t *testing.T) {
+	ch := initChain()
+	b0 := ch.GenesisBlock()
+	b1 := newBlock(b0, 1)
+	b2 := newBlock(b1, 1)
+	b3 := newBlock(b2, 1)
+	for _, b := range []*block.Block{b1, b2, b3} {
+		_, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, ch.SetFinalized(b1.Header().ID()))
+
+	assert.NotNil(t, ch.Rewind(b0.Header().ID()), "below finalized")
+	assert.NotNil(t, ch.Rewind(newBlock(b1, 2).Header().ID()), "not stored")
+
+	assert.Nil(t, ch.Rewind(b1.Header().ID()))
+	assert.Equal(t, b1.Header().ID(), ch.BestBlock().Header().ID())
+	_, err := ch.GetBlockHeader(b2.Header().ID())
+	assert.True(t, ch.IsNotFound(err))
+	_, err = ch.GetTrunkBlockID(2)
+	assert.True(t, ch.IsNotFound(err))
+
+	// rewound blocks can be added again
+	for _, b := range []*block.Block{b2, b3} {
+		fork, err := ch.AddBlock(b, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(fork.Trunk))
+	}
+	assert.Equal(t, b3.Header().ID(), ch.BestBlock().Header().ID())
+}
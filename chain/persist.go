@@ -15,10 +15,14 @@ import (
 
 var (
 	bestBlockKey        = []byte("best")
+	finalizedBlockKey   = []byte("finalized")
+	prunedBelowKey      = []byte("prunedBelow")
 	blockPrefix         = []byte("b") // (prefix, block id) -> block
 	txMetaPrefix        = []byte("t") // (prefix, tx id) -> tx location
 	blockReceiptsPrefix = []byte("r") // (prefix, block id) -> receipts
+	receiptExtrasPrefix = []byte("x") // (prefix, block id) -> non-consensus fields of receipt outputs
 	indexTrieRootPrefix = []byte("i") // (prefix, block id) -> trie root
+	blockBloomPrefix    = []byte("l") // (prefix, block id) -> logs bloom
 )
 
 // TxMeta contains information about a tx is settled.
@@ -61,6 +65,34 @@ func saveBestBlockID(w kv.Putter, id thor.Bytes32) error {
 	return w.Put(bestBlockKey, id[:])
 }
 
+// loadFinalizedBlockID returns the finalized block ID.
+func loadFinalizedBlockID(r kv.Getter) (thor.Bytes32, error) {
+	data, err := r.Get(finalizedBlockKey)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return thor.BytesToBytes32(data), nil
+}
+
+// saveFinalizedBlockID save the finalized block ID.
+func saveFinalizedBlockID(w kv.Putter, id thor.Bytes32) error {
+	return w.Put(finalizedBlockKey, id[:])
+}
+
+// loadPrunedBelow returns the number of block, states of blocks below which were pruned.
+func loadPrunedBelow(r kv.Getter) (uint32, error) {
+	var num uint32
+	if err := loadRLP(r, prunedBelowKey, &num); err != nil {
+		return 0, err
+	}
+	return num, nil
+}
+
+// savePrunedBelow save the number of block, states of blocks below which were pruned.
+func savePrunedBelow(w kv.Putter, num uint32) error {
+	return saveRLP(w, prunedBelowKey, num)
+}
+
 // loadBlockRaw load rlp encoded block raw data.
 func loadBlockRaw(r kv.Getter, id thor.Bytes32) (block.Raw, error) {
 	return r.Get(append(blockPrefix, id[:]...))
@@ -99,16 +131,69 @@ func loadTxMeta(r kv.Getter, txID thor.Bytes32) ([]TxMeta, error) {
 	return meta, nil
 }
 
+// outputExtra fields of receipt output out of the consensus encoding.
+type outputExtra struct {
+	GasUsed uint64
+	Data    []byte
+}
+
 // saveBlockReceipts save tx receipts of a block.
+// Receipts are saved in the consensus encoding, with other fields of outputs saved aside.
 func saveBlockReceipts(w kv.Putter, blockID thor.Bytes32, receipts tx.Receipts) error {
-	return saveRLP(w, append(blockReceiptsPrefix, blockID[:]...), receipts)
+	if err := saveRLP(w, append(blockReceiptsPrefix, blockID[:]...), receipts); err != nil {
+		return err
+	}
+	extras := make([][]outputExtra, 0, len(receipts))
+	for _, receipt := range receipts {
+		outputs := make([]outputExtra, 0, len(receipt.Outputs))
+		for _, o := range receipt.Outputs {
+			outputs = append(outputs, outputExtra{o.GasUsed, o.Data})
+		}
+		extras = append(extras, outputs)
+	}
+	return saveRLP(w, append(receiptExtrasPrefix, blockID[:]...), extras)
 }
 
 // loadBlockReceipts load tx receipts of a block.
+// Extra fields of outputs are left empty for receipts saved without them.
 func loadBlockReceipts(r kv.Getter, blockID thor.Bytes32) (tx.Receipts, error) {
 	var receipts tx.Receipts
 	if err := loadRLP(r, append(blockReceiptsPrefix, blockID[:]...), &receipts); err != nil {
 		return nil, err
 	}
+	var extras [][]outputExtra
+	if err := loadRLP(r, append(receiptExtrasPrefix, blockID[:]...), &extras); err != nil {
+		if r.IsNotFound(err) {
+			return receipts, nil
+		}
+		return nil, err
+	}
+	for i, receipt := range receipts {
+		if i >= len(extras) {
+			break
+		}
+		for j, o := range receipt.Outputs {
+			if j >= len(extras[i]) {
+				break
+			}
+			o.GasUsed = extras[i][j].GasUsed
+			o.Data = extras[i][j].Data
+		}
+	}
 	return receipts, nil
 }
+
+// saveBlockBloom save logs bloom of a block.
+func saveBlockBloom(w kv.Putter, blockID thor.Bytes32, bloom *thor.Bloom) error {
+	return w.Put(append(blockBloomPrefix, blockID[:]...), bloom.Bytes())
+}
+
+// loadBlockBloom load logs bloom of a block.
+func loadBlockBloom(r kv.Getter, blockID thor.Bytes32) (*thor.Bloom, error) {
+	data, err := r.Get(append(blockBloomPrefix, blockID[:]...))
+	if err != nil {
+		return nil, err
+	}
+	bloom := thor.BytesToBloom(data)
+	return &bloom, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/kv"
+)
+
+// SchemaVersion is the version of db layout written by this code.
+// Bump it and append a migration when the layout changes.
+const SchemaVersion = 1
+
+var schemaVersionKey = []byte("schema")
+
+// migration upgrades db from version (index + 1) to version (index + 2).
+type migration func(kv kv.GetPutter) error
+
+// migrations to be appended once the layout changes.
+var migrations = []migration{}
+
+// loadSchemaVersion returns the recorded schema version.
+// For db without version record, 0 is returned.
+func loadSchemaVersion(r kv.Getter) (uint32, error) {
+	data, err := r.Get(schemaVersionKey)
+	if err != nil {
+		if r.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(data) != 4 {
+		return 0, errors.New("malformed schema version")
+	}
+	return binary.BigEndian.Uint32(data), nil
+}
+
+// saveSchemaVersion records the schema version.
+func saveSchemaVersion(w kv.Putter, ver uint32) error {
+	var data [4]byte
+	binary.BigEndian.PutUint32(data[:], ver)
+	return w.Put(schemaVersionKey, data[:])
+}
+
+// migrate upgrades db to the current schema version.
+// It refuses to work with db written by newer code.
+func migrate(kv kv.GetPutter, fresh bool) error {
+	if fresh {
+		return saveSchemaVersion(kv, SchemaVersion)
+	}
+
+	ver, err := loadSchemaVersion(kv)
+	if err != nil {
+		return err
+	}
+	if ver > SchemaVersion {
+		return errors.Errorf("db schema version %v is newer than supported %v", ver, SchemaVersion)
+	}
+	if ver == 0 {
+		// db created before versioning was introduced, whose layout is of version 1
+		ver = 1
+		if err := saveSchemaVersion(kv, ver); err != nil {
+			return err
+		}
+	}
+	for ; ver < SchemaVersion; ver++ {
+		if err := migrations[ver-1](kv); err != nil {
+			return errors.Wrapf(err, "migrate db schema to version %v", ver+1)
+		}
+		if err := saveSchemaVersion(kv, ver+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+// CorruptError describes the first corrupt block found while verifying.
+type CorruptError struct {
+	Number uint32
+	ID     thor.Bytes32
+	Reason string
+}
+
+func (e *CorruptError) Error() string {
+	return fmt.Sprintf("corrupt block #%v %v: %v", e.Number, e.ID, e.Reason)
+}
+
+// IsCorrupt returns if the error is a CorruptError.
+func IsCorrupt(err error) bool {
+	_, ok := err.(*CorruptError)
+	return ok
+}
+
+// VerifyTrunk re-checks blocks on trunk in range [from, best].
+// Every stored block is re-hashed, parent linkage and tx/receipt roots are recomputed.
+// If hasRoot is not nil, it's used to check availability of state root.
+// A *CorruptError is returned to report the first corrupt block.
+// progress, if not nil, is called after each block verified.
+func (c *Chain) VerifyTrunk(from uint32, hasRoot func(root thor.Bytes32) (bool, error), progress func(num uint32)) error {
+	c.rw.RLock()
+	bestID := c.bestBlock.Header().ID()
+	c.rw.RUnlock()
+
+	var parent *block.Header
+	if from > 0 {
+		parentID, err := c.GetAncestorBlockID(bestID, from-1)
+		if err != nil {
+			return err
+		}
+		if parent, err = c.GetBlockHeader(parentID); err != nil {
+			return err
+		}
+	}
+
+	for num := from; num <= block.Number(bestID); num++ {
+		id, err := c.GetAncestorBlockID(bestID, num)
+		if err != nil {
+			return err
+		}
+		header, err := c.verifyBlock(id, parent, hasRoot)
+		if err != nil {
+			return err
+		}
+		parent = header
+		if progress != nil {
+			progress(num)
+		}
+	}
+	return nil
+}
+
+func (c *Chain) verifyBlock(id thor.Bytes32, parent *block.Header, hasRoot func(thor.Bytes32) (bool, error)) (*block.Header, error) {
+	corrupt := func(reason string) error {
+		return &CorruptError{block.Number(id), id, reason}
+	}
+
+	// decode from the stored raw data, bypass caches
+	raw, err := loadBlockRaw(c.kv, id)
+	if err != nil {
+		if c.kv.IsNotFound(err) {
+			return nil, corrupt("block missing")
+		}
+		return nil, err
+	}
+	var blk block.Block
+	if err := rlp.DecodeBytes(raw, &blk); err != nil {
+		return nil, corrupt("decode: " + err.Error())
+	}
+	header := blk.Header()
+
+	if header.ID() != id {
+		return nil, corrupt(fmt.Sprintf("id mismatch: have %v", header.ID()))
+	}
+
+	if parent == nil {
+		if id != c.genesisBlock.Header().ID() {
+			return nil, corrupt("genesis mismatch")
+		}
+	} else if header.ParentID() != parent.ID() {
+		return nil, corrupt(fmt.Sprintf("parent mismatch: want %v, have %v", parent.ID(), header.ParentID()))
+	}
+
+	if root := blk.Transactions().RootHash(); root != header.TxsRoot() {
+		return nil, corrupt(fmt.Sprintf("txs root mismatch: want %v, have %v", header.TxsRoot(), root))
+	}
+
+	if parent != nil {
+		receipts, err := loadBlockReceipts(c.kv, id)
+		if err != nil {
+			if c.kv.IsNotFound(err) {
+				return nil, corrupt("receipts missing")
+			}
+			return nil, err
+		}
+		if root := receipts.RootHash(); root != header.ReceiptsRoot() {
+			return nil, corrupt(fmt.Sprintf("receipts root mismatch: want %v, have %v", header.ReceiptsRoot(), root))
+		}
+	}
+
+	if hasRoot != nil {
+		has, err := hasRoot(header.StateRoot())
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, corrupt(fmt.Sprintf("state root %v missing", header.StateRoot()))
+		}
+	}
+	return header, nil
+}
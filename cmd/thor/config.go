@@ -0,0 +1,164 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	cli "gopkg.in/urfave/cli.v1"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// envPrefix prefix of environment variables to set flags, e.g. THOR_API_ADDR for --api-addr.
+const envPrefix = "THOR_"
+
+func flagEnvName(name string) string {
+	return envPrefix + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+// applyConfig sets flags not given in command line, from environment variables and then
+// the config file. So the precedence is command line > environment variable > config file > default value.
+func applyConfig(ctx *cli.Context, flags []cli.Flag) error {
+	values := make(map[string]string)
+	if path := ctx.String(configFileFlag.Name); path != "" {
+		var err error
+		if values, err = loadConfigFile(path); err != nil {
+			fatal(fmt.Sprintf("load config file [%v]: %v", path, err))
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, flag := range flags {
+		name := strings.TrimSpace(strings.Split(flag.GetName(), ",")[0])
+		if name == configFileFlag.Name {
+			continue
+		}
+		known[name] = true
+		if ctx.IsSet(name) {
+			continue
+		}
+		value, ok := os.LookupEnv(flagEnvName(name))
+		if !ok {
+			if value, ok = values[name]; !ok {
+				continue
+			}
+		}
+		if err := ctx.Set(name, value); err != nil {
+			fatal(fmt.Sprintf("set flag --%v: %v", name, err))
+		}
+	}
+	for name := range values {
+		if !known[name] {
+			fatal(fmt.Sprintf("load config file: unknown option '%v'", name))
+		}
+	}
+	return nil
+}
+
+// loadConfigFile loads a TOML or YAML file, and flattens it into flag values.
+// Keys of tables are joined with '-', e.g. 'addr' in table 'api' is for --api-addr.
+func loadConfigFile(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		var m map[string]interface{}
+		if _, err := toml.Decode(string(data), &m); err != nil {
+			return nil, err
+		}
+		doc = m
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unsupported file type, should be .toml, .yaml or .yml")
+	}
+
+	values := make(map[string]string)
+	if err := flattenConfig("", doc, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func flattenConfig(key string, v interface{}, values map[string]string) error {
+	join := func(k string) string {
+		if key == "" {
+			return k
+		}
+		return key + "-" + k
+	}
+	switch v := v.(type) {
+	case nil:
+		if key != "" {
+			values[key] = ""
+		}
+	case map[string]interface{}:
+		for k, sub := range v {
+			if err := flattenConfig(join(k), sub, values); err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		for k, sub := range v {
+			sk, ok := k.(string)
+			if !ok {
+				return errors.Errorf("invalid key %v", k)
+			}
+			if err := flattenConfig(join(sk), sub, values); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		// lists are joined into comma separated values
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := configScalar(key, item)
+			if err != nil {
+				return err
+			}
+			items = append(items, s)
+		}
+		values[key] = strings.Join(items, ",")
+	default:
+		if key == "" {
+			return errors.New("should be a table")
+		}
+		s, err := configScalar(key, v)
+		if err != nil {
+			return err
+		}
+		values[key] = s
+	}
+	return nil
+}
+
+func configScalar(key string, v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int64, uint64:
+		return fmt.Sprint(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", errors.Errorf("invalid value of %v", key)
+	}
+}
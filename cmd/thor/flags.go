@@ -7,13 +7,19 @@ package main
 
 import (
 	"github.com/inconshreveable/log15"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
 	cli "gopkg.in/urfave/cli.v1"
 )
 
 var (
+	configFileFlag = cli.StringFlag{
+		Name:  "config",
+		Usage: "path of TOML or YAML config file, whose options are named as flags",
+	}
 	networkFlag = cli.StringFlag{
 		Name:  "network",
-		Usage: "the network to join (test)",
+		Usage: "the network to join (test) or path to JSON genesis file of a private network",
 	}
 	configDirFlag = cli.StringFlag{
 		Name:   "config-dir",
@@ -40,11 +46,75 @@ var (
 		Value: "",
 		Usage: "comma separated list of domains from which to accept cross origin requests to API",
 	}
+	apiEthFlag = cli.BoolFlag{
+		Name:  "api-eth",
+		Usage: "enable Ethereum compatible JSON-RPC at /eth of API service",
+	}
+	apiGraphQLFlag = cli.BoolFlag{
+		Name:  "api-graphql",
+		Usage: "enable GraphQL queries at /graphql of API service",
+	}
+	apiTxPoolFlag = cli.BoolFlag{
+		Name:  "api-txpool",
+		Usage: "enable tx pool inspection at /txpool of API service (always available on admin service)",
+	}
+	apiAllowedPathsFlag = cli.StringFlag{
+		Name:  "api-allowed-paths",
+		Usage: "comma separated list of path prefixes allowed to access API, e.g. /accounts,/blocks (all allowed if empty)",
+	}
+	apiDeniedPathsFlag = cli.StringFlag{
+		Name:  "api-denied-paths",
+		Usage: "comma separated list of path prefixes denied to access API, e.g. /debug",
+	}
+	apiKeysFlag = cli.StringFlag{
+		Name:  "api-keys",
+		Usage: "comma separated list of API keys, requests without a valid key in X-Api-Key header or apikey query are rejected (no authentication if empty)",
+	}
+	apiRateLimitFlag = cli.Float64Flag{
+		Name:  "api-rate-limit",
+		Usage: "maximum requests per second to API from each IP (0 for no limit)",
+	}
+	apiKeyRateLimitFlag = cli.Float64Flag{
+		Name:  "api-key-rate-limit",
+		Usage: "maximum requests per second to API with each API key (0 for no limit)",
+	}
+	apiRateBurstFlag = cli.IntFlag{
+		Name:  "api-rate-burst",
+		Usage: "maximum requests to API in burst (defaults to the rate limit if 0)",
+	}
+	apiLogsLimitFlag = cli.Uint64Flag{
+		Name:  "api-logs-limit",
+		Value: 1000,
+		Usage: "maximum number of results returned by an event or transfer query, larger result sets are paginated by cursor",
+	}
 	verbosityFlag = cli.IntFlag{
 		Name:  "verbosity",
 		Value: int(log15.LvlInfo),
 		Usage: "log verbosity (0-9)",
 	}
+	logLevelsFlag = cli.StringFlag{
+		Name:  "log-levels",
+		Usage: "comma separated list of subsystem log levels overriding verbosity, e.g. comm=debug,txpool=warn",
+	}
+	logFormatFlag = cli.StringFlag{
+		Name:  "log-format",
+		Value: "console",
+		Usage: "log output format (console|json)",
+	}
+	logFileFlag = cli.StringFlag{
+		Name:  "log-file",
+		Usage: "path of log file, which is rotated by size (written to stderr if empty)",
+	}
+	logFileMaxSizeFlag = cli.IntFlag{
+		Name:  "log-file-max-size",
+		Value: 100,
+		Usage: "size in MB to rotate the log file at",
+	}
+	logFileMaxBackupsFlag = cli.IntFlag{
+		Name:  "log-file-max-backups",
+		Value: 10,
+		Usage: "number of rotated log files to keep",
+	}
 
 	maxPeersFlag = cli.IntFlag{
 		Name:  "max-peers",
@@ -58,15 +128,207 @@ var (
 	}
 	natFlag = cli.StringFlag{
 		Name:  "nat",
-		Value: "none",
-		Usage: "port mapping mechanism (any|none|upnp|pmp|extip:<IP>)",
+		Value: "any",
+		Usage: "port mapping mechanism (any|none|upnp|pmp|extip:<IP>), set to none to disable, e.g. in datacenter",
+	}
+	bootnodeFlag = cli.StringFlag{
+		Name:  "bootnode",
+		Usage: "comma separated list of bootstrap node enode URLs, to replace the built-in ones",
+	}
+	staticPeersFlag = cli.StringFlag{
+		Name:  "static-peers",
+		Usage: "comma separated list of enode URLs, which are always connected",
+	}
+	trustedPeersFlag = cli.StringFlag{
+		Name:  "trusted-peers",
+		Usage: "comma separated list of enode URLs, which are allowed to connect above the peer limit",
+	}
+	adminAddrFlag = cli.StringFlag{
+		Name:  "admin-addr",
+		Usage: "admin API service listening address, should never be exposed publicly (disabled if empty)",
+	}
+	adminTokenFlag = cli.StringFlag{
+		Name:  "admin-token",
+		Usage: "bearer token to authenticate admin API requests (loaded or generated in config dir if empty)",
+	}
+	pprofAddrFlag = cli.StringFlag{
+		Name:  "pprof-addr",
+		Usage: "pprof service listening address, requests authenticated by admin token, should never be exposed publicly (disabled if empty)",
+	}
+	pprofFlag = cli.BoolFlag{
+		Name:  "pprof",
+		Usage: "enable profiling on startup, which can also be switched by admin API",
+	}
+	metricsAddrFlag = cli.StringFlag{
+		Name:  "metrics-addr",
+		Usage: "metrics service listening address, to expose metrics at /metrics in Prometheus format (disabled if empty)",
 	}
 	onDemandFlag = cli.BoolFlag{
 		Name:  "on-demand",
 		Usage: "create new block when there is pending transaction",
 	}
+	blockIntervalFlag = cli.Uint64Flag{
+		Name:  "block-interval",
+		Usage: "interval in seconds to pack blocks, ignored if on demand (0 to pack only by POST /solo/mine of API)",
+		Value: thor.BlockInterval,
+	}
 	persistFlag = cli.BoolFlag{
 		Name:  "persist",
 		Usage: "blockchain data storage option, if setted data will be saved to disk",
 	}
+	sandboxFlag = cli.BoolFlag{
+		Name:  "sandbox",
+		Usage: "enable API to fork the chain into disposable in-memory sandboxes",
+	}
+	txPoolLimitFlag = cli.IntFlag{
+		Name:  "txpool-limit",
+		Value: txpool.DefaultPoolConfig.PoolSize,
+		Usage: "maximum number of transactions kept in tx pool",
+	}
+	txPoolLimitPerAccountFlag = cli.IntFlag{
+		Name:  "txpool-limit-per-account",
+		Value: int(txpool.DefaultPoolConfig.LimitPerAccount),
+		Usage: "maximum number of transactions each account can keep in tx pool",
+	}
+	txPoolLifetimeFlag = cli.DurationFlag{
+		Name:  "txpool-lifetime",
+		Value: txpool.DefaultPoolConfig.Lifetime,
+		Usage: "maximum time a non-executable transaction is kept in tx pool",
+	}
+	txPoolMinGasPriceFlag = cli.StringFlag{
+		Name:  "txpool-min-gas-price",
+		Usage: "minimum gas price in wei of transactions accepted by tx pool (no limit if empty)",
+	}
+	txPoolAllowedSendersFlag = cli.StringFlag{
+		Name:  "txpool-allowed-senders",
+		Usage: "comma separated list of addresses, only transactions from which are accepted by tx pool (all allowed if empty)",
+	}
+	txPoolDeniedSendersFlag = cli.StringFlag{
+		Name:  "txpool-denied-senders",
+		Usage: "comma separated list of addresses, transactions from which are rejected by tx pool",
+	}
+	txPoolMaxClauseDataFlag = cli.IntFlag{
+		Name:  "txpool-max-clause-data",
+		Usage: "maximum size in bytes of clause data of transactions accepted by tx pool (0 for no limit)",
+	}
+	txPoolContractCreationLimitFlag = cli.IntFlag{
+		Name:  "txpool-contract-creation-limit",
+		Usage: "maximum number of contract creating transactions accepted by tx pool per minute (0 for no limit)",
+	}
+	packTxOrderFlag = cli.StringFlag{
+		Name:  "pack-tx-order",
+		Value: "gasprice",
+		Usage: "order of txs to be packed (gasprice|arrival)",
+	}
+	packTxLimitPerOriginFlag = cli.IntFlag{
+		Name:  "pack-tx-limit-per-origin",
+		Usage: "maximum number of transactions packed into a block from each origin (0 for no limit)",
+	}
+	fastSyncFlag = cli.StringFlag{
+		Name:  "fast-sync",
+		Usage: "trusted block id to fast sync to, blocks before it are not executed",
+	}
+	modeFlag = cli.StringFlag{
+		Name:  "mode",
+		Value: "archive",
+		Usage: "node mode (archive|full|light), full nodes prune states of old blocks at startup, light nodes follow headers from --light-backend",
+	}
+	stateRetainFlag = cli.IntFlag{
+		Name:  "state-retain",
+		Value: 360,
+		Usage: "number of latest blocks whose states are retained in full mode",
+	}
+	lightBackendFlag = cli.StringFlag{
+		Name:  "light-backend",
+		Usage: "API URL of the full node which light mode syncs headers from",
+	}
+	verifyFromFlag = cli.IntFlag{
+		Name:  "from",
+		Usage: "block number to start verification from",
+	}
+	verifyStateFlag = cli.BoolFlag{
+		Name:  "state",
+		Usage: "also check availability of state root of each block",
+	}
+	reindexFromFlag = cli.IntFlag{
+		Name:  "from",
+		Usage: "block number to rebuild logs from",
+	}
+	replayFromFlag = cli.IntFlag{
+		Name:  "from",
+		Value: 1,
+		Usage: "block number to replay from",
+	}
+	replayToFlag = cli.IntFlag{
+		Name:  "to",
+		Usage: "block number to replay to (0 for the best block)",
+	}
+	replayBisectFlag = cli.BoolFlag{
+		Name:  "bisect",
+		Usage: "pinpoint the first divergent tx of the divergent block",
+	}
+	blockFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "path of block file",
+	}
+	exportFromFlag = cli.IntFlag{
+		Name:  "from",
+		Value: 1,
+		Usage: "block number to export from, ignored when resuming an existing file",
+	}
+	exportToFlag = cli.IntFlag{
+		Name:  "to",
+		Usage: "block number to export to (0 for the best block)",
+	}
+	importTrustedFlag = cli.BoolFlag{
+		Name:  "trusted",
+		Usage: "skip validation of block headers and bodies, txs are still executed to rebuild state",
+	}
+	pruneRetainFlag = cli.IntFlag{
+		Name:  "retain",
+		Value: 360,
+		Usage: "number of latest blocks whose states are retained",
+	}
+	stateFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "path of state snapshot file",
+	}
+	exportStateBlockFlag = cli.IntFlag{
+		Name:  "block",
+		Usage: "number of trunk block of the state (0 for the best block)",
+	}
+	migrateLayoutFlag = cli.StringFlag{
+		Name:  "layout",
+		Value: "unified",
+		Usage: "trie layout to migrate the state into (legacy|unified)",
+	}
+	migrateOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "directory of the database to write the migrated state into",
+	}
+	masterAddressFlag = cli.StringFlag{
+		Name:  "master-address",
+		Usage: "address of the master key in keystore, required if more than one key",
+	}
+	masterPasswordFileFlag = cli.StringFlag{
+		Name:  "master-password-file",
+		Usage: "file containing passphrase of the master key, prompted in terminal if not set",
+	}
+	signerURLFlag = cli.StringFlag{
+		Name:  "signer-url",
+		Usage: "URL of remote block signer holding the master key, https://host[/path] or unix:///path/to/socket (master key loaded locally if empty)",
+	}
+	signerTokenFileFlag = cli.StringFlag{
+		Name:  "signer-token-file",
+		Usage: "file containing bearer token to authenticate requests to remote block signer",
+	}
+	signerRateLimitFlag = cli.Float64Flag{
+		Name:  "signer-rate-limit",
+		Value: 1,
+		Usage: "maximum sign requests per second to remote block signer (0 for no limit)",
+	}
+	keyFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "path of keystore or raw key file to import, defaults to the raw master key file in config dir",
+	}
 )
@@ -6,17 +6,37 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/gorilla/mux"
 	"github.com/inconshreveable/log15"
 	"github.com/vechain/thor/api"
+	"github.com/vechain/thor/api/profiling"
+	"github.com/vechain/thor/api/sandboxes"
+	"github.com/vechain/thor/bft"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/blockfile"
+	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/cmd/thor/node"
 	"github.com/vechain/thor/cmd/thor/solo"
+	"github.com/vechain/thor/consensus"
+	"github.com/vechain/thor/evidence"
 	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/replay"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/statefile"
+	"github.com/vechain/thor/statemigrate"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
 	"github.com/vechain/thor/txpool"
 	cli "gopkg.in/urfave/cli.v1"
 )
@@ -43,32 +63,246 @@ func main() {
 		Usage:     "Node of VeChain Thor Network",
 		Copyright: "2018 VeChain Foundation <https://vechain.org/>",
 		Flags: []cli.Flag{
+			configFileFlag,
 			networkFlag,
 			configDirFlag,
 			dataDirFlag,
 			beneficiaryFlag,
+			masterAddressFlag,
+			masterPasswordFileFlag,
+			signerURLFlag,
+			signerTokenFileFlag,
+			signerRateLimitFlag,
 			apiAddrFlag,
 			apiCorsFlag,
+			apiEthFlag,
+			apiGraphQLFlag,
+			apiTxPoolFlag,
+			apiAllowedPathsFlag,
+			apiDeniedPathsFlag,
+			apiKeysFlag,
+			apiRateLimitFlag,
+			apiKeyRateLimitFlag,
+			apiRateBurstFlag,
+			apiLogsLimitFlag,
 			verbosityFlag,
+			logLevelsFlag,
+			logFormatFlag,
+			logFileFlag,
+			logFileMaxSizeFlag,
+			logFileMaxBackupsFlag,
 			maxPeersFlag,
 			p2pPortFlag,
 			natFlag,
+			bootnodeFlag,
+			staticPeersFlag,
+			trustedPeersFlag,
+			adminAddrFlag,
+			adminTokenFlag,
+			pprofAddrFlag,
+			pprofFlag,
+			metricsAddrFlag,
+			txPoolLimitFlag,
+			txPoolLimitPerAccountFlag,
+			txPoolLifetimeFlag,
+			txPoolMinGasPriceFlag,
+			txPoolAllowedSendersFlag,
+			txPoolDeniedSendersFlag,
+			txPoolMaxClauseDataFlag,
+			txPoolContractCreationLimitFlag,
+			packTxOrderFlag,
+			packTxLimitPerOriginFlag,
+			fastSyncFlag,
+			modeFlag,
+			stateRetainFlag,
+			lightBackendFlag,
+		},
+		Before: func(ctx *cli.Context) error {
+			return applyConfig(ctx, ctx.App.Flags)
 		},
 		Action: defaultAction,
 		Commands: []cli.Command{
+			{
+				Name:  "master-key",
+				Usage: "manage master keys in keystore",
+				Subcommands: []cli.Command{
+					{
+						Name:   "new",
+						Usage:  "create a new master key",
+						Flags:  []cli.Flag{configDirFlag, masterPasswordFileFlag},
+						Action: masterKeyNewAction,
+					},
+					{
+						Name:   "import",
+						Usage:  "import a keystore or raw key file",
+						Flags:  []cli.Flag{configDirFlag, keyFileFlag, masterPasswordFileFlag},
+						Action: masterKeyImportAction,
+					},
+					{
+						Name:   "export",
+						Usage:  "print the keystore file of a master key, which is still encrypted",
+						Flags:  []cli.Flag{configDirFlag, masterAddressFlag},
+						Action: masterKeyExportAction,
+					},
+					{
+						Name:   "list",
+						Usage:  "list master keys",
+						Flags:  []cli.Flag{configDirFlag},
+						Action: masterKeyListAction,
+					},
+				},
+			},
 			{
 				Name:  "solo",
 				Usage: "VeChain Thor client for test & dev",
 				Flags: []cli.Flag{
+					configFileFlag,
 					dataDirFlag,
 					apiAddrFlag,
 					apiCorsFlag,
+					apiEthFlag,
+					apiGraphQLFlag,
+					apiTxPoolFlag,
+					apiAllowedPathsFlag,
+					apiDeniedPathsFlag,
+					apiKeysFlag,
+					apiRateLimitFlag,
+					apiKeyRateLimitFlag,
+					apiRateBurstFlag,
+					apiLogsLimitFlag,
+					metricsAddrFlag,
 					onDemandFlag,
+					blockIntervalFlag,
 					persistFlag,
+					sandboxFlag,
+					txPoolLimitFlag,
+					txPoolLimitPerAccountFlag,
+					txPoolLifetimeFlag,
+					txPoolMinGasPriceFlag,
+					txPoolAllowedSendersFlag,
+					txPoolDeniedSendersFlag,
+					txPoolMaxClauseDataFlag,
+					txPoolContractCreationLimitFlag,
 					verbosityFlag,
+					logLevelsFlag,
+					logFormatFlag,
+					logFileFlag,
+					logFileMaxSizeFlag,
+					logFileMaxBackupsFlag,
+				},
+				Before: func(ctx *cli.Context) error {
+					return applyConfig(ctx, ctx.Command.Flags)
 				},
 				Action: soloAction,
 			},
+			{
+				Name:  "verify",
+				Usage: "verify integrity of block-chain database",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					verifyFromFlag,
+					verifyStateFlag,
+					verbosityFlag,
+				},
+				Action: verifyAction,
+			},
+			{
+				Name:  "replay",
+				Usage: "re-execute blocks upon states of their parents, and compare results against headers",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					replayFromFlag,
+					replayToFlag,
+					replayBisectFlag,
+					verbosityFlag,
+				},
+				Action: replayAction,
+			},
+			{
+				Name:  "reindex",
+				Usage: "rebuild log database from block-chain data",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					reindexFromFlag,
+					verbosityFlag,
+				},
+				Action: reindexAction,
+			},
+			{
+				Name:  "export",
+				Usage: "export blocks and receipts into a block file, resuming if the file exists",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					blockFileFlag,
+					exportFromFlag,
+					exportToFlag,
+					verbosityFlag,
+				},
+				Action: exportAction,
+			},
+			{
+				Name:  "import",
+				Usage: "import blocks from a block file, skipping blocks already in chain",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					blockFileFlag,
+					importTrustedFlag,
+					verbosityFlag,
+				},
+				Action: importAction,
+			},
+			{
+				Name:  "prune",
+				Usage: "delete states not retained from block-chain database, should be done with node stopped",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					pruneRetainFlag,
+					verbosityFlag,
+				},
+				Action: pruneAction,
+			},
+			{
+				Name:  "export-state",
+				Usage: "export snapshot of the full state at a block",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					stateFileFlag,
+					exportStateBlockFlag,
+					verbosityFlag,
+				},
+				Action: exportStateAction,
+			},
+			{
+				Name:  "import-state",
+				Usage: "import state snapshot into a fresh node, which then fast syncs to the block of snapshot",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					stateFileFlag,
+					verbosityFlag,
+				},
+				Action: importStateAction,
+			},
+			{
+				Name:  "migrate-state",
+				Usage: "rewrite the full state at a block into another trie layout, and verify equivalence",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					exportStateBlockFlag,
+					migrateLayoutFlag,
+					migrateOutFlag,
+					verbosityFlag,
+				},
+				Action: migrateStateAction,
+			},
 		},
 	}
 
@@ -81,10 +315,18 @@ func main() {
 func defaultAction(ctx *cli.Context) error {
 	defer func() { log.Info("exited") }()
 
-	initLogger(ctx)
+	logFilter := initLogger(ctx)
 	gene := selectGenesis(ctx)
+	mode := nodeMode(ctx)
+	exitSignal := handleExitSignal()
+	if mode == lightMode {
+		return runLightNode(ctx, exitSignal, gene)
+	}
 	instanceDir := makeInstanceDir(ctx, gene)
 
+	crashed, unmarkRunning := markRunning(instanceDir)
+	defer unmarkRunning()
+
 	mainDB := openMainDB(ctx, instanceDir)
 	defer func() { log.Info("closing main database..."); mainDB.Close() }()
 
@@ -92,21 +334,71 @@ func defaultAction(ctx *cli.Context) error {
 	defer func() { log.Info("closing log database..."); logDB.Close() }()
 
 	chain := initChain(gene, mainDB, logDB)
+	if crashed {
+		recoverChain(chain, state.NewCreator(mainDB), logDB)
+	}
+	if !applyMode(ctx, exitSignal, mode, chain, mainDB, instanceDir) {
+		return nil
+	}
+	snaps := openSnapshots(chain, mainDB)
+	defer func() {
+		log.Info("closing state snapshots...")
+		if err := snaps.Close(chain.BestBlock().Header().StateRoot()); err != nil {
+			log.Warn("failed to persist state snapshots", "err", err)
+		}
+	}()
+	stateCreator := state.NewCreator(mainDB).WithSnapshots(snaps).WithWriteSets(state.NewWriteSets())
+
 	master := loadNodeMaster(ctx)
+	evidenceDB := openEvidenceDB(instanceDir)
+	defer func() { log.Info("closing evidence database..."); evidenceDB.Close() }()
+	evidenceStore := evidence.New(evidenceDB, chain.GetBlockHeader)
 
-	txPool := txpool.New(chain, state.NewCreator(mainDB))
+	txPool := txpool.New(chain, stateCreator, txPoolConfig(ctx, filepath.Join(instanceDir, "txpool.journal")), gene.ForkConfig())
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
-	p2pcom := startP2PComm(ctx, chain, txPool, instanceDir)
+	p2pcom := startP2PComm(ctx, chain, txPool, mainDB, instanceDir)
 	defer p2pcom.Shutdown()
 
-	apiSrv, apiURL := startAPIServer(ctx, api.New(chain, state.NewCreator(mainDB), txPool, logDB, p2pcom.comm))
-	defer func() { log.Info("stopping API server..."); apiSrv.Shutdown(context.Background()) }()
+	apiHandler, apiCloser := api.New(chain, stateCreator, txPool, logDB, p2pcom.comm, evidenceStore, gene.ForkConfig(), strings.Split(ctx.String(apiCorsFlag.Name), ","), ctx.Uint64(apiLogsLimitFlag.Name), ctx.Bool(apiEthFlag.Name), ctx.Bool(apiGraphQLFlag.Name), ctx.Bool(apiTxPoolFlag.Name))
+	defer func() { log.Info("closing API..."); apiCloser() }()
 
-	printStartupMessage(gene, chain, master, instanceDir, apiURL)
+	apiSrv, apiURL := startAPIServer(ctx, apiHandler)
+	profiler, err := profiling.New(profiling.Config{Enabled: ctx.Bool(pprofFlag.Name)})
+	if err != nil {
+		fatal("init profiler:", err)
+	}
+	adminSrv, adminURL := startAdminServer(ctx, api.NewAdmin(p2pcom.p2pSrv, p2pcom.comm, chain, txPool, logDB, logFilter, profiler, fullVersion()))
+	if adminSrv != nil {
+		log.Info("admin API service started", "url", adminURL)
+	}
+	pprofSrv, pprofURL := startPprofServer(ctx, profiler)
+	if pprofSrv != nil {
+		log.Info("pprof service started", "url", pprofURL, "enabled", profiler.Config().Enabled)
+	}
 
-	return node.New(master, chain, state.NewCreator(mainDB), logDB, txPool, p2pcom.comm).
-		Run(handleExitSignal())
+	if metricsSrv, metricsURL := startMetricsServer(ctx); metricsSrv != nil {
+		log.Info("metrics service started", "url", metricsURL)
+		defer func() { shutdownServer("metrics", metricsSrv) }()
+	}
+
+	printStartupMessage(gene, chain, master, instanceDir, apiURL, p2pcom.p2pSrv.Self().String())
+
+	// API requests are refused once exit signal received, while the node is drained
+	defer stopOnExit(exitSignal, func() {
+		shutdownServer("API", apiSrv)
+		if adminSrv != nil {
+			shutdownServer("admin API", adminSrv)
+		}
+		if pprofSrv != nil {
+			shutdownServer("pprof", pprofSrv)
+		}
+	})()
+
+	fastSync(ctx, exitSignal, p2pcom.comm)
+
+	return node.New(master, chain, stateCreator, logDB, txPool, p2pcom.comm, evidenceStore, txSelector(ctx), gene.ForkConfig()).
+		Run(exitSignal)
 }
 
 func soloAction(ctx *cli.Context) error {
@@ -119,8 +411,12 @@ func soloAction(ctx *cli.Context) error {
 	var logDB *logdb.LogDB
 	var instanceDir string
 
+	var crashed bool
 	if ctx.Bool("persist") {
 		instanceDir = makeInstanceDir(ctx, gene)
+		var unmarkRunning func()
+		crashed, unmarkRunning = markRunning(instanceDir)
+		defer unmarkRunning()
 		mainDB = openMainDB(ctx, instanceDir)
 		logDB = openLogDB(ctx, instanceDir)
 	} else {
@@ -133,16 +429,538 @@ func soloAction(ctx *cli.Context) error {
 	defer func() { log.Info("closing log database..."); logDB.Close() }()
 
 	chain := initChain(gene, mainDB, logDB)
+	stateCreator := state.NewCreator(mainDB).WithWriteSets(state.NewWriteSets())
+	if crashed {
+		recoverChain(chain, stateCreator, logDB)
+	}
 
-	txPool := txpool.New(chain, state.NewCreator(mainDB))
+	var journal string
+	if ctx.Bool("persist") {
+		journal = filepath.Join(instanceDir, "txpool.journal")
+	}
+	txPool := txpool.New(chain, stateCreator, txPoolConfig(ctx, journal), gene.ForkConfig())
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
-	soloContext := solo.New(chain, state.NewCreator(mainDB), logDB, txPool, ctx.Bool("on-demand"))
+	blockInterval := ctx.Uint64(blockIntervalFlag.Name)
+	soloContext := solo.New(chain, stateCreator, logDB, txPool, ctx.Bool("on-demand"), time.Duration(blockInterval)*time.Second, gene.ForkConfig())
 
-	apiSrv, apiURL := startAPIServer(ctx, api.New(chain, state.NewCreator(mainDB), txPool, logDB, solo.Communicator{}))
-	defer func() { log.Info("stopping API server..."); apiSrv.Shutdown(context.Background()) }()
+	apiHandler, apiCloser := api.New(chain, stateCreator, txPool, logDB, solo.Communicator{}, evidence.New(openMemMainDB(), chain.GetBlockHeader), gene.ForkConfig(), strings.Split(ctx.String(apiCorsFlag.Name), ","), ctx.Uint64(apiLogsLimitFlag.Name), ctx.Bool(apiEthFlag.Name), ctx.Bool(apiGraphQLFlag.Name), ctx.Bool(apiTxPoolFlag.Name))
+	defer func() { log.Info("closing API..."); apiCloser() }()
+
+	// solo API to pack blocks on demand, along with the common API
+	router := mux.NewRouter()
+	soloContext.Mount(router, "/solo")
+	if ctx.Bool(sandboxFlag.Name) {
+		sandboxAPI := sandboxes.New(mainDB, chain, stateCreator, gene.ForkConfig())
+		defer func() { log.Info("closing sandboxes..."); sandboxAPI.Close() }()
+		sandboxAPI.Mount(router, "/sandboxes")
+	}
+	router.PathPrefix("/").Handler(apiHandler)
+
+	apiSrv, apiURL := startAPIServer(ctx, router)
+
+	if metricsSrv, metricsURL := startMetricsServer(ctx); metricsSrv != nil {
+		log.Info("metrics service started", "url", metricsURL)
+		defer func() { shutdownServer("metrics", metricsSrv) }()
+	}
 
 	printSoloStartupMessage(gene, chain, instanceDir, apiURL)
 
-	return soloContext.Run(handleExitSignal())
+	exitSignal := handleExitSignal()
+	defer stopOnExit(exitSignal, func() { shutdownServer("API", apiSrv) })()
+
+	return soloContext.Run(exitSignal)
+}
+
+func verifyAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	stateCreator := state.NewCreator(mainDB)
+	genesisBlock, _, err := gene.Build(stateCreator)
+	if err != nil {
+		fatal("build genesis block: ", err)
+	}
+	ch, err := chain.New(mainDB, genesisBlock)
+	if err != nil {
+		fatal("initialize block chain:", err)
+	}
+
+	var hasRoot func(thor.Bytes32) (bool, error)
+	if ctx.Bool(verifyStateFlag.Name) {
+		hasRoot = stateCreator.HasRoot
+	}
+
+	best := ch.BestBlock().Header().Number()
+	log.Info("start verifying", "from", ctx.Int(verifyFromFlag.Name), "to", best)
+
+	startTime := mclock.Now()
+	if err := ch.VerifyTrunk(uint32(ctx.Int(verifyFromFlag.Name)), hasRoot, func(num uint32) {
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("verifying", "num", num, "best", best)
+			startTime = mclock.Now()
+		}
+	}); err != nil {
+		if ce, ok := err.(*chain.CorruptError); ok {
+			fatal(fmt.Sprintf("first corrupt block found at #%v: %v", ce.Number, ce.Reason))
+		}
+		fatal("verify:", err)
+	}
+	log.Info("verification passed", "best", best)
+	return nil
+}
+
+func replayAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+
+	from, to := ctx.Int(replayFromFlag.Name), ctx.Int(replayToFlag.Name)
+	if from < 1 || to < 0 {
+		fatal("from should be positive, and to should not be negative")
+	}
+	if to == 0 {
+		to = int(chain.BestBlock().Header().Number())
+	}
+	if from > to {
+		log.Info("nothing to replay", "from", from, "to", to)
+		return nil
+	}
+	if prunedBelow := chain.PrunedBelow(); uint32(from) <= prunedBelow {
+		log.Warn("states may be pruned, replaying fails if state of parent missing", "prunedBelow", prunedBelow)
+	}
+	log.Info("start replaying", "from", from, "to", to, "bisect", ctx.Bool(replayBisectFlag.Name))
+
+	startTime := mclock.Now()
+	r := replay.New(chain, state.NewCreator(mainDB), gene.ForkConfig())
+	if err := r.Range(handleExitSignal(), uint32(from), uint32(to), ctx.Bool(replayBisectFlag.Name), func(num uint32) {
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("replaying", "num", num, "to", to)
+			startTime = mclock.Now()
+		}
+	}); err != nil {
+		if err == context.Canceled {
+			log.Warn("replaying interrupted")
+			return nil
+		}
+		if replay.IsDivergence(err) {
+			fatal("first divergent block found:", err)
+		}
+		fatal("replay:", err)
+	}
+	log.Info("replaying passed", "from", from, "to", to)
+	return nil
+}
+
+func reindexAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+
+	from := ctx.Int(reindexFromFlag.Name)
+	if from < 0 {
+		fatal("from should not be negative")
+	}
+	log.Info("start reindexing", "from", from, "to", chain.BestBlock().Header().Number())
+
+	startTime := mclock.Now()
+	if err := logDB.Reindex(handleExitSignal(), chain, uint32(from), func(num, target uint32) {
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("reindexing", "num", num, "target", target)
+			startTime = mclock.Now()
+		}
+	}); err != nil {
+		if err == context.Canceled {
+			log.Warn("reindexing interrupted, logs are incomplete until reindexed again")
+			return nil
+		}
+		fatal("reindex:", err)
+	}
+	log.Info("reindexing completed", "best", chain.BestBlock().Header().Number())
+	return nil
+}
+
+func exportAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+
+	from, to := ctx.Int(exportFromFlag.Name), ctx.Int(exportToFlag.Name)
+	if from < 0 || to < 0 {
+		fatal("from and to should not be negative")
+	}
+	if to == 0 {
+		to = int(chain.BestBlock().Header().Number())
+	}
+
+	file, last := openExportFile(ctx, chain)
+	defer file.Close()
+	if last != nil {
+		from = int(last.Number()) + 1
+		log.Info("resuming block file", "last", last.Number())
+	}
+	if from > to {
+		log.Info("nothing to export", "from", from, "to", to)
+		return nil
+	}
+	log.Info("start exporting", "from", from, "to", to)
+
+	bw := bufio.NewWriter(file)
+	startTime := mclock.Now()
+	err := blockfile.Export(handleExitSignal(), chain, blockfile.NewWriter(bw), uint32(from), uint32(to), func(num uint32) {
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("exporting", "num", num, "to", to)
+			startTime = mclock.Now()
+		}
+	})
+	// flushed anyway, so that blocks exported are kept for resuming
+	if err := bw.Flush(); err != nil {
+		fatal("write block file:", err)
+	}
+	if err != nil {
+		if err == context.Canceled {
+			log.Warn("exporting interrupted, run again to resume")
+			return nil
+		}
+		fatal("export:", err)
+	}
+	log.Info("exporting completed", "to", to)
+	return nil
+}
+
+func importAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+
+	path := ctx.String(blockFileFlag.Name)
+	if path == "" {
+		fatal("block file not specified")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		fatal("open block file:", err)
+	}
+	defer file.Close()
+
+	reader, err := blockfile.NewReader(file)
+	if err != nil {
+		fatal("read block file:", err)
+	}
+	if reader.GenesisID() != chain.GenesisBlock().Header().ID() {
+		fatal("block file of another network")
+	}
+
+	stateCreator := state.NewCreator(mainDB)
+	cons := consensus.New(chain, stateCreator, gene.ForkConfig())
+	bftEngine := bft.New(chain, stateCreator)
+	trusted := ctx.Bool(importTrustedFlag.Name)
+	exitSignal := handleExitSignal()
+
+	log.Info("start importing", "best", chain.BestBlock().Header().Number(), "trusted", trusted)
+	var imported, skipped int
+	startTime := mclock.Now()
+	for {
+		select {
+		case <-exitSignal.Done():
+			log.Warn("importing interrupted, run again to resume", "imported", imported)
+			return nil
+		default:
+		}
+
+		blk, _, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			fatal("read block file:", err)
+		}
+
+		header := blk.Header()
+		if _, err := chain.GetBlockHeader(header.ID()); err == nil {
+			skipped++
+			continue
+		} else if !chain.IsNotFound(err) {
+			fatal("get block header:", err)
+		}
+
+		var (
+			stage    *state.Stage
+			receipts tx.Receipts
+		)
+		if trusted {
+			stage, receipts, err = cons.Replay(blk)
+		} else {
+			stage, receipts, err = cons.Process(blk, uint64(time.Now().Unix()))
+		}
+		if err != nil {
+			fatal(fmt.Sprintf("import block #%v %v: %v", header.Number(), header.ID(), err))
+		}
+		if _, err := stage.Commit(); err != nil {
+			fatal("commit state:", err)
+		}
+		fork, err := chain.AddBlock(blk, receipts)
+		if err != nil {
+			fatal("add block:", err)
+		}
+		for _, h := range fork.Trunk {
+			if _, err := bftEngine.Process(h); err != nil {
+				log.Warn("failed to process finality", "err", err)
+			}
+		}
+		if err := node.WriteLogs(logDB, chain, blk, receipts, fork); err != nil {
+			fatal("commit logs:", err)
+		}
+		imported++
+
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("importing", "num", header.Number(), "imported", imported)
+			startTime = mclock.Now()
+		}
+	}
+	log.Info("importing completed", "imported", imported, "skipped", skipped, "best", chain.BestBlock().Header().Number())
+	return nil
+}
+
+func pruneAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+
+	retain := ctx.Int(pruneRetainFlag.Name)
+	if retain <= 0 {
+		fatal("retain should be positive")
+	}
+	pruneStates(handleExitSignal(), chain, mainDB, instanceDir, retain)
+	return nil
+}
+
+func exportStateAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+
+	path := ctx.String(stateFileFlag.Name)
+	if path == "" {
+		fatal("state snapshot file not specified")
+	}
+	header := stateBlockHeader(ctx, chain, mainDB)
+
+	file, err := os.Create(path)
+	if err != nil {
+		fatal("create state snapshot file:", err)
+	}
+	defer file.Close()
+
+	log.Info("start exporting state", "block", header.ID(), "num", header.Number(), "root", header.StateRoot())
+	bw := bufio.NewWriter(file)
+	startTime := mclock.Now()
+	if err := statefile.Export(handleExitSignal(), mainDB, bw, chain.GenesisBlock().Header().ID(), header, func(accounts int) {
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("exporting state", "accounts", accounts)
+			startTime = mclock.Now()
+		}
+	}); err != nil {
+		if err == context.Canceled {
+			log.Warn("exporting state interrupted, the snapshot file is incomplete")
+			return nil
+		}
+		fatal("export state:", err)
+	}
+	if err := bw.Flush(); err != nil {
+		fatal("write state snapshot file:", err)
+	}
+	log.Info("exporting state completed", "block", header.ID())
+	return nil
+}
+
+// stateBlockHeader returns header of the block specified by flag, whose state is available.
+func stateBlockHeader(ctx *cli.Context, chain *chain.Chain, mainDB *lvldb.LevelDB) *block.Header {
+	num := ctx.Int(exportStateBlockFlag.Name)
+	if num < 0 {
+		fatal("block should not be negative")
+	}
+	header := chain.BestBlock().Header()
+	if num > 0 {
+		var err error
+		if header, err = chain.GetTrunkBlockHeader(uint32(num)); err != nil {
+			fatal("get block header:", err)
+		}
+	}
+	if has, err := state.HasRoot(header.StateRoot(), mainDB); err != nil {
+		fatal("check state root:", err)
+	} else if !has {
+		fatal(fmt.Sprintf("state of block #%v not available", header.Number()))
+	}
+	return header
+}
+
+func importStateAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+
+	path := ctx.String(stateFileFlag.Name)
+	if path == "" {
+		fatal("state snapshot file not specified")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		fatal("open state snapshot file:", err)
+	}
+	defer file.Close()
+
+	reader, err := statefile.NewReader(bufio.NewReader(file))
+	if err != nil {
+		fatal("read state snapshot file:", err)
+	}
+	header := reader.Header()
+	if header.GenesisID != chain.GenesisBlock().Header().ID() {
+		fatal("state snapshot of another network")
+	}
+	if chain.BestBlock().Header().Number() >= header.Block.Number() {
+		fatal("chain already reaches the block of snapshot")
+	}
+
+	log.Info("start importing state", "block", header.Block.ID(), "num", header.Block.Number(), "root", header.Block.StateRoot())
+	startTime := mclock.Now()
+	if err := reader.Import(handleExitSignal(), mainDB, func(accounts int) {
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("importing state", "accounts", accounts)
+			startTime = mclock.Now()
+		}
+	}); err != nil {
+		if err == context.Canceled {
+			log.Warn("importing state interrupted, run again to import")
+			return nil
+		}
+		fatal("import state:", err)
+	}
+	log.Info("importing state completed, start the node with the block id as --fast-sync to sync from it", "block", header.Block.ID())
+	return nil
+}
+
+func migrateStateAction(ctx *cli.Context) error {
+	initLogger(ctx)
+	gene := selectGenesis(ctx)
+	instanceDir := makeInstanceDir(ctx, gene)
+
+	layout := statemigrate.LayoutByName(ctx.String(migrateLayoutFlag.Name))
+	if layout == nil {
+		fatal("unknown layout:", ctx.String(migrateLayoutFlag.Name))
+	}
+	out := ctx.String(migrateOutFlag.Name)
+	if out == "" {
+		fatal("output database directory not specified")
+	}
+
+	mainDB := openMainDB(ctx, instanceDir)
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	logDB := openLogDB(ctx, instanceDir)
+	defer func() { log.Info("closing log database..."); logDB.Close() }()
+
+	chain := initChain(gene, mainDB, logDB)
+	header := stateBlockHeader(ctx, chain, mainDB)
+
+	outDB, err := lvldb.New(out, lvldb.Options{
+		CacheSize:              128,
+		OpenFilesCacheCapacity: 64,
+	})
+	if err != nil {
+		fatal(fmt.Sprintf("open output database [%v]: %v", out, err))
+	}
+	defer func() { log.Info("closing output database..."); outDB.Close() }()
+
+	progress := func(action string) func(accounts int) {
+		startTime := mclock.Now()
+		return func(accounts int) {
+			if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+				log.Info(action+" state", "accounts", accounts)
+				startTime = mclock.Now()
+			}
+		}
+	}
+	exitSignal := handleExitSignal()
+
+	log.Info("start migrating state", "block", header.ID(), "num", header.Number(), "root", header.StateRoot(), "layout", layout.Name())
+	newRoot, err := statemigrate.Migrate(exitSignal, mainDB, outDB, header.StateRoot(), layout, progress("migrating"))
+	if err != nil {
+		if err == context.Canceled {
+			log.Warn("migrating state interrupted")
+			return nil
+		}
+		fatal("migrate state:", err)
+	}
+
+	log.Info("start verifying migrated state", "root", newRoot)
+	if err := statemigrate.Verify(exitSignal, mainDB, outDB, header.StateRoot(), layout, newRoot, progress("verifying")); err != nil {
+		if err == context.Canceled {
+			log.Warn("verifying state interrupted")
+			return nil
+		}
+		fatal("verify migrated state:", err)
+	}
+	log.Info("migrating state completed", "block", header.ID(), "layout", layout.Name(), "root", newRoot)
+	return nil
 }
@@ -0,0 +1,292 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/thor"
+	"golang.org/x/crypto/ssh/terminal"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+// legacyMasterKeyFile raw master key file in config dir, used before keystore introduced.
+const legacyMasterKeyFile = "master.key"
+
+// keystoreEntry an encrypted key file in keystore dir.
+type keystoreEntry struct {
+	path    string
+	address thor.Address
+}
+
+func makeKeystoreDir(ctx *cli.Context) string {
+	dir := filepath.Join(makeConfigDir(ctx), "keystore")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		fatal(fmt.Sprintf("create keystore dir [%v]: %v", dir, err))
+	}
+	return dir
+}
+
+// listKeystore lists key files in the dir, ordered by file name.
+// Files not in keystore format are ignored.
+func listKeystore(dir string) ([]*keystoreEntry, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*keystoreEntry
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var v struct {
+			Address string `json:"address"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			continue
+		}
+		addr, err := thor.ParseAddress(v.Address)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, &keystoreEntry{path, addr})
+	}
+	return entries, nil
+}
+
+// selectKeystore selects the entry of given address. The address can be omitted if only one entry.
+func selectKeystore(entries []*keystoreEntry, address string) (*keystoreEntry, error) {
+	if address == "" {
+		switch len(entries) {
+		case 0:
+			return nil, errors.New("no key in keystore")
+		case 1:
+			return entries[0], nil
+		default:
+			return nil, errors.Errorf("%v keys in keystore, select one by --%v", len(entries), masterAddressFlag.Name)
+		}
+	}
+	addr, err := thor.ParseAddress(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse address")
+	}
+	for _, entry := range entries {
+		if entry.address == addr {
+			return entry, nil
+		}
+	}
+	return nil, errors.Errorf("key of %v not in keystore", addr)
+}
+
+// saveKeystore encrypts the key into a new file in the dir, named as geth does.
+func saveKeystore(dir string, key *ecdsa.PrivateKey, passphrase string) (*keystoreEntry, error) {
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	data, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    addr,
+		PrivateKey: key,
+	}, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, err
+	}
+	return writeKeystore(dir, thor.Address(addr), data)
+}
+
+func writeKeystore(dir string, addr thor.Address, data []byte) (*keystoreEntry, error) {
+	entries, err := listKeystore(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.address == addr {
+			return nil, errors.Errorf("key of %v already in keystore", addr)
+		}
+	}
+	name := fmt.Sprintf("UTC--%v--%x", time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z"), addr[:])
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return nil, err
+	}
+	return &keystoreEntry{path, addr}, nil
+}
+
+func decryptKeystore(entry *keystoreEntry, passphrase string) (*ecdsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(entry.path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivateKey, nil
+}
+
+// readPassphrase reads passphrase from the password file if specified, or prompts in terminal.
+func readPassphrase(ctx *cli.Context, prompt string, confirm bool) string {
+	if path := ctx.String(masterPasswordFileFlag.Name); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			fatal("read password file:", err)
+		}
+		return strings.TrimRight(string(data), "\r\n")
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		fatal(fmt.Sprintf("passphrase required, use --%v if not in terminal", masterPasswordFileFlag.Name))
+	}
+	read := func(prompt string) string {
+		fmt.Fprint(os.Stderr, prompt)
+		defer fmt.Fprintln(os.Stderr)
+		passphrase, err := terminal.ReadPassword(fd)
+		if err != nil {
+			fatal("read passphrase:", err)
+		}
+		return string(passphrase)
+	}
+	passphrase := read(prompt)
+	if confirm && read("Repeat passphrase: ") != passphrase {
+		fatal("passphrases do not match")
+	}
+	return passphrase
+}
+
+// loadMasterKey loads the master key to produce blocks. The key is decrypted from keystore,
+// or loaded from the legacy raw key file if keystore is empty. A new key is created into
+// keystore if neither found.
+func loadMasterKey(ctx *cli.Context) *ecdsa.PrivateKey {
+	dir := makeKeystoreDir(ctx)
+	entries, err := listKeystore(dir)
+	if err != nil {
+		fatal("list keystore:", err)
+	}
+
+	if len(entries) == 0 {
+		legacy := filepath.Join(makeConfigDir(ctx), legacyMasterKeyFile)
+		if key, err := crypto.LoadECDSA(legacy); err == nil {
+			log.Warn("raw master key file in use, import it into keystore by 'thor master-key import'", "path", legacy)
+			return key
+		} else if !os.IsNotExist(err) {
+			fatal("load master key:", err)
+		}
+
+		log.Info("no master key found, creating a new one in keystore")
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			fatal("generate master key:", err)
+		}
+		entry, err := saveKeystore(dir, key, readPassphrase(ctx, "Passphrase of the new master key: ", true))
+		if err != nil {
+			fatal("save master key:", err)
+		}
+		log.Info("master key created", "address", entry.address, "path", entry.path)
+		return key
+	}
+
+	entry, err := selectKeystore(entries, ctx.String(masterAddressFlag.Name))
+	if err != nil {
+		fatal("select master key:", err)
+	}
+	key, err := decryptKeystore(entry, readPassphrase(ctx, fmt.Sprintf("Passphrase of master key %v: ", entry.address), false))
+	if err != nil {
+		fatal("decrypt master key:", err)
+	}
+	return key
+}
+
+func masterKeyNewAction(ctx *cli.Context) error {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		fatal("generate key:", err)
+	}
+	entry, err := saveKeystore(makeKeystoreDir(ctx), key, readPassphrase(ctx, "Passphrase: ", true))
+	if err != nil {
+		fatal("save key:", err)
+	}
+	fmt.Println(entry.address, entry.path)
+	return nil
+}
+
+// masterKeyImportAction imports a keystore file, or a raw key file which defaults to the legacy one.
+func masterKeyImportAction(ctx *cli.Context) error {
+	path := ctx.String(keyFileFlag.Name)
+	if path == "" {
+		path = filepath.Join(makeConfigDir(ctx), legacyMasterKeyFile)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		fatal("read key file:", err)
+	}
+
+	dir := makeKeystoreDir(ctx)
+	var entry *keystoreEntry
+	if json.Valid(data) {
+		// keystore file, kept encrypted as it is
+		key, err := keystore.DecryptKey(data, readPassphrase(ctx, "Passphrase of the keystore file: ", false))
+		if err != nil {
+			fatal("decrypt key file:", err)
+		}
+		if entry, err = writeKeystore(dir, thor.Address(key.Address), data); err != nil {
+			fatal("import key:", err)
+		}
+	} else {
+		key, err := crypto.LoadECDSA(path)
+		if err != nil {
+			fatal("load key file:", err)
+		}
+		if entry, err = saveKeystore(dir, key, readPassphrase(ctx, "Passphrase: ", true)); err != nil {
+			fatal("import key:", err)
+		}
+	}
+	fmt.Println(entry.address, entry.path)
+	return nil
+}
+
+// masterKeyExportAction writes the selected keystore file to stdout, still encrypted.
+func masterKeyExportAction(ctx *cli.Context) error {
+	entries, err := listKeystore(makeKeystoreDir(ctx))
+	if err != nil {
+		fatal("list keystore:", err)
+	}
+	entry, err := selectKeystore(entries, ctx.String(masterAddressFlag.Name))
+	if err != nil {
+		fatal("select key:", err)
+	}
+	data, err := ioutil.ReadFile(entry.path)
+	if err != nil {
+		fatal("read key file:", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func masterKeyListAction(ctx *cli.Context) error {
+	entries, err := listKeystore(makeKeystoreDir(ctx))
+	if err != nil {
+		fatal("list keystore:", err)
+	}
+	for _, entry := range entries {
+		fmt.Println(entry.address, entry.path)
+	}
+	return nil
+}
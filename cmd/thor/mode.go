@@ -0,0 +1,215 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/vechain/thor/bft"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lightclient"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/pruner"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+// node modes
+const (
+	archiveMode = "archive" // states of all blocks retained
+	fullMode    = "full"    // states of latest blocks, the finalized block and recent checkpoints retained
+	lightMode   = "light"   // headers followed from a full node, nothing stored
+)
+
+// pruneInterval number of blocks, about a day, accumulated beyond the retained ones before a full
+// node prunes again at startup, since each pruning sweeps the whole database.
+const pruneInterval = uint32(24 * 60 * 60 / thor.BlockInterval)
+
+func nodeMode(ctx *cli.Context) string {
+	switch mode := ctx.String(modeFlag.Name); mode {
+	case archiveMode, fullMode, lightMode:
+		return mode
+	default:
+		fatal(fmt.Sprintf("unknown node mode [%v], should be one of archive|full|light", mode))
+		return ""
+	}
+}
+
+// applyMode checks the database against the node mode, and prunes states for full mode if
+// enough blocks accumulated since the last pruning. It returns false if interrupted.
+func applyMode(ctx *cli.Context, exitSignal context.Context, mode string, chain *chain.Chain, mainDB *lvldb.LevelDB, instanceDir string) bool {
+	prunedBelow := chain.PrunedBelow()
+	if mode == archiveMode {
+		if prunedBelow > 0 {
+			fatal(fmt.Sprintf("states of blocks below #%v were pruned, archive mode is not possible, run with --%v %v or resync", prunedBelow, modeFlag.Name, fullMode))
+		}
+		return true
+	}
+
+	retain := ctx.Int(stateRetainFlag.Name)
+	if retain <= 0 {
+		fatal("state retain should be positive")
+	}
+	best := chain.BestBlock().Header().Number()
+	if best < uint32(retain) || best+1-uint32(retain) < prunedBelow+pruneInterval {
+		log.Debug("pruning skipped", "best", best, "prunedBelow", prunedBelow)
+		return true
+	}
+	log.Info("pruning states of blocks not retained, which may take a while", "retain", retain)
+	return pruneStates(exitSignal, chain, mainDB, instanceDir, retain)
+}
+
+// retainedBlocks returns numbers of blocks whose states are retained by pruning, i.e. the latest
+// blocks, the finalized block, and checkpoints to be evaluated by bft.
+func retainedBlocks(chain *chain.Chain, retain int) []uint32 {
+	best := chain.BestBlock().Header().Number()
+	retained := make(map[uint32]bool)
+	for i := 0; i < retain && uint32(i) <= best; i++ {
+		retained[best-uint32(i)] = true
+	}
+	retained[chain.FinalizedBlock().Number()] = true
+	checkpoint := best - best%bft.EpochLength
+	retained[checkpoint] = true
+	if checkpoint >= bft.EpochLength {
+		retained[checkpoint-bft.EpochLength] = true
+	}
+	nums := make([]uint32, 0, len(retained))
+	for num := range retained {
+		nums = append(nums, num)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	return nums
+}
+
+// pruneStates deletes states not retained from main db and compacts it, and records the pruned
+// block number into chain. It returns false if interrupted, when only unreachable entries deleted.
+func pruneStates(exitSignal context.Context, chain *chain.Chain, mainDB *lvldb.LevelDB, instanceDir string, retain int) bool {
+	nums := retainedBlocks(chain, retain)
+	best := chain.BestBlock().Header().Number()
+
+	stateCreator := state.NewCreator(mainDB)
+	p := pruner.New(mainDB)
+
+	log.Info("start marking retained states", "blocks", len(nums), "best", best)
+	startTime := mclock.Now()
+	for _, num := range nums {
+		select {
+		case <-exitSignal.Done():
+			log.Warn("pruning interrupted, nothing deleted")
+			return false
+		default:
+		}
+
+		header, err := chain.GetTrunkBlockHeader(num)
+		if err != nil {
+			fatal("get block header:", err)
+		}
+		if has, err := stateCreator.HasRoot(header.StateRoot()); err != nil {
+			fatal("check state root:", err)
+		} else if !has {
+			if num == best {
+				fatal("state of best block missing")
+			}
+			// e.g. blocks before fast sync pivot
+			log.Debug("state missing, skipped", "num", num)
+			continue
+		}
+		if err := p.RetainState(header.StateRoot()); err != nil {
+			fatal(fmt.Sprintf("mark state of block #%v: %v", num, err))
+		}
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("marking", "num", num, "marked", p.Marked())
+			startTime = mclock.Now()
+		}
+	}
+	if err := chain.EachIndexTrieRoot(p.RetainTrie); err != nil {
+		fatal("mark block number index tries:", err)
+	}
+	log.Info("marking completed", "marked", p.Marked())
+
+	// recorded before sweeping, since states might be partially deleted if interrupted
+	if best >= uint32(retain) {
+		if prunedBelow := best + 1 - uint32(retain); prunedBelow > chain.PrunedBelow() {
+			if err := chain.SetPrunedBelow(prunedBelow); err != nil {
+				fatal("record pruned block number:", err)
+			}
+		}
+	}
+
+	dbDir := filepath.Join(instanceDir, "main.db")
+	sizeBefore, err := dirSize(dbDir)
+	if err != nil {
+		fatal("get database size:", err)
+	}
+
+	stats, err := p.Sweep(exitSignal, func(stats *pruner.Stats) {
+		if mclock.Now()-startTime > mclock.AbsTime(time.Second*5) {
+			log.Info("sweeping", "deleted", stats.Deleted, "retained", stats.Retained)
+			startTime = mclock.Now()
+		}
+	})
+	if err != nil {
+		if err == context.Canceled {
+			log.Warn("pruning interrupted, run again to complete")
+			return false
+		}
+		fatal("sweep:", err)
+	}
+	log.Info("sweeping completed", "deleted", stats.Deleted, "size", common.StorageSize(stats.DeletedBytes))
+
+	log.Info("compacting main database...")
+	if err := mainDB.Compact(); err != nil {
+		fatal("compact:", err)
+	}
+	sizeAfter, err := dirSize(dbDir)
+	if err != nil {
+		fatal("get database size:", err)
+	}
+	log.Info("pruning completed", "before", common.StorageSize(sizeBefore), "after", common.StorageSize(sizeAfter), "reclaimed", common.StorageSize(sizeBefore-sizeAfter))
+	return true
+}
+
+// runLightNode follows the chain by headers from the full node at the light backend URL, starting
+// from the genesis block. No database is written, and no API is served.
+func runLightNode(ctx *cli.Context, exitSignal context.Context, gene *genesis.Genesis) error {
+	url := ctx.String(lightBackendFlag.Name)
+	if url == "" {
+		fatal(fmt.Sprintf("light mode requires --%v", lightBackendFlag.Name))
+	}
+	genesisBlock, _, err := gene.Build(state.NewCreator(openMemMainDB()))
+	if err != nil {
+		fatal("build genesis block: ", err)
+	}
+	client := lightclient.New(lightclient.NewHTTPBackend(url), genesisBlock.Header(), genesisBlock.Header(), gene.ForkConfig())
+	log.Info("light client started", "backend", url)
+
+	ticker := time.NewTicker(time.Duration(thor.BlockInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		best, err := client.Sync(exitSignal)
+		if err != nil {
+			if exitSignal.Err() != nil {
+				return nil
+			}
+			log.Warn("failed to sync headers", "err", err)
+		} else {
+			log.Info("headers synced", "best", best.Number(), "id", best.ID(), "finalized", client.Finalized().Number())
+		}
+		select {
+		case <-exitSignal.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -6,8 +6,12 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math/big"
 	"math/rand"
 	"net"
 	"net/http"
@@ -20,30 +24,81 @@ import (
 	"github.com/ethereum/go-ethereum/common/fdlimit"
 	"github.com/ethereum/go-ethereum/crypto"
 	ethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/gorilla/handlers"
 	"github.com/inconshreveable/log15"
+	"github.com/vechain/thor/api/admin"
+	"github.com/vechain/thor/api/profiling"
+	"github.com/vechain/thor/api/restrict"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/blockfile"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/cmd/thor/node"
 	"github.com/vechain/thor/comm"
+	"github.com/vechain/thor/cry"
 	"github.com/vechain/thor/genesis"
 	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/logging"
 	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/p2psrv"
+	"github.com/vechain/thor/packer"
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/txpool"
 	cli "gopkg.in/urfave/cli.v1"
 )
 
-func initLogger(ctx *cli.Context) {
-	logLevel := ctx.Int(verbosityFlag.Name)
-	log15.Root().SetHandler(log15.LvlFilterHandler(log15.Lvl(logLevel), log15.StderrHandler))
+// initLogger sets up the root logger, and returns the filter to adjust levels at runtime.
+func initLogger(ctx *cli.Context) *logging.Filter {
+	var (
+		w       io.Writer = os.Stderr
+		console           = true
+	)
+	if path := ctx.String(logFileFlag.Name); path != "" {
+		file, err := logging.OpenRotatingFile(path, int64(ctx.Int(logFileMaxSizeFlag.Name))*1024*1024, ctx.Int(logFileMaxBackupsFlag.Name))
+		if err != nil {
+			fatal(fmt.Sprintf("open log file [%v]: %v", path, err))
+		}
+		w, console = file, false
+	}
+
+	var (
+		format    log15.Format
+		ethFormat ethlog.Format
+	)
+	switch ctx.String(logFormatFlag.Name) {
+	case "", "console":
+		if console {
+			format, ethFormat = log15.TerminalFormat(), ethlog.TerminalFormat(true)
+		} else {
+			// no color codes in files
+			format, ethFormat = log15.LogfmtFormat(), ethlog.LogfmtFormat()
+		}
+	case "json":
+		format, ethFormat = log15.JsonFormat(), ethlog.JSONFormat()
+	default:
+		fatal(fmt.Sprintf("unrecognized log format '%v'", ctx.String(logFormatFlag.Name)))
+	}
+
+	pkgLevels, err := logging.ParsePkgLevels(ctx.String(logLevelsFlag.Name))
+	if err != nil {
+		fatal(fmt.Sprintf("parse -%v flag: %v", logLevelsFlag.Name, err))
+	}
+	filter := logging.NewFilter(log15.Lvl(ctx.Int(verbosityFlag.Name)), log15.StreamHandler(w, format))
+	for pkg, level := range pkgLevels {
+		filter.SetPkgLevel(pkg, level)
+	}
+	log15.Root().SetHandler(filter)
+
 	// set go-ethereum log lvl to Warn
-	ethLogHandler := ethlog.NewGlogHandler(ethlog.StreamHandler(os.Stderr, ethlog.TerminalFormat(true)))
+	ethLogHandler := ethlog.NewGlogHandler(ethlog.StreamHandler(w, ethFormat))
 	ethLogHandler.Verbosity(ethlog.LvlWarn)
 	ethlog.Root().SetHandler(ethLogHandler)
+	return filter
 }
 
 func selectGenesis(ctx *cli.Context) *genesis.Genesis {
@@ -56,6 +111,11 @@ func selectGenesis(ctx *cli.Context) *genesis.Genesis {
 		}
 		return gene
 	default:
+		if network != "" {
+			if _, err := os.Stat(network); err == nil {
+				return loadCustomGenesis(network)
+			}
+		}
 		cli.ShowAppHelp(ctx)
 		if network == "" {
 			fmt.Printf("network flag not specified: -%s\n", networkFlag.Name)
@@ -67,6 +127,28 @@ func selectGenesis(ctx *cli.Context) *genesis.Genesis {
 	}
 }
 
+// loadCustomGenesis builds genesis from the JSON spec file.
+func loadCustomGenesis(path string) *genesis.Genesis {
+	file, err := os.Open(path)
+	if err != nil {
+		fatal(fmt.Sprintf("open genesis file [%v]: %v", path, err))
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+
+	var gen genesis.CustomGenesis
+	if err := decoder.Decode(&gen); err != nil {
+		fatal(fmt.Sprintf("decode genesis file [%v]: %v", path, err))
+	}
+	gene, err := genesis.NewCustomNet(&gen)
+	if err != nil {
+		fatal(fmt.Sprintf("build genesis from [%v]: %v", path, err))
+	}
+	return gene
+}
+
 func makeConfigDir(ctx *cli.Context) string {
 	configDir := ctx.String(configDirFlag.Name)
 	if configDir == "" {
@@ -133,6 +215,15 @@ func openLogDB(ctx *cli.Context, dataDir string) *logdb.LogDB {
 	return db
 }
 
+func openEvidenceDB(dataDir string) *lvldb.LevelDB {
+	dir := filepath.Join(dataDir, "evidence.db")
+	db, err := lvldb.New(dir, lvldb.Options{})
+	if err != nil {
+		fatal(fmt.Sprintf("open evidence database [%v]: %v", dir, err))
+	}
+	return db
+}
+
 func initChain(gene *genesis.Genesis, mainDB *lvldb.LevelDB, logDB *logdb.LogDB) *chain.Chain {
 	genesisBlock, genesisEvents, err := gene.Build(state.NewCreator(mainDB))
 	if err != nil {
@@ -152,8 +243,168 @@ func initChain(gene *genesis.Genesis, mainDB *lvldb.LevelDB, logDB *logdb.LogDB)
 	return chain
 }
 
+// openSnapshots opens flat state snapshots at the best block, which are regenerated in background if missing.
+func openSnapshots(chain *chain.Chain, mainDB *lvldb.LevelDB) *state.Snapshots {
+	snaps, err := state.NewSnapshots(mainDB, chain.BestBlock().Header().StateRoot())
+	if err != nil {
+		fatal("open state snapshots:", err)
+	}
+	return snaps
+}
+
+// openExportFile opens the block file to export to. A new file gets the header written, while an
+// existing one is checked to be of the trunk, and truncated after the last complete entry for resuming.
+// The last block header in file is returned if any.
+func openExportFile(ctx *cli.Context, chain *chain.Chain) (*os.File, *block.Header) {
+	path := ctx.String(blockFileFlag.Name)
+	if path == "" {
+		fatal("block file not specified")
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		fatal("open block file:", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		fatal("open block file:", err)
+	}
+
+	genesisID := chain.GenesisBlock().Header().ID()
+	if info.Size() == 0 {
+		if err := blockfile.NewWriter(file).WriteHeader(genesisID); err != nil {
+			fatal("write block file:", err)
+		}
+		return file, nil
+	}
+
+	reader, err := blockfile.NewReader(file)
+	if err != nil {
+		fatal("read block file:", err)
+	}
+	if reader.GenesisID() != genesisID {
+		fatal("block file of another network")
+	}
+	var last *block.Header
+	offset := reader.Offset()
+	for {
+		blk, _, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF {
+			log.Warn("incomplete entry at the end of block file dropped", "offset", offset)
+			break
+		}
+		if err != nil {
+			fatal("read block file:", err)
+		}
+		last, offset = blk.Header(), reader.Offset()
+	}
+	if last != nil {
+		if id, err := chain.GetTrunkBlockID(last.Number()); err != nil || id != last.ID() {
+			fatal(fmt.Sprintf("block #%v in file not on trunk", last.Number()))
+		}
+	}
+	if err := file.Truncate(offset); err != nil {
+		fatal("truncate block file:", err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		fatal("seek block file:", err)
+	}
+	return file, last
+}
+
+func txPoolConfig(ctx *cli.Context, journal string) txpool.PoolConfig {
+	config := txpool.DefaultPoolConfig
+	config.Journal = journal
+	limit := ctx.Int(txPoolLimitFlag.Name)
+	limitPerAccount := ctx.Int(txPoolLimitPerAccountFlag.Name)
+	if limit <= 0 || limitPerAccount <= 0 {
+		fatal("tx pool limits should be positive")
+	}
+	lifetime := ctx.Duration(txPoolLifetimeFlag.Name)
+	if lifetime < time.Second {
+		fatal("tx pool lifetime should be at least 1s")
+	}
+	config.PoolSize = limit
+	config.LimitPerAccount = uint(limitPerAccount)
+	config.Lifetime = lifetime
+	config.Filters = txPoolFilters(ctx)
+	return config
+}
+
+func txPoolFilters(ctx *cli.Context) []txpool.Filter {
+	var filters []txpool.Filter
+	if str := ctx.String(txPoolMinGasPriceFlag.Name); str != "" {
+		price, ok := new(big.Int).SetString(str, 10)
+		if !ok || price.Sign() < 0 {
+			fatal("invalid tx pool min gas price:", str)
+		}
+		filters = append(filters, txpool.MinGasPrice(price))
+	}
+	parseAddresses := func(flagName string) []thor.Address {
+		var addrs []thor.Address
+		for _, item := range splitFlag(ctx, flagName) {
+			addr, err := thor.ParseAddress(item)
+			if err != nil {
+				fatal(fmt.Sprintf("parse -%v flag: %v", flagName, err))
+			}
+			addrs = append(addrs, addr)
+		}
+		return addrs
+	}
+	if addrs := parseAddresses(txPoolAllowedSendersFlag.Name); len(addrs) > 0 {
+		filters = append(filters, txpool.AllowSenders(addrs...))
+	}
+	if addrs := parseAddresses(txPoolDeniedSendersFlag.Name); len(addrs) > 0 {
+		filters = append(filters, txpool.DenySenders(addrs...))
+	}
+	if size := ctx.Int(txPoolMaxClauseDataFlag.Name); size > 0 {
+		filters = append(filters, txpool.MaxClauseDataSize(size))
+	}
+	if limit := ctx.Int(txPoolContractCreationLimitFlag.Name); limit > 0 {
+		filters = append(filters, txpool.ThrottleContractCreation(limit, time.Minute))
+	}
+	return filters
+}
+
+func txSelector(ctx *cli.Context) packer.Selector {
+	var selectors []packer.Selector
+	switch order := ctx.String(packTxOrderFlag.Name); order {
+	case "gasprice":
+		selectors = append(selectors, packer.ByGasPrice())
+	case "arrival":
+		selectors = append(selectors, packer.ByArrival())
+	default:
+		fatal("unsupported tx order:", order)
+	}
+
+	limit := ctx.Int(packTxLimitPerOriginFlag.Name)
+	if limit < 0 {
+		fatal("tx limit per origin should not be negative")
+	}
+	if limit > 0 {
+		selectors = append(selectors, packer.OriginCap(limit))
+	}
+	return packer.Chain(append(selectors, packer.RespectDependsOn())...)
+}
+
+func fastSync(ctx *cli.Context, exitSignal context.Context, comm *comm.Communicator) {
+	str := ctx.String(fastSyncFlag.Name)
+	if str == "" {
+		return
+	}
+	trustedID, err := thor.ParseBytes32(str)
+	if err != nil {
+		fatal("parse trusted block id:", err)
+	}
+	log.Info("fast syncing...", "trusted", trustedID)
+	if err := comm.FastSync(exitSignal, trustedID); err != nil {
+		fatal("fast sync:", err)
+	}
+}
+
 func loadNodeMaster(ctx *cli.Context) *node.Master {
-	configDir := makeConfigDir(ctx)
 	bene := func(master thor.Address) thor.Address {
 		beneStr := ctx.String(beneficiaryFlag.Name)
 		if beneStr == "" {
@@ -170,26 +421,50 @@ func loadNodeMaster(ctx *cli.Context) *node.Master {
 		i := rand.Intn(len(genesis.DevAccounts()))
 		acc := genesis.DevAccounts()[i]
 		return &node.Master{
-			PrivateKey:  acc.PrivateKey,
+			Signer:      cry.NewKeySigner(acc.PrivateKey),
 			Beneficiary: bene(acc.Address),
 		}
 	}
-	key, err := loadOrGeneratePrivateKey(filepath.Join(configDir, "master.key"))
-	if err != nil {
-		fatal("load or generate master key:", err)
+
+	var master *node.Master
+	if url := ctx.String(signerURLFlag.Name); url != "" {
+		master = &node.Master{Signer: loadRemoteSigner(ctx, url)}
+	} else {
+		master = &node.Master{Signer: cry.NewKeySigner(loadMasterKey(ctx))}
 	}
-	master := &node.Master{PrivateKey: key}
-	master.Beneficiary = master.Address()
+	master.Beneficiary = bene(master.Address())
 	return master
 }
 
+// loadRemoteSigner connects the remote signer, so that the master key is never loaded into memory.
+func loadRemoteSigner(ctx *cli.Context, url string) cry.Signer {
+	var token string
+	if path := ctx.String(signerTokenFileFlag.Name); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			fatal("read signer token file:", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	signer, err := cry.NewRemoteSigner(cry.RemoteSignerConfig{
+		URL:       url,
+		Token:     token,
+		RateLimit: ctx.Float64(signerRateLimitFlag.Name),
+	})
+	if err != nil {
+		fatal("connect remote signer:", err)
+	}
+	log.Info("remote signer connected", "master", signer.Address())
+	return signer
+}
+
 type p2pComm struct {
 	comm      *comm.Communicator
 	p2pSrv    *p2psrv.Server
 	savePeers func()
 }
 
-func startP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, instanceDir string) *p2pComm {
+func startP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, mainDB *lvldb.LevelDB, instanceDir string) *p2pComm {
 	configDir := makeConfigDir(ctx)
 	key, err := loadOrGeneratePrivateKey(filepath.Join(configDir, "p2p.key"))
 	if err != nil {
@@ -202,12 +477,18 @@ func startP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, i
 		fmt.Println("parse -nat flag:", err)
 		os.Exit(1)
 	}
+	bootnodes := parseNodes(ctx, bootnodeFlag.Name)
+	if len(bootnodes) == 0 {
+		bootnodes = bootstrapNodes
+	}
 	opts := &p2psrv.Options{
 		Name:           common.MakeName("thor", fullVersion()),
 		PrivateKey:     key,
 		MaxPeers:       ctx.Int(maxPeersFlag.Name),
 		ListenAddr:     fmt.Sprintf(":%v", ctx.Int(p2pPortFlag.Name)),
-		BootstrapNodes: bootstrapNodes,
+		BootstrapNodes: bootnodes,
+		StaticNodes:    parseNodes(ctx, staticPeersFlag.Name),
+		TrustedNodes:   parseNodes(ctx, trustedPeersFlag.Name),
 		NAT:            nat,
 	}
 
@@ -222,7 +503,18 @@ func startP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, i
 	}
 	srv := p2psrv.New(opts)
 
-	comm := comm.New(chain, txPool)
+	bansCachePath := filepath.Join(instanceDir, "bans.cache")
+	var bans []*comm.BannedNode
+	if data, err := ioutil.ReadFile(bansCachePath); err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn("failed to load bans cache", "err", err)
+		}
+	} else if err := rlp.DecodeBytes(data, &bans); err != nil {
+		log.Warn("failed to load bans cache", "err", err)
+	}
+
+	comm := comm.New(chain, txPool, mainDB)
+	comm.SetBannedNodes(bans)
 	if err := srv.Start(comm.Protocols()); err != nil {
 		fatal("start P2P server:", err)
 	}
@@ -241,10 +533,37 @@ func startP2PComm(ctx *cli.Context, chain *chain.Chain, txPool *txpool.TxPool, i
 			if err := ioutil.WriteFile(peersCachePath, data, 0600); err != nil {
 				log.Warn("failed to write peers cache", "err", err)
 			}
+
+			data, err = rlp.EncodeToBytes(comm.BannedNodes())
+			if err != nil {
+				log.Warn("failed to encode bans", "err", err)
+				return
+			}
+			if err := ioutil.WriteFile(bansCachePath, data, 0600); err != nil {
+				log.Warn("failed to write bans cache", "err", err)
+			}
 		},
 	}
 }
 
+// parseNodes parses comma separated enode URLs of the flag.
+func parseNodes(ctx *cli.Context, flagName string) p2psrv.Nodes {
+	var nodes p2psrv.Nodes
+	for _, url := range strings.Split(ctx.String(flagName), ",") {
+		if url = strings.TrimSpace(url); url == "" {
+			continue
+		}
+		node, err := discover.ParseNode(url)
+		if err != nil {
+			cli.ShowAppHelp(ctx)
+			fmt.Printf("parse -%v flag: %v\n", flagName, err)
+			os.Exit(1)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
 func (c *p2pComm) Shutdown() {
 	c.comm.Stop()
 	log.Info("stopping communicator...")
@@ -263,10 +582,15 @@ func startAPIServer(ctx *cli.Context, handler http.Handler) (*http.Server, strin
 		fatal(fmt.Sprintf("listen API addr [%v]: %v", addr, err))
 	}
 
+	if config := apiRestrictConfig(ctx); !config.IsZero() {
+		handler = restrict.New(config, handler)
+	}
+	// CORS wraps outside, to answer preflight requests, which carry no API key
 	if origins := ctx.String(apiCorsFlag.Name); origins != "" {
 		handler = handlers.CORS(
 			handlers.AllowedOrigins(strings.Split(origins, ",")),
-			handlers.AllowedHeaders([]string{"content-type"}),
+			handlers.AllowedHeaders([]string{"content-type", restrict.KeyHeader}),
+			handlers.ExposedHeaders([]string{utils.NextCursorHeader}),
 		)(handler)
 	}
 	srv := &http.Server{Handler: handler}
@@ -276,29 +600,133 @@ func startAPIServer(ctx *cli.Context, handler http.Handler) (*http.Server, strin
 	return srv, "http://" + listener.Addr().String() + "/"
 }
 
+// splitFlag splits the comma separated flag value, with empty items dropped.
+func splitFlag(ctx *cli.Context, flagName string) []string {
+	var items []string
+	for _, item := range strings.Split(ctx.String(flagName), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func apiRestrictConfig(ctx *cli.Context) restrict.Config {
+	config := restrict.Config{
+		RateLimit:    ctx.Float64(apiRateLimitFlag.Name),
+		KeyRateLimit: ctx.Float64(apiKeyRateLimitFlag.Name),
+		Burst:        ctx.Int(apiRateBurstFlag.Name),
+		Keys:         splitFlag(ctx, apiKeysFlag.Name),
+		AllowedPaths: splitFlag(ctx, apiAllowedPathsFlag.Name),
+		DeniedPaths:  splitFlag(ctx, apiDeniedPathsFlag.Name),
+	}
+	if config.RateLimit < 0 || config.KeyRateLimit < 0 || config.Burst < 0 {
+		fatal("API rate limit and burst should not be negative")
+	}
+	return config
+}
+
+// loadAdminToken returns the token to authenticate admin API requests.
+// If not specified by flag, it's loaded from or generated into the config dir.
+func loadAdminToken(ctx *cli.Context) string {
+	if token := ctx.String(adminTokenFlag.Name); token != "" {
+		return token
+	}
+	path := filepath.Join(makeConfigDir(ctx), "admin.token")
+	token, err := loadOrGenerateToken(path)
+	if err != nil {
+		fatal("load or generate admin token:", err)
+	}
+	log.Info("admin API token loaded", "path", path)
+	return token
+}
+
+// startAdminServer starts the admin API service if the address is set.
+// It returns nil if disabled.
+func startAdminServer(ctx *cli.Context, handler http.Handler) (*http.Server, string) {
+	addr := ctx.String(adminAddrFlag.Name)
+	if addr == "" {
+		return nil, ""
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fatal(fmt.Sprintf("listen admin addr [%v]: %v", addr, err))
+	}
+	srv := &http.Server{Handler: admin.RequireToken(loadAdminToken(ctx), handler)}
+	go func() {
+		srv.Serve(listener)
+	}()
+	return srv, "http://" + listener.Addr().String() + "/"
+}
+
+// startPprofServer starts the pprof service if the address is set. Requests are authenticated
+// by the admin token, since profiles reveal internals of the node.
+func startPprofServer(ctx *cli.Context, profiler *profiling.Profiler) (*http.Server, string) {
+	addr := ctx.String(pprofAddrFlag.Name)
+	if addr == "" {
+		return nil, ""
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fatal(fmt.Sprintf("listen pprof addr [%v]: %v", addr, err))
+	}
+	srv := &http.Server{Handler: admin.RequireToken(loadAdminToken(ctx), profiler.Handler())}
+	go func() {
+		srv.Serve(listener)
+	}()
+	return srv, "http://" + listener.Addr().String() + "/debug/pprof/"
+}
+
+// startMetricsServer starts the service to expose metrics at /metrics, if the address is set.
+// It returns nil if disabled.
+func startMetricsServer(ctx *cli.Context) (*http.Server, string) {
+	addr := ctx.String(metricsAddrFlag.Name)
+	if addr == "" {
+		return nil, ""
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fatal(fmt.Sprintf("listen metrics addr [%v]: %v", addr, err))
+	}
+	handler := http.NewServeMux()
+	handler.Handle("/metrics", metric.Handler())
+	srv := &http.Server{Handler: handler}
+	go func() {
+		srv.Serve(listener)
+	}()
+	return srv, "http://" + listener.Addr().String() + "/metrics"
+}
+
 func printStartupMessage(
 	gene *genesis.Genesis,
 	chain *chain.Chain,
 	master *node.Master,
 	dataDir string,
 	apiURL string,
+	nodeURL string,
 ) {
 	bestBlock := chain.BestBlock()
 
 	fmt.Printf(`Starting %v
     Network      [ %v %v ]    
+    Chain tag    [ %v ]
+    Forks        [ %v ]
     Best block   [ %v #%v @%v ]
     Master       [ %v ]
     Beneficiary  [ %v ]
     Instance dir [ %v ]
     API portal   [ %v ]
+    Node URL     [ %v ]
 `,
 		common.MakeName("Thor", fullVersion()),
 		gene.ID(), gene.Name(),
+		chain.Tag(),
+		gene.ForkConfig(),
 		bestBlock.Header().ID(), bestBlock.Header().Number(), time.Unix(int64(bestBlock.Header().Timestamp()), 0),
 		master.Address(), master.Beneficiary,
 		dataDir,
-		apiURL)
+		apiURL,
+		nodeURL)
 }
 
 func soloGenesis(ctx *cli.Context) *genesis.Genesis {
@@ -344,11 +772,13 @@ func printSoloStartupMessage(
 
 	info := fmt.Sprintf(`Starting %v
     Network     [ %v %v ]    
+    Chain tag   [ %v ]
     Best block  [ %v #%v @%v ]
     Data dir    [ %v ]
     API portal  [ %v ]`,
 		common.MakeName("Thor solo", fullVersion()),
 		gene.ID(), gene.Name(),
+		chain.Tag(),
 		bestBlock.Header().ID(), bestBlock.Header().Number(), time.Unix(int64(bestBlock.Header().Timestamp()), 0),
 		dataDir,
 		apiURL)
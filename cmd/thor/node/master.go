@@ -6,17 +6,16 @@
 package node
 
 import (
-	"crypto/ecdsa"
-
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/vechain/thor/cry"
 	"github.com/vechain/thor/thor"
 )
 
+// Master signs blocks by the master key, which is held in memory or by a remote signer.
 type Master struct {
-	PrivateKey  *ecdsa.PrivateKey
+	Signer      cry.Signer
 	Beneficiary thor.Address
 }
 
 func (m *Master) Address() thor.Address {
-	return thor.Address(crypto.PubkeyToAddress(m.PrivateKey.PublicKey))
+	return m.Signer.Address()
 }
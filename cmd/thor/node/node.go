@@ -18,12 +18,15 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/vechain/thor/bandwidth"
+	"github.com/vechain/thor/bft"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/cache"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/co"
 	"github.com/vechain/thor/comm"
 	"github.com/vechain/thor/consensus"
+	"github.com/vechain/thor/evidence"
 	"github.com/vechain/thor/logdb"
 	"github.com/vechain/thor/packer"
 	"github.com/vechain/thor/state"
@@ -35,15 +38,18 @@ import (
 var log = log15.New("pkg", "node")
 
 type Node struct {
-	goes   co.Goes
-	packer *packer.Packer
-	cons   *consensus.Consensus
+	goes      co.Goes
+	packer    *packer.Packer
+	cons      *consensus.Consensus
+	bandwidth bandwidth.Bandwidth
+	bft       *bft.Engine
 
 	master     *Master
 	chain      *chain.Chain
 	logDB      *logdb.LogDB
 	txPool     *txpool.TxPool
 	comm       *comm.Communicator
+	evidences  *evidence.Store
 	commitLock sync.Mutex
 }
 
@@ -54,15 +60,22 @@ func New(
 	logDB *logdb.LogDB,
 	txPool *txpool.TxPool,
 	comm *comm.Communicator,
+	evidences *evidence.Store,
+	txSelector packer.Selector,
+	forkConfig thor.ForkConfig,
 ) *Node {
+	p := packer.New(chain, stateCreator, master.Address(), master.Beneficiary, forkConfig)
+	p.SetTxSelector(txSelector)
 	return &Node{
-		packer: packer.New(chain, stateCreator, master.Address(), master.Beneficiary),
-		cons:   consensus.New(chain, stateCreator),
-		master: master,
-		chain:  chain,
-		logDB:  logDB,
-		txPool: txPool,
-		comm:   comm,
+		packer:    p,
+		bft:       bft.New(chain, stateCreator),
+		cons:      consensus.New(chain, stateCreator, forkConfig),
+		master:    master,
+		chain:     chain,
+		logDB:     logDB,
+		txPool:    txPool,
+		comm:      comm,
+		evidences: evidences,
 	}
 }
 
@@ -142,6 +155,8 @@ func (n *Node) houseKeeping(ctx context.Context) {
 					(consensus.IsParentMissing(err) && futureBlocks.Contains(newBlock.Header().ParentID())) {
 					log.Debug("future block added", "id", newBlock.Header().ID())
 					futureBlocks.Set(newBlock.Header().ID(), newBlock.Block)
+				} else if consensus.IsCritical(err) {
+					n.comm.ReportBadBlock(newBlock)
 				}
 			} else if isTrunk {
 				n.comm.BroadcastBlock(newBlock.Block)
@@ -220,6 +235,11 @@ func (n *Node) processBlock(blk *block.Block, stats *blockStats) (bool, error) {
 		return false, err
 	}
 	commitElapsed := mclock.Now() - startTime - execElapsed
+
+	if v, updated := n.bandwidth.Update(blk.Header(), time.Duration(execElapsed)); updated {
+		log.Debug("bandwidth updated", "gps", v)
+	}
+
 	stats.UpdateProcessed(1, len(receipts), execElapsed, commitElapsed, blk.Header().GasUsed())
 	n.processFork(fork)
 	return len(fork.Trunk) > 0, nil
@@ -234,26 +254,60 @@ func (n *Node) commitBlock(newBlock *block.Block, receipts tx.Receipts) (*chain.
 		return nil, err
 	}
 
-	forkIDs := make([]thor.Bytes32, 0, len(fork.Branch))
-	for _, header := range fork.Branch {
-		forkIDs = append(forkIDs, header.ID())
+	if ev, err := n.evidences.Track(newBlock.Header()); err != nil {
+		log.Warn("failed to track block slot", "err", err)
+	} else if ev != nil {
+		signer, _ := ev.Signer()
+		log.Warn("equivocation detected", "signer", signer, "timestamp", ev.First.Timestamp(), "first", ev.First.ID(), "second", ev.Second.ID())
 	}
 
-	batch := n.logDB.Prepare(newBlock.Header())
-	for i, tx := range newBlock.Transactions() {
-		origin, _ := tx.Signer()
-		txBatch := batch.ForTransaction(tx.ID(), origin)
-		for _, output := range receipts[i].Outputs {
-			txBatch.Insert(output.Events, output.Transfers)
+	for _, header := range fork.Trunk {
+		if finalized, err := n.bft.Process(header); err != nil {
+			log.Warn("failed to process finality", "err", err)
+		} else if finalized {
+			log.Info("block finalized", "id", shortID(n.chain.FinalizedBlock().ID()))
 		}
 	}
 
-	if err := batch.Commit(forkIDs...); err != nil {
+	if err := WriteLogs(n.logDB, n.chain, newBlock, receipts, fork); err != nil {
 		return nil, errors.Wrap(err, "commit logs")
 	}
 	return fork, nil
 }
 
+// WriteLogs keeps log db consistent with trunk after the new block added. Logs of blocks switched
+// off trunk are deleted, and those of blocks switched onto trunk are written, so side blocks are
+// not indexed until they become trunk.
+func WriteLogs(logDB *logdb.LogDB, chain *chain.Chain, newBlock *block.Block, receipts tx.Receipts, fork *chain.Fork) error {
+	if len(fork.Trunk) == 0 {
+		return nil
+	}
+	abandoned := make([]thor.Bytes32, 0, len(fork.Branch))
+	for _, header := range fork.Branch {
+		abandoned = append(abandoned, header.ID())
+	}
+
+	for _, header := range fork.Trunk {
+		txs, blockReceipts := newBlock.Transactions(), receipts
+		if header.ID() != newBlock.Header().ID() {
+			body, err := chain.GetBlockBody(header.ID())
+			if err != nil {
+				return err
+			}
+			if blockReceipts, err = chain.GetBlockReceipts(header.ID()); err != nil {
+				return err
+			}
+			txs = body.Txs
+		}
+		// abandoned ones are deleted along with the first block
+		if err := logDB.Prepare(header).ForBlock(txs, blockReceipts).Commit(abandoned...); err != nil {
+			return err
+		}
+		abandoned = nil
+	}
+	return nil
+}
+
 func (n *Node) processFork(fork *chain.Fork) {
 	if len(fork.Branch) >= 2 {
 		trunkLen := len(fork.Trunk)
@@ -48,6 +48,8 @@ func (n *Node) packerLoop(ctx context.Context) {
 		now := uint64(time.Now().Unix())
 
 		if flow == nil {
+			// 0 means no enough samples, gas limit kept as parent's
+			n.packer.SetTargetGasLimit(n.bandwidth.SuggestGasLimit())
 			if flow, err = n.packer.Schedule(best.Header(), now); err != nil {
 				if authorized {
 					authorized = false
@@ -79,7 +81,18 @@ func (n *Node) packerLoop(ctx context.Context) {
 }
 
 func (n *Node) pack(flow *packer.Flow) error {
-	txs := n.txPool.Pending(true)
+	pending := n.txPool.PendingTxs()
+	candidates := make([]*packer.Candidate, 0, len(pending))
+	for _, ptx := range pending {
+		candidates = append(candidates, &packer.Candidate{
+			Tx:          ptx.Tx,
+			Origin:      ptx.Origin,
+			GasPrice:    ptx.OverallGasPrice,
+			ArrivalTime: ptx.ArrivalTime,
+		})
+	}
+	candidates = n.packer.SelectTxs(candidates)
+
 	var txsToRemove []thor.Bytes32
 	defer func() {
 		for _, id := range txsToRemove {
@@ -87,20 +100,27 @@ func (n *Node) pack(flow *packer.Flow) error {
 		}
 	}()
 
+	// txs adopted after the block time would delay the block in its slot
+	deadline := time.Unix(int64(flow.When()), 0)
+
 	startTime := mclock.Now()
-	for _, tx := range txs {
-		if err := flow.Adopt(tx); err != nil {
+	for _, c := range candidates {
+		if time.Now().After(deadline) {
+			log.Debug("packing deadline reached", "adopted", flow.TxCount(), "candidates", len(candidates))
+			break
+		}
+		if err := flow.Adopt(c.Tx); err != nil {
 			if packer.IsGasLimitReached(err) {
 				break
 			}
 			if packer.IsTxNotAdoptableNow(err) {
 				continue
 			}
-			txsToRemove = append(txsToRemove, tx.ID())
+			txsToRemove = append(txsToRemove, c.Tx.ID())
 		}
 	}
 
-	newBlock, stage, receipts, err := flow.Pack(n.master.PrivateKey)
+	newBlock, stage, receipts, err := flow.PackWith(n.master.Signer)
 	if err != nil {
 		return err
 	}
@@ -128,15 +148,8 @@ func (n *Node) pack(flow *packer.Flow) error {
 		)
 	}
 
-	n.packer.SetTargetGasLimit(0)
-	if execElapsed > 0 {
-		gasUsed := newBlock.Header().GasUsed()
-		// calc target gas limit only if gas used above third of gas limit
-		if gasUsed > newBlock.Header().GasLimit()/3 {
-			targetGasLimit := uint64(thor.TolerableBlockPackingTime) * gasUsed / uint64(execElapsed)
-			n.packer.SetTargetGasLimit(targetGasLimit)
-			log.Debug("reset target gas limit", "value", targetGasLimit)
-		}
+	if v, updated := n.bandwidth.Update(newBlock.Header(), time.Duration(execElapsed)); updated {
+		log.Debug("bandwidth updated", "gps", v)
 	}
 	return nil
 }
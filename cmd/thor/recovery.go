@@ -0,0 +1,115 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/state"
+)
+
+const (
+	// runningMarkerFile is kept in instance dir while the node running, and left there
+	// if the node exited without closing databases.
+	runningMarkerFile = "running"
+	// maxRecoveryDepth max number of head blocks checked and rewritten by the recovery pass.
+	maxRecoveryDepth = 64
+)
+
+// markRunning creates the running marker in instance dir, and returns whether the last run
+// exited uncleanly. The returned func removes the marker, and should be called after
+// databases closed.
+func markRunning(instanceDir string) (bool, func()) {
+	if err := os.MkdirAll(instanceDir, 0700); err != nil {
+		fatal(fmt.Sprintf("create instance dir [%v]: %v", instanceDir, err))
+	}
+	path := filepath.Join(instanceDir, runningMarkerFile)
+	_, err := os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		fatal("check running marker:", err)
+	}
+	crashed := err == nil
+	if err := ioutil.WriteFile(path, []byte(fmt.Sprint(os.Getpid())), 0600); err != nil {
+		fatal("write running marker:", err)
+	}
+	return crashed, func() {
+		if err := os.Remove(path); err != nil {
+			log.Warn("failed to remove running marker", "err", err)
+		}
+	}
+}
+
+// recoverChain is done at startup after an unclean exit. Writes after the crash point might be
+// lost, and the head block might be partially imported, i.e. with its state or receipts missing.
+// The chain is rewound to the last consistent block, and logs of head blocks are rewritten, since
+// they are committed into another database after blocks.
+func recoverChain(chain *chain.Chain, stateCreator *state.Creator, logDB *logdb.LogDB) {
+	log.Warn("last run exited uncleanly, checking database consistency...")
+
+	consistent := func(header *block.Header) (bool, error) {
+		if has, err := stateCreator.HasRoot(header.StateRoot()); err != nil || !has {
+			return false, err
+		}
+		if header.Number() == 0 {
+			// receipts of genesis are not stored
+			return true, nil
+		}
+		if _, err := chain.GetBlockReceipts(header.ID()); err != nil {
+			if chain.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	best := chain.BestBlock().Header()
+	head := best
+	for {
+		ok, err := consistent(head)
+		if err != nil {
+			fatal("check block:", err)
+		}
+		if ok {
+			break
+		}
+		if best.Number()-head.Number() >= maxRecoveryDepth || head.Number() <= chain.FinalizedBlock().Number() {
+			// e.g. interrupted fast sync, which imports blocks without state, and resumes on restart
+			log.Warn("no consistent block found near the best block, skip rewinding", "best", best.ID())
+			head = best
+			break
+		}
+		if head, err = chain.GetBlockHeader(head.ParentID()); err != nil {
+			fatal("load block:", err)
+		}
+	}
+	if head.ID() != best.ID() {
+		if err := chain.Rewind(head.ID()); err != nil {
+			fatal("rewind chain:", err)
+		}
+		log.Warn("chain rewound to the last consistent block", "from", best.ID(), "to", head.ID())
+	}
+
+	// logs might be ahead of blocks, or missing for blocks imported before the crash
+	if err := logDB.Truncate(head.Number() + 1); err != nil {
+		fatal("drop logs:", err)
+	}
+	from := uint32(0)
+	if head.Number() > maxRecoveryDepth {
+		from = head.Number() - maxRecoveryDepth
+	}
+	if err := logDB.Reindex(context.Background(), chain, from, nil); err != nil {
+		fatal("rebuild logs:", err)
+	}
+	log.Info("database recovered", "best", head.ID())
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/api/utils"
+	"github.com/vechain/thor/thor"
+)
+
+const maxMineBlocks = 1000 // max number of blocks packed by a mining request
+
+// Mine request to pack blocks.
+type Mine struct {
+	Blocks int `json:"blocks"` // defaults to 1 if 0
+}
+
+// MinedBlock brief of a packed block.
+type MinedBlock struct {
+	ID           thor.Bytes32   `json:"id"`
+	Number       uint32         `json:"number"`
+	Timestamp    uint64         `json:"timestamp"`
+	Transactions []thor.Bytes32 `json:"transactions"`
+}
+
+func (s *Solo) handleMine(w http.ResponseWriter, req *http.Request) error {
+	var mine Mine
+	if err := utils.ParseJSON(req.Body, &mine); err != nil {
+		return utils.BadRequest(err, "body")
+	}
+	if mine.Blocks == 0 {
+		mine.Blocks = 1
+	}
+	if mine.Blocks < 0 || mine.Blocks > maxMineBlocks {
+		return utils.BadRequest(errors.Errorf("should be in range [1, %v]", maxMineBlocks), "blocks")
+	}
+	blocks, err := s.Mine(mine.Blocks)
+	if err != nil {
+		return err
+	}
+	mined := make([]*MinedBlock, 0, len(blocks))
+	for _, b := range blocks {
+		txs := make([]thor.Bytes32, 0, len(b.Transactions()))
+		for _, trx := range b.Transactions() {
+			txs = append(txs, trx.ID())
+		}
+		mined = append(mined, &MinedBlock{
+			ID:           b.Header().ID(),
+			Number:       b.Header().Number(),
+			Timestamp:    b.Header().Timestamp(),
+			Transactions: txs,
+		})
+	}
+	return utils.WriteJSON(w, mined)
+}
+
+// Mount mounts solo specific API, to pack blocks on demand.
+func (s *Solo) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+
+	sub.Path("/mine").Methods("POST").HandlerFunc(utils.WrapHandlerFunc(s.handleMine))
+}
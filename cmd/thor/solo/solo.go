@@ -8,9 +8,11 @@ package solo
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/co"
@@ -23,7 +25,7 @@ import (
 	"github.com/vechain/thor/txpool"
 )
 
-var log = log15.New()
+var log = log15.New("pkg", "solo")
 
 // Solo mode is the standalone client without p2p server
 type Solo struct {
@@ -33,22 +35,29 @@ type Solo struct {
 	logDB       *logdb.LogDB
 	bestBlockCh chan *block.Block
 	onDemand    bool
+	interval    time.Duration
+	packLock    sync.Mutex // to serialize packing, by interval, txs and mining requests
 }
 
-// New returns Solo instance
+// New returns Solo instance.
+// Blocks are packed every interval, or instantly when txs come if on demand.
+// Packing by interval is disabled if interval is 0, then blocks are only packed by Mine.
 func New(
 	chain *chain.Chain,
 	stateCreator *state.Creator,
 	logDB *logdb.LogDB,
 	txPool *txpool.TxPool,
 	onDemand bool,
+	interval time.Duration,
+	forkConfig thor.ForkConfig,
 ) *Solo {
 	return &Solo{
 		chain:    chain,
 		txPool:   txPool,
-		packer:   packer.New(chain, stateCreator, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address),
+		packer:   packer.New(chain, stateCreator, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, forkConfig),
 		logDB:    logDB,
 		onDemand: onDemand,
+		interval: interval,
 	}
 }
 
@@ -61,7 +70,7 @@ func (s *Solo) Run(ctx context.Context) error {
 	}()
 
 	goes.Go(func() {
-		s.interval(ctx)
+		s.loop(ctx)
 	})
 
 	goes.Go(func() {
@@ -73,11 +82,11 @@ func (s *Solo) Run(ctx context.Context) error {
 	return nil
 }
 
-func (s *Solo) interval(ctx context.Context) {
-	if s.onDemand {
+func (s *Solo) loop(ctx context.Context) {
+	if s.onDemand || s.interval == 0 {
 		return
 	}
-	ticker := time.NewTicker(time.Duration(10) * time.Second)
+	ticker := time.NewTicker(s.interval)
 	defer ticker.Stop()
 	s.packing()
 
@@ -118,12 +127,40 @@ func (s *Solo) watcher(ctx context.Context) {
 }
 
 func (s *Solo) packing() {
+	// If there is no tx packed in the on-demand mode then skip
+	if _, err := s.pack(!s.onDemand); err != nil {
+		log.Error(fmt.Sprintf("%+v", err))
+	}
+}
+
+// Mine packs n blocks instantly with pending txs, even if there's no tx.
+func (s *Solo) Mine(n int) ([]*block.Block, error) {
+	blocks := make([]*block.Block, 0, n)
+	for i := 0; i < n; i++ {
+		b, err := s.pack(true)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+// pack packs a block with pending txs, and returns nil if no tx packed and not forced.
+func (s *Solo) pack(force bool) (*block.Block, error) {
+	s.packLock.Lock()
+	defer s.packLock.Unlock()
 
 	best := s.chain.BestBlock()
 
-	flow, err := s.packer.Mock(best.Header(), uint64(time.Now().Unix()))
+	// keep timestamps increasing, when blocks are packed in the same second
+	now := uint64(time.Now().Unix())
+	if now <= best.Header().Timestamp() {
+		now = best.Header().Timestamp() + 1
+	}
+	flow, err := s.packer.Mock(best.Header(), now)
 	if err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+		return nil, errors.WithMessage(err, "mock packer")
 	}
 
 	pendingTxs := s.txPool.Pending(true)
@@ -140,6 +177,8 @@ func (s *Solo) packing() {
 			break
 		case packer.IsTxNotAdoptableNow(err):
 			continue
+		case err == nil:
+			// removed after block added
 		default:
 			s.txPool.Remove(tx.ID())
 		}
@@ -147,15 +186,15 @@ func (s *Solo) packing() {
 
 	b, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
 	if err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+		return nil, errors.WithMessage(err, "pack")
 	}
-	if _, err := stage.Commit(); err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+
+	if !force && len(b.Transactions()) == 0 {
+		return nil, nil
 	}
 
-	// If there is no tx packed in the on-demand mode then skip
-	if s.onDemand && len(b.Transactions()) == 0 {
-		return
+	if _, err := stage.Commit(); err != nil {
+		return nil, errors.WithMessage(err, "commit state")
 	}
 
 	blockID := b.Header().ID()
@@ -166,22 +205,17 @@ func (s *Solo) packing() {
 	)
 	log.Debug(b.String())
 
-	batch := s.logDB.Prepare(b.Header())
-	for i, tx := range b.Transactions() {
-		origin, _ := tx.Signer()
-		txBatch := batch.ForTransaction(tx.ID(), origin)
-		receipt := receipts[i]
-		for _, output := range receipt.Outputs {
-			txBatch.Insert(output.Events, output.Transfers)
-		}
-	}
-	if err := batch.Commit(); err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+	if err := s.logDB.Prepare(b.Header()).ForBlock(b.Transactions(), receipts).Commit(); err != nil {
+		return nil, errors.WithMessage(err, "commit logs")
 	}
 
 	// ignore fork when s
-	_, err = s.chain.AddBlock(b, receipts)
-	if err != nil {
-		log.Error(fmt.Sprintf("%+v", err))
+	if _, err := s.chain.AddBlock(b, receipts); err != nil {
+		return nil, errors.WithMessage(err, "add block")
+	}
+
+	for _, tx := range b.Transactions() {
+		s.txPool.Remove(tx.ID())
 	}
+	return b, nil
 }
@@ -8,14 +8,21 @@ package main
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -60,6 +67,28 @@ func loadOrGeneratePrivateKey(path string) (*ecdsa.PrivateKey, error) {
 	return key, nil
 }
 
+// loadOrGenerateToken loads the hex token from the file, or generates a random one into it.
+func loadOrGenerateToken(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b[:])
+	if err := ioutil.WriteFile(path, []byte(token), 0600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
 func defaultConfigDir() string {
 	if home := homeDir(); home != "" {
 		return filepath.Join(home, ".org.vechain.thor")
@@ -107,3 +136,42 @@ func handleExitSignal() context.Context {
 	}()
 	return ctx
 }
+
+// shutdownTimeout max duration to wait for active requests when stopping a server.
+const shutdownTimeout = 10 * time.Second
+
+func shutdownServer(name string, srv *http.Server) {
+	log.Info(fmt.Sprintf("stopping %v server...", name))
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Warn(fmt.Sprintf("%v server not stopped gracefully", name), "err", err)
+		srv.Close()
+	}
+}
+
+// stopOnExit calls stop once exit signal received. The returned func calls stop if not yet,
+// or waits for it done, so it can be deferred.
+func stopOnExit(exitSignal context.Context, stop func()) func() {
+	var once sync.Once
+	go func() {
+		<-exitSignal.Done()
+		once.Do(stop)
+	}()
+	return func() { once.Do(stop) }
+}
+
+// dirSize returns total size of regular files under the dir.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
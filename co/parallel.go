@@ -20,6 +20,7 @@ func Parallel(cb func(Enqueue)) {
 		cb(func(work func()) {
 			work()
 		})
+		return
 	}
 
 	var goes Goes
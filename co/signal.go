@@ -0,0 +1,52 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package co
+
+import (
+	"sync"
+)
+
+// Waiter provides channel to wait for.
+// The channel is closed once the event occurred.
+type Waiter interface {
+	C() <-chan struct{}
+}
+
+// Signal a rendezvous point for goroutines waiting for or announcing the occurrence of an event.
+// It's more friendly than sync.Cond, since it's channel base. That means you can do channel selection
+// to wait for an event.
+type Signal struct {
+	l  sync.Mutex
+	ch chan struct{}
+}
+
+// Broadcast wakes all goroutines that are waiting.
+func (s *Signal) Broadcast() {
+	s.l.Lock()
+	if s.ch != nil {
+		close(s.ch)
+		s.ch = nil
+	}
+	s.l.Unlock()
+}
+
+// NewWaiter create a Waiter object for acquiring channel to wait for.
+// The waiter is triggered by the next broadcast.
+func (s *Signal) NewWaiter() Waiter {
+	s.l.Lock()
+	if s.ch == nil {
+		s.ch = make(chan struct{})
+	}
+	ref := s.ch
+	s.l.Unlock()
+	return waiter(ref)
+}
+
+type waiter <-chan struct{}
+
+func (w waiter) C() <-chan struct{} {
+	return w
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package co_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vechain/thor/co"
+)
+
+func TestSignal(t *testing.T) {
+	var sig co.Signal
+
+	w1 := sig.NewWaiter()
+	w2 := sig.NewWaiter()
+	select {
+	case <-w1.C():
+		t.Fatal("should not be triggered before broadcast")
+	default:
+	}
+
+	go sig.Broadcast()
+	for _, w := range []co.Waiter{w1, w2} {
+		select {
+		case <-w.C():
+		case <-time.After(time.Second):
+			t.Fatal("should be triggered by broadcast")
+		}
+	}
+
+	select {
+	case <-sig.NewWaiter().C():
+		t.Fatal("new waiter should wait for the next broadcast")
+	default:
+	}
+}
@@ -71,6 +71,7 @@ func (c *Communicator) fetchBlockByID(peer *Peer, newBlockID thor.Bytes32) {
 	result, err := proto.GetBlockByID(c.ctx, peer, newBlockID)
 	if err != nil {
 		peer.logger.Debug("failed to get block by id", "err", err)
+		c.adjustScore(peer, penaltyTimeout, "get block by id")
 		return
 	}
 	if len(result) == 0 {
@@ -81,10 +82,12 @@ func (c *Communicator) fetchBlockByID(peer *Peer, newBlockID thor.Bytes32) {
 	var blk block.Block
 	if err := rlp.DecodeBytes(result, &blk); err != nil {
 		peer.logger.Debug("failed to decode block got by id", "err", err)
+		c.banPeer(peer, "bad block encoding")
 		return
 	}
 
 	c.newBlockFeed.Send(&NewBlockEvent{
 		Block: &blk,
+		peer:  peer,
 	})
 }
@@ -0,0 +1,70 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+)
+
+// BannedNode is a node refused to serve until the expiry.
+type BannedNode struct {
+	ID     discover.NodeID
+	Expiry uint64 // unix timestamp
+}
+
+// banList records banned nodes with their expiries.
+type banList struct {
+	m    map[discover.NodeID]uint64
+	lock sync.Mutex
+}
+
+func newBanList() *banList {
+	return &banList{m: make(map[discover.NodeID]uint64)}
+}
+
+// Ban bans the node until the expiry. An earlier expiry never shortens an existing ban.
+func (bl *banList) Ban(id discover.NodeID, expiry uint64) {
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	if expiry > bl.m[id] {
+		bl.m[id] = expiry
+	}
+}
+
+// IsBanned returns whether the node is banned at the moment.
+func (bl *banList) IsBanned(id discover.NodeID) bool {
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+	expiry, ok := bl.m[id]
+	if !ok {
+		return false
+	}
+	if expiry <= uint64(time.Now().Unix()) {
+		delete(bl.m, id)
+		return false
+	}
+	return true
+}
+
+// Slice returns all unexpired bans.
+func (bl *banList) Slice() []*BannedNode {
+	bl.lock.Lock()
+	defer bl.lock.Unlock()
+
+	now := uint64(time.Now().Unix())
+	nodes := make([]*BannedNode, 0, len(bl.m))
+	for id, expiry := range bl.m {
+		if expiry <= now {
+			delete(bl.m, id)
+			continue
+		}
+		nodes = append(nodes, &BannedNode{id, expiry})
+	}
+	return nodes
+}
@@ -4,3 +4,51 @@
 // file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
 
 package comm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/comm"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/txpool"
+)
+
+func TestBannedNodes(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(db))
+	ch, _ := chain.New(db, b0)
+	c := comm.New(ch, txpool.New(ch, state.NewCreator(db), txpool.DefaultPoolConfig, thor.NoFork), db)
+	assert.Empty(t, c.BannedNodes())
+
+	now := uint64(time.Now().Unix())
+	banned := &comm.BannedNode{ID: discover.NodeID{1}, Expiry: now + 3600}
+	c.SetBannedNodes([]*comm.BannedNode{
+		banned,
+		{ID: discover.NodeID{2}, Expiry: now - 1},
+		// shorter expiry doesn't override
+		{ID: discover.NodeID{1}, Expiry: now + 10},
+	})
+	assert.Equal(t, []*comm.BannedNode{banned}, c.BannedNodes())
+}
+
+func TestTxGossip(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, _ := g.Build(state.NewCreator(db))
+	ch, _ := chain.New(db, b0)
+	c := comm.New(ch, txpool.New(ch, state.NewCreator(db), txpool.DefaultPoolConfig, thor.NoFork), db)
+	assert.True(t, c.TxGossip())
+
+	c.SetTxGossip(false)
+	assert.False(t, c.TxGossip())
+	c.SetTxGossip(true)
+	assert.True(t, c.TxGossip())
+}
@@ -11,15 +11,19 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/inconshreveable/log15"
 	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/cache"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/co"
 	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/p2psrv"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
@@ -28,30 +32,48 @@ import (
 
 var log = log15.New("pkg", "comm")
 
+var (
+	peersGauge         = metric.NewGauge("sync", "peers", "count of connected peers")
+	syncedGauge        = metric.NewGauge("sync", "synced", "1 if the first synchronization passed")
+	syncCounter        = metric.NewCounterVec("sync", "rounds_total", "count of synchronization rounds with peers", "result")
+	syncDoneCounter    = syncCounter.WithLabelValues("done")
+	syncFailedCounter  = syncCounter.WithLabelValues("failed")
+	downloadedCounter  = metric.NewCounter("sync", "downloaded_blocks_total", "count of blocks downloaded in synchronization")
+	receivedNewCounter = metric.NewCounter("sync", "received_new_blocks_total", "count of new blocks broadcast by peers")
+)
+
 // Communicator communicates with remote p2p peers to exchange blocks and txs, etc.
 type Communicator struct {
 	chain          *chain.Chain
 	txPool         *txpool.TxPool
+	stateDB        kv.GetPutter
 	ctx            context.Context
 	cancel         context.CancelFunc
 	peerSet        *PeerSet
+	banList        *banList
+	fetchingTxs    *cache.RandCache
 	syncedCh       chan struct{}
 	newBlockFeed   event.Feed
 	announcementCh chan *announcement
 	feedScope      event.SubscriptionScope
 	goes           co.Goes
 	onceSynced     sync.Once
+	noTxGossip     int32
 }
 
 // New create a new Communicator instance.
-func New(chain *chain.Chain, txPool *txpool.TxPool) *Communicator {
+// stateDB is where state tries stored, to serve and receive state sync.
+func New(chain *chain.Chain, txPool *txpool.TxPool, stateDB kv.GetPutter) *Communicator {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Communicator{
 		chain:          chain,
 		txPool:         txPool,
+		stateDB:        stateDB,
 		ctx:            ctx,
 		cancel:         cancel,
 		peerSet:        newPeerSet(),
+		banList:        newBanList(),
+		fetchingTxs:    cache.NewRandCache(maxKnownTxs),
 		syncedCh:       make(chan struct{}),
 		announcementCh: make(chan *announcement),
 	}
@@ -95,11 +117,16 @@ func (c *Communicator) Sync(handler HandleBlockStream) {
 				log.Debug("synchronization start")
 
 				best := c.chain.BestBlock().Header()
-				// choose peer which has the head block with higher total score
-				peer := c.peerSet.Slice().Find(func(peer *Peer) bool {
-					_, totalScore := peer.Head()
-					return totalScore >= best.TotalScore()
-				})
+				// choose peer which has the head block with higher total score,
+				// and prefer the most useful one
+				var peer *Peer
+				for _, p := range c.peerSet.Slice() {
+					if _, totalScore := p.Head(); totalScore >= best.TotalScore() {
+						if peer == nil || p.Score() > peer.Score() {
+							peer = p
+						}
+					}
+				}
 				if peer == nil {
 					if c.peerSet.Len() < 3 {
 						log.Debug("no suitable peer to sync")
@@ -110,9 +137,13 @@ func (c *Communicator) Sync(handler HandleBlockStream) {
 				} else {
 					if err := c.sync(peer, best.Number(), handler); err != nil {
 						peer.logger.Debug("synchronization failed", "err", err)
+						c.adjustScore(peer, penaltyFailedSync, "synchronization failed")
+						syncFailedCounter.Inc()
 						break
 					}
 					peer.logger.Debug("synchronization done")
+					syncDoneCounter.Inc()
+					peer.AdjustScore(scoreSynced)
 				}
 				syncCount++
 
@@ -120,6 +151,7 @@ func (c *Communicator) Sync(handler HandleBlockStream) {
 					delay = syncInterval
 					c.onceSynced.Do(func() {
 						close(c.syncedCh)
+						syncedGauge.Set(1)
 					})
 				}
 			}
@@ -128,18 +160,25 @@ func (c *Communicator) Sync(handler HandleBlockStream) {
 }
 
 // Protocols returns all supported protocols.
+// Versions share the discovery topic of the first version, so that nodes of any version find each other.
 func (c *Communicator) Protocols() []*p2psrv.Protocol {
 	genesisID := c.chain.GenesisBlock().Header().ID()
-	return []*p2psrv.Protocol{
-		&p2psrv.Protocol{
+	protocols := make([]*p2psrv.Protocol, 0, len(proto.Versions))
+	for i, version := range proto.Versions {
+		version := version
+		protocols = append(protocols, &p2psrv.Protocol{
 			Protocol: p2p.Protocol{
 				Name:    proto.Name,
-				Version: proto.Version,
-				Length:  proto.Length,
-				Run:     c.servePeer,
+				Version: version,
+				Length:  proto.Lengths[i],
+				Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+					return c.servePeer(p, rw, version)
+				},
 			},
-			DiscTopic: fmt.Sprintf("%v%v@%x", proto.Name, proto.Version, genesisID[24:]),
-		}}
+			DiscTopic: fmt.Sprintf("%v%v@%x", proto.Name, proto.Version1, genesisID[24:]),
+		})
+	}
+	return protocols
 }
 
 // Start start the communicator.
@@ -160,8 +199,13 @@ type txsToSync struct {
 	synced bool
 }
 
-func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
-	peer := newPeer(p, rw)
+func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter, version uint) error {
+	if c.banList.IsBanned(p.ID()) {
+		log.Debug("refuse banned peer", "peer", p)
+		return p2p.DiscUselessPeer
+	}
+
+	peer := newPeer(p, rw, version)
 	c.goes.Go(func() {
 		c.runPeer(peer)
 	})
@@ -169,7 +213,12 @@ func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
 	var txsToSync txsToSync
 
 	return peer.Serve(func(msg *p2p.Msg, w func(interface{})) error {
-		return c.handleRPC(peer, msg, w, &txsToSync)
+		err := c.handleRPC(peer, msg, w, &txsToSync)
+		if err != nil {
+			// malformed or unknown messages are protocol violation
+			c.banPeer(peer, "protocol violation")
+		}
+		return err
 	}, proto.MaxMsgSize)
 }
 
@@ -192,7 +241,7 @@ func (c *Communicator) runPeer(peer *Peer) {
 	now := uint64(time.Now().Unix())
 	diff := now - status.SysTimestamp
 	if now < status.SysTimestamp {
-		diff = status.SysTimestamp
+		diff = status.SysTimestamp - now
 	}
 	if diff > thor.BlockInterval {
 		peer.logger.Debug("failed to handshake", "err", "sys time diff too large")
@@ -201,10 +250,12 @@ func (c *Communicator) runPeer(peer *Peer) {
 
 	peer.UpdateHead(status.BestBlockID, status.TotalScore)
 	c.peerSet.Add(peer)
+	peersGauge.Set(float64(c.peerSet.Len()))
 	peer.logger.Debug(fmt.Sprintf("peer added (%v)", c.peerSet.Len()))
 
 	defer func() {
 		c.peerSet.Remove(peer.ID())
+		peersGauge.Set(float64(c.peerSet.Len()))
 		peer.logger.Debug(fmt.Sprintf("peer removed (%v)", c.peerSet.Len()))
 	}()
 
@@ -257,6 +308,21 @@ func (c *Communicator) BroadcastBlock(blk *block.Block) {
 	}
 }
 
+// TxGossip returns whether txs from the pool are propagated to peers.
+func (c *Communicator) TxGossip() bool {
+	return atomic.LoadInt32(&c.noTxGossip) == 0
+}
+
+// SetTxGossip enables or disables propagating txs from the pool to peers.
+// Txs are still received and served on request while disabled.
+func (c *Communicator) SetTxGossip(enabled bool) {
+	var v int32
+	if !enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&c.noTxGossip, v)
+}
+
 // PeerCount returns count of peers.
 func (c *Communicator) PeerCount() int {
 	return c.peerSet.Len()
@@ -274,6 +340,7 @@ func (c *Communicator) PeersStats() []*PeerStats {
 			NetAddr:     peer.RemoteAddr().String(),
 			Inbound:     peer.Inbound(),
 			Duration:    uint64(time.Duration(peer.Duration()) / time.Second),
+			Score:       peer.Score(),
 		})
 	}
 	sort.Slice(stats, func(i, j int) bool {
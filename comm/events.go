@@ -14,6 +14,7 @@ import (
 // NewBlockEvent event emitted when received block announcement.
 type NewBlockEvent struct {
 	*block.Block
+	peer *Peer // from which the block received
 }
 
 // HandleBlockStream to handle the stream of downloaded blocks in sync process.
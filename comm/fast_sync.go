@@ -0,0 +1,246 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+const (
+	// maxNodeDataRequest max number of trie nodes requested in a call.
+	maxNodeDataRequest = 384
+	// maxHeadersRequest max number of headers served in a call.
+	maxHeadersRequest = 1024
+)
+
+// FastSync brings the chain to the trusted block without replaying blocks from genesis.
+// Blocks up to the trusted one are imported without execution, with their txs and
+// receipts verified against headers, then the state at the trusted block is downloaded.
+// It should be called before Sync, and the node switches to full block processing after
+// it returns.
+// Logs of blocks imported are not indexed, and blocks imported are rolled back if it fails.
+func (c *Communicator) FastSync(ctx context.Context, trustedID thor.Bytes32) (err error) {
+	var peer *Peer
+	for peer == nil {
+		peer = c.peerSet.Slice().Find(func(p *Peer) bool {
+			if p.Version() < proto.Version2 {
+				return false
+			}
+			_, totalScore := p.Head()
+			return totalScore >= c.chain.BestBlock().Header().TotalScore()
+		})
+		if peer != nil {
+			break
+		}
+		log.Debug("waiting for peer to fast sync")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second * 2):
+		}
+	}
+
+	best := c.chain.BestBlock().Header()
+	if best.Number() < block.Number(trustedID) {
+		defer func() {
+			if err != nil {
+				if rerr := c.chain.Rewind(best.ID()); rerr != nil {
+					log.Warn("failed to roll back fast synced blocks", "err", rerr)
+				}
+			}
+		}()
+		if err := c.importBlocks(ctx, peer, best, trustedID); err != nil {
+			return errors.WithMessage(err, "import blocks")
+		}
+	}
+	trusted, err := c.chain.GetBlockHeader(trustedID)
+	if err != nil {
+		return err
+	}
+	if err := c.syncState(ctx, peer, trusted.StateRoot()); err != nil {
+		return errors.WithMessage(err, "sync state")
+	}
+	log.Info("fast sync done", "block", trustedID)
+	return nil
+}
+
+// importBlocks imports blocks from the peer up to the trusted block, without execution.
+// The hash chain is verified back from the trusted block before any block written.
+func (c *Communicator) importBlocks(ctx context.Context, peer *Peer, best *block.Header, trustedID thor.Bytes32) error {
+	ids, err := c.fetchTrustedIDs(ctx, peer, best, trustedID)
+	if err != nil {
+		return err
+	}
+
+	for len(ids) > 0 {
+		result, err := proto.GetBlocksFromNumber(ctx, peer, block.Number(ids[0]))
+		if err != nil {
+			return err
+		}
+		if len(result) == 0 {
+			return errors.New("no more blocks")
+		}
+
+		blocks := make([]*block.Block, 0, len(result))
+		for i, raw := range result {
+			if i >= len(ids) {
+				break
+			}
+			var blk block.Block
+			if err := rlp.DecodeBytes(raw, &blk); err != nil {
+				return errors.Wrap(err, "invalid block")
+			}
+			header := blk.Header()
+			if header.ID() != ids[i] {
+				return errors.New("block mismatch")
+			}
+			if header.TxsRoot() != blk.Transactions().RootHash() {
+				return errors.New("txs root mismatch")
+			}
+			blocks = append(blocks, &blk)
+		}
+
+		receipts, err := proto.GetReceiptsByID(ctx, peer, ids[:len(blocks)])
+		if err != nil {
+			return err
+		}
+		if len(receipts) == 0 {
+			return errors.New("no receipts")
+		}
+		for i, r := range receipts {
+			if i >= len(blocks) {
+				break
+			}
+			if r.RootHash() != blocks[i].Header().ReceiptsRoot() {
+				return errors.New("receipts root mismatch")
+			}
+			if _, err := c.chain.AddBlock(blocks[i], r); err != nil {
+				return err
+			}
+			// continue from the last imported block, since receipts might be fewer than blocks
+			ids = ids[1:]
+		}
+		log.Debug("fast sync imported blocks", "best", c.chain.BestBlock().Header().Number())
+	}
+	return nil
+}
+
+// fetchTrustedIDs downloads headers back to front, from the trusted block to the best block.
+// It returns IDs of blocks above the best block up to the trusted block, in ascending order,
+// after the hash chain and signers verified.
+func (c *Communicator) fetchTrustedIDs(ctx context.Context, peer *Peer, best *block.Header, trustedID thor.Bytes32) ([]thor.Bytes32, error) {
+	ids := make([]thor.Bytes32, block.Number(trustedID)-best.Number())
+	expected := trustedID
+	for num := block.Number(trustedID); num > best.Number(); {
+		from := best.Number() + 1
+		if num-from >= maxHeadersRequest {
+			from = num - maxHeadersRequest + 1
+		}
+		headers, err := proto.GetHeadersFromNumber(ctx, peer, from)
+		if err != nil {
+			return nil, err
+		}
+		if uint32(len(headers)) <= num-from {
+			return nil, errors.New("not enough headers")
+		}
+		for i := int(num - from); i >= 0; i-- {
+			header := headers[i]
+			if header.ID() != expected {
+				return nil, errors.New("broken hash chain")
+			}
+			if _, err := header.Signer(); err != nil {
+				return nil, errors.WithMessage(err, "invalid signer")
+			}
+			ids[header.Number()-best.Number()-1] = expected
+			expected = header.ParentID()
+		}
+		num = from - 1
+		log.Debug("fast sync verified headers", "from", from)
+	}
+	if expected != best.ID() {
+		return nil, errors.New("trusted block not descending from best block")
+	}
+	return ids, nil
+}
+
+// syncState downloads the state trie with given root, including storage tries and codes.
+// The sync is resumable, since nodes already there are skipped.
+func (c *Communicator) syncState(ctx context.Context, peer *Peer, root thor.Bytes32) error {
+	var sched *trie.TrieSync
+	sched = trie.NewTrieSync(root, c.stateDB, func(leaf []byte, parent thor.Bytes32) error {
+		var acc state.Account
+		if err := rlp.DecodeBytes(leaf, &acc); err != nil {
+			return err
+		}
+		if len(acc.StorageRoot) > 0 {
+			sched.AddSubTrie(thor.BytesToBytes32(acc.StorageRoot), 64, parent, nil)
+		}
+		if len(acc.CodeHash) > 0 {
+			sched.AddRawEntry(thor.BytesToBytes32(acc.CodeHash), 64, parent)
+		}
+		return nil
+	})
+
+	// requested but not delivered
+	var undelivered []thor.Bytes32
+	for sched.Pending() > 0 {
+		hashes := append(append([]thor.Bytes32(nil), undelivered...), sched.Missing(maxNodeDataRequest-len(undelivered))...)
+		data, err := proto.GetNodeData(ctx, peer, hashes)
+		if err != nil {
+			return err
+		}
+
+		requested := make(map[thor.Bytes32]bool, len(hashes))
+		for _, hash := range hashes {
+			requested[hash] = true
+		}
+		results := make([]trie.SyncResult, 0, len(data))
+		for _, d := range data {
+			// trie nodes are hashed by blake2b, while codes by keccak
+			hash := thor.Blake2b(d)
+			if !requested[hash] {
+				hash = thor.BytesToBytes32(crypto.Keccak256(d))
+				if !requested[hash] {
+					return errors.New("unrequested node data")
+				}
+			}
+			delete(requested, hash)
+			results = append(results, trie.SyncResult{Hash: hash, Data: d})
+		}
+		if len(results) == 0 {
+			return errors.New("no node data")
+		}
+
+		undelivered = undelivered[:0]
+		for _, hash := range hashes {
+			if requested[hash] {
+				undelivered = append(undelivered, hash)
+			}
+		}
+
+		if _, i, err := sched.Process(results); err != nil {
+			return errors.Wrapf(err, "process node %v", results[i].Hash)
+		}
+		batch := c.stateDB.NewBatch()
+		if _, err := sched.Commit(batch); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -50,9 +50,10 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			return errors.WithMessage(err, "decode msg")
 		}
 
+		receivedNewCounter.Inc()
 		peer.MarkBlock(newBlock.Header().ID())
 		peer.UpdateHead(newBlock.Header().ID(), newBlock.Header().TotalScore())
-		c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock})
+		c.newBlockFeed.Send(&NewBlockEvent{Block: newBlock, peer: peer})
 		write(&struct{}{})
 	case proto.MsgNewBlockID:
 		var newBlockID thor.Bytes32
@@ -125,6 +126,98 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			size += metric.StorageSize(len(raw))
 		}
 		write(result)
+	case proto.MsgGetHeadersFromNumber:
+		var num uint32
+		if err := msg.Decode(&num); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		const maxHeaders = 1024
+		result := make([]*block.Header, 0, maxHeaders)
+		for len(result) < maxHeaders {
+			header, err := c.chain.GetTrunkBlockHeader(num)
+			if err != nil {
+				if !c.chain.IsNotFound(err) {
+					log.Error("failed to get block header by number", "err", err)
+				}
+				break
+			}
+			result = append(result, header)
+			num++
+		}
+		write(result)
+	case proto.MsgGetBodiesByID:
+		var ids []thor.Bytes32
+		if err := msg.Decode(&ids); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		const maxBodies = 1024
+		result := make([]*block.Body, 0, len(ids))
+		var size metric.StorageSize
+		for _, id := range ids {
+			if size >= maxResultSize || len(result) >= maxBodies {
+				break
+			}
+			body, err := c.chain.GetBlockBody(id)
+			if err != nil {
+				if !c.chain.IsNotFound(err) {
+					log.Error("failed to get block body", "err", err)
+				}
+				break
+			}
+			result = append(result, body)
+			for _, tx := range body.Txs {
+				size += tx.Size()
+			}
+		}
+		write(result)
+	case proto.MsgGetReceiptsByID:
+		var ids []thor.Bytes32
+		if err := msg.Decode(&ids); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		const maxReceipts = 1024
+		result := make([]*proto.BlockReceipts, 0, len(ids))
+		for _, id := range ids {
+			if len(result) >= maxReceipts {
+				break
+			}
+			receipts, err := c.chain.GetBlockReceipts(id)
+			if err != nil {
+				if !c.chain.IsNotFound(err) {
+					log.Error("failed to get block receipts", "err", err)
+				}
+				break
+			}
+			result = append(result, proto.NewBlockReceipts(receipts))
+		}
+		write(result)
+	case proto.MsgGetNodeData:
+		var hashes []thor.Bytes32
+		if err := msg.Decode(&hashes); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		const maxNodes = 384
+		result := make([][]byte, 0, len(hashes))
+		var size metric.StorageSize
+		for _, hash := range hashes {
+			if size >= maxResultSize || len(result) >= maxNodes {
+				break
+			}
+			data, err := c.stateDB.Get(hash[:])
+			if err != nil {
+				if !c.stateDB.IsNotFound(err) {
+					log.Error("failed to get node data", "err", err)
+				}
+				continue
+			}
+			result = append(result, data)
+			size += metric.StorageSize(len(data))
+		}
+		write(result)
 	case proto.MsgGetTxs:
 		const maxTxSyncSize = 100 * 1024
 		if err := msg.Decode(&struct{}{}); err != nil {
@@ -164,6 +257,49 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(interface{
 			}
 			write(toSend)
 		}
+	case proto.MsgNewTxIDs:
+		var ids []thor.Bytes32
+		if err := msg.Decode(&ids); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if len(ids) > maxTxAnnouncement {
+			return fmt.Errorf("too many tx ids (%v)", len(ids))
+		}
+
+		var toFetch []thor.Bytes32
+		for _, id := range ids {
+			peer.MarkTransaction(id)
+			if c.txPool.Get(id) != nil || c.fetchingTxs.Contains(id) {
+				continue
+			}
+			c.fetchingTxs.Set(id, struct{}{})
+			toFetch = append(toFetch, id)
+		}
+		if len(toFetch) > 0 {
+			c.goes.Go(func() { c.fetchAnnouncedTxs(peer, toFetch) })
+		}
+		write(&struct{}{})
+	case proto.MsgGetTxsByID:
+		var ids []thor.Bytes32
+		if err := msg.Decode(&ids); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		var (
+			result tx.Transactions
+			size   metric.StorageSize
+		)
+		for _, id := range ids {
+			if size >= maxResultSize || len(result) >= maxTxAnnouncement {
+				break
+			}
+			if tx := c.txPool.Get(id); tx != nil {
+				peer.MarkTransaction(id)
+				result = append(result, tx)
+				size += tx.Size()
+			}
+		}
+		write(result)
 	default:
 		return fmt.Errorf("unknown message (%v)", msg.Code)
 	}
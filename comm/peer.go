@@ -34,6 +34,7 @@ type Peer struct {
 	*rpc.RPC
 	logger log15.Logger
 
+	version     uint
 	createdTime mclock.AbsTime
 	knownTxs    *lru.Cache
 	knownBlocks *lru.Cache
@@ -42,9 +43,13 @@ type Peer struct {
 		id         thor.Bytes32
 		totalScore uint64
 	}
+	score struct {
+		sync.Mutex
+		value int
+	}
 }
 
-func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter, version uint) *Peer {
 	dir := "outbound"
 	if peer.Inbound() {
 		dir = "inbound"
@@ -52,6 +57,7 @@ func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
 	ctx := []interface{}{
 		"peer", peer,
 		"dir", dir,
+		"ver", version,
 	}
 	knownTxs, _ := lru.New(maxKnownTxs)
 	knownBlocks, _ := lru.New(maxKnownBlocks)
@@ -59,12 +65,18 @@ func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
 		Peer:        peer,
 		RPC:         rpc.New(peer, rw),
 		logger:      log.New(ctx...),
+		version:     version,
 		createdTime: mclock.Now(),
 		knownTxs:    knownTxs,
 		knownBlocks: knownBlocks,
 	}
 }
 
+// Version returns the protocol version negotiated with the peer.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
 // Head returns head block ID and total score.
 func (p *Peer) Head() (id thor.Bytes32, totalScore uint64) {
 	p.head.Lock()
@@ -81,6 +93,26 @@ func (p *Peer) UpdateHead(id thor.Bytes32, totalScore uint64) {
 	}
 }
 
+// Score returns the usefulness score of the peer.
+func (p *Peer) Score() int {
+	p.score.Lock()
+	defer p.score.Unlock()
+	return p.score.value
+}
+
+// AdjustScore adds delta to the score, and returns the new score.
+// The score is capped at maxPeerScore, so that a long-serving peer can't build
+// up credit to misbehave.
+func (p *Peer) AdjustScore(delta int) int {
+	p.score.Lock()
+	defer p.score.Unlock()
+	p.score.value += delta
+	if p.score.value > maxPeerScore {
+		p.score.value = maxPeerScore
+	}
+	return p.score.value
+}
+
 // MarkTransaction marks a transaction to known.
 func (p *Peer) MarkTransaction(id thor.Bytes32) {
 	p.knownTxs.Add(id, struct{}{})
@@ -12,11 +12,23 @@ import (
 // Constants
 const (
 	Name              = "thor"
-	Version    uint   = 1
-	Length     uint64 = 8
+	Version    uint   = Version2
+	Length     uint64 = 14
 	MaxMsgSize        = 10 * 1024 * 1024
 )
 
+// Protocol versions
+const (
+	Version1 uint = 1 // messages up to MsgGetTxs
+	Version2 uint = 2 // adds messages to sync headers, bodies, receipts and state, and to announce tx IDs
+)
+
+// Versions lists versions supported, the latest first, and Lengths the count of messages of each.
+var (
+	Versions = []uint{Version2, Version1}
+	Lengths  = []uint64{Length, 8}
+)
+
 // Protocol messages of thor
 const (
 	MsgGetStatus = iota
@@ -27,6 +39,12 @@ const (
 	MsgGetBlockIDByNumber
 	MsgGetBlocksFromNumber // fetch blocks from given number (including given number)
 	MsgGetTxs
+	MsgGetHeadersFromNumber // fetch headers from given number (including given number)
+	MsgGetBodiesByID
+	MsgGetReceiptsByID
+	MsgGetNodeData // fetch state trie nodes or codes by hashes
+	MsgNewTxIDs    // announce a batch of tx IDs
+	MsgGetTxsByID
 )
 
 // MsgName convert msg code to string.
@@ -48,6 +66,18 @@ func MsgName(msgCode uint64) string {
 		return "MsgGetBlocksFromNumber"
 	case MsgGetTxs:
 		return "MsgGetTxs"
+	case MsgGetHeadersFromNumber:
+		return "MsgGetHeadersFromNumber"
+	case MsgGetBodiesByID:
+		return "MsgGetBodiesByID"
+	case MsgGetReceiptsByID:
+		return "MsgGetReceiptsByID"
+	case MsgGetNodeData:
+		return "MsgGetNodeData"
+	case MsgNewTxIDs:
+		return "MsgNewTxIDs"
+	case MsgGetTxsByID:
+		return "MsgGetTxsByID"
 	default:
 		return fmt.Sprintf("unknown msg code(%v)", msgCode)
 	}
@@ -23,8 +23,51 @@ type (
 		BestBlockID    thor.Bytes32
 		TotalScore     uint64
 	}
+
+	// BlockReceipts receipts of a block, as result item of MsgGetReceiptsByID.
+	// Receipts are in the consensus encoding, with other fields of outputs carried aside.
+	BlockReceipts struct {
+		Receipts tx.Receipts
+		Extras   [][]OutputExtra
+	}
+
+	// OutputExtra fields of receipt output out of the consensus encoding.
+	OutputExtra struct {
+		GasUsed uint64
+		Data    []byte
+	}
 )
 
+// NewBlockReceipts creates BlockReceipts with extra fields of outputs collected.
+func NewBlockReceipts(receipts tx.Receipts) *BlockReceipts {
+	extras := make([][]OutputExtra, 0, len(receipts))
+	for _, receipt := range receipts {
+		outputs := make([]OutputExtra, 0, len(receipt.Outputs))
+		for _, o := range receipt.Outputs {
+			outputs = append(outputs, OutputExtra{o.GasUsed, o.Data})
+		}
+		extras = append(extras, outputs)
+	}
+	return &BlockReceipts{receipts, extras}
+}
+
+// Merged returns receipts with extra fields of outputs filled.
+func (br *BlockReceipts) Merged() tx.Receipts {
+	for i, receipt := range br.Receipts {
+		if i >= len(br.Extras) {
+			break
+		}
+		for j, o := range receipt.Outputs {
+			if j >= len(br.Extras[i]) {
+				break
+			}
+			o.GasUsed = br.Extras[i][j].GasUsed
+			o.Data = br.Extras[i][j].Data
+		}
+	}
+	return br.Receipts
+}
+
 // RPC defines RPC interface.
 type RPC interface {
 	Notify(ctx context.Context, msgCode uint64, arg interface{}) error
@@ -55,6 +98,12 @@ func NotifyNewTx(ctx context.Context, rpc RPC, tx *tx.Transaction) error {
 	return rpc.Notify(ctx, MsgNewTx, tx)
 }
 
+// NotifyNewTxIDs announce IDs of new txs to remote peer, whose bodies can be then
+// fetched by GetTxsByID.
+func NotifyNewTxIDs(ctx context.Context, rpc RPC, ids []thor.Bytes32) error {
+	return rpc.Notify(ctx, MsgNewTxIDs, ids)
+}
+
 // GetBlockByID query block from remote peer by given block ID.
 // It may return nil block even no error.
 func GetBlockByID(ctx context.Context, rpc RPC, id thor.Bytes32) (rlp.RawValue, error) {
@@ -94,3 +143,58 @@ func GetTxs(ctx context.Context, rpc RPC) (tx.Transactions, error) {
 	}
 	return txs, nil
 }
+
+// GetHeadersFromNumber get a batch of block headers starts with num from remote peer.
+func GetHeadersFromNumber(ctx context.Context, rpc RPC, num uint32) ([]*block.Header, error) {
+	var headers []*block.Header
+	if err := rpc.Call(ctx, MsgGetHeadersFromNumber, num, &headers); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// GetBodiesByID get block bodies by given block IDs from remote peer.
+// Bodies returned are in the order of IDs, and may be fewer than IDs, if the remote peer
+// doesn't have them all or the size limit reached.
+func GetBodiesByID(ctx context.Context, rpc RPC, ids []thor.Bytes32) ([]*block.Body, error) {
+	var bodies []*block.Body
+	if err := rpc.Call(ctx, MsgGetBodiesByID, ids, &bodies); err != nil {
+		return nil, err
+	}
+	return bodies, nil
+}
+
+// GetReceiptsByID get receipts of blocks by given block IDs from remote peer.
+// Like GetBodiesByID, it may return fewer items than IDs.
+// Fields of outputs out of the consensus encoding are also filled.
+func GetReceiptsByID(ctx context.Context, rpc RPC, ids []thor.Bytes32) ([]tx.Receipts, error) {
+	var result []*BlockReceipts
+	if err := rpc.Call(ctx, MsgGetReceiptsByID, ids, &result); err != nil {
+		return nil, err
+	}
+	receipts := make([]tx.Receipts, 0, len(result))
+	for _, br := range result {
+		receipts = append(receipts, br.Merged())
+	}
+	return receipts, nil
+}
+
+// GetNodeData get state trie nodes or codes by given hashes from remote peer.
+// Items not found are omitted, so the result should be matched by hash.
+func GetNodeData(ctx context.Context, rpc RPC, hashes []thor.Bytes32) ([][]byte, error) {
+	var data [][]byte
+	if err := rpc.Call(ctx, MsgGetNodeData, hashes, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetTxsByID get txs by given IDs from remote peer's pool.
+// Txs not found are omitted.
+func GetTxsByID(ctx context.Context, rpc RPC, ids []thor.Bytes32) (tx.Transactions, error) {
+	var txs tx.Transactions
+	if err := rpc.Call(ctx, MsgGetTxsByID, ids, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
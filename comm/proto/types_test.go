@@ -0,0 +1,44 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package proto_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+func TestBlockReceipts(t *testing.T) {
+	receipts := tx.Receipts{{
+		GasUsed:  21000,
+		GasPayer: thor.BytesToAddress([]byte("payer")),
+		Paid:     big.NewInt(1),
+		Reward:   big.NewInt(2),
+		Outputs: []*tx.Output{
+			{Events: tx.Events{}, Transfers: tx.Transfers{}, GasUsed: 100, Data: []byte{1, 2}},
+			{Events: tx.Events{}, Transfers: tx.Transfers{}, GasUsed: 200},
+		},
+	}}
+
+	data, err := rlp.EncodeToBytes(proto.NewBlockReceipts(receipts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var br proto.BlockReceipts
+	if err := rlp.DecodeBytes(data, &br); err != nil {
+		t.Fatal(err)
+	}
+	merged := br.Merged()
+	assert.Equal(t, receipts.RootHash(), merged.RootHash())
+	assert.Equal(t, uint64(100), merged[0].Outputs[0].GasUsed)
+	assert.Equal(t, []byte{1, 2}, merged[0].Outputs[0].Data)
+	assert.Equal(t, uint64(200), merged[0].Outputs[1].GasUsed)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+const (
+	maxPeerScore = 100
+	// peer will be disconnected and banned once its score drops to this line
+	banPeerScore = -100
+	banDuration  = 12 * time.Hour
+
+	scoreSynced         = 10  // a sync round served successfully
+	penaltyTimeout      = -10 // request failed or timed out
+	penaltyFailedSync   = -20 // a sync round aborted
+	penaltyInvalidBlock = -50 // block failed to pass consensus
+)
+
+// adjustScore adjusts score of the peer, and bans it if the score is too low.
+func (c *Communicator) adjustScore(peer *Peer, delta int, reason string) {
+	if score := peer.AdjustScore(delta); score <= banPeerScore {
+		c.banPeer(peer, reason)
+	} else if delta < 0 {
+		peer.logger.Debug("peer score lowered", "score", score, "reason", reason)
+	}
+}
+
+// banPeer bans the peer and disconnects it.
+func (c *Communicator) banPeer(peer *Peer, reason string) {
+	c.banList.Ban(peer.ID(), uint64(time.Now().Add(banDuration).Unix()))
+	peer.logger.Debug("peer banned", "reason", reason)
+	peer.Disconnect(p2p.DiscUselessPeer)
+}
+
+// ReportBadBlock penalizes the peer the new block received from, when the block
+// is found invalid.
+func (c *Communicator) ReportBadBlock(ev *NewBlockEvent) {
+	if ev.peer != nil {
+		c.adjustScore(ev.peer, penaltyInvalidBlock, "invalid block")
+	}
+}
+
+// BannedNodes returns nodes currently banned.
+func (c *Communicator) BannedNodes() []*BannedNode {
+	return c.banList.Slice()
+}
+
+// SetBannedNodes restores banned nodes, e.g. loaded from previous run.
+// It should be called before the communicator starting to serve peers.
+func (c *Communicator) SetBannedNodes(nodes []*BannedNode) {
+	for _, node := range nodes {
+		c.banList.Ban(node.ID, node.Expiry)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/co"
+	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/thor"
+)
+
+const (
+	maxBodiesRequest     = 64 // max number of block bodies requested in a call
+	maxSkeletonWitnesses = 2  // max number of other peers to cross-validate a skeleton segment
+)
+
+// fetchBlocks downloads blocks from fromNum, and sends them to blockCh in sequence.
+// Headers are fetched in segments from the peer as the skeleton, which is cross-validated
+// with other peers, then bodies are filled from multiple peers in parallel.
+// Since blocks are executed and persisted while downloading, an interrupted sync is resumed
+// from the best block, e.g. after restart.
+// Peers of version 1 don't serve headers and bodies, blocks are downloaded in whole from them.
+func (c *Communicator) fetchBlocks(ctx context.Context, peer *Peer, fromNum uint32, blockCh chan<- *block.Block) error {
+	if peer.Version() < proto.Version2 {
+		return c.fetchBlocksByNumber(ctx, peer, fromNum, blockCh)
+	}
+	parentID, err := c.chain.GetTrunkBlockID(fromNum - 1)
+	if err != nil {
+		return err
+	}
+	for {
+		headers, err := c.fetchSkeleton(ctx, peer, fromNum, parentID)
+		if err != nil {
+			return errors.WithMessage(err, "fetch skeleton")
+		}
+		if len(headers) == 0 {
+			return nil
+		}
+
+		blocks, err := c.fillBodies(ctx, peer, headers)
+		if err != nil {
+			return errors.WithMessage(err, "fill bodies")
+		}
+
+		downloadedCounter.Add(float64(len(blocks)))
+		for _, blk := range blocks {
+			peer.MarkBlock(blk.Header().ID())
+			select {
+			case <-ctx.Done():
+				return nil
+			case blockCh <- blk:
+			}
+		}
+
+		last := headers[len(headers)-1]
+		fromNum, parentID = last.Number()+1, last.ID()
+	}
+}
+
+// fetchBlocksByNumber downloads blocks from fromNum in whole from the peer, and sends them to blockCh in sequence.
+func (c *Communicator) fetchBlocksByNumber(ctx context.Context, peer *Peer, fromNum uint32, blockCh chan<- *block.Block) error {
+	for {
+		result, err := proto.GetBlocksFromNumber(ctx, peer, fromNum)
+		if err != nil {
+			return err
+		}
+		if len(result) == 0 {
+			return nil
+		}
+
+		downloadedCounter.Add(float64(len(result)))
+		for _, raw := range result {
+			var blk block.Block
+			if err := rlp.DecodeBytes(raw, &blk); err != nil {
+				return errors.Wrap(err, "invalid block")
+			}
+			if _, err := blk.Header().Signer(); err != nil {
+				return errors.Wrap(err, "invalid block")
+			}
+			if blk.Header().Number() != fromNum {
+				return errors.New("broken sequence")
+			}
+			peer.MarkBlock(blk.Header().ID())
+			fromNum++
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case blockCh <- &blk:
+			}
+		}
+	}
+}
+
+// fetchSkeleton fetches a segment of headers from the peer, which must be linked to the parent.
+func (c *Communicator) fetchSkeleton(ctx context.Context, peer *Peer, fromNum uint32, parentID thor.Bytes32) ([]*block.Header, error) {
+	headers, err := proto.GetHeadersFromNumber(ctx, peer, fromNum)
+	if err != nil {
+		return nil, err
+	}
+	for _, header := range headers {
+		if header.Number() != fromNum || header.ParentID() != parentID {
+			return nil, errors.New("broken sequence")
+		}
+		if _, err := header.Signer(); err != nil {
+			return nil, errors.Wrap(err, "invalid header")
+		}
+		fromNum, parentID = fromNum+1, header.ID()
+	}
+	if len(headers) > 0 {
+		if err := c.confirmSkeleton(ctx, peer, headers[len(headers)-1]); err != nil {
+			return nil, err
+		}
+	}
+	return headers, nil
+}
+
+// confirmSkeleton cross-validates the last header of a segment with other peers.
+// The segment is rejected if witnesses mostly have different blocks at the height.
+// Witnesses not having the height are not counted, as well as those failing to answer.
+func (c *Communicator) confirmSkeleton(ctx context.Context, peer *Peer, last *block.Header) error {
+	witnesses := c.peerSet.Slice().Filter(func(p *Peer) bool {
+		_, totalScore := p.Head()
+		return p != peer && totalScore >= last.TotalScore()
+	})
+	if len(witnesses) > maxSkeletonWitnesses {
+		witnesses = witnesses[:maxSkeletonWitnesses]
+	}
+
+	var agreed, disagreed int
+	for _, w := range witnesses {
+		id, err := proto.GetBlockIDByNumber(ctx, w, last.Number())
+		if err != nil {
+			w.logger.Debug("failed to get block id by number", "err", err)
+			continue
+		}
+		if id == last.ID() {
+			agreed++
+		} else if !id.IsZero() {
+			disagreed++
+		}
+	}
+	if disagreed > agreed {
+		return errors.New("skeleton rejected by other peers")
+	}
+	return nil
+}
+
+// fillBodies fetches bodies for headers in parallel, from peers of version 2 having these blocks.
+// The peer provided the skeleton is the fallback if others fail.
+func (c *Communicator) fillBodies(ctx context.Context, peer *Peer, headers []*block.Header) ([]*block.Block, error) {
+	last := headers[len(headers)-1]
+	peers := append(Peers{peer}, c.peerSet.Slice().Filter(func(p *Peer) bool {
+		_, totalScore := p.Head()
+		return p != peer && p.Version() >= proto.Version2 && totalScore >= last.TotalScore()
+	})...)
+
+	n := (len(headers) + maxBodiesRequest - 1) / maxBodiesRequest
+	results := make([][]*block.Block, n)
+	errs := make([]error, n)
+
+	var goes co.Goes
+	for i := 0; i < n; i++ {
+		i := i
+		chunk := headers[i*maxBodiesRequest:]
+		if len(chunk) > maxBodiesRequest {
+			chunk = chunk[:maxBodiesRequest]
+		}
+		p := peers[i%len(peers)]
+		goes.Go(func() {
+			blocks, err := c.fetchBodies(ctx, p, chunk)
+			if err != nil && p != peer {
+				c.adjustScore(p, penaltyTimeout, "fetch bodies")
+				blocks, err = c.fetchBodies(ctx, peer, chunk)
+			}
+			results[i], errs[i] = blocks, err
+		})
+	}
+	goes.Wait()
+
+	blocks := make([]*block.Block, 0, len(headers))
+	for i, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, results[i]...)
+	}
+	return blocks, nil
+}
+
+// fetchBodies fetches bodies for headers from the peer, and composes blocks.
+func (c *Communicator) fetchBodies(ctx context.Context, peer *Peer, headers []*block.Header) ([]*block.Block, error) {
+	blocks := make([]*block.Block, 0, len(headers))
+	for len(blocks) < len(headers) {
+		rest := headers[len(blocks):]
+		ids := make([]thor.Bytes32, 0, len(rest))
+		for _, header := range rest {
+			ids = append(ids, header.ID())
+		}
+
+		bodies, err := proto.GetBodiesByID(ctx, peer, ids)
+		if err != nil {
+			return nil, err
+		}
+		if len(bodies) == 0 {
+			return nil, errors.New("no bodies")
+		}
+		for i, body := range bodies {
+			if i >= len(rest) {
+				break
+			}
+			if body.Txs.RootHash() != rest[i].TxsRoot() {
+				return nil, errors.New("txs root mismatch")
+			}
+			blocks = append(blocks, block.Compose(rest[i], body.Txs))
+		}
+	}
+	return blocks, nil
+}
@@ -9,7 +9,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/co"
@@ -41,39 +40,8 @@ func (c *Communicator) download(peer *Peer, fromNum uint32, handler HandleBlockS
 	})
 	goes.Go(func() {
 		defer close(blockCh)
-		for {
-			result, err := proto.GetBlocksFromNumber(ctx, peer, fromNum)
-			if err != nil {
-				errCh <- err
-				return
-			}
-			if len(result) == 0 {
-				return
-			}
-
-			for _, raw := range result {
-				var blk block.Block
-				if err := rlp.DecodeBytes(raw, &blk); err != nil {
-					errCh <- errors.Wrap(err, "invalid block")
-					return
-				}
-				if _, err := blk.Header().Signer(); err != nil {
-					errCh <- errors.Wrap(err, "invalid block")
-					return
-				}
-				if blk.Header().Number() != fromNum {
-					errCh <- errors.New("broken sequence")
-					return
-				}
-				peer.MarkBlock(blk.Header().ID())
-				fromNum++
-
-				select {
-				case <-ctx.Done():
-					return
-				case blockCh <- &blk:
-				}
-			}
+		if err := c.fetchBlocks(ctx, peer, fromNum, blockCh); err != nil {
+			errCh <- err
 		}
 	})
 	goes.Wait()
@@ -6,34 +6,105 @@
 package comm
 
 import (
+	"math"
+	"time"
+
 	"github.com/vechain/thor/comm/proto"
+	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
 )
 
+const (
+	// minTxGossipPeers minimum number of peers a new tx is gossiped to.
+	minTxGossipPeers = 4
+	// maxTxAnnouncement max number of tx IDs in an announcement.
+	maxTxAnnouncement = 256
+	// txAnnounceInterval interval to flush batched tx announcements.
+	txAnnounceInterval = 100 * time.Millisecond
+)
+
 func (c *Communicator) txsLoop() {
 
 	txCh := make(chan *tx.Transaction)
 	sub := c.txPool.SubscribeNewTransaction(txCh)
 	defer sub.Unsubscribe()
 
+	ticker := time.NewTicker(txAnnounceInterval)
+	defer ticker.Stop()
+
+	// tx IDs to be announced, batched per peer
+	announcements := make(map[*Peer][]thor.Bytes32)
+
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
 		case tx := <-txCh:
+			if !c.TxGossip() {
+				break
+			}
 			peers := c.peerSet.Slice().Filter(func(p *Peer) bool {
 				return !p.IsTransactionKnown(tx.ID())
 			})
 
-			for _, peer := range peers {
+			// peers are in random order, push the tx to a subset of them, and only
+			// announce the tx ID to the rest, who fetch the tx on demand.
+			// peers of version 1 can't fetch on demand, so they are always pushed.
+			n := int(math.Sqrt(float64(len(peers))))
+			if n < minTxGossipPeers {
+				n = minTxGossipPeers
+			}
+
+			for i, peer := range peers {
 				peer := peer
 				peer.MarkTransaction(tx.ID())
+				if i >= n && peer.Version() >= proto.Version2 {
+					announcements[peer] = append(announcements[peer], tx.ID())
+					continue
+				}
 				c.goes.Go(func() {
 					if err := proto.NotifyNewTx(c.ctx, peer, tx); err != nil {
 						peer.logger.Debug("failed to broadcast tx", "err", err)
 					}
 				})
 			}
+		case <-ticker.C:
+			for peer, ids := range announcements {
+				delete(announcements, peer)
+				for len(ids) > 0 {
+					batch := ids
+					if len(batch) > maxTxAnnouncement {
+						batch = batch[:maxTxAnnouncement]
+					}
+					ids = ids[len(batch):]
+
+					peer := peer
+					c.goes.Go(func() {
+						if err := proto.NotifyNewTxIDs(c.ctx, peer, batch); err != nil {
+							peer.logger.Debug("failed to announce tx ids", "err", err)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// fetchAnnouncedTxs fetches txs announced by the peer, and adds them into the pool.
+func (c *Communicator) fetchAnnouncedTxs(peer *Peer, ids []thor.Bytes32) {
+	defer func() {
+		for _, id := range ids {
+			c.fetchingTxs.Remove(id)
 		}
+	}()
+
+	txs, err := proto.GetTxsByID(c.ctx, peer, ids)
+	if err != nil {
+		peer.logger.Debug("failed to fetch announced txs", "err", err)
+		return
+	}
+	for _, tx := range txs {
+		peer.MarkTransaction(tx.ID())
+		c.txPool.Add(tx)
 	}
 }
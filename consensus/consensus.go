@@ -8,7 +8,10 @@ package consensus
 import (
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/poa"
+	"github.com/vechain/thor/runtime"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
 )
 
@@ -17,13 +20,18 @@ import (
 type Consensus struct {
 	chain        *chain.Chain
 	stateCreator *state.Creator
+	slots        poa.Slots
+	forkConfig   thor.ForkConfig
 }
 
 // New create a Consensus instance.
-func New(chain *chain.Chain, stateCreator *state.Creator) *Consensus {
+func New(chain *chain.Chain, stateCreator *state.Creator, forkConfig thor.ForkConfig) *Consensus {
 	return &Consensus{
 		chain:        chain,
-		stateCreator: stateCreator}
+		stateCreator: stateCreator,
+		slots:        poa.NewSlots(chain.GenesisBlock().Header().Timestamp()),
+		forkConfig:   forkConfig,
+	}
 }
 
 // Process process a block.
@@ -58,3 +66,50 @@ func (c *Consensus) Process(blk *block.Block, nowTimestamp uint64) (*state.Stage
 
 	return stage, receipts, nil
 }
+
+// Replay executes txs of a block from trusted source upon the state of its parent.
+// Checks of header and body are skipped, while proposer updates are applied and
+// the execution result is still verified against roots in header.
+func (c *Consensus) Replay(blk *block.Block) (*state.Stage, tx.Receipts, error) {
+	header := blk.Header()
+
+	parentHeader, err := c.chain.GetBlockHeader(header.ParentID())
+	if err != nil {
+		if !c.chain.IsNotFound(err) {
+			return nil, nil, err
+		}
+		return nil, nil, errParentMissing
+	}
+
+	state, err := c.stateCreator.NewState(parentHeader.StateRoot())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.validateProposer(header, parentHeader, state); err != nil {
+		return nil, nil, err
+	}
+	return c.verifyBlock(blk, state)
+}
+
+// NewRuntimeForReplay create a runtime to replay txs of the block, upon the state of its parent.
+// Proposer updates are applied as validation does, so that txs are replayed in the same context.
+func (c *Consensus) NewRuntimeForReplay(header *block.Header) (*runtime.Runtime, error) {
+	parentHeader, err := c.chain.GetBlockHeader(header.ParentID())
+	if err != nil {
+		if !c.chain.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, errParentMissing
+	}
+
+	state, err := c.stateCreator.NewState(parentHeader.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateProposer(header, parentHeader, state); err != nil {
+		return nil, err
+	}
+	return c.newRuntime(header, state), nil
+}
@@ -87,7 +87,7 @@ func newTestConsensus(t *testing.T) *testConsensus {
 	}
 
 	proposer := genesis.DevAccounts()[0]
-	p := packer.New(c, stateCreator, proposer.Address, proposer.Address)
+	p := packer.New(c, stateCreator, proposer.Address, proposer.Address, thor.NoFork)
 	flow, err := p.Schedule(parent.Header(), uint64(time.Now().Unix()))
 	if err != nil {
 		t.Fatal(err)
@@ -98,7 +98,7 @@ func newTestConsensus(t *testing.T) *testConsensus {
 		t.Fatal(err)
 	}
 
-	con := New(c, stateCreator)
+	con := New(c, stateCreator, thor.NoFork)
 	if _, _, err := con.Process(original, flow.When()); err != nil {
 		t.Fatal(err)
 	}
@@ -243,6 +243,32 @@ func (tc *testConsensus) TestTxDepBroken() {
 	tc.assert.Equal(err, consensusError("tx dep broken"))
 }
 
+func (tc *testConsensus) TestTxExecutionFailed() {
+	// no energy to pay for gas
+	key, _ := crypto.GenerateKey()
+	transaction := txBuilder(tc.tag).Build()
+	sig, _ := crypto.Sign(transaction.SigningHash().Bytes(), key)
+	err := tc.consent(
+		tc.sign(
+			tc.originalBuilder().Transaction(transaction.WithSignature(sig)).Build(),
+		),
+	)
+	tc.assert.True(IsCritical(err))
+	tc.assert.Contains(err.Error(), "tx execution failed")
+
+	// vm error reverts the tx, rather than fails it
+	// PUSH1 0, PUSH1 0, REVERT
+	reverted := txSign(txBuilder(tc.tag).Clause(tx.NewClause(nil).WithData([]byte{0x60, 0x00, 0x60, 0x00, 0xfd})))
+	err = tc.consent(
+		tc.sign(
+			tc.originalBuilder().Transaction(reverted).Build(),
+		),
+	)
+	tc.assert.NotContains(err.Error(), "tx execution failed")
+	// executed, but the header built for the empty block mismatches
+	tc.assert.Contains(err.Error(), "block gas used mismatch")
+}
+
 func (tc *testConsensus) TestKnownBlock() {
 	err := tc.consent(tc.parent)
 	tc.assert.Equal(err, errKnownBlock)
@@ -316,6 +342,21 @@ func (tc *testConsensus) TestValidateBlockBody() {
 		expect := consensusError("tx ref future block: ref 100, current 1")
 		tc.assert.Equal(err, expect)
 	}
+	triggers["triggerErrDelegationNotActivated"] = func() {
+		var features tx.Features
+		features.SetDelegated(true)
+		trx, _ := tx.SignDelegated(txBuilder(tc.tag).Features(features).Build(), genesis.DevAccounts()[0].PrivateKey, genesis.DevAccounts()[1].PrivateKey)
+		blk := tc.sign(tc.originalBuilder().Transaction(trx).Build())
+
+		expect := consensusError("tx delegation not activated")
+		tc.assert.Equal(expect, tc.consent(blk))
+
+		// passes body validation once activated
+		forkConfig := tc.con.forkConfig
+		defer func() { tc.con.forkConfig = forkConfig }()
+		tc.con.forkConfig.VIP191 = 1
+		tc.assert.NotEqual(expect, tc.consent(blk))
+	}
 
 	for _, trigger := range triggers {
 		trigger()
@@ -370,3 +411,17 @@ func (tc *testConsensus) TestValidateProposer() {
 		trigger()
 	}
 }
+
+func (tc *testConsensus) TestReplay() {
+	stage, receipts, err := tc.con.Replay(tc.original)
+	tc.assert.Nil(err)
+	tc.assert.Equal(tc.original.Header().ReceiptsRoot(), receipts.RootHash())
+	root, err := stage.Hash()
+	tc.assert.Nil(err)
+	tc.assert.Equal(tc.original.Header().StateRoot(), root)
+
+	// execution result is still verified
+	blk := tc.sign(tc.originalBuilder().GasUsed(tc.original.Header().GasUsed() + 1).Build())
+	_, _, err = tc.con.Replay(blk)
+	tc.assert.True(IsCritical(err))
+}
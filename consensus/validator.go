@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/co"
 	"github.com/vechain/thor/poa"
 	"github.com/vechain/thor/runtime"
 	"github.com/vechain/thor/state"
@@ -27,6 +28,8 @@ func (c *Consensus) validate(
 ) (*state.Stage, tx.Receipts, error) {
 	header := block.Header()
 
+	preVerify(block)
+
 	if err := c.validateBlockHeader(header, parentHeader, nowTimestamp); err != nil {
 		return nil, nil, err
 	}
@@ -47,12 +50,29 @@ func (c *Consensus) validate(
 	return stage, receipts, nil
 }
 
+// preVerify recovers signers of the block and its txs in parallel, ahead of the
+// sequential validation. Signers are cached, while errors are left to be reported
+// by the validation.
+func preVerify(blk *block.Block) {
+	co.Parallel(func(queue co.Enqueue) {
+		queue(func() { blk.Header().Signer() })
+		for _, trx := range blk.Transactions() {
+			trx := trx
+			queue(func() {
+				if _, err := trx.Signer(); err == nil {
+					trx.Delegator()
+				}
+			})
+		}
+	})
+}
+
 func (c *Consensus) validateBlockHeader(header *block.Header, parent *block.Header, nowTimestamp uint64) error {
 	if header.Timestamp() <= parent.Timestamp() {
 		return consensusError(fmt.Sprintf("block timestamp behind parents: parent %v, current %v", parent.Timestamp(), header.Timestamp()))
 	}
 
-	if (header.Timestamp()-parent.Timestamp())%thor.BlockInterval != 0 {
+	if !c.slots.IsAligned(header.Timestamp()) {
 		return consensusError(fmt.Sprintf("block interval not rounded: parent %v, current %v", parent.Timestamp(), header.Timestamp()))
 	}
 
@@ -93,7 +113,12 @@ func (c *Consensus) validateProposer(header *block.Header, parent *block.Header,
 		})
 	}
 
-	sched, err := poa.NewScheduler(signer, proposers, parent.Number(), parent.Timestamp())
+	var sched *poa.Scheduler
+	if header.Number() >= c.forkConfig.SCHEDV2 {
+		sched, err = poa.NewSchedulerV2(signer, proposers, parent.Number(), parent.Timestamp())
+	} else {
+		sched, err = poa.NewScheduler(signer, proposers, parent.Number(), parent.Timestamp())
+	}
 	if err != nil {
 		return consensusError(fmt.Sprintf("block signer invalid: %v %v", signer, err))
 	}
@@ -135,20 +160,21 @@ func (c *Consensus) validateBlockBody(blk *block.Block) error {
 			return consensusError(fmt.Sprintf("tx expired: ref %v, current %v, expiration %v", tx.BlockRef().Number(), header.Number(), tx.Expiration()))
 		case tx.HasReservedFields():
 			return consensusError(fmt.Sprintf("tx reserved fields not empty"))
+		case tx.IsTyped() && header.Number() < c.forkConfig.DYNFEE:
+			return consensusError(fmt.Sprintf("tx type %v not activated", tx.Type()))
+		case tx.Features().IsIsolated() && header.Number() < c.forkConfig.CLAUSEISO:
+			return consensusError("tx clause isolation not activated")
+		case tx.Features().IsDelegated() && header.Number() < c.forkConfig.VIP191:
+			return consensusError("tx delegation not activated")
 		}
 	}
 
 	return nil
 }
 
-func (c *Consensus) verifyBlock(blk *block.Block, state *state.State) (*state.Stage, tx.Receipts, error) {
-	var totalGasUsed uint64
-	txs := blk.Transactions()
-	receipts := make(tx.Receipts, 0, len(txs))
-	processedTxs := make(map[thor.Bytes32]bool)
-	header := blk.Header()
+func (c *Consensus) newRuntime(header *block.Header, state *state.State) *runtime.Runtime {
 	signer, _ := header.Signer()
-	rt := runtime.New(
+	return runtime.New(
 		c.chain.NewSeeker(header.ParentID()),
 		state,
 		&xenv.BlockContext{
@@ -158,7 +184,17 @@ func (c *Consensus) verifyBlock(blk *block.Block, state *state.State) (*state.St
 			Time:        header.Timestamp(),
 			GasLimit:    header.GasLimit(),
 			TotalScore:  header.TotalScore(),
-		})
+		},
+		c.forkConfig)
+}
+
+func (c *Consensus) verifyBlock(blk *block.Block, state *state.State) (*state.Stage, tx.Receipts, error) {
+	var totalGasUsed uint64
+	txs := blk.Transactions()
+	receipts := make(tx.Receipts, 0, len(txs))
+	processedTxs := make(map[thor.Bytes32]bool)
+	header := blk.Header()
+	rt := c.newRuntime(header, state)
 
 	findTx := func(txID thor.Bytes32) (found bool, reverted bool, err error) {
 		if reverted, ok := processedTxs[txID]; ok {
@@ -199,7 +235,16 @@ func (c *Consensus) verifyBlock(blk *block.Block, state *state.State) (*state.St
 
 		receipt, err := rt.ExecuteTransaction(tx)
 		if err != nil {
-			return nil, nil, err
+			// errors of state or chain access are internal
+			if err := state.Err(); err != nil {
+				return nil, nil, err
+			}
+			if err := rt.Seeker().Err(); err != nil {
+				return nil, nil, err
+			}
+			// otherwise the tx is not executable, e.g. gas unaffordable, which makes the block invalid.
+			// vm errors never go here, but revert the tx in receipt.
+			return nil, nil, consensusError(fmt.Sprintf("tx execution failed: %v", err))
 		}
 
 		totalGasUsed += receipt.GasUsed
@@ -0,0 +1,55 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package cry implements hashing and verification of off-chain signed data, i.e. EIP-191 personal
+// messages and EIP-712 typed structured data, compatible with wallets of Ethereum.
+// It also provides signers, with the key in memory or held by a remote signing service.
+package cry
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/thor"
+)
+
+// PersonalMessageHash computes the EIP-191 hash of a personal message, which is prefixed with
+// "\x19Ethereum Signed Message:\n" and its length, so that it's never a valid tx.
+func PersonalMessageHash(msg []byte) thor.Bytes32 {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(msg))
+	return thor.Bytes32(crypto.Keccak256Hash([]byte(prefix), msg))
+}
+
+// RecoverSigner recovers the signer address of the hash from the 65 bytes [R || S || V]
+// signature. V is either 0/1, or 27/28 as wallets produce.
+func RecoverSigner(hash thor.Bytes32, sig []byte) (thor.Address, error) {
+	if len(sig) != 65 {
+		return thor.Address{}, errors.New("invalid signature length")
+	}
+	sig = append([]byte(nil), sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return thor.Address{}, err
+	}
+	return thor.Address(crypto.PubkeyToAddress(*pub)), nil
+}
+
+// VerifyPersonalMessage returns the signer address of the signed personal message.
+func VerifyPersonalMessage(msg []byte, sig []byte) (thor.Address, error) {
+	return RecoverSigner(PersonalMessageHash(msg), sig)
+}
+
+// VerifyTypedData returns the signer address of the signed typed data.
+func VerifyTypedData(data *TypedData, sig []byte) (thor.Address, error) {
+	hash, err := data.Hash()
+	if err != nil {
+		return thor.Address{}, err
+	}
+	return RecoverSigner(hash, sig)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package cry_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/cry"
+	"github.com/vechain/thor/thor"
+)
+
+// the example of EIP-712
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestTypedData(t *testing.T) {
+	var td cry.TypedData
+	if err := json.Unmarshal([]byte(mailTypedData), &td); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := td.EncodeType("Mail")
+	assert.Nil(t, err)
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", encoded)
+
+	domainSeparator, err := td.DomainSeparator()
+	assert.Nil(t, err)
+	assert.Equal(t, thor.BytesToBytes32(hexutil.MustDecode("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f")), domainSeparator)
+
+	msgHash, err := td.HashStruct("Mail", td.Message)
+	assert.Nil(t, err)
+	assert.Equal(t, thor.BytesToBytes32(hexutil.MustDecode("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e")), msgHash)
+
+	hash, err := td.Hash()
+	assert.Nil(t, err)
+	assert.Equal(t, thor.BytesToBytes32(hexutil.MustDecode("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2")), hash)
+
+	// signed by the key keccak256("cow"), with v = 28
+	sig := hexutil.MustDecode("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c")
+	cow := thor.BytesToAddress(hexutil.MustDecode("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"))
+	signer, err := cry.VerifyTypedData(&td, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, cow, signer)
+
+	td.Message["contents"] = "Hello, Alice!"
+	signer, err = cry.VerifyTypedData(&td, sig)
+	assert.Nil(t, err)
+	assert.NotEqual(t, cow, signer)
+
+	delete(td.Message, "contents")
+	_, err = td.Hash()
+	assert.NotNil(t, err)
+}
+
+func TestPersonalMessage(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	msg := []byte("hello")
+
+	hash := cry.PersonalMessageHash(msg)
+	assert.Equal(t, thor.Bytes32(crypto.Keccak256Hash([]byte("\x19Ethereum Signed Message:\n5hello"))), hash)
+
+	sig, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := cry.VerifyPersonalMessage(msg, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), signer)
+
+	// v as wallets produce
+	sig[64] += 27
+	signer, err = cry.VerifyPersonalMessage(msg, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), signer)
+
+	_, err = cry.VerifyPersonalMessage(msg, sig[:64])
+	assert.NotNil(t, err)
+}
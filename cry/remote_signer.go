@@ -0,0 +1,231 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package cry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/metric"
+	"github.com/vechain/thor/thor"
+)
+
+var (
+	log = log15.New("pkg", "cry")
+
+	remoteSignCounter = metric.NewCounterVec("signer", "remote_requests_total", "count of requests to remote signer", "result")
+)
+
+const defaultRemoteSignerTimeout = 3 * time.Second
+
+// RemoteSignerConfig config of a remote signer.
+type RemoteSignerConfig struct {
+	URL       string        // https://host[/path], or unix:///path/to/socket
+	Token     string        // bearer token to authenticate requests, optional
+	RateLimit float64       // maximum sign requests per second, 0 for no limit
+	Timeout   time.Duration // timeout of each request, defaults to 3 seconds if 0
+}
+
+// RemoteSigner a signer whose key is held by an external signing service, so that the key
+// never resides in memory of the node.
+//
+// The service is expected to serve:
+//
+//	GET  /address, responds {"address": "0x..."}
+//	POST /sign, requests {"address": "0x...", "hash": "0x..."}, responds {"signature": "0x..."}
+//
+// Each request carries an X-Request-Id header, which is also logged, to be audited against
+// logs of the service. Signatures returned are verified before use.
+type RemoteSigner struct {
+	base    string
+	token   string
+	client  *http.Client
+	address thor.Address
+
+	rate   float64
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRemoteSigner create a remote signer, and fetches the address of its key from the service.
+func NewRemoteSigner(config RemoteSignerConfig) (*RemoteSigner, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, err
+	}
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultRemoteSignerTimeout
+	}
+
+	s := &RemoteSigner{
+		token:  config.Token,
+		client: &http.Client{Timeout: timeout},
+		rate:   config.RateLimit,
+		tokens: 1,
+	}
+	switch u.Scheme {
+	case "https":
+		s.base = strings.TrimSuffix(config.URL, "/")
+	case "unix":
+		path := u.Path
+		s.base = "http://unix"
+		s.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		}
+	default:
+		return nil, errors.Errorf("unsupported scheme '%v', https or unix expected", u.Scheme)
+	}
+
+	var res struct {
+		Address *thor.Address `json:"address"`
+	}
+	if err := s.call("GET", "/address", nil, &res); err != nil {
+		return nil, errors.WithMessage(err, "fetch address")
+	}
+	if res.Address == nil {
+		return nil, errors.New("fetch address: missing address")
+	}
+	s.address = *res.Address
+	return s, nil
+}
+
+// Address returns the address of the remote key.
+func (s *RemoteSigner) Address() thor.Address {
+	return s.address
+}
+
+// Sign requests the service to sign the hash. Requests exceeding the rate limit are rejected
+// without reaching the service.
+func (s *RemoteSigner) Sign(hash thor.Bytes32) ([]byte, error) {
+	if !s.take(time.Now()) {
+		remoteSignCounter.WithLabelValues("limited").Inc()
+		log.Warn("remote sign request rate limited", "hash", hash)
+		return nil, errors.New("remote signer: rate limit exceeded")
+	}
+
+	id := newRequestID()
+	start := time.Now()
+	sig, err := s.sign(id, hash)
+	if err != nil {
+		remoteSignCounter.WithLabelValues("failed").Inc()
+		log.Warn("remote sign request failed", "id", id, "hash", hash, "elapsed", time.Since(start), "err", err)
+		return nil, errors.WithMessage(err, "remote signer")
+	}
+	remoteSignCounter.WithLabelValues("signed").Inc()
+	log.Info("remote sign request done", "id", id, "hash", hash, "elapsed", time.Since(start))
+	return sig, nil
+}
+
+func (s *RemoteSigner) sign(id string, hash thor.Bytes32) ([]byte, error) {
+	req := struct {
+		Address thor.Address `json:"address"`
+		Hash    thor.Bytes32 `json:"hash"`
+	}{s.address, hash}
+	var res struct {
+		Signature hexutil.Bytes `json:"signature"`
+	}
+	if err := s.callWithID(id, "POST", "/sign", &req, &res); err != nil {
+		return nil, err
+	}
+
+	// never trust the service blindly
+	sig := []byte(res.Signature)
+	signer, err := RecoverSigner(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	if signer != s.address {
+		return nil, errors.Errorf("signature of unexpected signer %v", signer)
+	}
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	return sig, nil
+}
+
+// take takes a token to send a sign request, which is refilled at the rate limit.
+func (s *RemoteSigner) take(now time.Time) bool {
+	if s.rate <= 0 {
+		return true
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.last.IsZero() {
+		s.tokens = math.Min(1, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	}
+	s.last = now
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+func (s *RemoteSigner) call(method, path string, reqObj, resObj interface{}) error {
+	return s.callWithID(newRequestID(), method, path, reqObj, resObj)
+}
+
+func (s *RemoteSigner) callWithID(id, method, path string, reqObj, resObj interface{}) error {
+	var body io.Reader
+	if reqObj != nil {
+		data, err := json.Marshal(reqObj)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, s.base+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", id)
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("%v: %v", res.Status, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, resObj)
+}
+
+func newRequestID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return fmt.Sprintf("%x", b)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package cry_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/cry"
+	"github.com/vechain/thor/thor"
+)
+
+// serveSigner serves a signing service on a unix socket, signing with signKey on behalf of key.
+func serveSigner(t *testing.T, path string, token string, key, signKey *ecdsa.PrivateKey) func() {
+	addr := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/address", func(w http.ResponseWriter, req *http.Request) {
+		if token != "" && req.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"address": &addr})
+	})
+	mux.HandleFunc("/sign", func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Hash thor.Bytes32 `json:"hash"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sig, _ := crypto.Sign(body.Hash[:], signKey)
+		json.NewEncoder(w).Encode(map[string]interface{}{"signature": hexutil.Bytes(sig)})
+	})
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go http.Serve(ln, mux)
+	return func() { ln.Close() }
+}
+
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, _ := crypto.GenerateKey()
+	path := filepath.Join(dir, "signer.sock")
+	closer := serveSigner(t, path, "secret", key, key)
+	defer closer()
+
+	_, err = cry.NewRemoteSigner(cry.RemoteSignerConfig{URL: "unix://" + path, Token: "wrong"})
+	assert.NotNil(t, err, "unauthorized")
+	_, err = cry.NewRemoteSigner(cry.RemoteSignerConfig{URL: "http://localhost"})
+	assert.NotNil(t, err, "plain http")
+
+	signer, err := cry.NewRemoteSigner(cry.RemoteSignerConfig{
+		URL:       "unix://" + path,
+		Token:     "secret",
+		RateLimit: 0.001,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, cry.NewKeySigner(key).Address(), signer.Address())
+
+	hash := thor.Blake2b([]byte("block"))
+	sig, err := signer.Sign(hash)
+	assert.Nil(t, err)
+	recovered, err := cry.RecoverSigner(hash, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, signer.Address(), recovered)
+
+	_, err = signer.Sign(hash)
+	assert.NotNil(t, err, "rate limited")
+}
+
+func TestRemoteSignerUnexpectedSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+	path := filepath.Join(dir, "signer.sock")
+	closer := serveSigner(t, path, "", key, otherKey)
+	defer closer()
+
+	signer, err := cry.NewRemoteSigner(cry.RemoteSignerConfig{URL: "unix://" + path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = signer.Sign(thor.Blake2b([]byte("block")))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package cry
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/vechain/thor/thor"
+)
+
+// Signer signs hashes on behalf of an address, with the key held in memory or by an external service.
+type Signer interface {
+	// Address returns the address of the key.
+	Address() thor.Address
+	// Sign returns the 65 bytes [R || S || V] signature of the hash, where V is 0 or 1.
+	Sign(hash thor.Bytes32) ([]byte, error)
+}
+
+type keySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewKeySigner create a signer with the private key in memory.
+func NewKeySigner(key *ecdsa.PrivateKey) Signer {
+	return &keySigner{key}
+}
+
+func (s *keySigner) Address() thor.Address {
+	return thor.Address(crypto.PubkeyToAddress(s.key.PublicKey))
+}
+
+func (s *keySigner) Sign(hash thor.Bytes32) ([]byte, error) {
+	return crypto.Sign(hash[:], s.key)
+}
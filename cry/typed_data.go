@@ -0,0 +1,304 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package cry
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/thor"
+)
+
+// domainType name of the type of domain, which should be defined in types.
+const domainType = "EIP712Domain"
+
+// TypedDataField a member of a struct type.
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData EIP-712 typed structured data, as in JSON of eth_signTypedData.
+// Values are JSON decoded, where integers are numbers or decimal/hex strings, and
+// bytes and addresses are hex strings.
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// Hash computes the digest to be signed, keccak256("\x19\x01" || domainSeparator || hashStruct(message)).
+func (td *TypedData) Hash() (thor.Bytes32, error) {
+	domainSeparator, err := td.DomainSeparator()
+	if err != nil {
+		return thor.Bytes32{}, errors.WithMessage(err, "domain")
+	}
+	msgHash, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return thor.Bytes32{}, errors.WithMessage(err, "message")
+	}
+	return thor.Bytes32(crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator[:], msgHash[:])), nil
+}
+
+// DomainSeparator computes hashStruct of the domain.
+func (td *TypedData) DomainSeparator() (thor.Bytes32, error) {
+	return td.HashStruct(domainType, td.Domain)
+}
+
+// HashStruct computes keccak256(typeHash || encodeData(data)) of the struct type.
+func (td *TypedData) HashStruct(typeName string, data map[string]interface{}) (thor.Bytes32, error) {
+	encoded, err := td.encodeData(typeName, data)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return thor.Bytes32(crypto.Keccak256Hash(encoded)), nil
+}
+
+// TypeHash computes keccak256 of the encoded type.
+func (td *TypedData) TypeHash(typeName string) (thor.Bytes32, error) {
+	encoded, err := td.EncodeType(typeName)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return thor.Bytes32(crypto.Keccak256Hash([]byte(encoded))), nil
+}
+
+// EncodeType encodes the struct type as "Name(type1 name1,type2 name2)", followed by struct
+// types it references, sorted by name.
+func (td *TypedData) EncodeType(typeName string) (string, error) {
+	deps := make(map[string]bool)
+	if err := td.dependencies(typeName, deps); err != nil {
+		return "", err
+	}
+	delete(deps, typeName)
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	for _, name := range append([]string{typeName}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, field := range td.Types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(field.Type)
+			b.WriteByte(' ')
+			b.WriteString(field.Name)
+		}
+		b.WriteByte(')')
+	}
+	return b.String(), nil
+}
+
+// dependencies collects struct types referenced by the type, including itself.
+func (td *TypedData) dependencies(typeName string, deps map[string]bool) error {
+	if deps[typeName] {
+		return nil
+	}
+	fields, ok := td.Types[typeName]
+	if !ok {
+		return errors.Errorf("type %v undefined", typeName)
+	}
+	deps[typeName] = true
+	for _, field := range fields {
+		if _, ok := td.Types[elemType(field.Type)]; ok {
+			if err := td.dependencies(elemType(field.Type), deps); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// elemType strips array suffixes of the type.
+func elemType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+	return typ
+}
+
+func (td *TypedData) encodeData(typeName string, data map[string]interface{}) ([]byte, error) {
+	typeHash, err := td.TypeHash(typeName)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Write(typeHash[:])
+	for _, field := range td.Types[typeName] {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, errors.Errorf("%v.%v missing", typeName, field.Name)
+		}
+		encoded, err := td.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, errors.WithMessage(err, typeName+"."+field.Name)
+		}
+		buf.Write(encoded)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeValue encodes the value of the type into 32 bytes.
+func (td *TypedData) encodeValue(typ string, value interface{}) ([]byte, error) {
+	// arrays are encoded as hash of concatenated encoded elements
+	if strings.HasSuffix(typ, "]") {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("%v expected array", typ)
+		}
+		itemType := typ[:strings.LastIndexByte(typ, '[')]
+		var buf bytes.Buffer
+		for _, item := range items {
+			encoded, err := td.encodeValue(itemType, item)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(encoded)
+		}
+		return crypto.Keccak256(buf.Bytes()), nil
+	}
+
+	// structs are encoded as hashStruct
+	if _, ok := td.Types[typ]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("%v expected object", typ)
+		}
+		hash, err := td.HashStruct(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		return hash[:], nil
+	}
+
+	switch typ {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, errors.New("string expected")
+		}
+		return crypto.Keccak256([]byte(str)), nil
+	case "bytes":
+		data, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(data), nil
+	case "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("bool expected")
+		}
+		if b {
+			return math.PaddedBigBytes(big.NewInt(1), 32), nil
+		}
+		return make([]byte, 32), nil
+	case "address":
+		data, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != 20 {
+			return nil, errors.New("invalid address length")
+		}
+		return append(make([]byte, 12), data...), nil
+	}
+
+	if strings.HasPrefix(typ, "bytes") {
+		size, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return nil, errors.Errorf("unknown type %v", typ)
+		}
+		data, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != size {
+			return nil, errors.Errorf("%v expected %v bytes", typ, size)
+		}
+		padded := make([]byte, 32)
+		copy(padded, data)
+		return padded, nil
+	}
+
+	signed := strings.HasPrefix(typ, "int")
+	if signed || strings.HasPrefix(typ, "uint") {
+		bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"))
+		if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, errors.Errorf("unknown type %v", typ)
+		}
+		n, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if signed {
+			limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+			if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+				return nil, errors.Errorf("%v out of range", typ)
+			}
+		} else if n.Sign() < 0 || n.BitLen() > bits {
+			return nil, errors.Errorf("%v out of range", typ)
+		}
+		return math.PaddedBigBytes(math.U256(n), 32), nil
+	}
+	return nil, errors.Errorf("unknown type %v", typ)
+}
+
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return hexutil.Decode(v)
+	}
+	return nil, errors.New("hex string expected")
+}
+
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		n, acc := big.NewFloat(v).Int(nil)
+		if acc != big.Exact {
+			return nil, errors.New("integer expected")
+		}
+		return n, nil
+	case json.Number:
+		return toBigInt(string(v))
+	case string:
+		base := 10
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			v, base = v[2:], 16
+		}
+		n, ok := new(big.Int).SetString(v, base)
+		if !ok {
+			return nil, errors.New("invalid integer")
+		}
+		return n, nil
+	}
+	return nil, errors.New("integer expected")
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package evidence detects and keeps proofs of equivocation, that a proposer signed
+// two different blocks in the same slot.
+// Evidences are off-chain facts. Governance can act on them, e.g. revoke the
+// proposer from the Authority built-in.
+package evidence
+
+import (
+	"errors"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+// Evidence proves a proposer signed two different blocks in the same slot.
+type Evidence struct {
+	First  *block.Header
+	Second *block.Header
+}
+
+// Signer returns the equivocating proposer.
+func (e *Evidence) Signer() (thor.Address, error) {
+	return e.First.Signer()
+}
+
+// Verify checks if the evidence is valid.
+func (e *Evidence) Verify() error {
+	if e.First == nil || e.Second == nil {
+		return errors.New("incomplete evidence")
+	}
+	if e.First.ID() == e.Second.ID() {
+		return errors.New("same block")
+	}
+	if e.First.Timestamp() != e.Second.Timestamp() {
+		return errors.New("different slots")
+	}
+	signer1, err := e.First.Signer()
+	if err != nil {
+		return err
+	}
+	signer2, err := e.Second.Signer()
+	if err != nil {
+		return err
+	}
+	if signer1 != signer2 {
+		return errors.New("different signers")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package evidence
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+)
+
+var (
+	slotPrefix     = []byte("es") // (prefix, signer, timestamp) -> block id
+	evidencePrefix = []byte("ev") // (prefix, signer, timestamp) -> evidence
+)
+
+// Store tracks block headers by slot, and persists evidences found.
+// It requires a dedicated kv store, since evidences are listed by prefix iteration.
+type Store struct {
+	kv        kv.GetPutter
+	getHeader func(id thor.Bytes32) (*block.Header, error)
+	lock      sync.Mutex
+}
+
+// New create a Store upon kv, which should not be shared with others.
+// getHeader is used to load the previously tracked header.
+func New(kv kv.GetPutter, getHeader func(id thor.Bytes32) (*block.Header, error)) *Store {
+	return &Store{kv: kv, getHeader: getHeader}
+}
+
+func makeKey(prefix []byte, signer thor.Address, timestamp uint64) []byte {
+	key := make([]byte, 0, len(prefix)+len(signer)+8)
+	key = append(key, prefix...)
+	key = append(key, signer[:]...)
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], timestamp)
+	return append(key, b[:]...)
+}
+
+// Track records the header, and returns an evidence if another block in the same slot
+// signed by the same signer was tracked.
+// The evidence is persisted before returned.
+func (s *Store) Track(header *block.Header) (*Evidence, error) {
+	if header.Number() == 0 {
+		return nil, nil
+	}
+	signer, err := header.Signer()
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	slotKey := makeKey(slotPrefix, signer, header.Timestamp())
+	data, err := s.kv.Get(slotKey)
+	if err != nil {
+		if !s.kv.IsNotFound(err) {
+			return nil, err
+		}
+		id := header.ID()
+		return nil, s.kv.Put(slotKey, id[:])
+	}
+
+	id := thor.BytesToBytes32(data)
+	if id == header.ID() {
+		return nil, nil
+	}
+
+	evKey := makeKey(evidencePrefix, signer, header.Timestamp())
+	if has, err := s.kv.Has(evKey); err != nil {
+		return nil, err
+	} else if has {
+		// only the first evidence of the slot is kept
+		return nil, nil
+	}
+
+	first, err := s.getHeader(id)
+	if err != nil {
+		return nil, err
+	}
+	ev := &Evidence{first, header}
+	if err := ev.Verify(); err != nil {
+		return nil, err
+	}
+	enc, err := rlp.EncodeToBytes(ev)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.kv.Put(evKey, enc); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// Evidences returns evidences against the signer.
+// If signer is nil, all evidences returned.
+func (s *Store) Evidences(signer *thor.Address) ([]*Evidence, error) {
+	prefix := evidencePrefix
+	if signer != nil {
+		prefix = append(append([]byte(nil), evidencePrefix...), signer[:]...)
+	}
+	it := s.kv.NewIterator(*kv.NewRangeWithBytesPrefix(prefix))
+	defer it.Release()
+
+	var evs []*Evidence
+	for it.Next() {
+		var ev Evidence
+		if err := rlp.DecodeBytes(it.Value(), &ev); err != nil {
+			return nil, err
+		}
+		evs = append(evs, &ev)
+	}
+	return evs, it.Error()
+}
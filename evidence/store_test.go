@@ -0,0 +1,91 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package evidence_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/evidence"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+func TestStore(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	headers := make(map[thor.Bytes32]*block.Header)
+	store := evidence.New(kv, func(id thor.Bytes32) (*block.Header, error) {
+		if h, ok := headers[id]; ok {
+			return h, nil
+		}
+		return nil, errors.New("not found")
+	})
+
+	key, _ := crypto.GenerateKey()
+	signer := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+	newHeader := func(timestamp uint64, gasLimit uint64) *block.Header {
+		blk := new(block.Builder).Timestamp(timestamp).GasLimit(gasLimit).Build()
+		sig, _ := crypto.Sign(blk.Header().SigningHash().Bytes(), key)
+		h := blk.WithSignature(sig).Header()
+		headers[h.ID()] = h
+		return h
+	}
+
+	h1 := newHeader(10, 1)
+	h2 := newHeader(10, 2)
+	h3 := newHeader(20, 1)
+
+	for _, h := range []*block.Header{h1, h1, h3} {
+		ev, err := store.Track(h)
+		assert.Nil(t, err)
+		assert.Nil(t, ev)
+	}
+
+	ev, err := store.Track(h2)
+	assert.Nil(t, err)
+	assert.Equal(t, h1.ID(), ev.First.ID())
+	assert.Equal(t, h2.ID(), ev.Second.ID())
+	assert.Nil(t, ev.Verify())
+
+	// only the first evidence of the slot kept
+	ev, err = store.Track(newHeader(10, 3))
+	assert.Nil(t, err)
+	assert.Nil(t, ev)
+
+	evs, err := store.Evidences(&signer)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(evs))
+	s, _ := evs[0].Signer()
+	assert.Equal(t, signer, s)
+	assert.Equal(t, h2.ID(), evs[0].Second.ID())
+
+	evs, err = store.Evidences(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(evs))
+
+	evs, err = store.Evidences(&thor.Address{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(evs))
+}
+
+func TestVerify(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sign := func(blk *block.Block) *block.Header {
+		sig, _ := crypto.Sign(blk.Header().SigningHash().Bytes(), key)
+		return blk.WithSignature(sig).Header()
+	}
+	h1 := sign(new(block.Builder).Timestamp(10).Build())
+	h2 := sign(new(block.Builder).Timestamp(10).GasLimit(1).Build())
+	h3 := sign(new(block.Builder).Timestamp(20).Build())
+
+	assert.Nil(t, (&evidence.Evidence{First: h1, Second: h2}).Verify())
+	assert.NotNil(t, (&evidence.Evidence{First: h1}).Verify())
+	assert.NotNil(t, (&evidence.Evidence{First: h1, Second: h1}).Verify())
+	assert.NotNil(t, (&evidence.Evidence{First: h1, Second: h3}).Verify())
+}
@@ -20,8 +20,9 @@ import (
 
 // Builder helper to build genesis block.
 type Builder struct {
-	timestamp uint64
-	gasLimit  uint64
+	timestamp  uint64
+	gasLimit   uint64
+	forkConfig thor.ForkConfig
 
 	stateProcs []func(state *state.State) error
 	calls      []call
@@ -44,6 +45,12 @@ func (b *Builder) GasLimit(limit uint64) *Builder {
 	return b
 }
 
+// ForkConfig set fork config, under which genesis calls are executed.
+func (b *Builder) ForkConfig(fc thor.ForkConfig) *Builder {
+	b.forkConfig = fc
+	return b
+}
+
 // State add a state process
 func (b *Builder) State(proc func(state *state.State) error) *Builder {
 	b.stateProcs = append(b.stateProcs, proc)
@@ -85,7 +92,7 @@ func (b *Builder) Build(stateCreator *state.Creator) (blk *block.Block, events t
 	rt := runtime.New(nil, state, &xenv.BlockContext{
 		Time:     b.timestamp,
 		GasLimit: b.gasLimit,
-	})
+	}, b.forkConfig)
 
 	for _, call := range b.calls {
 		out := rt.ExecuteClause(call.clause, 0, math.MaxUint64, &xenv.TransactionContext{
@@ -0,0 +1,190 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+)
+
+// CustomGenesis specification of genesis for private networks, usually decoded from JSON.
+type CustomGenesis struct {
+	LaunchTime uint64          `json:"launchTime"`
+	GasLimit   uint64          `json:"gasLimit"` // defaults to thor.InitialGasLimit if 0
+	Accounts   []Account       `json:"accounts"`
+	Authority  []Authority     `json:"authority"`
+	Params     Params          `json:"params"`
+	ForkConfig thor.ForkConfig `json:"forkConfig"` // forks omitted are enabled from genesis
+}
+
+// Account account allocated in genesis.
+type Account struct {
+	Address thor.Address            `json:"address"`
+	Balance *math.HexOrDecimal256   `json:"balance"`
+	Energy  *math.HexOrDecimal256   `json:"energy"`
+	Code    hexutil.Bytes           `json:"code"`
+	Storage map[string]thor.Bytes32 `json:"storage"` // keyed by hex encoded storage keys
+}
+
+// Authority initial block proposer.
+type Authority struct {
+	MasterAddress   thor.Address `json:"masterAddress"`
+	EndorsorAddress thor.Address `json:"endorsorAddress"`
+	Identity        thor.Bytes32 `json:"identity"`
+}
+
+// Params initial values of builtin params, which default to the ones of thor package if omitted.
+type Params struct {
+	RewardRatio         *math.HexOrDecimal256 `json:"rewardRatio"`
+	BaseGasPrice        *math.HexOrDecimal256 `json:"baseGasPrice"`
+	ProposerEndorsement *math.HexOrDecimal256 `json:"proposerEndorsement"`
+	ExecutorAddress     *thor.Address         `json:"executorAddress"` // defaults to the builtin executor
+}
+
+type alloc struct {
+	address thor.Address
+	balance *big.Int
+	energy  *big.Int
+	code    []byte
+	storage map[thor.Bytes32]thor.Bytes32
+}
+
+func paramValue(value *math.HexOrDecimal256, def *big.Int) *big.Int {
+	if value == nil {
+		return def
+	}
+	return (*big.Int)(value)
+}
+
+// NewCustomNet create genesis according to the specification.
+func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
+	return newCustomNet(gen, "customnet")
+}
+
+func newCustomNet(gen *CustomGenesis, name string) (*Genesis, error) {
+	if gen.LaunchTime == 0 {
+		return nil, errors.New("launch time not specified")
+	}
+	if len(gen.Authority) == 0 {
+		return nil, errors.New("no authority specified")
+	}
+	for param, value := range map[string]*math.HexOrDecimal256{
+		"reward ratio":         gen.Params.RewardRatio,
+		"base gas price":       gen.Params.BaseGasPrice,
+		"proposer endorsement": gen.Params.ProposerEndorsement,
+	} {
+		if value != nil && (*big.Int)(value).Sign() < 0 {
+			return nil, errors.Errorf("negative %v", param)
+		}
+	}
+	launchTime := gen.LaunchTime
+	gasLimit := gen.GasLimit
+	if gasLimit == 0 {
+		gasLimit = thor.InitialGasLimit
+	}
+
+	// copied, so that the spec can be reused after genesis created
+	allocs := make([]alloc, 0, len(gen.Accounts))
+	for _, acc := range gen.Accounts {
+		a := alloc{
+			address: acc.Address,
+			balance: &big.Int{},
+			energy:  &big.Int{},
+			code:    append([]byte(nil), acc.Code...),
+			storage: make(map[thor.Bytes32]thor.Bytes32, len(acc.Storage)),
+		}
+		if acc.Balance != nil {
+			a.balance.Set((*big.Int)(acc.Balance))
+		}
+		if acc.Energy != nil {
+			a.energy.Set((*big.Int)(acc.Energy))
+		}
+		if a.balance.Sign() < 0 || a.energy.Sign() < 0 {
+			return nil, errors.Errorf("negative balance or energy of account %v", acc.Address)
+		}
+		for k, v := range acc.Storage {
+			key, err := thor.ParseBytes32(k)
+			if err != nil {
+				return nil, errors.Wrapf(err, "storage key of account %v", acc.Address)
+			}
+			a.storage[key] = v
+		}
+		allocs = append(allocs, a)
+	}
+
+	// address of the builtin executor, which is not deployed yet
+	executor := thor.BytesToAddress([]byte("Executor"))
+	if gen.Params.ExecutorAddress != nil {
+		executor = *gen.Params.ExecutorAddress
+	}
+
+	builder := new(Builder).
+		Timestamp(launchTime).
+		GasLimit(gasLimit).
+		ForkConfig(gen.ForkConfig).
+		State(func(state *state.State) error {
+			// alloc precompiled contracts
+			for addr := range vm.PrecompiledContractsByzantium {
+				state.SetCode(thor.Address(addr), emptyRuntimeBytecode)
+			}
+
+			// setup builtin contracts
+			state.SetCode(builtin.Authority.Address, builtin.Authority.RuntimeBytecodes())
+			state.SetCode(builtin.Energy.Address, builtin.Energy.RuntimeBytecodes())
+			state.SetCode(builtin.Params.Address, builtin.Params.RuntimeBytecodes())
+			state.SetCode(builtin.Prototype.Address, builtin.Prototype.RuntimeBytecodes())
+			state.SetCode(builtin.Extension.Address, builtin.Extension.RuntimeBytecodes())
+
+			tokenSupply := &big.Int{}
+			energySupply := &big.Int{}
+			for _, a := range allocs {
+				state.SetBalance(a.address, a.balance)
+				state.SetEnergy(a.address, a.energy, launchTime)
+				tokenSupply.Add(tokenSupply, a.balance)
+				energySupply.Add(energySupply, a.energy)
+				if len(a.code) > 0 {
+					state.SetCode(a.address, a.code)
+				}
+				for k, v := range a.storage {
+					state.SetStorage(a.address, k, v)
+				}
+			}
+			builtin.Energy.Native(state, launchTime).SetInitialSupply(tokenSupply, energySupply)
+			return nil
+		}).
+		Call(
+			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyExecutorAddress, new(big.Int).SetBytes(executor[:]))),
+			thor.Address{}).
+		Call(
+			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyRewardRatio, paramValue(gen.Params.RewardRatio, thor.InitialRewardRatio))),
+			executor).
+		Call(
+			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyBaseGasPrice, paramValue(gen.Params.BaseGasPrice, thor.InitialBaseGasPrice))),
+			executor).
+		Call(
+			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyProposerEndorsement, paramValue(gen.Params.ProposerEndorsement, thor.InitialProposerEndorsement))),
+			executor)
+
+	for _, a := range gen.Authority {
+		builder.Call(
+			tx.NewClause(&builtin.Authority.Address).WithData(mustEncodeInput(builtin.Authority.ABI, "add", a.MasterAddress, a.EndorsorAddress, a.Identity)),
+			executor)
+	}
+
+	id, err := builder.ComputeID()
+	if err != nil {
+		return nil, err
+	}
+	return &Genesis{builder, id, name, gen.ForkConfig}, nil
+}
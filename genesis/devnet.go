@@ -11,12 +11,9 @@ import (
 	"math/big"
 	"sync/atomic"
 
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/vechain/thor/builtin"
-	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
-	"github.com/vechain/thor/tx"
-	"github.com/vechain/thor/vm"
 )
 
 // DevAccount account for development.
@@ -58,63 +55,38 @@ func DevAccounts() []DevAccount {
 	return accs
 }
 
-// NewDevnet create genesis for solo mode.
-func NewDevnet() (*Genesis, error) {
-	launchTime := uint64(1526400000) // 'Wed May 16 2018 00:00:00 GMT+0800 (CST)'
-
+// DevnetSpec returns specification of the devnet, which allocates VET and energy to dev accounts,
+// and registers them as authority, with all forks enabled from genesis.
+// The first dev account is the executor, and the block proposer of solo mode.
+// A new copy is returned each time, so that tests can build variants of devnet from it.
+func DevnetSpec() *CustomGenesis {
+	bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
 	executor := DevAccounts()[0].Address
 
-	builder := new(Builder).
-		GasLimit(thor.InitialGasLimit).
-		Timestamp(launchTime).
-		State(func(state *state.State) error {
-			// alloc precompiled contracts
-			for addr := range vm.PrecompiledContractsByzantium {
-				state.SetCode(thor.Address(addr), emptyRuntimeBytecode)
-			}
-
-			// setup builtin contracts
-			state.SetCode(builtin.Authority.Address, builtin.Authority.RuntimeBytecodes())
-			state.SetCode(builtin.Energy.Address, builtin.Energy.RuntimeBytecodes())
-			state.SetCode(builtin.Params.Address, builtin.Params.RuntimeBytecodes())
-			state.SetCode(builtin.Prototype.Address, builtin.Prototype.RuntimeBytecodes())
-			state.SetCode(builtin.Extension.Address, builtin.Extension.RuntimeBytecodes())
-
-			tokenSupply := &big.Int{}
-			energySupply := &big.Int{}
-			for _, a := range DevAccounts() {
-				bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
-				state.SetBalance(a.Address, bal)
-				state.SetEnergy(a.Address, bal, launchTime)
-				tokenSupply.Add(tokenSupply, bal)
-				energySupply.Add(energySupply, bal)
-			}
-			builtin.Energy.Native(state, launchTime).SetInitialSupply(tokenSupply, energySupply)
-			return nil
-		}).
-		Call(
-			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyExecutorAddress, new(big.Int).SetBytes(executor[:]))),
-			thor.Address{}).
-		Call(
-			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyRewardRatio, thor.InitialRewardRatio)),
-			executor).
-		Call(
-			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyBaseGasPrice, thor.InitialBaseGasPrice)),
-			executor).
-		Call(
-			tx.NewClause(&builtin.Params.Address).WithData(mustEncodeInput(builtin.Params.ABI, "set", thor.KeyProposerEndorsement, thor.InitialProposerEndorsement)),
-			executor)
-
-	for i, a := range DevAccounts() {
-		builder.Call(
-			tx.NewClause(&builtin.Authority.Address).WithData(mustEncodeInput(builtin.Authority.ABI, "add", a.Address, a.Address, thor.BytesToBytes32([]byte(fmt.Sprintf("a%v", i))))),
-			executor)
+	gen := &CustomGenesis{
+		LaunchTime: 1526400000, // 'Wed May 16 2018 00:00:00 GMT+0800 (CST)'
+		GasLimit:   thor.InitialGasLimit,
+		Params: Params{
+			ExecutorAddress: &executor,
+		},
+		ForkConfig: thor.ForkConfig{},
 	}
-
-	id, err := builder.ComputeID()
-	if err != nil {
-		return nil, err
+	for i, a := range DevAccounts() {
+		gen.Accounts = append(gen.Accounts, Account{
+			Address: a.Address,
+			Balance: (*math.HexOrDecimal256)(new(big.Int).Set(bal)),
+			Energy:  (*math.HexOrDecimal256)(new(big.Int).Set(bal)),
+		})
+		gen.Authority = append(gen.Authority, Authority{
+			MasterAddress:   a.Address,
+			EndorsorAddress: a.Address,
+			Identity:        thor.BytesToBytes32([]byte(fmt.Sprintf("a%v", i))),
+		})
 	}
+	return gen
+}
 
-	return &Genesis{builder, id, "devnet"}, nil
+// NewDevnet create genesis for solo mode.
+func NewDevnet() (*Genesis, error) {
+	return newCustomNet(DevnetSpec(), "devnet")
 }
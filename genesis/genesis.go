@@ -17,9 +17,10 @@ import (
 
 // Genesis to build genesis block.
 type Genesis struct {
-	builder *Builder
-	id      thor.Bytes32
-	name    string
+	builder    *Builder
+	id         thor.Bytes32
+	name       string
+	forkConfig thor.ForkConfig
 }
 
 // Build build the genesis block.
@@ -39,11 +40,23 @@ func (g *Genesis) ID() thor.Bytes32 {
 	return g.id
 }
 
+// ChainTag returns chain tag, which is the last byte of genesis ID.
+// Transactions are bound to a network by carrying the chain tag, to prevent
+// being replayed on other networks.
+func (g *Genesis) ChainTag() byte {
+	return g.id[31]
+}
+
 // Name returns network name.
 func (g *Genesis) Name() string {
 	return g.name
 }
 
+// ForkConfig returns fork config of the network.
+func (g *Genesis) ForkConfig() thor.ForkConfig {
+	return g.forkConfig
+}
+
 func mustEncodeInput(abi *abi.ABI, name string, args ...interface{}) []byte {
 	m, found := abi.MethodByName(name)
 	if !found {
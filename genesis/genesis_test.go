@@ -6,12 +6,17 @@
 package genesis_test
 
 import (
+	"encoding/json"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/builtin"
 	"github.com/vechain/thor/genesis"
 	"github.com/vechain/thor/lvldb"
 	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
 )
 
 func TestTestnetGenesis(t *testing.T) {
@@ -24,4 +29,119 @@ func TestTestnetGenesis(t *testing.T) {
 
 	_, err = state.New(b0.Header().StateRoot(), kv)
 	assert.Nil(t, err)
+
+	assert.Equal(t, b0.Header().ID()[31], gene.ChainTag())
+}
+
+const customGenesis = `{
+	"launchTime": 1526400000,
+	"accounts": [{
+		"address": "0x7567d83b7b8d80addcb281a71d54fc7b3364ffed",
+		"balance": "1000000000000000000000",
+		"energy": "0x3635c9adc5dea00000",
+		"code": "0x6060604052600256",
+		"storage": {
+			"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"
+		}
+	}],
+	"authority": [{
+		"masterAddress": "0x435933c8064b4ae76be665428e0307ef2ccfbd68",
+		"endorsorAddress": "0x7567d83b7b8d80addcb281a71d54fc7b3364ffed",
+		"identity": "0x000000000000000068747470733a2f2f636f6e6e65782e76656368612e696e2f"
+	}],
+	"params": {
+		"baseGasPrice": "1000"
+	},
+	"forkConfig": {
+		"SCHEDV2": 4294967295
+	}
+}`
+
+func TestCustomNetGenesis(t *testing.T) {
+	var gen genesis.CustomGenesis
+	if err := json.Unmarshal([]byte(customGenesis), &gen); err != nil {
+		t.Fatal(err)
+	}
+	gene, err := genesis.NewCustomNet(&gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, thor.ForkConfig{SCHEDV2: math.MaxUint32}, gene.ForkConfig())
+
+	kv, _ := lvldb.NewMem()
+	b0, _, err := gene.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, gene.ID(), b0.Header().ID())
+	assert.Equal(t, uint64(1526400000), b0.Header().Timestamp())
+	assert.Equal(t, thor.InitialGasLimit, b0.Header().GasLimit())
+
+	// deterministic
+	gene2, err := genesis.NewCustomNet(&gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, gene.ID(), gene2.ID())
+
+	st, err := state.New(b0.Header().StateRoot(), kv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc := gen.Accounts[0].Address
+	assert.Equal(t, (*big.Int)(gen.Accounts[0].Balance), st.GetBalance(acc))
+	assert.Equal(t, (*big.Int)(gen.Accounts[0].Energy), st.GetEnergy(acc, b0.Header().Timestamp()))
+	assert.Equal(t, []byte(gen.Accounts[0].Code), st.GetCode(acc))
+	assert.Equal(t, thor.BytesToBytes32([]byte{2}), st.GetStorage(acc, thor.BytesToBytes32([]byte{1})))
+
+	assert.Equal(t, big.NewInt(1000), builtin.Params.Native(st).Get(thor.KeyBaseGasPrice))
+	assert.Equal(t, thor.InitialRewardRatio, builtin.Params.Native(st).Get(thor.KeyRewardRatio))
+	assert.Equal(t, thor.BytesToAddress([]byte("Executor")), thor.BytesToAddress(builtin.Params.Native(st).Get(thor.KeyExecutorAddress).Bytes()))
+	candidate, found := builtin.Authority.Native(st).Get(gen.Authority[0].MasterAddress)
+	if assert.True(t, found) {
+		assert.Equal(t, gen.Authority[0].EndorsorAddress, candidate.Endorsor)
+	}
+
+	_, err = genesis.NewCustomNet(&genesis.CustomGenesis{LaunchTime: 1})
+	assert.Error(t, err, "no authority")
+}
+
+func TestDevnetSpec(t *testing.T) {
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "devnet", gene.Name())
+
+	custom, err := genesis.NewCustomNet(genesis.DevnetSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, gene.ID(), custom.ID(), "devnet should be built from the spec")
+
+	// variant of devnet
+	spec := genesis.DevnetSpec()
+	spec.Accounts[0].Balance = nil
+	variant, err := genesis.NewCustomNet(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, gene.ID(), variant.ID())
+	assert.NotNil(t, genesis.DevnetSpec().Accounts[0].Balance, "spec should be copied")
+
+	kv, _ := lvldb.NewMem()
+	b0, _, err := gene.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := state.New(b0.Header().StateRoot(), kv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, acc := range genesis.DevAccounts() {
+		assert.True(t, st.GetBalance(acc.Address).Sign() > 0)
+		assert.True(t, st.GetEnergy(acc.Address, b0.Header().Timestamp()).Sign() > 0)
+		_, found := builtin.Authority.Native(st).Get(acc.Address)
+		assert.True(t, found)
+	}
 }
@@ -29,6 +29,7 @@ func NewTestnet() (*Genesis, error) {
 	builder := new(Builder).
 		Timestamp(launchTime).
 		GasLimit(thor.InitialGasLimit).
+		ForkConfig(thor.NoFork).
 		State(func(state *state.State) error {
 			tokenSupply := new(big.Int)
 
@@ -81,5 +82,5 @@ func NewTestnet() (*Genesis, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Genesis{builder, id, "testnet"}, nil
+	return &Genesis{builder, id, "testnet", thor.NoFork}, nil
 }
@@ -0,0 +1,119 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/thor"
+)
+
+// httpBackend requests the REST API of a full node.
+type httpBackend struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPBackend create a backend requesting the REST API of a full node at the url.
+func NewHTTPBackend(url string) Backend {
+	return &httpBackend{
+		url:    strings.TrimRight(url, "/"),
+		client: &http.Client{},
+	}
+}
+
+// get requests the path, and decodes the json response into v. It returns false if the response is null.
+func (b *httpBackend) get(ctx context.Context, path string, v interface{}) (bool, error) {
+	req, err := http.NewRequest("GET", b.url+path, nil)
+	if err != nil {
+		return false, err
+	}
+	res, err := b.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return false, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return false, errors.Errorf("%v %v: %s", res.Status, path, bytes.TrimSpace(body))
+	}
+	if string(bytes.TrimSpace(body)) == "null" {
+		return false, nil
+	}
+	return true, json.Unmarshal(body, v)
+}
+
+func (b *httpBackend) header(ctx context.Context, revision string) (*block.Header, error) {
+	var raw struct {
+		Raw string `json:"raw"`
+	}
+	if ok, err := b.get(ctx, "/blocks/"+revision+"/header", &raw); err != nil || !ok {
+		return nil, err
+	}
+	data, err := hexutil.Decode(raw.Raw)
+	if err != nil {
+		return nil, err
+	}
+	var header block.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+func (b *httpBackend) BestHeader(ctx context.Context) (*block.Header, error) {
+	header, err := b.header(ctx, "best")
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("best block missing")
+	}
+	return header, nil
+}
+
+func (b *httpBackend) Header(ctx context.Context, num uint32) (*block.Header, error) {
+	return b.header(ctx, fmt.Sprint(num))
+}
+
+func (b *httpBackend) Proof(ctx context.Context, blockID thor.Bytes32, addr thor.Address, keys []thor.Bytes32) ([][]byte, error) {
+	query := url.Values{"revision": {blockID.String()}}
+	for _, key := range keys {
+		query.Add("key", key.String())
+	}
+	var proof struct {
+		Nodes []string `json:"nodes"`
+	}
+	if ok, err := b.get(ctx, "/accounts/"+addr.String()+"/proof?"+query.Encode(), &proof); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("proof missing")
+	}
+	nodes := make([][]byte, 0, len(proof.Nodes))
+	for _, node := range proof.Nodes {
+		data, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, data)
+	}
+	return nodes, nil
+}
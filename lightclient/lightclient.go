@@ -0,0 +1,362 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package lightclient implements a client which follows the chain by headers only, for mobile
+// wallets and embedded integrations, without trusting the full node it connects to.
+//
+// Starting from a trusted header, headers are synced and checked as consensus does, that
+// each one is signed by the proposer in turn, recoverable by the scheduler from authority
+// candidates at the parent state. Candidates are tracked by applying proposer updates of
+// each block, and loaded with proofs at checkpoints, or once a header fails to be verified
+// with the tracked ones. Finality is tracked by counting votes of checkpoints as the bft
+// package does.
+//
+// Accounts and storage values are proved by trie nodes requested from the full node, and
+// verified against the state root of synced headers.
+package lightclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/bft"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin/authority"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/poa"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+// maxHeaders number of recent headers kept, to count votes of checkpoints and to handle forks.
+const maxHeaders = bft.EpochLength*2 + 1
+
+// Backend serves headers and proofs, normally a full node.
+type Backend interface {
+	// BestHeader returns header of the best block.
+	BestHeader(ctx context.Context) (*block.Header, error)
+	// Header returns header of the trunk block at the number, or nil if not found.
+	Header(ctx context.Context, num uint32) (*block.Header, error)
+	// Proof returns trie nodes proving the account, and storage values of given keys, at the block.
+	Proof(ctx context.Context, blockID thor.Bytes32, addr thor.Address, keys []thor.Bytes32) ([][]byte, error)
+}
+
+// entry a synced header, with authority candidates at its state.
+type entry struct {
+	header     *block.Header
+	candidates []*authority.Candidate
+	loaded     bool // whether candidates are loaded with proofs, or tracked by proposer updates
+}
+
+// Client the light client.
+type Client struct {
+	backend    Backend
+	slots      poa.Slots
+	forkConfig thor.ForkConfig
+
+	syncLock  sync.Mutex
+	rw        sync.RWMutex
+	entries   []*entry // contiguous recent headers on trunk, ends with the best one
+	justified *block.Header
+	finalized *block.Header
+}
+
+// New create a light client, which trusts the header and syncs from it.
+// The genesis header is required to check alignment of block time.
+func New(backend Backend, genesis *block.Header, trusted *block.Header, forkConfig thor.ForkConfig) *Client {
+	return &Client{
+		backend:    backend,
+		slots:      poa.NewSlots(genesis.Timestamp()),
+		forkConfig: forkConfig,
+		entries:    []*entry{{header: trusted}},
+		justified:  trusted,
+		finalized:  trusted,
+	}
+}
+
+// Best returns header of the best block synced.
+func (c *Client) Best() *block.Header {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.entries[len(c.entries)-1].header
+}
+
+// Justified returns header of the latest justified checkpoint.
+func (c *Client) Justified() *block.Header {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.justified
+}
+
+// Finalized returns header of the latest finalized block, which is the trusted one if none finalized since.
+func (c *Client) Finalized() *block.Header {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.finalized
+}
+
+// Sync syncs headers up to the best block of the backend, and returns header of the best block synced.
+// Headers are switched to the fork of the backend, unless it forks from blocks finalized, or from
+// blocks no longer kept.
+func (c *Client) Sync(ctx context.Context) (*block.Header, error) {
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	best, err := c.backend.BestHeader(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "get best header")
+	}
+	for {
+		head := c.head()
+		if head.header.Number() >= best.Number() {
+			return head.header, nil
+		}
+		header, err := c.backend.Header(ctx, head.header.Number()+1)
+		if err != nil {
+			return nil, errors.WithMessage(err, "get header")
+		}
+		if header == nil {
+			// trunk of the backend changed while syncing
+			return head.header, nil
+		}
+		if header.ParentID() != head.header.ID() {
+			if err := c.rewind(ctx); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := c.append(ctx, head, header); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("verify header %v", header.ID()))
+		}
+	}
+}
+
+func (c *Client) head() *entry {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+	return c.entries[len(c.entries)-1]
+}
+
+// rewind drops headers not on trunk of the backend, down to the common ancestor.
+func (c *Client) rewind(ctx context.Context) error {
+	for {
+		c.rw.RLock()
+		n := len(c.entries)
+		head := c.entries[n-1].header
+		c.rw.RUnlock()
+
+		if n == 1 || head.Number() <= c.Finalized().Number() {
+			return errors.New("backend forks from blocks finalized or no longer kept")
+		}
+		header, err := c.backend.Header(ctx, head.Number()-1)
+		if err != nil {
+			return errors.WithMessage(err, "get header")
+		}
+		if header == nil {
+			return errors.New("backend trunk missing")
+		}
+
+		c.rw.Lock()
+		c.entries = c.entries[:n-1]
+		if c.justified.Number() >= head.Number() {
+			c.justified = c.finalized
+		}
+		c.rw.Unlock()
+
+		if header.ID() == c.entries[n-2].header.ID() {
+			return nil
+		}
+	}
+}
+
+// append verifies the header upon the parent, and puts it on top.
+func (c *Client) append(ctx context.Context, parent *entry, header *block.Header) error {
+	if header.Timestamp() <= parent.header.Timestamp() {
+		return errors.New("block timestamp behind parent")
+	}
+	if !c.slots.IsAligned(header.Timestamp()) {
+		return errors.New("block interval not rounded")
+	}
+	if !block.GasLimit(header.GasLimit()).IsValid(parent.header.GasLimit()) {
+		return errors.New("block gas limit invalid")
+	}
+	if header.GasUsed() > header.GasLimit() {
+		return errors.New("block gas used exceeds limit")
+	}
+
+	// candidates at checkpoints are loaded anyway, for counting votes
+	if !parent.loaded && (parent.candidates == nil || parent.header.Number()%bft.EpochLength == 0) {
+		if err := c.load(ctx, parent); err != nil {
+			return err
+		}
+	}
+	candidates, err := c.verifyProposer(header, parent)
+	if err != nil {
+		if parent.loaded {
+			return err
+		}
+		// tracked candidates may be out of date, if changed by txs
+		if err := c.load(ctx, parent); err != nil {
+			return err
+		}
+		if candidates, err = c.verifyProposer(header, parent); err != nil {
+			return err
+		}
+	}
+
+	c.rw.Lock()
+	defer c.rw.Unlock()
+	c.entries = append(c.entries, &entry{header: header, candidates: candidates})
+	if len(c.entries) > maxHeaders {
+		c.entries = c.entries[len(c.entries)-maxHeaders:]
+	}
+	return c.updateFinality()
+}
+
+func (c *Client) load(ctx context.Context, e *entry) error {
+	candidates, err := loadCandidates(ctx, c.backend, e.header)
+	if err != nil {
+		return errors.WithMessage(err, "load authority candidates")
+	}
+	e.candidates = candidates
+	e.loaded = true
+	return nil
+}
+
+// verifyProposer checks the signer and the score as consensus does, with candidates at the parent,
+// and returns candidates updated.
+func (c *Client) verifyProposer(header *block.Header, parent *entry) ([]*authority.Candidate, error) {
+	signer, err := header.Signer()
+	if err != nil {
+		return nil, errors.WithMessage(err, "block signer unavailable")
+	}
+	proposers := make([]poa.Proposer, 0, len(parent.candidates))
+	for _, cand := range parent.candidates {
+		proposers = append(proposers, poa.Proposer{Address: cand.Signer, Active: cand.Active})
+	}
+
+	var sched *poa.Scheduler
+	if header.Number() >= c.forkConfig.SCHEDV2 {
+		sched, err = poa.NewSchedulerV2(signer, proposers, parent.header.Number(), parent.header.Timestamp())
+	} else {
+		sched, err = poa.NewScheduler(signer, proposers, parent.header.Number(), parent.header.Timestamp())
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("block signer %v invalid", signer))
+	}
+	if !sched.IsTheTime(header.Timestamp()) {
+		return nil, errors.Errorf("block timestamp unscheduled: t %v, s %v", header.Timestamp(), signer)
+	}
+	updates, score := sched.Updates(header.Timestamp())
+	if parent.header.TotalScore()+score != header.TotalScore() {
+		return nil, errors.Errorf("block total score invalid: want %v, have %v", parent.header.TotalScore()+score, header.TotalScore())
+	}
+
+	active := make(map[thor.Address]bool, len(updates))
+	for _, u := range updates {
+		active[u.Address] = u.Active
+	}
+	candidates := make([]*authority.Candidate, 0, len(parent.candidates))
+	for _, cand := range parent.candidates {
+		if a, ok := active[cand.Signer]; ok {
+			cpy := *cand
+			cpy.Active = a
+			cand = &cpy
+		}
+		candidates = append(candidates, cand)
+	}
+	return candidates, nil
+}
+
+// updateFinality justifies and finalizes checkpoints once the best block closes an epoch.
+// It should be called with the write lock held.
+func (c *Client) updateFinality() error {
+	best := c.entries[len(c.entries)-1].header
+	num := best.Number()
+	if num == 0 || num%bft.EpochLength != 0 {
+		return nil
+	}
+	checkpoint, justified, err := c.evaluate(num, num-bft.EpochLength)
+	if err != nil || !justified {
+		return err
+	}
+	c.justified = checkpoint
+
+	if num < bft.EpochLength*2 {
+		return nil
+	}
+	prevCheckpoint, justified, err := c.evaluate(num-bft.EpochLength, num-bft.EpochLength*2)
+	if err != nil || !justified {
+		return err
+	}
+	if prevCheckpoint.Number() > c.finalized.Number() {
+		c.finalized = prevCheckpoint
+	}
+	return nil
+}
+
+// evaluate checks whether the checkpoint is justified by blocks after it, up to the head.
+// A checkpoint not kept, or without candidates loaded, is treated as not justified.
+func (c *Client) evaluate(headNum, checkpointNum uint32) (*block.Header, bool, error) {
+	first := c.entries[0].header.Number()
+	if checkpointNum < first {
+		return nil, false, nil
+	}
+	cp := c.entries[checkpointNum-first]
+	if !cp.loaded {
+		return nil, false, nil
+	}
+	quorum := bft.Quorum(cp.candidates)
+
+	voters := make(map[thor.Address]bool)
+	for _, e := range c.entries[checkpointNum-first+1 : headNum-first+1] {
+		signer, err := e.header.Signer()
+		if err != nil {
+			return nil, false, err
+		}
+		voters[signer] = true
+		if len(voters) >= quorum {
+			return cp.header, true, nil
+		}
+	}
+	return cp.header, false, nil
+}
+
+// getHeader returns the synced header with given id.
+func (c *Client) getHeader(id thor.Bytes32) (*block.Header, error) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	first := c.entries[0].header.Number()
+	if num := block.Number(id); num >= first && num-first < uint32(len(c.entries)) {
+		if e := c.entries[num-first]; e.header.ID() == id {
+			return e.header, nil
+		}
+	}
+	if c.finalized.ID() == id {
+		return c.finalized, nil
+	}
+	return nil, errors.New("block not synced")
+}
+
+// State returns state at the block, which should be synced and kept, with the account and storage
+// values of given keys proved by the backend. Other accounts and storage values are unavailable
+// unless proved by the same nodes, and reading them results in error of the state.
+func (c *Client) State(ctx context.Context, blockID thor.Bytes32, addr thor.Address, keys ...thor.Bytes32) (*state.State, error) {
+	header, err := c.getHeader(blockID)
+	if err != nil {
+		return nil, err
+	}
+	db, err := lvldb.NewMem()
+	if err != nil {
+		return nil, err
+	}
+	if err := prove(ctx, c.backend, db, header, addr, keys); err != nil {
+		return nil, err
+	}
+	return state.New(header.StateRoot(), db)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package lightclient_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/bft"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lightclient"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// localBackend serves from the chain in memory, as a full node does.
+type localBackend struct {
+	chain        *chain.Chain
+	stateCreator *state.Creator
+}
+
+func (b *localBackend) BestHeader(ctx context.Context) (*block.Header, error) {
+	return b.chain.BestBlock().Header(), nil
+}
+
+func (b *localBackend) Header(ctx context.Context, num uint32) (*block.Header, error) {
+	header, err := b.chain.GetTrunkBlockHeader(num)
+	if b.chain.IsNotFound(err) {
+		return nil, nil
+	}
+	return header, err
+}
+
+type nodeList [][]byte
+
+func (l *nodeList) Put(key, value []byte) error {
+	*l = append(*l, append([]byte(nil), value...))
+	return nil
+}
+
+func (b *localBackend) Proof(ctx context.Context, blockID thor.Bytes32, addr thor.Address, keys []thor.Bytes32) ([][]byte, error) {
+	header, err := b.chain.GetBlockHeader(blockID)
+	if err != nil {
+		return nil, err
+	}
+	var nodes nodeList
+	if err := b.stateCreator.Prove(header.StateRoot(), addr, keys, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// tamperedBackend corrupts the last node of each proof.
+type tamperedBackend struct {
+	*localBackend
+}
+
+func (b *tamperedBackend) Proof(ctx context.Context, blockID thor.Bytes32, addr thor.Address, keys []thor.Bytes32) ([][]byte, error) {
+	nodes, err := b.localBackend.Proof(ctx, blockID, addr, keys)
+	if err != nil {
+		return nil, err
+	}
+	last := nodes[len(nodes)-1]
+	last[len(last)-1] ^= 0xff
+	return nodes, nil
+}
+
+var recipient = thor.BytesToAddress([]byte("recipient"))
+
+// newBackend packs blocks by a single proposer, with a transfer to recipient in the first one.
+func newBackend(t *testing.T, blocks int) (*localBackend, *block.Header) {
+	db, _ := lvldb.NewMem()
+	stateCreator := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b0, _, err := gene.Build(stateCreator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := chain.New(db, b0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposer := genesis.DevAccounts()[0]
+	for i := 0; i < blocks; i++ {
+		parent := c.BestBlock().Header()
+		flow, err := packer.New(c, stateCreator, proposer.Address, proposer.Address, thor.NoFork).
+			Schedule(parent, parent.Timestamp())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			trx := new(tx.Builder).
+				ChainTag(c.Tag()).
+				GasPriceCoef(1).
+				Expiration(100).
+				Gas(21000).
+				Clause(tx.NewClause(&recipient).WithValue(big.NewInt(10))).
+				Build()
+			sig, _ := crypto.Sign(trx.SigningHash().Bytes(), proposer.PrivateKey)
+			if err := flow.Adopt(trx.WithSignature(sig)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		blk, stage, receipts, err := flow.Pack(proposer.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stage.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.AddBlock(blk, receipts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &localBackend{c, stateCreator}, b0.Header()
+}
+
+func TestSync(t *testing.T) {
+	backend, genesisHeader := newBackend(t, bft.EpochLength*3)
+	client := lightclient.New(backend, genesisHeader, genesisHeader, thor.NoFork)
+
+	best, err := client.Sync(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, backend.chain.BestBlock().Header().ID(), best.ID())
+	assert.Equal(t, best.ID(), client.Best().ID())
+
+	// finality agrees with the bft engine of full nodes
+	engine := bft.New(backend.chain, backend.stateCreator)
+	for num := uint32(1); num <= best.Number(); num++ {
+		header, _ := backend.chain.GetTrunkBlockHeader(num)
+		if _, err := engine.Process(header); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.True(t, client.Finalized().Number() > 0)
+	assert.Equal(t, backend.chain.FinalizedBlock().ID(), client.Finalized().ID())
+	assert.Equal(t, engine.Justified(), client.Justified().ID())
+
+	st, err := client.State(context.Background(), best.ID(), recipient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, big.NewInt(10), st.GetBalance(recipient))
+	assert.Nil(t, st.Err())
+
+	_, err = client.State(context.Background(), thor.Bytes32{}, recipient)
+	assert.NotNil(t, err, "block not synced")
+
+	// synced already
+	again, err := client.Sync(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, best.ID(), again.ID())
+}
+
+func TestSyncForged(t *testing.T) {
+	backend, genesisHeader := newBackend(t, 3)
+	client := lightclient.New(backend, genesisHeader, genesisHeader, thor.NoFork)
+	best, err := client.Sync(context.Background())
+	assert.Nil(t, err)
+
+	// signed by a key not in authority
+	key, _ := crypto.GenerateKey()
+	blk := new(block.Builder).
+		ParentID(best.ID()).
+		Timestamp(best.Timestamp() + thor.BlockInterval).
+		TotalScore(best.TotalScore() + 1).
+		GasLimit(best.GasLimit()).
+		StateRoot(best.StateRoot()).
+		Build()
+	sig, _ := crypto.Sign(blk.Header().SigningHash().Bytes(), key)
+	if _, err := backend.chain.AddBlock(blk.WithSignature(sig), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Sync(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, best.ID(), client.Best().ID())
+}
+
+func TestStateTampered(t *testing.T) {
+	backend, genesisHeader := newBackend(t, 1)
+	client := lightclient.New(&tamperedBackend{backend}, genesisHeader, genesisHeader, thor.NoFork)
+
+	st, err := client.State(context.Background(), genesisHeader.ID(), genesis.DevAccounts()[0].Address)
+	if err == nil {
+		st.GetBalance(genesis.DevAccounts()[0].Address)
+		err = st.Err()
+	}
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package lightclient
+
+import (
+	"context"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/builtin/authority"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+)
+
+// storage key of the head of candidates list in Authority, see builtin/authority
+var authorityHeadKey = thor.Blake2b([]byte("head"))
+
+// prove requests proof nodes from the backend, and puts them into db keyed by their hashes.
+// Nodes are not trusted, but those unreachable from the state root are never read.
+func prove(ctx context.Context, backend Backend, db kv.Putter, header *block.Header, addr thor.Address, keys []thor.Bytes32) error {
+	nodes, err := backend.Proof(ctx, header.ID(), addr, keys)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		hash := thor.Blake2b(node)
+		if err := db.Put(hash[:], node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCandidates loads authority candidates at the state of the block, by walking through
+// the candidates list with proofs, and then picking them as consensus does.
+func loadCandidates(ctx context.Context, backend Backend, header *block.Header) ([]*authority.Candidate, error) {
+	db, err := lvldb.NewMem()
+	if err != nil {
+		return nil, err
+	}
+	if err := prove(ctx, backend, db, header, builtin.Params.Address, []thor.Bytes32{thor.KeyProposerEndorsement}); err != nil {
+		return nil, err
+	}
+	if err := prove(ctx, backend, db, header, builtin.Authority.Address, []thor.Bytes32{authorityHeadKey}); err != nil {
+		return nil, err
+	}
+	st, err := state.New(header.StateRoot(), db)
+	if err != nil {
+		return nil, err
+	}
+
+	aut := builtin.Authority.Native(st)
+	for signer := aut.First(); signer != nil && st.Err() == nil; signer = aut.Next(*signer) {
+		if err := prove(ctx, backend, db, header, builtin.Authority.Address, []thor.Bytes32{thor.BytesToBytes32(signer[:])}); err != nil {
+			return nil, err
+		}
+		candidate, ok := aut.Get(*signer)
+		if !ok {
+			break
+		}
+		// balance of the endorsor is required to satisfy the endorsement
+		if err := prove(ctx, backend, db, header, candidate.Endorsor, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	endorsement := builtin.Params.Native(st).Get(thor.KeyProposerEndorsement)
+	candidates := aut.Candidates(endorsement, thor.MaxBlockProposers)
+	if err := st.Err(); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
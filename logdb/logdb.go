@@ -10,6 +10,8 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 
 	sqlite3 "github.com/mattn/go-sqlite3"
 	"github.com/vechain/thor/block"
@@ -21,6 +23,7 @@ type LogDB struct {
 	path          string
 	db            *sql.DB
 	driverVersion string
+	commitLock    sync.Mutex // to serialize commits, which reindexing relies on
 }
 
 // New create or open log db at given path.
@@ -34,18 +37,38 @@ func New(path string) (logDB *LogDB, err error) {
 			db.Close()
 		}
 	}()
+	if err := migrate(db); err != nil {
+		return nil, err
+	}
 	if _, err := db.Exec(eventTableSchema + transferTableSchema); err != nil {
 		return nil, err
 	}
 
 	driverVer, _, _ := sqlite3.Version()
 	return &LogDB{
-		path,
-		db,
-		driverVer,
+		path:          path,
+		db:            db,
+		driverVersion: driverVer,
 	}, nil
 }
 
+// migrate prepares data of earlier versions for the current schema.
+func migrate(db *sql.DB) error {
+	var tables, indexes int
+	if err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'transfer'").Scan(&tables); err != nil {
+		return err
+	}
+	if err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'index' AND name = 'transferPrim'").Scan(&indexes); err != nil {
+		return err
+	}
+	if tables > 0 && indexes == 0 {
+		if _, err := db.Exec(transferDedupStmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // NewMem create a log db in ram.
 func NewMem() (*LogDB, error) {
 	return New(":memory:")
@@ -62,7 +85,7 @@ func (db *LogDB) Path() string {
 
 func (db *LogDB) Prepare(header *block.Header) *BlockBatch {
 	return &BlockBatch{
-		db:     db.db,
+		logDB:  db,
 		header: header,
 	}
 }
@@ -71,24 +94,92 @@ func (db *LogDB) FilterEvents(ctx context.Context, filter *EventFilter) ([]*Even
 	if filter == nil {
 		return db.queryEvents(ctx, "SELECT * FROM event")
 	}
+	condition, args := eventCondition(filter)
+	stmt, args := paginate("SELECT * FROM event WHERE 1"+condition, args, "eventIndex", filter.Order, filter.Options)
+	return db.queryEvents(ctx, stmt, args...)
+}
+
+// CountEvents counts events matched by the filter, regardless of options.
+func (db *LogDB) CountEvents(ctx context.Context, filter *EventFilter) (uint64, error) {
+	stmt := "SELECT COUNT(*) FROM event WHERE 1"
 	var args []interface{}
-	stmt := "SELECT * FROM event WHERE 1"
-	condition := "blockNumber"
-	if filter.Range != nil {
-		if filter.Range.Unit == Time {
-			condition = "blockTime"
-		}
-		args = append(args, filter.Range.From)
-		stmt += " AND " + condition + " >= ? "
-		if filter.Range.To >= filter.Range.From {
-			args = append(args, filter.Range.To)
-			stmt += " AND " + condition + " <= ? "
+	if filter != nil {
+		var condition string
+		condition, args = eventCondition(filter)
+		stmt += condition
+	}
+	return db.count(ctx, stmt, args...)
+}
+
+func (db *LogDB) FilterTransfers(ctx context.Context, filter *TransferFilter) ([]*Transfer, error) {
+	if filter == nil {
+		return db.queryTransfers(ctx, "SELECT * FROM transfer")
+	}
+	condition, args := transferCondition(filter)
+	stmt, args := paginate("SELECT * FROM transfer WHERE 1"+condition, args, "transferIndex", filter.Order, filter.Options)
+	return db.queryTransfers(ctx, stmt, args...)
+}
+
+// CountTransfers counts transfers matched by the filter, regardless of options.
+func (db *LogDB) CountTransfers(ctx context.Context, filter *TransferFilter) (uint64, error) {
+	stmt := "SELECT COUNT(*) FROM transfer WHERE 1"
+	var args []interface{}
+	if filter != nil {
+		var condition string
+		condition, args = transferCondition(filter)
+		stmt += condition
+	}
+	return db.count(ctx, stmt, args...)
+}
+
+// rangeCondition returns the condition of the column in range.
+// Both bounds are inclusive, and the upper one is ignored if less than the lower one.
+func rangeCondition(column string, rng *Range) (string, []interface{}) {
+	stmt := " AND " + column + " >= ? "
+	args := []interface{}{rng.From}
+	if rng.To >= rng.From {
+		stmt += " AND " + column + " <= ? "
+		args = append(args, rng.To)
+	}
+	return stmt, args
+}
+
+// rangesCondition returns the condition of both the range in its unit, and the time range.
+func rangesCondition(rng *Range, timeRange *Range) (string, []interface{}) {
+	var (
+		stmt string
+		args []interface{}
+	)
+	if rng != nil {
+		column := "blockNumber"
+		if rng.Unit == Time {
+			column = "blockTime"
 		}
+		stmt, args = rangeCondition(column, rng)
+	}
+	if timeRange != nil {
+		s, a := rangeCondition("blockTime", timeRange)
+		stmt += s
+		args = append(args, a...)
 	}
+	return stmt, args
+}
+
+func eventCondition(filter *EventFilter) (string, []interface{}) {
+	stmt, args := rangesCondition(filter.Range, filter.TimeRange)
+
+	var addrs []thor.Address
 	if filter.Address != nil {
-		args = append(args, filter.Address.Bytes())
-		stmt += " AND address = ? "
+		addrs = append(addrs, *filter.Address)
 	}
+	addrs = append(addrs, filter.Addresses...)
+	if len(addrs) > 0 {
+		for _, addr := range addrs {
+			args = append(args, addr.Bytes())
+		}
+		stmt += " AND address IN (?" + strings.Repeat(",?", len(addrs)-1) + ") "
+	}
+
 	length := len(filter.TopicSet)
 	if length > 0 {
 		for i, topics := range filter.TopicSet {
@@ -110,38 +201,12 @@ func (db *LogDB) FilterEvents(ctx context.Context, filter *EventFilter) ([]*Even
 			}
 		}
 	}
-
-	if filter.Order == DESC {
-		stmt += " ORDER BY blockNumber DESC,eventIndex DESC "
-	} else {
-		stmt += " ORDER BY blockNumber ASC,eventIndex ASC "
-	}
-
-	if filter.Options != nil {
-		stmt += " limit ?, ? "
-		args = append(args, filter.Options.Offset, filter.Options.Limit)
-	}
-	return db.queryEvents(ctx, stmt, args...)
+	return stmt, args
 }
 
-func (db *LogDB) FilterTransfers(ctx context.Context, filter *TransferFilter) ([]*Transfer, error) {
-	if filter == nil {
-		return db.queryTransfers(ctx, "SELECT * FROM transfer")
-	}
-	var args []interface{}
-	stmt := "SELECT * FROM transfer WHERE 1"
-	condition := "blockNumber"
-	if filter.Range != nil {
-		if filter.Range.Unit == Time {
-			condition = "blockTime"
-		}
-		args = append(args, filter.Range.From)
-		stmt += " AND " + condition + " >= ? "
-		if filter.Range.To >= filter.Range.From {
-			args = append(args, filter.Range.To)
-			stmt += " AND " + condition + " <= ? "
-		}
-	}
+func transferCondition(filter *TransferFilter) (string, []interface{}) {
+	stmt, args := rangesCondition(filter.Range, filter.TimeRange)
+
 	if filter.TxID != nil {
 		args = append(args, filter.TxID.Bytes())
 		stmt += " AND txID = ? "
@@ -173,16 +238,35 @@ func (db *LogDB) FilterTransfers(ctx context.Context, filter *TransferFilter) ([
 			}
 		}
 	}
-	if filter.Order == DESC {
-		stmt += " ORDER BY blockNumber DESC,transferIndex DESC "
-	} else {
-		stmt += " ORDER BY blockNumber ASC,transferIndex ASC "
+	return stmt, args
+}
+
+// paginate appends the cursor condition, order and limit to the statement.
+// Logs are ordered by position, which is unique as logs of abandoned blocks are deleted,
+// so results after the cursor are stable while new blocks come.
+func paginate(stmt string, args []interface{}, indexColumn string, order Order, options *Options) (string, []interface{}) {
+	op, dir := ">", "ASC"
+	if order == DESC {
+		op, dir = "<", "DESC"
+	}
+	if options != nil && options.Cursor != nil {
+		stmt += fmt.Sprintf(" AND (blockNumber %v ? OR (blockNumber = ? AND %v %v ?)) ", op, indexColumn, op)
+		args = append(args, options.Cursor.BlockNumber, options.Cursor.BlockNumber, options.Cursor.Index)
 	}
-	if filter.Options != nil {
+	stmt += fmt.Sprintf(" ORDER BY blockNumber %v,%v %v ", dir, indexColumn, dir)
+	if options != nil {
 		stmt += " limit ?, ? "
-		args = append(args, filter.Options.Offset, filter.Options.Limit)
+		args = append(args, options.Offset, options.Limit)
 	}
-	return db.queryTransfers(ctx, stmt, args...)
+	return stmt, args
+}
+
+func (db *LogDB) count(ctx context.Context, stmt string, args ...interface{}) (uint64, error) {
+	var count uint64
+	if err := db.db.QueryRowContext(ctx, stmt, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
 }
 
 func (db *LogDB) queryEvents(ctx context.Context, stmt string, args ...interface{}) ([]*Event, error) {
@@ -315,14 +399,14 @@ func topicValue(topic *thor.Bytes32) []byte {
 }
 
 type BlockBatch struct {
-	db        *sql.DB
+	logDB     *LogDB
 	header    *block.Header
 	events    []*Event
 	transfers []*Transfer
 }
 
-func (bb *BlockBatch) execInTx(proc func(*sql.Tx) error) (err error) {
-	tx, err := bb.db.Begin()
+func (db *LogDB) execInTx(proc func(*sql.Tx) error) (err error) {
+	tx, err := db.db.Begin()
 	if err != nil {
 		return err
 	}
@@ -334,52 +418,72 @@ func (bb *BlockBatch) execInTx(proc func(*sql.Tx) error) (err error) {
 }
 
 func (bb *BlockBatch) Commit(abandonedBlocks ...thor.Bytes32) error {
-	return bb.execInTx(func(tx *sql.Tx) error {
-		for _, event := range bb.events {
-			if _, err := tx.Exec("INSERT OR REPLACE INTO event(blockID ,eventIndex, blockNumber ,blockTime ,txID ,txOrigin ,address ,topic0 ,topic1 ,topic2 ,topic3 ,topic4, data) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);",
-				event.BlockID.Bytes(),
-				event.Index,
-				event.BlockNumber,
-				event.BlockTime,
-				event.TxID.Bytes(),
-				event.TxOrigin.Bytes(),
-				event.Address.Bytes(),
-				topicValue(event.Topics[0]),
-				topicValue(event.Topics[1]),
-				topicValue(event.Topics[2]),
-				topicValue(event.Topics[3]),
-				topicValue(event.Topics[4]),
-				event.Data,
-			); err != nil {
-				return err
-			}
+	bb.logDB.commitLock.Lock()
+	defer bb.logDB.commitLock.Unlock()
+
+	return bb.logDB.execInTx(func(tx *sql.Tx) error {
+		return bb.write(tx, abandonedBlocks...)
+	})
+}
+
+// write inserts logs of the batch and deletes those of abandoned blocks in the sql tx.
+func (bb *BlockBatch) write(tx *sql.Tx, abandonedBlocks ...thor.Bytes32) error {
+	for _, event := range bb.events {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO event(blockID ,eventIndex, blockNumber ,blockTime ,txID ,txOrigin ,address ,topic0 ,topic1 ,topic2 ,topic3 ,topic4, data) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+			event.BlockID.Bytes(),
+			event.Index,
+			event.BlockNumber,
+			event.BlockTime,
+			event.TxID.Bytes(),
+			event.TxOrigin.Bytes(),
+			event.Address.Bytes(),
+			topicValue(event.Topics[0]),
+			topicValue(event.Topics[1]),
+			topicValue(event.Topics[2]),
+			topicValue(event.Topics[3]),
+			topicValue(event.Topics[4]),
+			event.Data,
+		); err != nil {
+			return err
 		}
+	}
 
-		for _, transfer := range bb.transfers {
-			if _, err := tx.Exec("INSERT OR REPLACE INTO transfer(blockID ,transferIndex, blockNumber ,blockTime ,txID ,txOrigin ,sender ,recipient ,amount) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?);",
-				transfer.BlockID.Bytes(),
-				transfer.Index,
-				transfer.BlockNumber,
-				transfer.BlockTime,
-				transfer.TxID.Bytes(),
-				transfer.TxOrigin.Bytes(),
-				transfer.Sender.Bytes(),
-				transfer.Recipient.Bytes(),
-				transfer.Amount.Bytes(),
-			); err != nil {
-				return err
-			}
+	for _, transfer := range bb.transfers {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO transfer(blockID ,transferIndex, blockNumber ,blockTime ,txID ,txOrigin ,sender ,recipient ,amount) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?);",
+			transfer.BlockID.Bytes(),
+			transfer.Index,
+			transfer.BlockNumber,
+			transfer.BlockTime,
+			transfer.TxID.Bytes(),
+			transfer.TxOrigin.Bytes(),
+			transfer.Sender.Bytes(),
+			transfer.Recipient.Bytes(),
+			transfer.Amount.Bytes(),
+		); err != nil {
+			return err
 		}
-		for _, id := range abandonedBlocks {
-			if _, err := tx.Exec("DELETE FROM event WHERE blockID = ?;", id.Bytes()); err != nil {
-				return err
-			}
-			if _, err := tx.Exec("DELETE FROM transfer WHERE blockID = ?;", id.Bytes()); err != nil {
-				return err
-			}
+	}
+	for _, id := range abandonedBlocks {
+		if _, err := tx.Exec("DELETE FROM event WHERE blockID = ?;", id.Bytes()); err != nil {
+			return err
 		}
-		return nil
-	})
+		if _, err := tx.Exec("DELETE FROM transfer WHERE blockID = ?;", id.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForBlock inserts logs of all txs in the block, with receipts of these txs.
+func (bb *BlockBatch) ForBlock(txs tx.Transactions, receipts tx.Receipts) *BlockBatch {
+	for i, trx := range txs {
+		origin, _ := trx.Signer()
+		txBatch := bb.ForTransaction(trx.ID(), origin)
+		for _, output := range receipts[i].Outputs {
+			txBatch.Insert(output.Events, output.Transfers)
+		}
+	}
+	return bb
 }
 
 func (bb *BlockBatch) ForTransaction(txID thor.Bytes32, txOrigin thor.Address) struct {
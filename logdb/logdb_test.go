@@ -12,9 +12,14 @@ import (
 	"os/user"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
 	"github.com/vechain/thor/logdb"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
 )
@@ -76,6 +81,125 @@ func TestEvents(t *testing.T) {
 	assert.Equal(t, len(es), limit, "limit should be equal")
 }
 
+func TestCursor(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	txEvent := &tx.Event{Address: thor.BytesToAddress([]byte("addr"))}
+	header := new(block.Builder).Build().Header()
+	for i := 0; i < 10; i++ {
+		// 2 events per block
+		if err := db.Prepare(header).ForTransaction(thor.Bytes32{}, thor.Address{}).
+			Insert(tx.Events{txEvent, txEvent}, nil).Commit(); err != nil {
+			t.Fatal(err)
+		}
+		header = new(block.Builder).ParentID(header.ID()).Build().Header()
+	}
+
+	for _, order := range []logdb.Order{logdb.ASC, logdb.DESC} {
+		var (
+			cursor *logdb.Cursor
+			all    []*logdb.Event
+		)
+		for {
+			es, err := db.FilterEvents(context.Background(), &logdb.EventFilter{
+				Options: &logdb.Options{Limit: 3, Cursor: cursor},
+				Order:   order,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			all = append(all, es...)
+			if len(es) < 3 {
+				break
+			}
+			cursor = es[len(es)-1].Cursor()
+		}
+		assert.Equal(t, 20, len(all), order)
+		for i := 1; i < len(all); i++ {
+			prev, cur := all[i-1], all[i]
+			if order == logdb.ASC {
+				assert.True(t, prev.BlockNumber < cur.BlockNumber || (prev.BlockNumber == cur.BlockNumber && prev.Index < cur.Index))
+			} else {
+				assert.True(t, prev.BlockNumber > cur.BlockNumber || (prev.BlockNumber == cur.BlockNumber && prev.Index > cur.Index))
+			}
+		}
+	}
+
+	var cursor logdb.Cursor
+	assert.Nil(t, cursor.UnmarshalText([]byte((&logdb.Cursor{BlockNumber: 1, Index: 2}).String())))
+	assert.Equal(t, logdb.Cursor{BlockNumber: 1, Index: 2}, cursor)
+	assert.NotNil(t, cursor.UnmarshalText([]byte("0x01")))
+}
+
+func TestCount(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	addrs := []thor.Address{thor.BytesToAddress([]byte("a0")), thor.BytesToAddress([]byte("a1")), thor.BytesToAddress([]byte("a2"))}
+	// blocks #2 to #31, with timestamp of block #n (n-2)*10, and address of it addrs[(n-2)%3]
+	header := new(block.Builder).Build().Header()
+	for i := 0; i < 30; i++ {
+		header = new(block.Builder).ParentID(header.ID()).Timestamp(uint64(i) * 10).Build().Header()
+		addr := addrs[i%len(addrs)]
+		if err := db.Prepare(header).ForTransaction(thor.Bytes32{}, addr).
+			Insert(tx.Events{{Address: addr}}, tx.Transfers{{Sender: addr, Recipient: addr, Amount: big.NewInt(1)}}).Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filter := &logdb.EventFilter{
+		Addresses: addrs[1:],
+		Range:     &logdb.Range{Unit: logdb.Block, From: 1, To: 20},
+		TimeRange: &logdb.Range{From: 50, To: 250},
+		Options:   &logdb.Options{Limit: 2},
+	}
+	// both bounds are inclusive, so blocks #7 to #20, 2 of 3 addresses
+	count, err := db.CountEvents(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(9), count)
+
+	es, err := db.FilterEvents(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 2, len(es)) {
+		assert.Equal(t, uint32(7), es[0].BlockNumber)
+		assert.Equal(t, uint32(9), es[1].BlockNumber)
+	}
+
+	filter.Address = &addrs[0]
+	count, err = db.CountEvents(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(14), count)
+
+	count, err = db.CountEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(30), count)
+
+	count, err = db.CountTransfers(context.Background(), &logdb.TransferFilter{
+		AddressSets: []*logdb.AddressSet{{Sender: &addrs[0]}, {Recipient: &addrs[1]}},
+		TimeRange:   &logdb.Range{From: 100},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// blocks #12 to #31
+	assert.Equal(t, uint64(13), count)
+}
+
 func TestTransfers(t *testing.T) {
 	db, err := logdb.NewMem()
 	if err != nil {
@@ -127,6 +251,166 @@ func TestTransfers(t *testing.T) {
 	assert.Equal(t, len(ts), count, "transfers searched")
 }
 
+func TestForBlock(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	to := thor.BytesToAddress([]byte("to"))
+	key, _ := crypto.GenerateKey()
+	trx, _ := tx.Sign(new(tx.Builder).Clause(tx.NewClause(&to)).Build(), key)
+	receipts := tx.Receipts{{
+		Outputs: []*tx.Output{{
+			Events:    tx.Events{{Address: to}},
+			Transfers: tx.Transfers{{Sender: thor.Address(crypto.PubkeyToAddress(key.PublicKey)), Recipient: to, Amount: big.NewInt(1)}},
+		}},
+	}}
+
+	header := new(block.Builder).Build().Header()
+	// committed twice, e.g. rewritten when switched onto trunk again
+	for i := 0; i < 2; i++ {
+		if err := db.Prepare(header).ForBlock(tx.Transactions{trx}, receipts).Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	origin := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+	ts, err := db.FilterTransfers(context.Background(), &logdb.TransferFilter{
+		AddressSets: []*logdb.AddressSet{{TxOrigin: &origin}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(ts)) {
+		assert.Equal(t, trx.ID(), ts[0].TxID)
+	}
+
+	es, err := db.FilterEvents(context.Background(), &logdb.EventFilter{Address: &to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(es)) {
+		assert.Equal(t, origin, es[0].TxOrigin)
+	}
+
+	// abandoned
+	if err := db.Prepare(header).Commit(header.ID()); err != nil {
+		t.Fatal(err)
+	}
+	es, err = db.FilterEvents(context.Background(), &logdb.EventFilter{Address: &to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(es))
+}
+
+func TestReindex(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	kv, _ := lvldb.NewMem()
+	gene, _ := genesis.NewDevnet()
+	b0, _, err := gene.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := chain.New(kv, b0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := thor.BytesToAddress([]byte("to"))
+	genesisEvent := &tx.Event{Address: thor.BytesToAddress([]byte("genesis"))}
+	if err := db.Prepare(b0.Header()).ForTransaction(thor.Bytes32{}, thor.Address{}).
+		Insert(tx.Events{genesisEvent}, nil).Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := crypto.GenerateKey()
+	parent := b0
+	for i := 0; i < 3; i++ {
+		trx, _ := tx.Sign(new(tx.Builder).Nonce(uint64(i)).Clause(tx.NewClause(&to)).Build(), key)
+		blk := new(block.Builder).ParentID(parent.Header().ID()).TotalScore(parent.Header().TotalScore() + 1).Transaction(trx).Build()
+		receipts := tx.Receipts{{Outputs: []*tx.Output{{Events: tx.Events{{Address: to}}}}}}
+		if _, err := ch.AddBlock(blk, receipts); err != nil {
+			t.Fatal(err)
+		}
+		parent = blk
+	}
+
+	// stale logs of a block once on trunk
+	stale := new(block.Builder).ParentID(b0.Header().ID()).TotalScore(100).Build()
+	if err := db.Prepare(stale.Header()).ForTransaction(thor.Bytes32{}, thor.Address{}).
+		Insert(tx.Events{{Address: to}}, nil).Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []uint32
+	if err := db.Reindex(context.Background(), ch, 0, func(num, target uint32) {
+		progress = append(progress, num, target)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []uint32{3, 3}, progress)
+
+	es, err := db.FilterEvents(context.Background(), &logdb.EventFilter{Address: &to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 3, len(es)) {
+		for i, e := range es {
+			id, _ := ch.GetTrunkBlockID(uint32(i + 1))
+			assert.Equal(t, id, e.BlockID)
+		}
+	}
+	es, err = db.FilterEvents(context.Background(), &logdb.EventFilter{Address: &genesisEvent.Address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(es), "genesis logs should be kept")
+
+	assert.Error(t, db.Reindex(context.Background(), ch, 4, nil))
+}
+
+func TestTruncate(t *testing.T) {
+	db, err := logdb.NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	to := thor.BytesToAddress([]byte("to"))
+	// logs of blocks numbered 2 to 4
+	parent := new(block.Builder).Build()
+	for i := 0; i < 3; i++ {
+		blk := new(block.Builder).ParentID(parent.Header().ID()).Build()
+		if err := db.Prepare(blk.Header()).ForTransaction(thor.Bytes32{}, thor.Address{}).
+			Insert(tx.Events{{Address: to}}, tx.Transfers{{Recipient: to, Amount: big.NewInt(1)}}).Commit(); err != nil {
+			t.Fatal(err)
+		}
+		parent = blk
+	}
+
+	assert.Nil(t, db.Truncate(3))
+	es, err := db.FilterEvents(context.Background(), &logdb.EventFilter{Address: &to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(es)) {
+		assert.Equal(t, uint32(2), es[0].BlockNumber)
+	}
+	ts, err := db.FilterTransfers(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(ts))
+}
+
 func home() (string, error) {
 	// try to get HOME env
 	if home := os.Getenv("HOME"); home != "" {
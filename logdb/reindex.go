@@ -0,0 +1,115 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logdb
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/chain"
+)
+
+const reindexBatchSize = 256 // number of blocks written in a sql tx while reindexing
+
+// Reindex drops logs from the block number, and rebuilds them by scanning blocks and receipts
+// on trunk, up to the best block when started. Logs of genesis are kept, since they are not
+// in receipts.
+//
+// It's safe to reindex against a live node, which keeps committing logs of new blocks.
+// Trunk blocks are read with commits blocked, so logs of blocks switched off trunk by a reorg are
+// either not written, or deleted later by the commit of the reorg. progress is called after each batch written.
+func (db *LogDB) Reindex(ctx context.Context, chain *chain.Chain, from uint32, progress func(num, target uint32)) error {
+	target := chain.BestBlock().Header().Number()
+	if from > target {
+		return errors.New("from exceeds best block")
+	}
+	if from == 0 {
+		if target == 0 {
+			return nil
+		}
+		from = 1
+	}
+
+	if err := db.truncate(from, target); err != nil {
+		return errors.Wrap(err, "drop logs")
+	}
+
+	for num := from; num <= target; {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		end := num + reindexBatchSize - 1
+		if end > target || end < num {
+			end = target
+		}
+		if err := db.reindexRange(chain, num, end); err != nil {
+			return errors.WithMessage(err, "rebuild logs")
+		}
+		if progress != nil {
+			progress(end, target)
+		}
+		if end == target {
+			break
+		}
+		num = end + 1
+	}
+	return nil
+}
+
+// Truncate deletes logs of blocks from the block number, e.g. those left above the best block
+// after the chain rewound.
+func (db *LogDB) Truncate(from uint32) error {
+	return db.truncate(from, math.MaxUint32)
+}
+
+// truncate deletes logs of blocks in range [from, to].
+func (db *LogDB) truncate(from, to uint32) error {
+	db.commitLock.Lock()
+	defer db.commitLock.Unlock()
+
+	return db.execInTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("DELETE FROM event WHERE blockNumber >= ? AND blockNumber <= ?;", from, to); err != nil {
+			return err
+		}
+		_, err := tx.Exec("DELETE FROM transfer WHERE blockNumber >= ? AND blockNumber <= ?;", from, to)
+		return err
+	})
+}
+
+// reindexRange writes logs of trunk blocks in range [from, to] in a sql tx.
+func (db *LogDB) reindexRange(chain *chain.Chain, from, to uint32) error {
+	db.commitLock.Lock()
+	defer db.commitLock.Unlock()
+
+	return db.execInTx(func(tx *sql.Tx) error {
+		for num := from; ; num++ {
+			// read from trunk under commit lock, so that blocks switched off trunk are
+			// either not read, or deleted by the commit of the reorg after written.
+			blk, err := chain.GetTrunkBlock(num)
+			if err != nil {
+				return err
+			}
+			receipts, err := chain.GetBlockReceipts(blk.Header().ID())
+			if err != nil {
+				return err
+			}
+			if err := db.Prepare(blk.Header()).
+				ForBlock(blk.Transactions(), receipts).
+				write(tx); err != nil {
+				return err
+			}
+			if num == to {
+				break
+			}
+		}
+		return nil
+	})
+}
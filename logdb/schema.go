@@ -26,8 +26,10 @@ const (
 CREATE UNIQUE INDEX IF NOT EXISTS prim ON event(blockID, eventIndex);
 
 CREATE INDEX IF NOT EXISTS blockNumberIndex ON event(blockNumber);
+CREATE INDEX IF NOT EXISTS eventPositionIndex ON event(blockNumber, eventIndex);
 CREATE INDEX IF NOT EXISTS blockTimeIndex ON event(blockTime);
 CREATE INDEX IF NOT EXISTS addressIndex ON event(address);
+CREATE INDEX IF NOT EXISTS eventTxOriginIndex ON event(txOrigin);
 CREATE INDEX IF NOT EXISTS topicIndex0 ON event(topic0);
 CREATE INDEX IF NOT EXISTS topicIndex1 ON event(topic1);
 CREATE INDEX IF NOT EXISTS topicIndex2 ON event(topic2);
@@ -47,10 +49,17 @@ CREATE INDEX IF NOT EXISTS topicIndex4 ON event(topic4);`
 	amount BLOB
 );
 
-CREATE UNIQUE INDEX IF NOT EXISTS prim ON transfer(blockID, transferIndex);
+CREATE UNIQUE INDEX IF NOT EXISTS transferPrim ON transfer(blockID, transferIndex);
 
-CREATE INDEX IF NOT EXISTS blockNumberIndex ON transfer(blockNumber);
-CREATE INDEX IF NOT EXISTS blockTimeIndex ON transfer(blockTime);
+CREATE INDEX IF NOT EXISTS transferBlockNumberIndex ON transfer(blockNumber);
+CREATE INDEX IF NOT EXISTS transferPositionIndex ON transfer(blockNumber, transferIndex);
+CREATE INDEX IF NOT EXISTS transferBlockTimeIndex ON transfer(blockTime);
+CREATE INDEX IF NOT EXISTS transferTxIDIndex ON transfer(txID);
+CREATE INDEX IF NOT EXISTS transferTxOriginIndex ON transfer(txOrigin);
 CREATE INDEX IF NOT EXISTS senderIndex ON transfer(sender);
 CREATE INDEX IF NOT EXISTS recipientIndex ON transfer(recipient);`
+
+	// transfer indexes were once named the same as event ones, which share the namespace, so never created.
+	// duplicated rows, which the unique index should have prevented, are removed before creating it.
+	transferDedupStmt = `DELETE FROM transfer WHERE rowid NOT IN (SELECT MIN(rowid) FROM transfer GROUP BY blockID, transferIndex);`
 )
@@ -6,8 +6,11 @@
 package logdb
 
 import (
+	"encoding/binary"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
@@ -95,15 +98,63 @@ type Range struct {
 type Options struct {
 	Offset uint64
 	Limit  uint64
+	Cursor *Cursor // to continue after the position, in the order of query
+}
+
+// Cursor position of a log, to paginate query results stably.
+// It's encoded as an opaque hex string in JSON.
+type Cursor struct {
+	BlockNumber uint32
+	Index       uint32
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *Cursor) MarshalText() ([]byte, error) {
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:], c.BlockNumber)
+	binary.BigEndian.PutUint32(b[4:], c.Index)
+	return []byte(hexutil.Encode(b[:])), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Cursor) UnmarshalText(text []byte) error {
+	b, err := hexutil.Decode(string(text))
+	if err != nil {
+		return err
+	}
+	if len(b) != 8 {
+		return errors.New("invalid cursor length")
+	}
+	c.BlockNumber = binary.BigEndian.Uint32(b)
+	c.Index = binary.BigEndian.Uint32(b[4:])
+	return nil
+}
+
+// String returns the encoded cursor.
+func (c *Cursor) String() string {
+	text, _ := c.MarshalText()
+	return string(text)
+}
+
+// Cursor returns the cursor at position of the event.
+func (e *Event) Cursor() *Cursor {
+	return &Cursor{e.BlockNumber, e.Index}
+}
+
+// Cursor returns the cursor at position of the transfer.
+func (t *Transfer) Cursor() *Cursor {
+	return &Cursor{t.BlockNumber, t.Index}
 }
 
 //EventFilter filter
 type EventFilter struct {
-	Address  *thor.Address // always a contract address
-	TopicSet [][5]*thor.Bytes32
-	Range    *Range
-	Options  *Options
-	Order    Order //default asc
+	Address   *thor.Address  // always a contract address
+	Addresses []thor.Address // along with Address, events emitted by any of them are matched
+	TopicSet  [][5]*thor.Bytes32
+	Range     *Range
+	TimeRange *Range // constrains block time along with Range, unit ignored
+	Options   *Options
+	Order     Order //default asc
 }
 
 type AddressSet struct {
@@ -116,6 +167,7 @@ type TransferFilter struct {
 	TxID        *thor.Bytes32
 	AddressSets []*AddressSet
 	Range       *Range
+	TimeRange   *Range // constrains block time along with Range, unit ignored
 	Options     *Options
 	Order       Order //default asc
 }
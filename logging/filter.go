@@ -0,0 +1,160 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package logging provides log handlers for the node, which filter logs by levels of subsystems
+// adjustable at runtime, and write them into size rotated files.
+//
+// Subsystems are identified by the 'pkg' context of loggers, e.g. log15.New("pkg", "comm").
+package logging
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+)
+
+// pkgKey context key to identify subsystem of a record.
+const pkgKey = "pkg"
+
+var levelNames = []string{
+	log15.LvlCrit:  "crit",
+	log15.LvlError: "error",
+	log15.LvlWarn:  "warn",
+	log15.LvlInfo:  "info",
+	log15.LvlDebug: "debug",
+}
+
+// ParseLevel parses level name (crit, error, warn, info, debug), or verbosity number.
+func ParseLevel(str string) (log15.Lvl, error) {
+	for lvl, name := range levelNames {
+		if strings.EqualFold(str, name) {
+			return log15.Lvl(lvl), nil
+		}
+	}
+	n, err := strconv.ParseUint(str, 10, 8)
+	if err != nil {
+		return 0, errors.Errorf("invalid log level '%v'", str)
+	}
+	return log15.Lvl(n), nil
+}
+
+// LevelName returns name of the level, or the number if more verbose than debug.
+func LevelName(lvl log15.Lvl) string {
+	if int(lvl) < len(levelNames) {
+		return levelNames[lvl]
+	}
+	return strconv.Itoa(int(lvl))
+}
+
+// Filter passes records not more verbose than the level of their subsystems to the next handler.
+// Subsystems without level set follow the default level.
+// It's safe to adjust levels concurrently.
+type Filter struct {
+	next log15.Handler
+
+	lock      sync.RWMutex
+	level     log15.Lvl
+	pkgLevels map[string]log15.Lvl
+}
+
+// NewFilter create a filter with the default level.
+func NewFilter(level log15.Lvl, next log15.Handler) *Filter {
+	return &Filter{
+		next:      next,
+		level:     level,
+		pkgLevels: make(map[string]log15.Lvl),
+	}
+}
+
+// Log implements log15.Handler.
+func (f *Filter) Log(r *log15.Record) error {
+	level := f.levelOf(pkgOf(r))
+	if r.Lvl > level {
+		return nil
+	}
+	return f.next.Log(r)
+}
+
+func pkgOf(r *log15.Record) string {
+	for i := 0; i+1 < len(r.Ctx); i += 2 {
+		if key, ok := r.Ctx[i].(string); ok && key == pkgKey {
+			pkg, _ := r.Ctx[i+1].(string)
+			return pkg
+		}
+	}
+	return ""
+}
+
+func (f *Filter) levelOf(pkg string) log15.Lvl {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	if level, ok := f.pkgLevels[pkg]; ok {
+		return level
+	}
+	return f.level
+}
+
+// Level returns the default level.
+func (f *Filter) Level() log15.Lvl {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.level
+}
+
+// SetLevel sets the default level.
+func (f *Filter) SetLevel(level log15.Lvl) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.level = level
+}
+
+// PkgLevels returns levels set for subsystems.
+func (f *Filter) PkgLevels() map[string]log15.Lvl {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	levels := make(map[string]log15.Lvl, len(f.pkgLevels))
+	for pkg, level := range f.pkgLevels {
+		levels[pkg] = level
+	}
+	return levels
+}
+
+// SetPkgLevel sets level of the subsystem, overriding the default one.
+func (f *Filter) SetPkgLevel(pkg string, level log15.Lvl) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.pkgLevels[pkg] = level
+}
+
+// ClearPkgLevel clears level of the subsystem, which follows the default level then.
+func (f *Filter) ClearPkgLevel(pkg string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.pkgLevels, pkg)
+}
+
+// ParsePkgLevels parses comma separated list of subsystem levels, e.g. 'comm=debug,api=warn'.
+func ParsePkgLevels(str string) (map[string]log15.Lvl, error) {
+	levels := make(map[string]log15.Lvl)
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, errors.Errorf("invalid subsystem level '%v'", item)
+		}
+		level, err := ParseLevel(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, err
+		}
+		levels[strings.TrimSpace(parts[0])] = level
+	}
+	return levels, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package logging_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/inconshreveable/log15"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/logging"
+)
+
+func TestParseLevel(t *testing.T) {
+	for str, want := range map[string]log15.Lvl{
+		"crit":  log15.LvlCrit,
+		"Error": log15.LvlError,
+		"warn":  log15.LvlWarn,
+		"info":  log15.LvlInfo,
+		"debug": log15.LvlDebug,
+		"9":     log15.Lvl(9),
+	} {
+		lvl, err := logging.ParseLevel(str)
+		assert.Nil(t, err, str)
+		assert.Equal(t, want, lvl, str)
+	}
+	_, err := logging.ParseLevel("verbose")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, "debug", logging.LevelName(log15.LvlDebug))
+	assert.Equal(t, "9", logging.LevelName(log15.Lvl(9)))
+
+	levels, err := logging.ParsePkgLevels("comm=debug, api=warn")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]log15.Lvl{"comm": log15.LvlDebug, "api": log15.LvlWarn}, levels)
+	_, err = logging.ParsePkgLevels("comm")
+	assert.NotNil(t, err)
+}
+
+func TestFilter(t *testing.T) {
+	var records []*log15.Record
+	filter := logging.NewFilter(log15.LvlInfo, log15.FuncHandler(func(r *log15.Record) error {
+		records = append(records, r)
+		return nil
+	}))
+	logger := log15.New()
+	logger.SetHandler(filter)
+	comm := logger.New("pkg", "comm")
+
+	comm.Debug("dropped")
+	logger.Info("passed")
+	assert.Equal(t, 1, len(records))
+
+	filter.SetPkgLevel("comm", log15.LvlDebug)
+	comm.Debug("passed")
+	logger.Debug("dropped")
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, map[string]log15.Lvl{"comm": log15.LvlDebug}, filter.PkgLevels())
+
+	filter.ClearPkgLevel("comm")
+	filter.SetLevel(log15.LvlWarn)
+	comm.Info("dropped")
+	comm.Warn("passed")
+	assert.Equal(t, 3, len(records))
+	assert.Equal(t, log15.LvlWarn, filter.Level())
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thor-logging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "thor.log")
+	rf, err := logging.OpenRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		_, err := rf.Write([]byte(line))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, rf.Close())
+
+	read := func(path string) string {
+		data, _ := ioutil.ReadFile(path)
+		return string(data)
+	}
+	assert.Equal(t, "dddddddd\n", read(path))
+	assert.Equal(t, "cccccccc\n", read(path+".1"))
+	assert.Equal(t, "bbbbbbbb\n", read(path+".2"))
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "oldest backup should be deleted")
+
+	// appended when reopened
+	rf, err = logging.OpenRotatingFile(path, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rf.Write([]byte("eeeeeeee\n"))
+	rf.Close()
+	assert.Equal(t, "dddddddd\neeeeeeee\n", read(path))
+}
@@ -6,6 +6,8 @@
 package lvldb
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/filter"
@@ -13,10 +15,23 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/storage"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
 )
 
 var _ kv.GetPutCloser = (*LevelDB)(nil)
 
+var (
+	opCounter                   = metric.NewCounterVec("kv", "ops_total", "count of db operations", "op")
+	getCounter                  = opCounter.WithLabelValues("get")
+	hasCounter                  = opCounter.WithLabelValues("has")
+	putCounter                  = opCounter.WithLabelValues("put")
+	deleteCounter               = opCounter.WithLabelValues("delete")
+	iterateCounter              = opCounter.WithLabelValues("iterate")
+	batchWriteCounter           = opCounter.WithLabelValues("batch_write")
+	batchWriteOpCounter         = metric.NewCounter("kv", "batch_write_ops_total", "count of ops written in batches")
+	batchWriteDurationHistogram = metric.NewHistogram("kv", "batch_write_duration_seconds", "duration of batch writes", metric.DurationBuckets)
+)
+
 // Options options for creating level db instance.
 type Options struct {
 	CacheSize              int
@@ -77,24 +92,33 @@ func (ldb *LevelDB) IsNotFound(err error) bool {
 // Get retrieve value for given key.
 // It returns an error if key not found. The error can be checked via IsNotFound.
 func (ldb *LevelDB) Get(key []byte) (value []byte, err error) {
+	getCounter.Inc()
 	return ldb.db.Get(key, &readOpt)
 }
 
 // Has returns whether a key exists.
 func (ldb *LevelDB) Has(key []byte) (bool, error) {
+	hasCounter.Inc()
 	return ldb.db.Has(key, &readOpt)
 }
 
 // Put save value fo give key.
 func (ldb *LevelDB) Put(key, value []byte) error {
+	putCounter.Inc()
 	return ldb.db.Put(key, value, &writeOpt)
 }
 
 // Delete deletes the give key and its value.
 func (ldb *LevelDB) Delete(key []byte) error {
+	deleteCounter.Inc()
 	return ldb.db.Delete(key, &writeOpt)
 }
 
+// Compact compacts the whole key space, to reclaim space taken by deleted entries.
+func (ldb *LevelDB) Compact() error {
+	return ldb.db.CompactRange(util.Range{})
+}
+
 // Close close the level db.
 // Later operations will all fail.
 func (ldb *LevelDB) Close() error {
@@ -111,6 +135,7 @@ func (ldb *LevelDB) NewBatch() kv.Batch {
 
 // NewIterator create a iterator by range.
 func (ldb *LevelDB) NewIterator(r kv.Range) kv.Iterator {
+	iterateCounter.Inc()
 	return ldb.db.NewIterator(&util.Range{
 		Start: r.From,
 		Limit: r.To,
@@ -151,5 +176,9 @@ func (b *levelDBBatch) Len() int {
 
 // Write perform all ops in this batch.
 func (b *levelDBBatch) Write() error {
+	defer func(start time.Time) { batchWriteDurationHistogram.Observe(time.Since(start).Seconds()) }(time.Now())
+
+	batchWriteCounter.Inc()
+	batchWriteOpCounter.Add(float64(b.batch.Len()))
 	return b.db.Write(b.batch, &writeOpt)
 }
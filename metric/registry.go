@@ -0,0 +1,107 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package metric
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixed to all metric names, as in 'thor_{subsystem}_{name}'.
+const namespace = "thor"
+
+// DurationBuckets histogram buckets in seconds, ranges from 1ms to 10s.
+var DurationBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{Namespace: namespace}),
+		prometheus.NewGoCollector(),
+	)
+}
+
+// NewCounter creates and registers a counter.
+func NewCounter(subsystem, name, help string) prometheus.Counter {
+	c := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	})
+	registry.MustRegister(c)
+	return c
+}
+
+// NewCounterVec creates and registers a counter partitioned by labels.
+func NewCounterVec(subsystem, name, help string, labels ...string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	registry.MustRegister(c)
+	return c
+}
+
+// NewCounterFunc creates and registers a counter, whose value is collected by calling f.
+func NewCounterFunc(subsystem, name, help string, f func() float64) prometheus.CounterFunc {
+	c := prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, f)
+	registry.MustRegister(c)
+	return c
+}
+
+// NewGauge creates and registers a gauge.
+func NewGauge(subsystem, name, help string) prometheus.Gauge {
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	})
+	registry.MustRegister(g)
+	return g
+}
+
+// NewHistogram creates and registers a histogram.
+func NewHistogram(subsystem, name, help string, buckets []float64) prometheus.Histogram {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+		Buckets:   buckets,
+	})
+	registry.MustRegister(h)
+	return h
+}
+
+// NewHistogramVec creates and registers a histogram partitioned by labels.
+func NewHistogramVec(subsystem, name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+		Buckets:   buckets,
+	}, labels)
+	registry.MustRegister(h)
+	return h
+}
+
+// Handler returns the handler to serve all registered metrics in Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
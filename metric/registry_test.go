@@ -0,0 +1,43 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package metric_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/metric"
+)
+
+func TestRegistry(t *testing.T) {
+	counter := metric.NewCounterVec("test", "ops_total", "count of test ops", "op")
+	counter.WithLabelValues("get").Add(2)
+	histogram := metric.NewHistogram("test", "duration_seconds", "duration of test ops", metric.DurationBuckets)
+	histogram.Observe(0.02)
+
+	ts := httptest.NewServer(metric.Handler())
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(data)
+
+	assert.Contains(t, body, `thor_test_ops_total{op="get"} 2`)
+	assert.Contains(t, body, `thor_test_duration_seconds_bucket{le="0.025"} 1`)
+	assert.Contains(t, body, `thor_test_duration_seconds_bucket{le="0.01"} 0`)
+	assert.Contains(t, body, "thor_test_duration_seconds_count 1")
+	assert.Contains(t, body, "go_goroutines")
+}
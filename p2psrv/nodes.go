@@ -74,3 +74,13 @@ func (nm *nodeMap) Len() int {
 	defer nm.lock.Unlock()
 	return len(nm.m)
 }
+
+func (nm *nodeMap) Slice() Nodes {
+	nm.lock.Lock()
+	defer nm.lock.Unlock()
+	nodes := make(Nodes, 0, len(nm.m))
+	for _, node := range nm.m {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
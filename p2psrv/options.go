@@ -44,6 +44,12 @@ type Options struct {
 	// protocol.
 	BootstrapNodes Nodes
 
+	// StaticNodes are always connected, and reconnected on disconnect.
+	StaticNodes Nodes
+
+	// TrustedNodes are allowed to connect even above the peer limit.
+	TrustedNodes Nodes
+
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
 	// IP networks contained in the list are considered.
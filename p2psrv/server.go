@@ -7,12 +7,15 @@ package p2psrv
 
 import (
 	"math"
+	"net"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/discv5"
+	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/inconshreveable/log15"
 	"github.com/vechain/thor/cache"
 	"github.com/vechain/thor/co"
@@ -28,6 +31,10 @@ type Server struct {
 	knownNodes      *cache.PrioCache
 	discoveredNodes *cache.RandCache
 	dialingNodes    *nodeMap
+	staticNodes     *nodeMap
+	trustedNodes    Nodes
+	nat             nat.Interface
+	externalIP      atomic.Value // net.IP
 }
 
 // New create a p2p server.
@@ -45,6 +52,11 @@ func New(opts *Options) *Server {
 		discoveredNodes.Set(node.ID, node)
 	}
 
+	staticNodes := newNodeMap()
+	for _, node := range opts.StaticNodes {
+		staticNodes.Add(node)
+	}
+
 	return &Server{
 		srv: &p2p.Server{
 			Config: p2p.Config{
@@ -55,6 +67,8 @@ func New(opts *Options) *Server {
 				DiscoveryV5:      !opts.NoDiscovery,
 				ListenAddr:       opts.ListenAddr,
 				BootstrapNodesV5: v5nodes,
+				StaticNodes:      opts.StaticNodes,
+				TrustedNodes:     opts.TrustedNodes,
 				NetRestrict:      opts.NetRestrict,
 				NAT:              opts.NAT,
 				NoDial:           opts.NoDial,
@@ -65,13 +79,22 @@ func New(opts *Options) *Server {
 		knownNodes:      knownNodes,
 		discoveredNodes: discoveredNodes,
 		dialingNodes:    newNodeMap(),
+		staticNodes:     staticNodes,
+		trustedNodes:    opts.TrustedNodes,
+		nat:             opts.NAT,
 	}
 }
 
 // Self returns self enode url.
 // Only available when server is running.
+// If NAT is enabled, the IP is replaced by the external IP discovered on start, which is
+// the endpoint advertised to other nodes.
 func (s *Server) Self() *discover.Node {
-	return s.srv.Self()
+	self := s.srv.Self()
+	if ip, ok := s.externalIP.Load().(net.IP); ok {
+		return discover.NewNode(self.ID, ip, self.UDP, self.TCP)
+	}
+	return self
 }
 
 // Start start the server.
@@ -105,7 +128,25 @@ func (s *Server) Start(protocols []*Protocol) error {
 	}
 	log.Debug("start up", "self", s.Self())
 
+	if s.nat != nil {
+		// querying the NAT device may be slow
+		s.goes.Go(func() {
+			ip, err := s.nat.ExternalIP()
+			if err != nil {
+				log.Debug("failed to get external ip", "err", err)
+				return
+			}
+			s.externalIP.Store(ip)
+			log.Debug("external ip discovered", "self", s.Self())
+		})
+	}
+
+	registered := make(map[string]bool)
 	for _, proto := range protocols {
+		if registered[proto.DiscTopic] {
+			continue
+		}
+		registered[proto.DiscTopic] = true
 		topicToRegister := discv5.Topic(proto.DiscTopic)
 		log.Debug("registering topic", "topic", topicToRegister)
 		s.goes.Go(func() {
@@ -143,14 +184,26 @@ func (s *Server) KnownNodes() Nodes {
 // server is shut down. If the connection fails for any reason, the server will
 // attempt to reconnect the peer.
 func (s *Server) AddStatic(node *discover.Node) {
+	s.staticNodes.Add(node)
 	s.srv.AddPeer(node)
 }
 
 // RemoveStatic disconnects from the given node
 func (s *Server) RemoveStatic(node *discover.Node) {
+	s.staticNodes.Remove(node.ID)
 	s.srv.RemovePeer(node)
 }
 
+// StaticNodes returns static nodes, either configured or added at runtime.
+func (s *Server) StaticNodes() Nodes {
+	return s.staticNodes.Slice()
+}
+
+// TrustedNodes returns trusted nodes, which are exempt from the peer limit.
+func (s *Server) TrustedNodes() Nodes {
+	return append(Nodes(nil), s.trustedNodes...)
+}
+
 // NodeInfo gathers and returns a collection of metadata known about the host.
 func (s *Server) NodeInfo() *p2p.NodeInfo {
 	return s.srv.NodeInfo()
@@ -3,4 +3,11 @@
 // Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
 // file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
 
+// Package packer produces new blocks.
+//
+// Packer.Schedule computes the slot when the proposer is in turn, and returns a Flow, which
+// executes txs against an overlay state of the parent. Txs are adopted one by one with
+// Flow.Adopt till the gas limit reached, then Flow.Pack builds and signs the block.
+// The caller is responsible for committing the state stage, adding the block to the chain
+// and broadcasting it.
 package packer
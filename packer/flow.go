@@ -8,9 +8,9 @@ package packer
 import (
 	"crypto/ecdsa"
 
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/cry"
 	"github.com/vechain/thor/runtime"
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
@@ -51,6 +51,11 @@ func (f *Flow) When() uint64 {
 	return f.runtime.Context().Time
 }
 
+// TxCount returns count of adopted txs.
+func (f *Flow) TxCount() int {
+	return len(f.txs)
+}
+
 func (f *Flow) findTx(txID thor.Bytes32) (found bool, reverted bool, err error) {
 	if reverted, ok := f.processedTxs[txID]; ok {
 		return true, reverted, nil
@@ -74,6 +79,12 @@ func (f *Flow) Adopt(tx *tx.Transaction) error {
 		return badTxError{"chain tag mismatch"}
 	case tx.HasReservedFields():
 		return badTxError{"reserved fields not empty"}
+	case tx.IsTyped() && f.runtime.Context().Number < f.packer.forkConfig.DYNFEE:
+		return badTxError{"tx type not activated"}
+	case tx.Features().IsIsolated() && f.runtime.Context().Number < f.packer.forkConfig.CLAUSEISO:
+		return badTxError{"tx clause isolation not activated"}
+	case tx.Features().IsDelegated() && f.runtime.Context().Number < f.packer.forkConfig.VIP191:
+		return badTxError{"tx delegation not activated"}
 	case f.runtime.Context().Number < tx.BlockRef().Number():
 		return errTxNotAdoptableNow
 	case tx.IsExpired(f.runtime.Context().Number):
@@ -124,8 +135,13 @@ func (f *Flow) Adopt(tx *tx.Transaction) error {
 
 // Pack build and sign the new block.
 func (f *Flow) Pack(privateKey *ecdsa.PrivateKey) (*block.Block, *state.Stage, tx.Receipts, error) {
-	if f.packer.proposer != thor.Address(crypto.PubkeyToAddress(privateKey.PublicKey)) {
-		return nil, nil, nil, errors.New("private key mismatch")
+	return f.PackWith(cry.NewKeySigner(privateKey))
+}
+
+// PackWith build the new block, and sign it by the signer, whose key may be held remotely.
+func (f *Flow) PackWith(signer cry.Signer) (*block.Block, *state.Stage, tx.Receipts, error) {
+	if f.packer.proposer != signer.Address() {
+		return nil, nil, nil, errors.New("signer mismatch")
 	}
 
 	if err := f.runtime.Seeker().Err(); err != nil {
@@ -152,7 +168,7 @@ func (f *Flow) Pack(privateKey *ecdsa.PrivateKey) (*block.Block, *state.Stage, t
 	}
 	newBlock := builder.Build()
 
-	sig, err := crypto.Sign(newBlock.Header().SigningHash().Bytes(), privateKey)
+	sig, err := signer.Sign(newBlock.Header().SigningHash())
 	if err != nil {
 		return nil, nil, nil, err
 	}
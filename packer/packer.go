@@ -24,6 +24,8 @@ type Packer struct {
 	proposer       thor.Address
 	beneficiary    thor.Address
 	targetGasLimit uint64
+	forkConfig     thor.ForkConfig
+	selector       Selector
 }
 
 // New create a new Packer instance.
@@ -31,7 +33,8 @@ func New(
 	chain *chain.Chain,
 	stateCreator *state.Creator,
 	proposer thor.Address,
-	beneficiary thor.Address) *Packer {
+	beneficiary thor.Address,
+	forkConfig thor.ForkConfig) *Packer {
 
 	return &Packer{
 		chain,
@@ -39,6 +42,8 @@ func New(
 		proposer,
 		beneficiary,
 		0,
+		forkConfig,
+		DefaultSelector,
 	}
 }
 
@@ -61,7 +66,12 @@ func (p *Packer) Schedule(parent *block.Header, nowTimestamp uint64) (flow *Flow
 	}
 
 	// calc the time when it's turn to produce block
-	sched, err := poa.NewScheduler(p.proposer, proposers, parent.Number(), parent.Timestamp())
+	var sched *poa.Scheduler
+	if parent.Number()+1 >= p.forkConfig.SCHEDV2 {
+		sched, err = poa.NewSchedulerV2(p.proposer, proposers, parent.Number(), parent.Timestamp())
+	} else {
+		sched, err = poa.NewScheduler(p.proposer, proposers, parent.Number(), parent.Timestamp())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +93,8 @@ func (p *Packer) Schedule(parent *block.Header, nowTimestamp uint64) (flow *Flow
 			Time:        newBlockTime,
 			GasLimit:    p.gasLimit(parent.GasLimit()),
 			TotalScore:  parent.TotalScore() + score,
-		})
+		},
+		p.forkConfig)
 
 	return newFlow(p, parent, rt), nil
 }
@@ -107,7 +118,8 @@ func (p *Packer) Mock(parent *block.Header, targetTime uint64) (*Flow, error) {
 			Time:        targetTime,
 			GasLimit:    p.gasLimit(parent.GasLimit()),
 			TotalScore:  parent.TotalScore() + 1,
-		})
+		},
+		p.forkConfig)
 
 	return newFlow(p, parent, rt), nil
 }
@@ -124,3 +136,13 @@ func (p *Packer) gasLimit(parentGasLimit uint64) uint64 {
 func (p *Packer) SetTargetGasLimit(gl uint64) {
 	p.targetGasLimit = gl
 }
+
+// SetTxSelector set the selector to order txs to be adopted.
+func (p *Packer) SetTxSelector(selector Selector) {
+	p.selector = selector
+}
+
+// SelectTxs orders candidates with the tx selector.
+func (p *Packer) SelectTxs(candidates []*Candidate) []*Candidate {
+	return p.selector(candidates)
+}
@@ -83,7 +83,7 @@ func TestP(t *testing.T) {
 
 	for {
 		best := c.BestBlock()
-		p := packer.New(c, stateCreator, a1.Address, a1.Address)
+		p := packer.New(c, stateCreator, a1.Address, a1.Address, thor.NoFork)
 		flow, err := p.Schedule(best.Header(), uint64(time.Now().Unix()))
 		if err != nil {
 			t.Fatal(err)
@@ -95,9 +95,10 @@ func TestP(t *testing.T) {
 		}
 
 		blk, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+		assert.Equal(t, flow.TxCount(), len(blk.Transactions()))
 		root, _ := stage.Commit()
 		assert.Equal(t, root, blk.Header().StateRoot())
-		fmt.Println(consensus.New(c, stateCreator).Process(blk, uint64(time.Now().Unix()*2)))
+		fmt.Println(consensus.New(c, stateCreator, thor.NoFork).Process(blk, uint64(time.Now().Unix()*2)))
 
 		if _, err := c.AddBlock(blk, receipts); err != nil {
 			t.Fatal(err)
@@ -112,3 +113,74 @@ func TestP(t *testing.T) {
 	fmt.Println(best.Header().Number(), best.Header().GasUsed())
 	//	fmt.Println(best)
 }
+
+func TestDelegationFork(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	defer kv.Close()
+
+	g, _ := genesis.NewDevnet()
+	stateCreator := state.NewCreator(kv)
+	b0, _, _ := g.Build(stateCreator)
+	c, _ := chain.New(kv, b0)
+
+	var features tx.Features
+	features.SetDelegated(true)
+	trx := new(tx.Builder).
+		ChainTag(c.Tag()).
+		Gas(21000).
+		Expiration(100).
+		Features(features).
+		Build()
+	trx, _ = tx.SignDelegated(trx, genesis.DevAccounts()[0].PrivateKey, genesis.DevAccounts()[1].PrivateKey)
+
+	adopt := func(fc thor.ForkConfig) error {
+		p := packer.New(c, stateCreator, genesis.DevAccounts()[0].Address, genesis.DevAccounts()[0].Address, fc)
+		flow, err := p.Mock(b0.Header(), b0.Header().Timestamp()+thor.BlockInterval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return flow.Adopt(trx)
+	}
+
+	// the flow packs block #1
+	fc := thor.NoFork
+	fc.VIP191 = 2
+	assert.True(t, packer.IsBadTx(adopt(fc)))
+
+	fc.VIP191 = 1
+	assert.Nil(t, adopt(fc))
+}
+
+func TestSelectors(t *testing.T) {
+	newCandidate := func(origin byte, gasPrice int64, arrival int64, dependsOn *thor.Bytes32) *packer.Candidate {
+		trx := new(tx.Builder).Nonce(uint64(arrival)).DependsOn(dependsOn).Build()
+		sig, _ := crypto.Sign(trx.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+		return &packer.Candidate{
+			Tx:          trx.WithSignature(sig),
+			Origin:      thor.BytesToAddress([]byte{origin}),
+			GasPrice:    big.NewInt(gasPrice),
+			ArrivalTime: arrival,
+		}
+	}
+	c1 := newCandidate(1, 10, 1, nil)
+	c2 := newCandidate(1, 30, 2, nil)
+	c3 := newCandidate(2, 20, 3, nil)
+	c4 := newCandidate(1, 30, 4, nil)
+	candidates := []*packer.Candidate{c4, c3, c2, c1}
+
+	assert.Equal(t, []*packer.Candidate{c2, c4, c3, c1}, packer.ByGasPrice()(candidates))
+	assert.Equal(t, []*packer.Candidate{c1, c2, c3, c4}, packer.ByArrival()(candidates))
+	assert.Equal(t, []*packer.Candidate{c4, c3, c2}, packer.OriginCap(2)(candidates))
+	assert.Equal(t, []*packer.Candidate{c2, c4, c3}, packer.Chain(packer.ByGasPrice(), packer.OriginCap(2))(candidates))
+	// input not modified
+	assert.Equal(t, []*packer.Candidate{c4, c3, c2, c1}, candidates)
+
+	id1 := c1.Tx.ID()
+	d1 := newCandidate(3, 50, 5, &id1)
+	idd1 := d1.Tx.ID()
+	dd1 := newCandidate(3, 60, 6, &idd1)
+	assert.Equal(t, []*packer.Candidate{c2, c4, c3, c1, d1, dd1}, packer.DefaultSelector([]*packer.Candidate{c1, c2, c3, c4, d1, dd1}))
+
+	// dependency not in candidates
+	assert.Equal(t, []*packer.Candidate{dd1, c1}, packer.RespectDependsOn()([]*packer.Candidate{dd1, c1}))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package packer
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// Candidate is a tx to be tried to adopt, with info for selection.
+type Candidate struct {
+	Tx          *tx.Transaction
+	Origin      thor.Address
+	GasPrice    *big.Int // overall gas price
+	ArrivalTime int64
+}
+
+// Selector decides the order in which candidates are tried to adopt.
+// It may also drop candidates.
+type Selector func(candidates []*Candidate) []*Candidate
+
+// DefaultSelector orders candidates by gas price, with DependsOn respected.
+var DefaultSelector = Chain(ByGasPrice(), RespectDependsOn())
+
+// Chain combines selectors, which are applied in the given order.
+func Chain(selectors ...Selector) Selector {
+	return func(candidates []*Candidate) []*Candidate {
+		for _, s := range selectors {
+			candidates = s(candidates)
+		}
+		return candidates
+	}
+}
+
+// ByGasPrice orders candidates by gas price descending. Earlier arrived one goes first
+// when gas prices are equal.
+func ByGasPrice() Selector {
+	return func(candidates []*Candidate) []*Candidate {
+		sorted := append([]*Candidate(nil), candidates...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if c := sorted[i].GasPrice.Cmp(sorted[j].GasPrice); c != 0 {
+				return c > 0
+			}
+			return sorted[i].ArrivalTime < sorted[j].ArrivalTime
+		})
+		return sorted
+	}
+}
+
+// ByArrival orders candidates by arrival time.
+func ByArrival() Selector {
+	return func(candidates []*Candidate) []*Candidate {
+		sorted := append([]*Candidate(nil), candidates...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].ArrivalTime < sorted[j].ArrivalTime
+		})
+		return sorted
+	}
+}
+
+// OriginCap keeps at most limit candidates of each origin. Candidates beyond the limit are
+// dropped, and left for later blocks.
+func OriginCap(limit int) Selector {
+	return func(candidates []*Candidate) []*Candidate {
+		counts := make(map[thor.Address]int)
+		selected := make([]*Candidate, 0, len(candidates))
+		for _, c := range candidates {
+			if counts[c.Origin] < limit {
+				counts[c.Origin]++
+				selected = append(selected, c)
+			}
+		}
+		return selected
+	}
+}
+
+// RespectDependsOn moves candidates behind the candidates they depend on, while the
+// order is kept otherwise.
+func RespectDependsOn() Selector {
+	return func(candidates []*Candidate) []*Candidate {
+		all := make(map[thor.Bytes32]bool, len(candidates))
+		for _, c := range candidates {
+			all[c.Tx.ID()] = true
+		}
+
+		var (
+			selected = make([]*Candidate, 0, len(candidates))
+			placed   = make(map[thor.Bytes32]bool, len(candidates))
+			waiting  = make(map[thor.Bytes32][]*Candidate)
+			place    func(c *Candidate)
+		)
+		place = func(c *Candidate) {
+			id := c.Tx.ID()
+			selected = append(selected, c)
+			placed[id] = true
+			deps := waiting[id]
+			delete(waiting, id)
+			for _, dep := range deps {
+				place(dep)
+			}
+		}
+
+		for _, c := range candidates {
+			if dep := c.Tx.DependsOn(); dep != nil && all[*dep] && !placed[*dep] {
+				waiting[*dep] = append(waiting[*dep], c)
+				continue
+			}
+			place(c)
+		}
+		return selected
+	}
+}
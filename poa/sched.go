@@ -12,12 +12,21 @@ import (
 	"github.com/vechain/thor/thor"
 )
 
+// EpochLength number of blocks in an epoch of scheduler v2.
+// An epoch is long enough for every proposer to get a chance.
+const EpochLength = uint32(thor.MaxBlockProposers)
+
 // Scheduler to schedule the time when a proposer to produce a block.
 type Scheduler struct {
 	proposer          Proposer
 	actives           []Proposer
 	parentBlockNumber uint32
 	parentBlockTime   uint64
+	slots             Slots
+
+	// for v2 only
+	v2       bool
+	shuffled []Proposer // actives shuffled for the epoch of new block
 }
 
 // NewScheduler create a Scheduler object.
@@ -47,57 +56,126 @@ func NewScheduler(
 	}
 
 	return &Scheduler{
-		proposer,
-		actives,
-		parentBlockNumber,
-		parentBlockTime,
+		proposer:          proposer,
+		actives:           actives,
+		parentBlockNumber: parentBlockNumber,
+		parentBlockTime:   parentBlockTime,
+		slots:             NewSlots(parentBlockTime),
+	}, nil
+}
+
+// NewSchedulerV2 create a Scheduler object, which differs from NewScheduler in:
+//  1. active proposers are shuffled once per epoch of EpochLength blocks, seeded by number of
+//     the epoch's first block, and take turns by block number and slot, so that every active
+//     proposer gets a chance in an epoch;
+//  2. inactive proposer is skipped, except for backoff slots, which are exponentially
+//     sparser as time since parent block goes (see isBackoffSlot).
+func NewSchedulerV2(
+	addr thor.Address,
+	proposers []Proposer,
+	parentBlockNumber uint32,
+	parentBlockTime uint64) (*Scheduler, error) {
+
+	actives := make([]Proposer, 0, len(proposers))
+	listed := false
+	var proposer Proposer
+	for _, p := range proposers {
+		if p.Address == addr {
+			proposer = p
+			listed = true
+		}
+		if p.Active {
+			actives = append(actives, p)
+		}
+	}
+
+	if !listed {
+		return nil, errors.New("unauthorized block proposer")
+	}
+
+	return &Scheduler{
+		proposer:          proposer,
+		actives:           actives,
+		parentBlockNumber: parentBlockNumber,
+		parentBlockTime:   parentBlockTime,
+		slots:             NewSlots(parentBlockTime),
+		v2:                true,
+		shuffled:          shuffle(actives, epochSeed(parentBlockNumber+1)),
 	}, nil
 }
 
+// epochSeed returns seed to shuffle proposers for the epoch which the block belongs to.
+// It's taken from the first block of the epoch, so that the order is stable within the epoch.
+func epochSeed(blockNumber uint32) uint64 {
+	first := blockNumber / EpochLength * EpochLength
+	return dprp(first, 0)
+}
+
 func (s *Scheduler) whoseTurn(t uint64) Proposer {
+	if s.v2 {
+		return s.whoseTurnV2(t)
+	}
 	index := dprp(s.parentBlockNumber, t) % uint64(len(s.actives))
 	return s.actives[index]
 }
 
-// Schedule to determine time of the proposer to produce a block, according to `nowTime`.
-// `newBlockTime` is promised to be >= nowTime and > parentBlockTime
-func (s *Scheduler) Schedule(nowTime uint64) (newBlockTime uint64) {
-	const T = thor.BlockInterval
+func (s *Scheduler) whoseTurnV2(t uint64) Proposer {
+	slot := s.slots.Slot(t)
+	if len(s.actives) == 0 || (!s.proposer.Active && isBackoffSlot(slot, uint64(len(s.actives)))) {
+		return s.proposer
+	}
+	// turns go through the shuffled order by block number, and advance by one for each missed slot
+	return s.shuffled[(uint64(s.parentBlockNumber)+slot)%uint64(len(s.shuffled))]
+}
 
-	newBlockTime = s.parentBlockTime + T
+// isBackoffSlot returns whether an inactive proposer can propose in the slot.
+// They are slots n, 2n, 4n, 8n ... after parent block, where n is the count of active proposers.
+// So an inactive proposer gets a chance only after all active ones missed their turns, and the
+// chances get sparser as time goes.
+func isBackoffSlot(slot uint64, n uint64) bool {
+	if slot < n || slot%n != 0 {
+		return false
+	}
+	r := slot / n
+	return r&(r-1) == 0
+}
 
-	if nowTime > newBlockTime {
-		// ensure T aligned, and >= nowTime
-		newBlockTime += (nowTime - newBlockTime + T - 1) / T * T
+// shuffle returns a copy of proposers in deterministic random order (Fisher-Yates).
+func shuffle(proposers []Proposer, seed uint64) []Proposer {
+	shuffled := append([]Proposer(nil), proposers...)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := dprp(uint32(i), seed) % uint64(i+1)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
 	}
+	return shuffled
+}
 
-	for {
-		p := s.whoseTurn(newBlockTime)
-		if p.Address == s.proposer.Address {
+// Schedule to determine time of the proposer to produce a block, according to `nowTime`.
+// `newBlockTime` is promised to be >= nowTime and > parentBlockTime
+func (s *Scheduler) Schedule(nowTime uint64) (newBlockTime uint64) {
+	for newBlockTime = s.slots.NextSlot(nowTime); ; newBlockTime += thor.BlockInterval {
+		if s.IsSlotLeader(newBlockTime) {
 			return newBlockTime
 		}
-
-		// try next time slot
-		newBlockTime += T
 	}
 }
 
+// IsSlotLeader returns if the proposer is the one to produce block in the slot starts at t.
+func (s *Scheduler) IsSlotLeader(t uint64) bool {
+	return s.whoseTurn(t).Address == s.proposer.Address
+}
+
 // IsTheTime returns if the newBlockTime is correct for the proposer.
 func (s *Scheduler) IsTheTime(newBlockTime uint64) bool {
-	if s.parentBlockTime >= newBlockTime {
+	if !s.slots.IsAligned(newBlockTime) {
 		// invalid block time
 		return false
 	}
-
-	if (newBlockTime-s.parentBlockTime)%thor.BlockInterval != 0 {
-		// invalid block time
-		return false
-	}
-
-	return s.whoseTurn(newBlockTime).Address == s.proposer.Address
+	return s.IsSlotLeader(newBlockTime)
 }
 
 // Updates returns proposers whose status are change, and the score when new block time is assumed to be newBlockTime.
+// Active proposers missed their slots are deactivated, and the scheduled proposer is activated if not yet.
 func (s *Scheduler) Updates(newBlockTime uint64) (updates []Proposer, score uint64) {
 
 	toDeactivate := make(map[thor.Address]Proposer)
@@ -112,9 +190,12 @@ func (s *Scheduler) Updates(newBlockTime uint64) (updates []Proposer, score uint
 	}
 
 	updates = make([]Proposer, 0, len(toDeactivate)+1)
-	for _, p := range toDeactivate {
-		p.Active = false
-		updates = append(updates, p)
+	// iterate actives rather than the map, to keep updates in deterministic order
+	for _, p := range s.actives {
+		if _, ok := toDeactivate[p.Address]; ok {
+			p.Active = false
+			updates = append(updates, p)
+		}
 	}
 
 	if !s.proposer.Active {
@@ -123,7 +204,12 @@ func (s *Scheduler) Updates(newBlockTime uint64) (updates []Proposer, score uint
 		updates = append(updates, cpy)
 	}
 
-	score = uint64(len(s.actives)) - uint64(len(toDeactivate))
+	activeCount := uint64(len(s.actives))
+	if s.v2 && !s.proposer.Active {
+		// not counted in actives for v2
+		activeCount++
+	}
+	score = activeCount - uint64(len(toDeactivate))
 	return
 }
 
@@ -79,4 +79,100 @@ func TestUpdates(t *testing.T) {
 		_, score := sched.Updates(tt.newBlockTime)
 		assert.Equal(t, tt.want, score)
 	}
+
+	// p1 is activated at once
+	updates, _ := sched.Updates(parentTime + thor.BlockInterval)
+	assert.Equal(t, []poa.Proposer{{p1, true}}, updates)
+
+	// p2 missed its slots, so deactivated
+	updates, _ = sched.Updates(parentTime + thor.BlockInterval*30)
+	assert.Equal(t, []poa.Proposer{{p2, false}, {p1, true}}, updates)
+}
+
+func TestSlots(t *testing.T) {
+	slots := poa.NewSlots(parentTime)
+
+	tests := []struct {
+		t       uint64
+		slot    uint64
+		next    uint64
+		aligned bool
+	}{
+		{parentTime - 1, 0, parentTime + thor.BlockInterval, false},
+		{parentTime, 0, parentTime + thor.BlockInterval, false},
+		{parentTime + 1, 0, parentTime + thor.BlockInterval, false},
+		{parentTime + thor.BlockInterval, 1, parentTime + thor.BlockInterval, true},
+		{parentTime + thor.BlockInterval + 1, 1, parentTime + thor.BlockInterval*2, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.slot, slots.Slot(tt.t))
+		assert.Equal(t, tt.next, slots.NextSlot(tt.t))
+		assert.Equal(t, tt.aligned, slots.IsAligned(tt.t))
+	}
+}
+
+func TestScheduleV2(t *testing.T) {
+	actives := []poa.Proposer{{p1, true}, {p2, true}, {p3, true}, {p4, false}}
+
+	_, err := poa.NewSchedulerV2(p5, actives, 1, parentTime)
+	assert.NotNil(t, err)
+
+	// every active proposer gets turns evenly in an epoch
+	counts := make(map[thor.Address]int)
+	for _, p := range actives[:3] {
+		sched, _ := poa.NewSchedulerV2(p.Address, actives, 1, parentTime)
+		for i := uint64(1); i <= 3; i++ {
+			if sched.IsTheTime(parentTime + thor.BlockInterval*i) {
+				counts[p.Address]++
+			}
+		}
+		nbt := sched.Schedule(parentTime)
+		assert.True(t, sched.IsTheTime(nbt))
+	}
+	assert.Equal(t, map[thor.Address]int{p1: 1, p2: 1, p3: 1}, counts)
+
+	// inactive proposer proposes only in backoff slots 3, 6, 12, 24...
+	sched, _ := poa.NewSchedulerV2(p4, actives, 1, parentTime)
+	var slots []uint64
+	for i := uint64(1); i <= 24; i++ {
+		if sched.IsTheTime(parentTime + thor.BlockInterval*i) {
+			slots = append(slots, i)
+		}
+	}
+	assert.Equal(t, []uint64{3, 6, 12, 24}, slots)
+
+	// proposers of slot 1 and 2 missed, and p4 gets activated
+	updates, score := sched.Updates(parentTime + thor.BlockInterval*3)
+	assert.Equal(t, uint64(2), score)
+	assert.Equal(t, 3, len(updates))
+	assert.Equal(t, poa.Proposer{p4, true}, updates[2])
+}
+
+func TestScheduleV2StableInEpoch(t *testing.T) {
+	actives := []poa.Proposer{{p1, true}, {p2, true}, {p3, true}, {p4, true}, {p5, true}}
+
+	// leader of slot at t, for the block after parent
+	leader := func(parentNum uint32, parentTime, t uint64) thor.Address {
+		for _, p := range actives {
+			sched, _ := poa.NewSchedulerV2(p.Address, actives, parentNum, parentTime)
+			if sched.IsTheTime(t) {
+				return p.Address
+			}
+		}
+		return thor.Address{}
+	}
+
+	for num := uint32(1); num < poa.EpochLength-1; num++ {
+		// the slot missed by block num is taken by the same one as of block num+1
+		missed := leader(num-1, parentTime, parentTime+thor.BlockInterval*2)
+		assert.Equal(t, leader(num, parentTime+thor.BlockInterval, parentTime+thor.BlockInterval*2), missed)
+	}
+
+	// every active proposer gets turns evenly in an epoch
+	counts := make(map[thor.Address]int)
+	for num := uint32(0); num < uint32(len(actives))*2; num++ {
+		counts[leader(num, parentTime, parentTime+thor.BlockInterval)]++
+	}
+	assert.Equal(t, map[thor.Address]int{p1: 2, p2: 2, p3: 2, p4: 2, p5: 2}, counts)
 }
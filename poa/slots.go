@@ -0,0 +1,52 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package poa
+
+import "github.com/vechain/thor/thor"
+
+// Slots maps wall-clock time to block slots.
+// Slot 0 starts at the origin time (usually the genesis timestamp), and each slot lasts
+// thor.BlockInterval seconds.
+type Slots struct {
+	origin uint64
+}
+
+// NewSlots create slots starting at the origin time.
+func NewSlots(origin uint64) Slots {
+	return Slots{origin}
+}
+
+// Origin returns the origin time.
+func (s Slots) Origin() uint64 {
+	return s.origin
+}
+
+// Slot returns index of slot which the time t falls in.
+// 0 returned if t is before origin.
+func (s Slots) Slot(t uint64) uint64 {
+	if t <= s.origin {
+		return 0
+	}
+	return (t - s.origin) / thor.BlockInterval
+}
+
+// SlotTime returns the start time of the slot.
+func (s Slots) SlotTime(slot uint64) uint64 {
+	return s.origin + slot*thor.BlockInterval
+}
+
+// IsAligned returns if t is exactly the start time of a slot after the origin.
+func (s Slots) IsAligned(t uint64) bool {
+	return t > s.origin && (t-s.origin)%thor.BlockInterval == 0
+}
+
+// NextSlot returns start time of the earliest slot, which is after the origin, and not before t.
+func (s Slots) NextSlot(t uint64) uint64 {
+	if t <= s.origin {
+		return s.origin + thor.BlockInterval
+	}
+	return s.SlotTime((t - s.origin + thor.BlockInterval - 1) / thor.BlockInterval)
+}
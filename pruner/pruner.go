@@ -0,0 +1,159 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package pruner reclaims space of main db, by deleting trie nodes and codes unreachable
+// from retained roots.
+//
+// Trie nodes and codes are stored keyed by their 32 bytes hashes, while other data, e.g.
+// blocks, is keyed with prefixes. Pruning marks hashes reachable from retained roots in
+// memory first, then sweeps entries of hash keys not marked. It should be done offline.
+package pruner
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+const sweepBatchSize = 4096 // number of deletes written in a batch while sweeping
+
+// Stats statistics of sweeping.
+type Stats struct {
+	Retained     int   // number of entries retained
+	Deleted      int   // number of entries deleted
+	DeletedBytes int64 // total size of keys and values deleted
+}
+
+// Pruner marks and sweeps hash keyed entries.
+type Pruner struct {
+	kv     kv.GetPutter
+	marked map[thor.Bytes32]struct{}
+}
+
+// New create a pruner.
+func New(kv kv.GetPutter) *Pruner {
+	return &Pruner{
+		kv:     kv,
+		marked: make(map[thor.Bytes32]struct{}),
+	}
+}
+
+// Marked returns number of hashes marked.
+func (p *Pruner) Marked() int {
+	return len(p.marked)
+}
+
+// RetainState marks nodes of the account trie with given root, along with storage tries and codes of accounts.
+func (p *Pruner) RetainState(root thor.Bytes32) error {
+	return p.mark(root, func(blob []byte) error {
+		var acc state.Account
+		if err := rlp.DecodeBytes(blob, &acc); err != nil {
+			return errors.Wrap(err, "decode account")
+		}
+		if len(acc.CodeHash) > 0 {
+			p.marked[thor.BytesToBytes32(acc.CodeHash)] = struct{}{}
+		}
+		if len(acc.StorageRoot) > 0 {
+			return p.mark(thor.BytesToBytes32(acc.StorageRoot), nil)
+		}
+		return nil
+	})
+}
+
+// RetainTrie marks nodes of the trie with given root, whose leaves refer to nothing else,
+// e.g. block number index tries of chain.
+func (p *Pruner) RetainTrie(root thor.Bytes32) error {
+	return p.mark(root, nil)
+}
+
+// mark marks nodes of the trie, and calls onLeaf with each leaf value.
+// Subtrees already marked are skipped, since they were walked through.
+func (p *Pruner) mark(root thor.Bytes32, onLeaf func(blob []byte) error) error {
+	if _, ok := p.marked[root]; ok {
+		return nil
+	}
+	tr, err := trie.New(root, p.kv)
+	if err != nil {
+		return err
+	}
+	it := tr.NodeIterator(nil)
+	for descend := true; it.Next(descend); {
+		descend = true
+		if hash := it.Hash(); !hash.IsZero() {
+			// nodes embedded in parents have no hash
+			if _, ok := p.marked[hash]; ok {
+				descend = false
+				continue
+			}
+			p.marked[hash] = struct{}{}
+		}
+		if it.Leaf() && onLeaf != nil {
+			if err := onLeaf(it.LeafBlob()); err != nil {
+				return err
+			}
+		}
+	}
+	return it.Error()
+}
+
+// Sweep deletes entries of hash keys not marked. progress is called after each batch written.
+func (p *Pruner) Sweep(ctx context.Context, progress func(stats *Stats)) (*Stats, error) {
+	var stats Stats
+	batch := p.kv.NewBatch()
+	flush := func() error {
+		if batch.Len() == 0 {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch = p.kv.NewBatch()
+		if progress != nil {
+			progress(&stats)
+		}
+		return nil
+	}
+
+	it := p.kv.NewIterator(kv.Range{})
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(thor.Bytes32{}) {
+			continue
+		}
+		if _, ok := p.marked[thor.BytesToBytes32(key)]; ok {
+			stats.Retained++
+			continue
+		}
+		if err := batch.Delete(append([]byte(nil), key...)); err != nil {
+			return nil, err
+		}
+		stats.Deleted++
+		stats.DeletedBytes += int64(len(key) + len(it.Value()))
+
+		if batch.Len() >= sweepBatchSize {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package pruner_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/pruner"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+func TestPrune(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	stateCreator := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b0, _, err := gene.Build(stateCreator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := chain.New(db, b0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposer := genesis.DevAccounts()[0]
+	for i := 0; i < 3; i++ {
+		p := packer.New(c, stateCreator, proposer.Address, proposer.Address, thor.NoFork)
+		flow, err := p.Schedule(c.BestBlock().Header(), uint64(time.Now().Unix()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// keep clear of precompiled contract addresses
+		to := thor.BytesToAddress([]byte{byte(i + 1), 0xff})
+		trx := new(tx.Builder).
+			ChainTag(c.Tag()).
+			GasPriceCoef(1).
+			Expiration(100).
+			Gas(21000).
+			Nonce(uint64(i)).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(10))).
+			Build()
+		sig, _ := crypto.Sign(trx.SigningHash().Bytes(), proposer.PrivateKey)
+		if err := flow.Adopt(trx.WithSignature(sig)); err != nil {
+			t.Fatal(err)
+		}
+		blk, stage, receipts, err := flow.Pack(proposer.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stage.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.AddBlock(blk, receipts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	best := c.BestBlock().Header()
+	b1, _ := c.GetTrunkBlock(1)
+
+	p := pruner.New(db)
+	assert.Nil(t, p.RetainState(best.StateRoot()))
+	assert.Nil(t, c.EachIndexTrieRoot(p.RetainTrie))
+	assert.True(t, p.Marked() > 0)
+
+	stats, err := p.Sweep(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.True(t, stats.Deleted > 0)
+	assert.True(t, stats.Retained > 0)
+
+	has, _ := stateCreator.HasRoot(b1.Header().StateRoot())
+	assert.False(t, has, "state root of old block should be pruned")
+
+	st, err := stateCreator.NewState(best.StateRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, big.NewInt(10), st.GetBalance(thor.BytesToAddress([]byte{byte(i + 1), 0xff})))
+	}
+	assert.NotEmpty(t, st.GetCode(builtin.Authority.Address))
+	assert.Nil(t, st.Err())
+
+	// block number index tries retained
+	for num := uint32(0); num <= best.Number(); num++ {
+		_, err := c.GetTrunkBlockID(num)
+		assert.Nil(t, err)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package replay re-executes stored blocks upon states of their parents, and compares results
+// against headers, to diagnose consensus bugs.
+//
+// Blocks are replayed independently, so a range of blocks is replayed as long as states of
+// their parents are available. For a divergent block, the first divergent tx can be pinpointed
+// by bisecting its txs, comparing receipts of replayed tx prefixes with the stored ones.
+package replay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/consensus"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// DivergenceError describes the replayed result of a block diverged from the header.
+type DivergenceError struct {
+	Number uint32
+	ID     thor.Bytes32
+	Reason string
+	// index of the first divergent tx, -1 if not pinpointed
+	TxIndex int
+}
+
+func (e *DivergenceError) Error() string {
+	if e.TxIndex >= 0 {
+		return fmt.Sprintf("block #%v %v diverged: %v, first at tx %v", e.Number, e.ID, e.Reason, e.TxIndex)
+	}
+	return fmt.Sprintf("block #%v %v diverged: %v", e.Number, e.ID, e.Reason)
+}
+
+// IsDivergence returns if the error is a DivergenceError.
+func IsDivergence(err error) bool {
+	_, ok := err.(*DivergenceError)
+	return ok
+}
+
+// Replayer replays stored blocks.
+type Replayer struct {
+	chain *chain.Chain
+	cons  *consensus.Consensus
+}
+
+// New create a replayer.
+func New(chain *chain.Chain, stateCreator *state.Creator, forkConfig thor.ForkConfig) *Replayer {
+	return &Replayer{
+		chain: chain,
+		cons:  consensus.New(chain, stateCreator, forkConfig),
+	}
+}
+
+// execute executes the first n txs of the block, upon the state of its parent.
+func (r *Replayer) execute(blk *block.Block, n int) (tx.Receipts, *state.State, error) {
+	rt, err := r.cons.NewRuntimeForReplay(blk.Header())
+	if err != nil {
+		return nil, nil, err
+	}
+	receipts := make(tx.Receipts, 0, n)
+	for i, tx := range blk.Transactions()[:n] {
+		receipt, err := rt.ExecuteTransaction(tx)
+		if err != nil {
+			return nil, nil, &DivergenceError{blk.Header().Number(), blk.Header().ID(), fmt.Sprintf("tx execution failed: %v", err), i}
+		}
+		receipts = append(receipts, receipt)
+	}
+	if err := rt.Seeker().Err(); err != nil {
+		return nil, nil, err
+	}
+	return receipts, rt.State(), nil
+}
+
+// Block replays the block, and returns DivergenceError if gas used, receipts root or state root
+// mismatches the header. With bisect, the first divergent tx is pinpointed if possible.
+func (r *Replayer) Block(blk *block.Block, bisect bool) error {
+	header := blk.Header()
+	receipts, st, err := r.execute(blk, len(blk.Transactions()))
+	if err != nil {
+		return err
+	}
+	diverged := func(reason string) error {
+		derr := &DivergenceError{header.Number(), header.ID(), reason, -1}
+		if bisect {
+			index, err := r.Bisect(blk)
+			if err != nil {
+				return err
+			}
+			derr.TxIndex = index
+		}
+		return derr
+	}
+
+	var gasUsed uint64
+	for _, receipt := range receipts {
+		gasUsed += receipt.GasUsed
+	}
+	if header.GasUsed() != gasUsed {
+		return diverged(fmt.Sprintf("gas used mismatch: want %v, have %v", header.GasUsed(), gasUsed))
+	}
+	if root := receipts.RootHash(); header.ReceiptsRoot() != root {
+		return diverged(fmt.Sprintf("receipts root mismatch: want %v, have %v", header.ReceiptsRoot(), root))
+	}
+	root, err := st.Stage().Hash()
+	if err != nil {
+		return err
+	}
+	if header.StateRoot() != root {
+		return diverged(fmt.Sprintf("state root mismatch: want %v, have %v", header.StateRoot(), root))
+	}
+	return nil
+}
+
+// Bisect returns index of the first tx of the block, whose replayed receipt diverges from the
+// stored one. Tx prefixes are replayed and their receipts root compared, since once diverged,
+// longer prefixes diverge as well. It returns -1 if receipts all matched, i.e. the divergence only
+// shows in the state.
+func (r *Replayer) Bisect(blk *block.Block) (int, error) {
+	stored, err := r.chain.GetBlockReceipts(blk.Header().ID())
+	if err != nil {
+		return 0, err
+	}
+	txs := blk.Transactions()
+	if len(stored) != len(txs) {
+		return 0, fmt.Errorf("stored receipts count mismatch: want %v, have %v", len(txs), len(stored))
+	}
+
+	// whether the prefix of n txs diverges
+	divergent := func(n int) (bool, error) {
+		receipts, _, err := r.execute(blk, n)
+		if err != nil {
+			if IsDivergence(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return receipts.RootHash() != stored[:n].RootHash(), nil
+	}
+
+	// search the smallest divergent prefix
+	lo, hi := 1, len(txs)+1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		d, err := divergent(mid)
+		if err != nil {
+			return 0, err
+		}
+		if d {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if lo > len(txs) {
+		return -1, nil
+	}
+	return lo - 1, nil
+}
+
+// Range replays trunk blocks in [from, to], and returns DivergenceError of the first divergent one.
+// progress is called after each block replayed.
+func (r *Replayer) Range(ctx context.Context, from, to uint32, bisect bool, progress func(num uint32)) error {
+	for num := from; num <= to; num++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		blk, err := r.chain.GetTrunkBlock(num)
+		if err != nil {
+			return err
+		}
+		if err := r.Block(blk, bisect); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(num)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package replay_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/replay"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+func newChain(t *testing.T) (*chain.Chain, *state.Creator) {
+	db, _ := lvldb.NewMem()
+	stateCreator := state.NewCreator(db)
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b0, _, err := gene.Build(stateCreator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := chain.New(db, b0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, stateCreator
+}
+
+// packBlock packs a block of n txs upon the best block.
+func packBlock(t *testing.T, c *chain.Chain, stateCreator *state.Creator, n int) (*block.Block, *state.Stage, tx.Receipts) {
+	proposer := genesis.DevAccounts()[0]
+	to := thor.BytesToAddress([]byte("to"))
+	p := packer.New(c, stateCreator, proposer.Address, proposer.Address, thor.NoFork)
+	flow, err := p.Schedule(c.BestBlock().Header(), uint64(time.Now().Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		trx := new(tx.Builder).
+			ChainTag(c.Tag()).
+			GasPriceCoef(1).
+			Expiration(100).
+			Gas(21000).
+			Nonce(uint64(c.BestBlock().Header().Number())*100 + uint64(i)).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(10))).
+			BlockRef(tx.NewBlockRef(c.BestBlock().Header().Number())).
+			Build()
+		sig, _ := crypto.Sign(trx.SigningHash().Bytes(), proposer.PrivateKey)
+		if err := flow.Adopt(trx.WithSignature(sig)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	blk, stage, receipts, err := flow.Pack(proposer.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blk, stage, receipts
+}
+
+func TestReplay(t *testing.T) {
+	c, stateCreator := newChain(t)
+	for i := 0; i < 3; i++ {
+		blk, stage, receipts := packBlock(t, c, stateCreator, 2)
+		if _, err := stage.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.AddBlock(blk, receipts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := replay.New(c, stateCreator, thor.NoFork)
+	var replayed []uint32
+	assert.Nil(t, r.Range(context.Background(), 1, 3, true, func(num uint32) {
+		replayed = append(replayed, num)
+	}))
+	assert.Equal(t, []uint32{1, 2, 3}, replayed)
+}
+
+func TestReplayDivergent(t *testing.T) {
+	c, stateCreator := newChain(t)
+	blk, stage, receipts := packBlock(t, c, stateCreator, 3)
+	if _, err := stage.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// forge a block as if the second tx used more gas
+	forgedReceipt := *receipts[1]
+	forgedReceipt.GasUsed++
+	forged := tx.Receipts{receipts[0], &forgedReceipt, receipts[2]}
+
+	header := blk.Header()
+	builder := new(block.Builder).
+		ParentID(header.ParentID()).
+		Timestamp(header.Timestamp()).
+		TotalScore(header.TotalScore()).
+		GasLimit(header.GasLimit()).
+		GasUsed(header.GasUsed() + 1).
+		Beneficiary(header.Beneficiary()).
+		StateRoot(header.StateRoot()).
+		ReceiptsRoot(forged.RootHash())
+	for _, trx := range blk.Transactions() {
+		builder.Transaction(trx)
+	}
+	forgedBlk := builder.Build()
+	sig, _ := crypto.Sign(forgedBlk.Header().SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	forgedBlk = forgedBlk.WithSignature(sig)
+	if _, err := c.AddBlock(forgedBlk, forged); err != nil {
+		t.Fatal(err)
+	}
+
+	r := replay.New(c, stateCreator, thor.NoFork)
+	err := r.Range(context.Background(), 1, 1, false, nil)
+	assert.True(t, replay.IsDivergence(err))
+	assert.Equal(t, -1, err.(*replay.DivergenceError).TxIndex)
+
+	err = r.Range(context.Background(), 1, 1, true, nil)
+	assert.True(t, replay.IsDivergence(err))
+	assert.Equal(t, uint32(1), err.(*replay.DivergenceError).Number)
+	assert.Equal(t, 1, err.(*replay.DivergenceError).TxIndex)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+	"github.com/vechain/thor/xenv"
+)
+
+// selector of solidity 'Error(string)'
+var errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+// CallResult result of read-only call.
+type CallResult struct {
+	Data            []byte
+	GasUsed         uint64
+	Events          tx.Events
+	Transfers       tx.Transfers
+	VMErr           error
+	RevertReason    string        // decoded from revert data, if any
+	ContractAddress *thor.Address // if creating contract
+}
+
+// Call executes the clause upon the state at the given block, as if it's the only clause of a tx sent by caller.
+// Changes of state are reverted before Call returns.
+func Call(
+	seeker *chain.Seeker,
+	state *state.State,
+	header *block.Header,
+	clause *tx.Clause,
+	caller thor.Address,
+	gas uint64,
+	gasPrice *big.Int,
+	forkConfig thor.ForkConfig,
+) (*CallResult, error) {
+	results, err := CallBatch(seeker, state, header, []*tx.Clause{clause}, caller, gas, gasPrice, forkConfig)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// CallBatch executes clauses in sequence upon the state at the given block, as if they are clauses of a tx sent by caller.
+// Clauses share the gas, and each one sees the changes made by previous ones.
+// The execution stops at the first failed clause, so results may be shorter than clauses.
+// Changes of state are reverted before CallBatch returns.
+func CallBatch(
+	seeker *chain.Seeker,
+	state *state.State,
+	header *block.Header,
+	clauses []*tx.Clause,
+	caller thor.Address,
+	gas uint64,
+	gasPrice *big.Int,
+	forkConfig thor.ForkConfig,
+) ([]*CallResult, error) {
+	rt := newCallRuntime(seeker, state, header, forkConfig)
+	txCtx := newCallTxContext(caller, gasPrice)
+
+	checkpoint := state.NewCheckpoint()
+	defer state.RevertTo(checkpoint)
+
+	results := make([]*CallResult, 0, len(clauses))
+	for i, clause := range clauses {
+		output := rt.ExecuteClause(clause, uint32(i), gas, txCtx)
+		if err := seeker.Err(); err != nil {
+			return nil, err
+		}
+		if err := state.Err(); err != nil {
+			return nil, err
+		}
+
+		results = append(results, newCallResult(output, gas))
+
+		if output.VMErr != nil {
+			break
+		}
+		gas = output.LeftOverGas
+	}
+	return results, nil
+}
+
+// newCallRuntime creates a runtime to execute clauses by the end of the given block.
+func newCallRuntime(seeker *chain.Seeker, state *state.State, header *block.Header, forkConfig thor.ForkConfig) *Runtime {
+	signer, _ := header.Signer()
+	return New(seeker, state, &xenv.BlockContext{
+		Beneficiary: header.Beneficiary(),
+		Signer:      signer,
+		Number:      header.Number(),
+		Time:        header.Timestamp(),
+		GasLimit:    header.GasLimit(),
+		TotalScore:  header.TotalScore(),
+	}, forkConfig)
+}
+
+func newCallTxContext(caller thor.Address, gasPrice *big.Int) *xenv.TransactionContext {
+	if gasPrice == nil {
+		gasPrice = &big.Int{}
+	}
+	return &xenv.TransactionContext{
+		Origin:     caller,
+		GasPrice:   gasPrice,
+		ProvedWork: &big.Int{},
+	}
+}
+
+func newCallResult(output *Output, gas uint64) *CallResult {
+	result := &CallResult{
+		Data:            output.Data,
+		GasUsed:         gas - output.LeftOverGas,
+		Events:          output.Events,
+		Transfers:       output.Transfers,
+		VMErr:           output.VMErr,
+		ContractAddress: output.ContractAddress,
+	}
+	if output.VMErr == vm.ErrExecutionReverted {
+		result.RevertReason, _ = DecodeRevertReason(output.Data)
+	}
+	return result
+}
+
+// DecodeRevertReason decodes reason from revert data in form of 'Error(string)'.
+func DecodeRevertReason(data []byte) (string, bool) {
+	if len(data) < 4+32+32 || !bytes.Equal(data[:4], errorSelector) {
+		return "", false
+	}
+	data = data[4:]
+	offset, ok := readUint(data[:32])
+	if !ok || offset+32 > uint64(len(data)) {
+		return "", false
+	}
+	size, ok := readUint(data[offset : offset+32])
+	if !ok || offset+32+size > uint64(len(data)) {
+		return "", false
+	}
+	return string(data[offset+32 : offset+32+size]), true
+}
+
+// readUint reads 32 bytes word as uint64, fails if overflow.
+func readUint(word []byte) (uint64, bool) {
+	for _, b := range word[:24] {
+		if b != 0 {
+			return 0, false
+		}
+	}
+	v := binary.BigEndian.Uint64(word[24:])
+	// limit to prevent overflow when adding
+	if v > 1<<32 {
+		return 0, false
+	}
+	return v, true
+}
@@ -3,4 +3,6 @@
 // Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
 // file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
 
+// Package runtime executes clauses and transactions upon state.State through EVM.
+// The state is adapted to EVM by statedb.StateDB, and results are collected into receipts.
 package runtime
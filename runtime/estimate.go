@@ -0,0 +1,95 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package runtime
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// EstimateGas binary-searches the minimal gas for a tx of clauses sent by caller to succeed upon
+// the state at the given block. The gas includes the intrinsic gas, and refunds are applied after
+// each clause as ExecuteTransaction does.
+// If clauses fail even with gasCap, the estimated gas is zero and the result of the failed clause is returned.
+// Changes of state are reverted before EstimateGas returns.
+func EstimateGas(
+	seeker *chain.Seeker,
+	state *state.State,
+	header *block.Header,
+	clauses []*tx.Clause,
+	caller thor.Address,
+	gasPrice *big.Int,
+	gasCap uint64,
+	forkConfig thor.ForkConfig,
+) (uint64, *CallResult, error) {
+	intrinsicGas, err := tx.IntrinsicGas(clauses...)
+	if err != nil {
+		return 0, nil, err
+	}
+	if gasCap < intrinsicGas {
+		return 0, nil, errors.New("gas cap below intrinsic gas")
+	}
+
+	rt := newCallRuntime(seeker, state, header, forkConfig)
+	txCtx := newCallTxContext(caller, gasPrice)
+
+	// execute returns the result of the failed clause, or nil if all clauses succeeded.
+	execute := func(gas uint64) (*CallResult, error) {
+		checkpoint := state.NewCheckpoint()
+		defer state.RevertTo(checkpoint)
+
+		leftOverGas := gas - intrinsicGas
+		for i, clause := range clauses {
+			output := rt.ExecuteClause(clause, uint32(i), leftOverGas, txCtx)
+			if err := seeker.Err(); err != nil {
+				return nil, err
+			}
+			if err := state.Err(); err != nil {
+				return nil, err
+			}
+			if output.VMErr != nil {
+				return newCallResult(output, leftOverGas), nil
+			}
+
+			gasUsed := leftOverGas - output.LeftOverGas
+			leftOverGas = output.LeftOverGas
+
+			refund := gasUsed / 2
+			if refund > output.RefundGas {
+				refund = output.RefundGas
+			}
+			leftOverGas += refund
+		}
+		return nil, nil
+	}
+
+	failure, err := execute(gasCap)
+	if err != nil || failure != nil {
+		return 0, failure, err
+	}
+
+	// lo always fails, while hi always succeeds
+	lo, hi := intrinsicGas-1, gasCap
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		failure, err := execute(mid)
+		if err != nil {
+			return 0, nil, err
+		}
+		if failure != nil {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, nil, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package runtime
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/vm"
+)
+
+// reservedPrecompileLen is the length of leading zero bytes of addresses reserved for precompiles.
+const reservedPrecompileLen = 18
+
+var precompiles = struct {
+	m    map[thor.Address][]precompileEntry
+	lock sync.RWMutex
+}{m: make(map[thor.Address][]precompileEntry)}
+
+type precompileEntry struct {
+	activation uint32
+	contract   vm.PrecompiledContract
+}
+
+// RegisterPrecompile registers an additional precompiled contract at addr, which is active since block number activation.
+// The address should be in the reserved range (0x0000...0000 - 0x0000...ffff), and not occupied by standard precompiles.
+// An address can be registered multiple times with increasing activation numbers, to upgrade the implementation or
+// gas schedule at forks.
+// It should be called before any runtime execution.
+func RegisterPrecompile(addr thor.Address, activation uint32, contract vm.PrecompiledContract) error {
+	if contract == nil {
+		return errors.New("nil precompiled contract")
+	}
+	if !isReservedPrecompileAddress(addr) {
+		return errors.New("address not reserved for precompiles")
+	}
+	if _, ok := vm.PrecompiledContractsByzantium[common.Address(addr)]; ok {
+		return errors.New("address occupied by standard precompile")
+	}
+
+	precompiles.lock.Lock()
+	defer precompiles.lock.Unlock()
+
+	entries := precompiles.m[addr]
+	if len(entries) > 0 && entries[len(entries)-1].activation >= activation {
+		return errors.New("activation must be greater than previous registration")
+	}
+	precompiles.m[addr] = append(entries, precompileEntry{activation, contract})
+	return nil
+}
+
+// lookupPrecompile returns the precompiled contract active at block number num.
+func lookupPrecompile(addr thor.Address, num uint32) vm.PrecompiledContract {
+	precompiles.lock.RLock()
+	defer precompiles.lock.RUnlock()
+
+	entries := precompiles.m[addr]
+	for i := len(entries) - 1; i >= 0; i-- {
+		if num >= entries[i].activation {
+			return entries[i].contract
+		}
+	}
+	return nil
+}
+
+func isReservedPrecompileAddress(addr thor.Address) bool {
+	if addr.IsZero() {
+		return false
+	}
+	for _, b := range addr[:reservedPrecompileLen] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NewPrecompile creates a precompiled contract with gas schedule and run func.
+func NewPrecompile(gas func(input []byte) uint64, run func(input []byte) ([]byte, error)) vm.PrecompiledContract {
+	return &precompile{gas, run}
+}
+
+// LinearGas returns a gas schedule charges base plus perWord for each 32-byte word of input.
+func LinearGas(base, perWord uint64) func(input []byte) uint64 {
+	return func(input []byte) uint64 {
+		return base + uint64(len(input)+31)/32*perWord
+	}
+}
+
+type precompile struct {
+	gas func(input []byte) uint64
+	run func(input []byte) ([]byte, error)
+}
+
+func (p *precompile) RequiredGas(input []byte) uint64  { return p.gas(input) }
+func (p *precompile) Run(input []byte) ([]byte, error) { return p.run(input) }
@@ -21,6 +21,7 @@ import (
 type ResolvedTransaction struct {
 	tx           *tx.Transaction
 	Origin       thor.Address
+	Delegator    *thor.Address
 	IntrinsicGas uint64
 	Clauses      []*tx.Clause
 }
@@ -31,6 +32,10 @@ func ResolveTransaction(tx *tx.Transaction) (*ResolvedTransaction, error) {
 	if err != nil {
 		return nil, err
 	}
+	delegator, err := tx.Delegator()
+	if err != nil {
+		return nil, err
+	}
 	intrinsicGas, err := tx.IntrinsicGas()
 	if err != nil {
 		return nil, err
@@ -39,6 +44,16 @@ func ResolveTransaction(tx *tx.Transaction) (*ResolvedTransaction, error) {
 		return nil, errors.New("intrinsic gas exceeds provided gas")
 	}
 
+	// fee caps are present only for dynamic fee tx
+	if maxFee, maxPriorityFee := tx.MaxFeePerGas(), tx.MaxPriorityFeePerGas(); maxFee != nil {
+		if maxFee.Sign() < 0 || maxPriorityFee.Sign() < 0 {
+			return nil, errors.New("negative fee cap")
+		}
+		if maxFee.Cmp(maxPriorityFee) < 0 {
+			return nil, errors.New("max fee per gas less than max priority fee per gas")
+		}
+	}
+
 	clauses := tx.Clauses()
 	sumValue := new(big.Int)
 	for _, clause := range clauses {
@@ -56,6 +71,7 @@ func ResolveTransaction(tx *tx.Transaction) (*ResolvedTransaction, error) {
 	return &ResolvedTransaction{
 		tx,
 		origin,
+		delegator,
 		intrinsicGas,
 		clauses,
 	}, nil
@@ -93,6 +109,9 @@ func (r *ResolvedTransaction) BuyGas(state *state.State, blockTime uint64) (
 	returnGas func(uint64), err error) {
 
 	baseGasPrice = builtin.Params.Native(state).Get(thor.KeyBaseGasPrice)
+	if maxFee := r.tx.MaxFeePerGas(); maxFee != nil && maxFee.Cmp(baseGasPrice) < 0 {
+		return nil, nil, thor.Address{}, nil, errors.New("max fee per gas less than base gas price")
+	}
 	gasPrice = r.tx.GasPrice(baseGasPrice)
 
 	energy := builtin.Energy.Native(state, blockTime)
@@ -103,6 +122,14 @@ func (r *ResolvedTransaction) BuyGas(state *state.State, blockTime uint64) (
 	}
 
 	prepaid := new(big.Int).Mul(new(big.Int).SetUint64(r.tx.Gas()), gasPrice)
+	if r.Delegator != nil {
+		// delegated tx is always paid by the delegator
+		if energy.Sub(*r.Delegator, prepaid) {
+			return baseGasPrice, gasPrice, *r.Delegator, func(rgas uint64) { doReturnGas(rgas) }, nil
+		}
+		return nil, nil, thor.Address{}, nil, errors.New("insufficient energy")
+	}
+
 	commonTo := r.CommonTo()
 	if commonTo != nil {
 		binding := builtin.Prototype.Native(state).Bind(*commonTo)
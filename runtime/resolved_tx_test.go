@@ -174,6 +174,14 @@ func (tr *testResolvedTransaction) TestBuyGas() {
 		genesis.DevAccounts()[2].Address,
 		buyGas(txSign(txBuild().Clause(clause().WithValue(big.NewInt(100))))),
 	)
+
+	// delegator pays, even if sponsored
+	var features tx.Features
+	features.SetDelegated(true)
+	tr.assert.Equal(
+		genesis.DevAccounts()[3].Address,
+		buyGas(txSignDelegated(txBuild().Features(features).Clause(clause().WithValue(big.NewInt(100))))),
+	)
 }
 
 func clause() *tx.Clause {
@@ -195,3 +203,10 @@ func txSign(builder *tx.Builder) *tx.Transaction {
 	sig, _ := crypto.Sign(transaction.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
 	return transaction.WithSignature(sig)
 }
+
+func txSignDelegated(builder *tx.Builder) *tx.Transaction {
+	transaction := builder.Build()
+	sig, _ := crypto.Sign(transaction.SigningHash().Bytes(), genesis.DevAccounts()[0].PrivateKey)
+	dsig, _ := crypto.Sign(transaction.DelegatorSigningHash(genesis.DevAccounts()[0].Address).Bytes(), genesis.DevAccounts()[3].PrivateKey)
+	return transaction.WithSignature(append(sig, dsig...))
+}
@@ -6,6 +6,7 @@
 package runtime
 
 import (
+	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -39,19 +40,22 @@ func init() {
 	}
 }
 
-var chainConfig = params.ChainConfig{
-	ChainId:             big.NewInt(0),
-	HomesteadBlock:      big.NewInt(0),
-	DAOForkBlock:        big.NewInt(0),
-	DAOForkSupport:      false,
-	EIP150Block:         big.NewInt(0),
-	EIP150Hash:          common.Hash{},
-	EIP155Block:         big.NewInt(0),
-	EIP158Block:         big.NewInt(0),
-	ByzantiumBlock:      big.NewInt(0),
-	ConstantinopleBlock: nil,
-	Ethash:              nil,
-	Clique:              nil,
+// newChainConfig maps fork config to ethereum chain config, to activate EVM features.
+func newChainConfig(forkConfig thor.ForkConfig) *params.ChainConfig {
+	return &params.ChainConfig{
+		ChainId:             big.NewInt(0),
+		HomesteadBlock:      big.NewInt(0),
+		DAOForkBlock:        big.NewInt(0),
+		DAOForkSupport:      false,
+		EIP150Block:         big.NewInt(0),
+		EIP150Hash:          common.Hash{},
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(int64(forkConfig.ETH_CONST)),
+		Ethash:              nil,
+		Clique:              nil,
+	}
 }
 
 // Output output of clause execution.
@@ -67,10 +71,12 @@ type Output struct {
 
 // Runtime bases on EVM and VeChain Thor builtins.
 type Runtime struct {
-	vmConfig vm.Config
-	seeker   *chain.Seeker
-	state    *state.State
-	ctx      *xenv.BlockContext
+	vmConfig    vm.Config
+	chainConfig *params.ChainConfig
+	seeker      *chain.Seeker
+	state       *state.State
+	ctx         *xenv.BlockContext
+	forkConfig  thor.ForkConfig
 }
 
 // New create a Runtime object.
@@ -78,17 +84,21 @@ func New(
 	seeker *chain.Seeker,
 	state *state.State,
 	ctx *xenv.BlockContext,
+	forkConfig thor.ForkConfig,
 ) *Runtime {
 	return &Runtime{
-		seeker: seeker,
-		state:  state,
-		ctx:    ctx,
+		chainConfig: newChainConfig(forkConfig),
+		seeker:      seeker,
+		state:       state,
+		ctx:         ctx,
+		forkConfig:  forkConfig,
 	}
 }
 
 func (rt *Runtime) Seeker() *chain.Seeker       { return rt.seeker }
 func (rt *Runtime) State() *state.State         { return rt.state }
 func (rt *Runtime) Context() *xenv.BlockContext { return rt.ctx }
+func (rt *Runtime) ForkConfig() thor.ForkConfig { return rt.forkConfig }
 
 // SetVMConfig config VM.
 // Returns this runtime.
@@ -109,10 +119,8 @@ func (rt *Runtime) newEVM(stateDB *statedb.StateDB, clauseIndex uint32, txCtx *x
 			}
 			// touch energy balance when token balance changed
 			// SHOULD be performed before transfer
-			rt.state.SetEnergy(thor.Address(sender),
-				rt.state.GetEnergy(thor.Address(sender), rt.ctx.Time), rt.ctx.Time)
-			rt.state.SetEnergy(thor.Address(recipient),
-				rt.state.GetEnergy(thor.Address(recipient), rt.ctx.Time), rt.ctx.Time)
+			rt.state.TouchEnergy(thor.Address(sender), rt.ctx.Time)
+			rt.state.TouchEnergy(thor.Address(recipient), rt.ctx.Time)
 
 			stateDB.SubBalance(common.Address(sender), amount)
 			stateDB.AddBalance(common.Address(recipient), amount)
@@ -211,6 +219,9 @@ func (rt *Runtime) newEVM(stateDB *statedb.StateDB, clauseIndex uint32, txCtx *x
 			}
 
 			if amount := stateDB.GetBalance(contractAddr); amount.Sign() != 0 {
+				if rt.ctx.Number >= rt.forkConfig.ENERGY_SETTLE {
+					rt.state.TouchEnergy(thor.Address(tokenReceiver), rt.ctx.Time)
+				}
 				stateDB.AddBalance(tokenReceiver, amount)
 
 				stateDB.AddTransfer(&tx.Transfer{
@@ -220,6 +231,9 @@ func (rt *Runtime) newEVM(stateDB *statedb.StateDB, clauseIndex uint32, txCtx *x
 				})
 			}
 		},
+		GetPrecompiledContract: func(_ *vm.EVM, addr common.Address) vm.PrecompiledContract {
+			return lookupPrecompile(thor.Address(addr), rt.ctx.Number)
+		},
 		Origin:      common.Address(txCtx.Origin),
 		GasPrice:    txCtx.GasPrice,
 		Coinbase:    common.Address(rt.ctx.Beneficiary),
@@ -227,7 +241,7 @@ func (rt *Runtime) newEVM(stateDB *statedb.StateDB, clauseIndex uint32, txCtx *x
 		BlockNumber: new(big.Int).SetUint64(uint64(rt.ctx.Number)),
 		Time:        new(big.Int).SetUint64(rt.ctx.Time),
 		Difficulty:  &big.Int{},
-	}, stateDB, &chainConfig, rt.vmConfig)
+	}, stateDB, rt.chainConfig, rt.vmConfig)
 }
 
 // ExecuteClause executes single clause.
@@ -266,7 +280,19 @@ func (rt *Runtime) ExecuteClause(
 
 // ExecuteTransaction executes a transaction.
 // If some clause failed, receipt.Outputs will be nil and vmOutputs may shorter than clause count.
+// For tx with clause isolation, a failed clause reverts only its own changes, and subsequent clauses
+// still execute. Outputs are kept for all clauses, with failed ones flagged.
 func (rt *Runtime) ExecuteTransaction(tx *tx.Transaction) (receipt *tx.Receipt, err error) {
+	if tx.IsTyped() && rt.ctx.Number < rt.forkConfig.DYNFEE {
+		return nil, errors.New("tx type not activated")
+	}
+	isolated := tx.Features().IsIsolated()
+	if isolated && rt.ctx.Number < rt.forkConfig.CLAUSEISO {
+		return nil, errors.New("tx clause isolation not activated")
+	}
+	if tx.Features().IsDelegated() && rt.ctx.Number < rt.forkConfig.VIP191 {
+		return nil, errors.New("tx delegation not activated")
+	}
 	resolvedTx, err := ResolveTransaction(tx)
 	if err != nil {
 		return nil, err
@@ -286,6 +312,8 @@ func (rt *Runtime) ExecuteTransaction(tx *tx.Transaction) (receipt *tx.Receipt,
 
 	txCtx := resolvedTx.ToContext(gasPrice, rt.ctx.Number, rt.seeker.GetID)
 	for i, clause := range resolvedTx.Clauses {
+		// clause level checkpoint, to discard partial changes of failed clause
+		clauseCheckpoint := rt.state.NewCheckpoint()
 		output := rt.ExecuteClause(clause, uint32(i), leftOverGas, txCtx)
 
 		gasUsed := leftOverGas - output.LeftOverGas
@@ -302,13 +330,29 @@ func (rt *Runtime) ExecuteTransaction(tx *tx.Transaction) (receipt *tx.Receipt,
 
 		if output.VMErr != nil {
 			// vm exception here
-			// revert all executed clauses
-			rt.state.RevertTo(checkpoint)
+			// revert the failed clause
+			rt.state.RevertTo(clauseCheckpoint)
 			receipt.Reverted = true
+			if isolated {
+				// changes of other clauses retained
+				receipt.Outputs = append(receipt.Outputs, &Tx.Output{
+					GasUsed:  gasUsed,
+					Data:     output.Data,
+					Reverted: true,
+				})
+				continue
+			}
+			// then all executed clauses
+			rt.state.RevertTo(checkpoint)
 			receipt.Outputs = nil
 			break
 		}
-		receipt.Outputs = append(receipt.Outputs, &Tx.Output{Events: output.Events, Transfers: output.Transfers})
+		receipt.Outputs = append(receipt.Outputs, &Tx.Output{
+			Events:    output.Events,
+			Transfers: output.Transfers,
+			GasUsed:   gasUsed,
+			Data:      output.Data,
+		})
 	}
 
 	receipt.GasUsed = tx.Gas() - leftOverGas
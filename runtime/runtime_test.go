@@ -71,7 +71,7 @@ func TestContractSuicide(t *testing.T) {
 	}
 
 	origin := genesis.DevAccounts()[0].Address
-	out := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{Time: time}).
+	out := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{Time: time}, thor.NoFork).
 		ExecuteClause(tx.NewClause(&addr).WithData(methodData), 0, math.MaxUint64, &xenv.TransactionContext{Origin: origin})
 	if out.VMErr != nil {
 		t.Fatal(out.VMErr)
@@ -102,6 +102,45 @@ func TestContractSuicide(t *testing.T) {
 	assert.Equal(new(big.Int).Add(bal, big.NewInt(100)), state.GetEnergy(origin, time))
 }
 
+func TestSuicideSettlesReceiverEnergy(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	stateCreator := state.NewCreator(kv)
+	b0, _, err := g.Build(stateCreator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+
+	origin := genesis.DevAccounts()[0].Address
+	now := b0.Header().Timestamp() + 1000
+
+	// returns energy of origin before and after receiving tokens of suicided contract
+	suicide := func(fc thor.ForkConfig) (*big.Int, *big.Int) {
+		st, _ := stateCreator.NewState(b0.Header().StateRoot())
+
+		// CALLER SELFDESTRUCT
+		addr := thor.BytesToAddress([]byte("acc01"))
+		st.SetCode(addr, []byte{0x33, 0xff})
+		st.SetBalance(addr, big.NewInt(1e18))
+
+		before := st.GetEnergy(origin, now)
+		out := runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{Time: now}, fc).
+			ExecuteClause(tx.NewClause(&addr), 0, math.MaxUint64, &xenv.TransactionContext{Origin: origin})
+		assert.Nil(t, out.VMErr)
+		return before, st.GetEnergy(origin, now)
+	}
+
+	// energy grown before receiving tokens is not affected by the new balance
+	before, after := suicide(thor.ForkConfig{})
+	assert.Equal(t, before, after)
+
+	// not settled before fork
+	before, after = suicide(thor.NoFork)
+	assert.NotEqual(t, before, after)
+}
+
 func TestCall(t *testing.T) {
 	kv, _ := lvldb.NewMem()
 
@@ -115,7 +154,7 @@ func TestCall(t *testing.T) {
 
 	state, _ := state.New(b0.Header().StateRoot(), kv)
 
-	rt := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{})
+	rt := runtime.New(ch.NewSeeker(b0.Header().ID()), state, &xenv.BlockContext{}, thor.NoFork)
 
 	method, _ := builtin.Params.ABI.MethodByName("executor")
 	data, err := method.EncodeInput()
@@ -177,3 +216,400 @@ func TestExecuteTransaction(t *testing.T) {
 	// _ = receipt
 	// assert.Equal(t, state.GetBalance(addr1), new(big.Int).Sub(balance1, big.NewInt(10)))
 }
+
+func TestReadOnlyCall(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, _ := chain.New(kv, b0)
+	st, _ := state.New(b0.Header().StateRoot(), kv)
+
+	method, _ := builtin.Params.ABI.MethodByName("executor")
+	data, _ := method.EncodeInput()
+	result, err := runtime.Call(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		tx.NewClause(&builtin.Params.Address).WithData(data),
+		thor.Address{}, 100000, nil, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Nil(t, result.VMErr)
+	assert.True(t, result.GasUsed > 0)
+
+	var addr common.Address
+	assert.Nil(t, method.DecodeOutput(result.Data, &addr))
+	assert.Equal(t, genesis.DevAccounts()[0].Address, thor.Address(addr))
+
+	// value transfer is not committed
+	sender := genesis.DevAccounts()[0].Address
+	to := thor.BytesToAddress([]byte("to"))
+	balance := st.GetBalance(sender)
+	result, err = runtime.Call(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		tx.NewClause(&to).WithValue(big.NewInt(1)),
+		sender, 100000, nil, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Nil(t, result.VMErr)
+	assert.Equal(t, 1, len(result.Transfers))
+	assert.Equal(t, balance, st.GetBalance(sender))
+	assert.Equal(t, 0, st.GetBalance(to).Sign())
+
+	// PUSH1 0, PUSH1 0, RETURN
+	contract := thor.BytesToAddress([]byte("contract"))
+	st.SetCode(contract, []byte{0x60, 0x00, 0x60, 0x00, 0xf3})
+	result, err = runtime.Call(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		tx.NewClause(&contract),
+		sender, 100000, nil, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Nil(t, result.VMErr)
+	assert.Equal(t, uint64(3+3), result.GasUsed)
+}
+
+func TestCallBatch(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, _ := chain.New(kv, b0)
+	st, _ := state.New(b0.Header().StateRoot(), kv)
+
+	key := thor.BytesToBytes32([]byte("key"))
+	setMethod, _ := builtin.Params.ABI.MethodByName("set")
+	setData, _ := setMethod.EncodeInput(key, big.NewInt(1))
+	getMethod, _ := builtin.Params.ABI.MethodByName("get")
+	getData, _ := getMethod.EncodeInput(key)
+	clauses := []*tx.Clause{
+		tx.NewClause(&builtin.Params.Address).WithData(setData),
+		tx.NewClause(&builtin.Params.Address).WithData(getData),
+	}
+
+	// the executor's change is seen by the next clause
+	executor := genesis.DevAccounts()[0].Address
+	results, err := runtime.CallBatch(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		clauses, executor, 100000, nil, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results))
+	assert.Nil(t, results[0].VMErr)
+	assert.Nil(t, results[1].VMErr)
+
+	var value *big.Int
+	assert.Nil(t, getMethod.DecodeOutput(results[1].Data, &value))
+	assert.Equal(t, big.NewInt(1), value)
+	assert.Equal(t, 0, builtin.Params.Native(st).Get(key).Sign())
+
+	// execution stops at the failed clause
+	results, err = runtime.CallBatch(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		clauses, thor.BytesToAddress([]byte("other")), 100000, nil, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.NotNil(t, results[0].VMErr)
+	assert.Equal(t, "builtin: executor required", results[0].RevertReason)
+}
+
+func TestEstimateGas(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch, _ := chain.New(kv, b0)
+	st, _ := state.New(b0.Header().StateRoot(), kv)
+	executor := genesis.DevAccounts()[0].Address
+
+	// plain transfer costs intrinsic gas only
+	to := thor.BytesToAddress([]byte("to"))
+	clause := tx.NewClause(&to).WithValue(big.NewInt(1))
+	gas, failure, err := runtime.EstimateGas(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		[]*tx.Clause{clause}, executor, nil, 1000000, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Nil(t, failure)
+	intrinsicGas, _ := tx.IntrinsicGas(clause)
+	assert.Equal(t, intrinsicGas, gas)
+
+	key := thor.BytesToBytes32([]byte("key"))
+	method, _ := builtin.Params.ABI.MethodByName("set")
+	data, _ := method.EncodeInput(key, big.NewInt(1))
+	clause = tx.NewClause(&builtin.Params.Address).WithData(data)
+	intrinsicGas, _ = tx.IntrinsicGas(clause)
+
+	gas, failure, err = runtime.EstimateGas(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		[]*tx.Clause{clause}, executor, nil, 1000000, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Nil(t, failure)
+	assert.True(t, gas > intrinsicGas)
+	assert.Equal(t, 0, builtin.Params.Native(st).Get(key).Sign())
+
+	// the estimated gas is just enough
+	result, err := runtime.Call(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		clause, executor, gas-intrinsicGas, nil, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Nil(t, result.VMErr)
+	result, err = runtime.Call(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		clause, executor, gas-intrinsicGas-1, nil, thor.NoFork)
+	assert.Nil(t, err)
+	assert.NotNil(t, result.VMErr)
+
+	// fails even with gas cap
+	gas, failure, err = runtime.EstimateGas(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		[]*tx.Clause{clause}, to, nil, 1000000, thor.NoFork)
+	assert.Nil(t, err)
+	assert.Zero(t, gas)
+	assert.Equal(t, "builtin: executor required", failure.RevertReason)
+
+	_, _, err = runtime.EstimateGas(ch.NewSeeker(b0.Header().ID()), st, b0.Header(),
+		[]*tx.Clause{clause}, executor, nil, intrinsicGas-1, thor.NoFork)
+	assert.NotNil(t, err)
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	data, _ := hex.DecodeString("08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"6f6f707300000000000000000000000000000000000000000000000000000000")
+	reason, ok := runtime.DecodeRevertReason(data)
+	assert.True(t, ok)
+	assert.Equal(t, "oops", reason)
+
+	_, ok = runtime.DecodeRevertReason(data[:40])
+	assert.False(t, ok)
+}
+
+func TestClauseOutputs(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+	st, _ := state.New(b0.Header().StateRoot(), kv)
+
+	method, _ := builtin.Params.ABI.MethodByName("executor")
+	data, _ := method.EncodeInput()
+	to := thor.BytesToAddress([]byte("to"))
+
+	trx, err := tx.Sign(new(tx.Builder).
+		ChainTag(ch.Tag()).
+		Gas(100000).
+		Clause(tx.NewClause(&builtin.Params.Address).WithData(data)).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+		Build(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rt := runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{
+		Number: 1,
+		Time:   b0.Header().Timestamp() + thor.BlockInterval,
+	}, thor.NoFork)
+	receipt, err := rt.ExecuteTransaction(trx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, receipt.Reverted)
+	assert.Equal(t, 2, len(receipt.Outputs))
+
+	var addr common.Address
+	assert.Nil(t, method.DecodeOutput(receipt.Outputs[0].Data, &addr))
+	assert.Equal(t, genesis.DevAccounts()[0].Address, thor.Address(addr))
+	assert.Equal(t, 1, len(receipt.Outputs[1].Transfers))
+
+	intrinsicGas, _ := trx.IntrinsicGas()
+	assert.True(t, intrinsicGas+receipt.Outputs[0].GasUsed+receipt.Outputs[1].GasUsed >= receipt.GasUsed)
+}
+
+func TestClauseIsolation(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+	st, _ := state.New(b0.Header().StateRoot(), kv)
+
+	to := thor.BytesToAddress([]byte("to"))
+	reverter := thor.BytesToAddress([]byte("reverter"))
+	st.SetCode(reverter, []byte{0x60, 0x00, 0x60, 0x00, 0xfd}) // REVERT(0, 0)
+
+	var features tx.Features
+	features.SetIsolated(true)
+	trx, err := tx.Sign(new(tx.Builder).
+		ChainTag(ch.Tag()).
+		Gas(100000).
+		Features(features).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+		Clause(tx.NewClause(&reverter).WithValue(big.NewInt(1))).
+		Clause(tx.NewClause(&to).WithValue(big.NewInt(1))).
+		Build(), genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fc := thor.NoFork
+	fc.CLAUSEISO = 2
+	newRuntime := func(num uint32) *runtime.Runtime {
+		return runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{
+			Number: num,
+			Time:   b0.Header().Timestamp() + thor.BlockInterval,
+		}, fc)
+	}
+
+	_, err = newRuntime(1).ExecuteTransaction(trx)
+	assert.NotNil(t, err, "clause isolation not activated")
+
+	receipt, err := newRuntime(2).ExecuteTransaction(trx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, receipt.Reverted)
+	if !assert.Equal(t, 3, len(receipt.Outputs)) {
+		return
+	}
+	assert.False(t, receipt.Outputs[0].Reverted)
+	assert.True(t, receipt.Outputs[1].Reverted)
+	assert.Empty(t, receipt.Outputs[1].Transfers)
+	assert.False(t, receipt.Outputs[2].Reverted)
+
+	// only the failed clause reverted
+	assert.Equal(t, big.NewInt(2), st.GetBalance(to))
+	assert.Equal(t, 0, st.GetBalance(reverter).Sign())
+}
+
+func TestPrecompileRegistry(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+	st, _ := state.New(b0.Header().StateRoot(), kv)
+
+	echo := runtime.NewPrecompile(runtime.LinearGas(100, 10), func(input []byte) ([]byte, error) {
+		return input, nil
+	})
+	addr := thor.BytesToAddress([]byte{0x1, 0x0})
+
+	assert.NotNil(t, runtime.RegisterPrecompile(thor.BytesToAddress([]byte{1}), 0, echo), "standard precompile")
+	assert.NotNil(t, runtime.RegisterPrecompile(thor.BytesToAddress([]byte("foo")), 0, echo), "not reserved")
+	assert.Nil(t, runtime.RegisterPrecompile(addr, 10, echo))
+	assert.NotNil(t, runtime.RegisterPrecompile(addr, 10, echo), "activation not increased")
+	assert.Nil(t, runtime.RegisterPrecompile(addr, 20, runtime.NewPrecompile(runtime.LinearGas(200, 10), func(input []byte) ([]byte, error) {
+		return input, nil
+	})))
+
+	input := []byte("hello")
+	origin := genesis.DevAccounts()[0].Address
+	exec := func(num uint32) *runtime.Output {
+		return runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{Number: num, Time: b0.Header().Timestamp()}, thor.NoFork).
+			ExecuteClause(tx.NewClause(&addr).WithData(input), 0, 10000, &xenv.TransactionContext{Origin: origin})
+	}
+
+	// not activated
+	out := exec(9)
+	assert.Nil(t, out.VMErr)
+	assert.Nil(t, out.Data)
+	assert.Equal(t, uint64(10000), out.LeftOverGas)
+
+	out = exec(10)
+	assert.Nil(t, out.VMErr)
+	assert.Equal(t, input, out.Data)
+	assert.Equal(t, uint64(10000-110), out.LeftOverGas)
+
+	// upgraded gas schedule
+	out = exec(20)
+	assert.Nil(t, out.VMErr)
+	assert.Equal(t, uint64(10000-210), out.LeftOverGas)
+}
+
+func TestForkConfig(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+	st, _ := state.New(b0.Header().StateRoot(), kv)
+
+	// PUSH1 1 PUSH1 1 SHL STOP
+	addr := thor.BytesToAddress([]byte("shl"))
+	st.SetCode(addr, []byte{0x60, 0x01, 0x60, 0x01, 0x1b, 0x00})
+
+	fc := thor.NoFork
+	fc.ETH_CONST = 10
+
+	exec := func(num uint32) *runtime.Output {
+		return runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{Number: num, Time: b0.Header().Timestamp()}, fc).
+			ExecuteClause(tx.NewClause(&addr), 0, 10000, &xenv.TransactionContext{Origin: genesis.DevAccounts()[0].Address})
+	}
+	assert.NotNil(t, exec(9).VMErr, "invalid opcode before fork")
+	assert.Nil(t, exec(10).VMErr)
+
+	trx, _ := tx.Sign(new(tx.Builder).
+		ChainTag(ch.Tag()).
+		Gas(21000).
+		DynamicFee(big.NewInt(0), thor.InitialBaseGasPrice).
+		Clause(tx.NewClause(&addr)).
+		Build(), genesis.DevAccounts()[0].PrivateKey)
+	_, err = runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{Number: 1, Time: b0.Header().Timestamp()}, thor.NoFork).
+		ExecuteTransaction(trx)
+	assert.NotNil(t, err, "dynamic fee tx not activated")
+}
+
+func TestInternalTransfers(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+	st, _ := state.New(b0.Header().StateRoot(), kv)
+
+	origin := genesis.DevAccounts()[0].Address
+	caller := thor.BytesToAddress([]byte("caller"))
+	callee := thor.BytesToAddress([]byte("callee"))
+	reverter := thor.BytesToAddress([]byte("reverter"))
+
+	// CALL(GAS, to, 5, 0, 0, 0, 0)
+	callWithValue := func(to thor.Address) []byte {
+		code, _ := hex.DecodeString("60006000600060006005" + "73" + hex.EncodeToString(to[:]) + "5af1" + "00")
+		return code
+	}
+	st.SetCode(reverter, []byte{0x60, 0x00, 0x60, 0x00, 0xfd}) // REVERT(0, 0)
+
+	exec := func(to thor.Address) *runtime.Output {
+		st.SetCode(caller, callWithValue(to))
+		return runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{Time: b0.Header().Timestamp()}, thor.NoFork).
+			ExecuteClause(tx.NewClause(&caller).WithValue(big.NewInt(10)), 0, math.MaxUint64, &xenv.TransactionContext{Origin: origin})
+	}
+
+	out := exec(callee)
+	assert.Nil(t, out.VMErr)
+	assert.Equal(t, tx.Transfers{
+		{Sender: origin, Recipient: caller, Amount: big.NewInt(10)},
+		{Sender: caller, Recipient: callee, Amount: big.NewInt(5)},
+	}, out.Transfers)
+
+	// transfers of reverted inner call are discarded
+	out = exec(reverter)
+	assert.Nil(t, out.VMErr)
+	assert.Equal(t, tx.Transfers{
+		{Sender: origin, Recipient: caller, Amount: big.NewInt(10)},
+	}, out.Transfers)
+}
@@ -16,10 +16,13 @@ import (
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
 )
 
 var codeSizeCache, _ = lru.New(32 * 1024)
 
+var _ vm.StateDB = (*StateDB)(nil)
+
 // StateDB implements evm.StateDB, only adapt to evm.
 type StateDB struct {
 	state *state.State
@@ -245,3 +245,33 @@ func (test *snapshotTest) checkEqual(state, checkstate *statedb.StateDB) error {
 	}
 	return nil
 }
+
+func TestRevertLogsAndRefund(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	state, _ := State.NewCreator(db).NewState(thor.Bytes32{})
+	stateDB := statedb.New(state)
+
+	addr := common.BytesToAddress([]byte("addr"))
+	stateDB.AddBalance(addr, big.NewInt(1))
+
+	stateDB.AddRefund(10)
+	stateDB.AddLog(&types.Log{Address: addr})
+
+	rev := stateDB.Snapshot()
+	stateDB.AddRefund(20)
+	stateDB.AddLog(&types.Log{Address: addr})
+	if !stateDB.Suicide(addr) || !stateDB.HasSuicided(addr) {
+		t.Fatal("should suicide")
+	}
+	stateDB.RevertToSnapshot(rev)
+
+	if stateDB.GetRefund() != 10 {
+		t.Errorf("refund not reverted: %v", stateDB.GetRefund())
+	}
+	if events, _ := stateDB.GetLogs(); len(events) != 1 {
+		t.Errorf("logs not reverted: %v", len(events))
+	}
+	if stateDB.HasSuicided(addr) || !stateDB.Exist(addr) {
+		t.Error("suicide not reverted")
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package sandbox
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/lvldb"
+)
+
+// overlay a kv store layered upon a read-only base, with writes kept in the in-memory kv.
+// Deleted keys are tracked, not to fall through to the base.
+type overlay struct {
+	base    kv.Getter
+	mem     *lvldb.LevelDB
+	lock    sync.RWMutex
+	deleted map[string]bool
+}
+
+func newOverlay(base kv.Getter) (*overlay, error) {
+	mem, err := lvldb.NewMem()
+	if err != nil {
+		return nil, err
+	}
+	return &overlay{
+		base:    base,
+		mem:     mem,
+		deleted: make(map[string]bool),
+	}, nil
+}
+
+func (o *overlay) isDeleted(key []byte) bool {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	return o.deleted[string(key)]
+}
+
+func (o *overlay) Get(key []byte) ([]byte, error) {
+	val, err := o.mem.Get(key)
+	if err == nil || !o.mem.IsNotFound(err) {
+		return val, err
+	}
+	if o.isDeleted(key) {
+		return nil, err
+	}
+	return o.base.Get(key)
+}
+
+func (o *overlay) Has(key []byte) (bool, error) {
+	if has, err := o.mem.Has(key); err != nil || has {
+		return has, err
+	}
+	if o.isDeleted(key) {
+		return false, nil
+	}
+	return o.base.Has(key)
+}
+
+func (o *overlay) IsNotFound(err error) bool {
+	return o.mem.IsNotFound(err) || o.base.IsNotFound(err)
+}
+
+func (o *overlay) Put(key, value []byte) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	delete(o.deleted, string(key))
+	return o.mem.Put(key, value)
+}
+
+func (o *overlay) Delete(key []byte) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.deleted[string(key)] = true
+	return o.mem.Delete(key)
+}
+
+func (o *overlay) NewBatch() kv.Batch {
+	return &overlayBatch{overlay: o}
+}
+
+func (o *overlay) NewIterator(r kv.Range) kv.Iterator {
+	return &mergedIterator{
+		base:      o.base.NewIterator(r),
+		mem:       o.mem.NewIterator(r),
+		isDeleted: o.isDeleted,
+	}
+}
+
+// Close releases the in-memory kv, while the base is left open.
+func (o *overlay) Close() error {
+	return o.mem.Close()
+}
+
+type overlayOp struct {
+	key, value []byte
+	delete     bool
+}
+
+// overlayBatch buffers ops to be applied to the overlay on write.
+type overlayBatch struct {
+	overlay *overlay
+	ops     []overlayOp
+}
+
+func (b *overlayBatch) Put(key, value []byte) error {
+	b.ops = append(b.ops, overlayOp{
+		key:   append([]byte(nil), key...),
+		value: append([]byte(nil), value...),
+	})
+	return nil
+}
+
+func (b *overlayBatch) Delete(key []byte) error {
+	b.ops = append(b.ops, overlayOp{key: append([]byte(nil), key...), delete: true})
+	return nil
+}
+
+func (b *overlayBatch) NewBatch() kv.Batch {
+	return b.overlay.NewBatch()
+}
+
+func (b *overlayBatch) Len() int {
+	return len(b.ops)
+}
+
+func (b *overlayBatch) Write() error {
+	for _, op := range b.ops {
+		var err error
+		if op.delete {
+			err = b.overlay.Delete(op.key)
+		} else {
+			err = b.overlay.Put(op.key, op.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	b.ops = nil
+	return nil
+}
+
+// mergedIterator iterates kvs of both the base and the in-memory kv in key order, where
+// the in-memory ones take precedence.
+type mergedIterator struct {
+	base, mem     kv.Iterator
+	isDeleted     func(key []byte) bool
+	started       bool
+	baseOK, memOK bool
+	key, value    []byte
+}
+
+func (it *mergedIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		it.baseOK = it.base.Next()
+		it.memOK = it.mem.Next()
+	}
+	// skip base kvs deleted
+	for it.baseOK && it.isDeleted(it.base.Key()) {
+		it.baseOK = it.base.Next()
+	}
+	switch {
+	case !it.baseOK && !it.memOK:
+		it.key, it.value = nil, nil
+		return false
+	case !it.memOK:
+		it.take(it.base)
+		it.baseOK = it.base.Next()
+	case !it.baseOK:
+		it.take(it.mem)
+		it.memOK = it.mem.Next()
+	default:
+		switch c := bytes.Compare(it.base.Key(), it.mem.Key()); {
+		case c < 0:
+			it.take(it.base)
+			it.baseOK = it.base.Next()
+		case c > 0:
+			it.take(it.mem)
+			it.memOK = it.mem.Next()
+		default:
+			it.take(it.mem)
+			it.baseOK = it.base.Next()
+			it.memOK = it.mem.Next()
+		}
+	}
+	return true
+}
+
+// take copies the current kv of the iterator, which may be overwritten once advanced.
+func (it *mergedIterator) take(from kv.Iterator) {
+	it.key = append([]byte(nil), from.Key()...)
+	it.value = append([]byte(nil), from.Value()...)
+}
+
+func (it *mergedIterator) Release() {
+	it.base.Release()
+	it.mem.Release()
+}
+
+func (it *mergedIterator) Error() error {
+	if err := it.base.Error(); err != nil {
+		return err
+	}
+	return it.mem.Error()
+}
+
+func (it *mergedIterator) Key() []byte   { return it.key }
+func (it *mergedIterator) Value() []byte { return it.value }
@@ -0,0 +1,203 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package sandbox forks the chain at any block into a disposable in-memory overlay, for development.
+// Hypothetical txs can be submitted and mined into instant blocks, and states can be modified
+// directly, while the chain forked is never written. Once closed, all changes are thrown away.
+package sandbox
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/packer"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+var log = log15.New("pkg", "sandbox")
+
+// Sandbox a fork of the chain, whose blocks and states are kept in memory upon the kv of the chain forked.
+// Blocks are packed and signed by the first dev account, regardless of the PoA schedule, and timestamps
+// of blocks are increased by the block interval.
+type Sandbox struct {
+	kv           *overlay
+	chain        *chain.Chain
+	stateCreator *state.Creator
+	packer       *packer.Packer
+	pending      tx.Transactions
+	lock         sync.Mutex
+}
+
+// Fork forks the chain, whose blocks and states are stored in kv, at the block of given id.
+func Fork(kv kv.Getter, genesisBlock *block.Block, blockID thor.Bytes32, forkConfig thor.ForkConfig) (*Sandbox, error) {
+	ov, err := newOverlay(kv)
+	if err != nil {
+		return nil, err
+	}
+	ch, err := chain.NewFork(ov, genesisBlock, blockID)
+	if err != nil {
+		ov.Close()
+		return nil, err
+	}
+	stateCreator := state.NewCreator(ov)
+	if _, err := stateCreator.NewState(ch.BestBlock().Header().StateRoot()); err != nil {
+		ov.Close()
+		return nil, errors.WithMessage(err, "state")
+	}
+	master := genesis.DevAccounts()[0].Address
+	return &Sandbox{
+		kv:           ov,
+		chain:        ch,
+		stateCreator: stateCreator,
+		packer:       packer.New(ch, stateCreator, master, master, forkConfig),
+	}, nil
+}
+
+// Chain returns the forked chain.
+func (s *Sandbox) Chain() *chain.Chain {
+	return s.chain
+}
+
+// StateCreator returns the state creator of the forked chain.
+func (s *Sandbox) StateCreator() *state.Creator {
+	return s.stateCreator
+}
+
+// State returns the state of the best block. Changes made to it are not kept, use Modify instead.
+func (s *Sandbox) State() (*state.State, error) {
+	return s.stateCreator.NewState(s.chain.BestBlock().Header().StateRoot())
+}
+
+// Pending returns txs submitted but not mined yet.
+func (s *Sandbox) Pending() tx.Transactions {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append(tx.Transactions(nil), s.pending...)
+}
+
+// Submit submits the tx to be mined. It's rejected if not adoptable upon the best block.
+func (s *Sandbox) Submit(trx *tx.Transaction) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, p := range s.pending {
+		if p.ID() == trx.ID() {
+			return errors.New("tx already submitted")
+		}
+	}
+	// a dry run upon the best block, to report errors early
+	flow, err := s.packer.Mock(s.chain.BestBlock().Header(), s.nextTimestamp())
+	if err != nil {
+		return err
+	}
+	if err := flow.Adopt(trx); err != nil && !packer.IsTxNotAdoptableNow(err) {
+		return err
+	}
+	s.pending = append(s.pending, trx)
+	return nil
+}
+
+// Mine packs n blocks instantly with pending txs, even if there's no tx.
+// Txs failed to be adopted are dropped, except those not adoptable yet.
+func (s *Sandbox) Mine(n int) ([]*block.Block, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	blocks := make([]*block.Block, 0, n)
+	for i := 0; i < n; i++ {
+		flow, err := s.packer.Mock(s.chain.BestBlock().Header(), s.nextTimestamp())
+		if err != nil {
+			return nil, errors.WithMessage(err, "mock packer")
+		}
+		var remained tx.Transactions
+		for j, trx := range s.pending {
+			err := flow.Adopt(trx)
+			if packer.IsGasLimitReached(err) {
+				remained = append(remained, s.pending[j:]...)
+				break
+			}
+			if packer.IsTxNotAdoptableNow(err) {
+				remained = append(remained, trx)
+			} else if err != nil {
+				log.Debug("tx dropped", "id", trx.ID(), "err", err)
+			}
+		}
+		s.pending = remained
+
+		b, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey)
+		if err != nil {
+			return nil, errors.WithMessage(err, "pack")
+		}
+		if _, err := stage.Commit(); err != nil {
+			return nil, errors.WithMessage(err, "commit state")
+		}
+		if _, err := s.chain.AddBlock(b, receipts); err != nil {
+			return nil, errors.WithMessage(err, "add block")
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+// Modify modifies the state of the best block directly, e.g. balances, energy, code and storage,
+// and the changes are carried by a new empty block. blockTime is the timestamp of the new block.
+func (s *Sandbox) Modify(fn func(st *state.State, blockTime uint64) error) (*block.Block, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	parent := s.chain.BestBlock().Header()
+	st, err := s.stateCreator.NewState(parent.StateRoot())
+	if err != nil {
+		return nil, err
+	}
+	blockTime := s.nextTimestamp()
+	if err := fn(st, blockTime); err != nil {
+		return nil, err
+	}
+	stateRoot, err := st.Stage().Commit()
+	if err != nil {
+		return nil, errors.WithMessage(err, "commit state")
+	}
+
+	master := genesis.DevAccounts()[0]
+	b := new(block.Builder).
+		Beneficiary(master.Address).
+		GasLimit(parent.GasLimit()).
+		ParentID(parent.ID()).
+		Timestamp(blockTime).
+		TotalScore(parent.TotalScore() + 1).
+		ReceiptsRoot(tx.Receipts(nil).RootHash()).
+		StateRoot(stateRoot).
+		Build()
+	sig, err := crypto.Sign(b.Header().SigningHash().Bytes(), master.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	b = b.WithSignature(sig)
+	if _, err := s.chain.AddBlock(b, nil); err != nil {
+		return nil, errors.WithMessage(err, "add block")
+	}
+	return b, nil
+}
+
+// Close throws away all changes of the sandbox. The sandbox should not be used any more.
+func (s *Sandbox) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pending = nil
+	return s.kv.Close()
+}
+
+func (s *Sandbox) nextTimestamp() uint64 {
+	return s.chain.BestBlock().Header().Timestamp() + thor.BlockInterval
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package sandbox
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+func TestSandbox(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	b0, _, err := g.Build(state.NewCreator(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(db, b0)
+
+	sb, err := Fork(db, b0, b0.Header().ID(), thor.NoFork)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := genesis.DevAccounts()[1]
+	bob := thor.BytesToAddress([]byte("bob"))
+
+	// modify state directly
+	b1, err := sb.Modify(func(st *state.State, blockTime uint64) error {
+		st.SetBalance(bob, big.NewInt(100))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(1), b1.Header().Number())
+	assert.Equal(t, b1.Header().ID(), sb.Chain().BestBlock().Header().ID())
+
+	// submit and mine a tx
+	trx, _ := tx.Sign(new(tx.Builder).
+		ChainTag(ch.Tag()).
+		Gas(21000).
+		Expiration(100).
+		Clause(tx.NewClause(&bob).WithValue(big.NewInt(1))).
+		Build(), alice.PrivateKey)
+	assert.Nil(t, sb.Submit(trx))
+	assert.NotNil(t, sb.Submit(trx), "duplicated")
+	assert.Equal(t, 1, len(sb.Pending()))
+
+	badTx, _ := tx.Sign(new(tx.Builder).ChainTag(ch.Tag()+1).Gas(21000).Build(), alice.PrivateKey)
+	assert.NotNil(t, sb.Submit(badTx), "chain tag mismatch")
+
+	blocks, err := sb.Mine(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(blocks))
+	assert.Equal(t, 1, len(blocks[0].Transactions()))
+	assert.Equal(t, 0, len(blocks[1].Transactions()))
+	assert.Empty(t, sb.Pending())
+	assert.Equal(t, blocks[0].Header().Timestamp()+thor.BlockInterval, blocks[1].Header().Timestamp())
+
+	st, err := sb.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, big.NewInt(101), st.GetBalance(bob))
+
+	// the chain forked is untouched
+	assert.Equal(t, b0.Header().ID(), ch.BestBlock().Header().ID())
+	mainState, _ := state.New(b0.Header().StateRoot(), db)
+	assert.Equal(t, 0, mainState.GetBalance(bob).Sign())
+	_, err = ch.GetBlockHeader(blocks[0].Header().ID())
+	assert.True(t, ch.IsNotFound(err))
+
+	assert.Nil(t, sb.Close())
+}
+
+func TestOverlay(t *testing.T) {
+	base, _ := lvldb.NewMem()
+	base.Put([]byte("a"), []byte("1"))
+	base.Put([]byte("b"), []byte("2"))
+	base.Put([]byte("c"), []byte("3"))
+
+	ov, err := newOverlay(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, ov.Put([]byte("b"), []byte("20")))
+	assert.Nil(t, ov.Delete([]byte("c")))
+	batch := ov.NewBatch()
+	batch.Put([]byte("d"), []byte("4"))
+	assert.Nil(t, batch.Write())
+
+	val, err := ov.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), val)
+	val, _ = ov.Get([]byte("b"))
+	assert.Equal(t, []byte("20"), val)
+	_, err = ov.Get([]byte("c"))
+	assert.True(t, ov.IsNotFound(err))
+	has, _ := ov.Has([]byte("c"))
+	assert.False(t, has)
+
+	it := ov.NewIterator(kv.Range{})
+	var kvs []string
+	for it.Next() {
+		kvs = append(kvs, string(it.Key())+"="+string(it.Value()))
+	}
+	it.Release()
+	assert.Nil(t, it.Error())
+	assert.Equal(t, []string{"a=1", "b=20", "d=4"}, kvs)
+
+	// base untouched
+	val, _ = base.Get([]byte("b"))
+	assert.Equal(t, []byte("2"), val)
+	has, _ = base.Has([]byte("c"))
+	assert.True(t, has)
+}
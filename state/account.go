@@ -6,6 +6,7 @@
 package state
 
 import (
+	"bytes"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -32,6 +33,16 @@ func (a *Account) IsEmpty() bool {
 		len(a.CodeHash) == 0
 }
 
+// equal returns whether accounts are of the same data.
+func (a *Account) equal(b *Account) bool {
+	return a.Balance.Cmp(b.Balance) == 0 &&
+		a.Energy.Cmp(b.Energy) == 0 &&
+		a.BlockTime == b.BlockTime &&
+		bytes.Equal(a.Master, b.Master) &&
+		bytes.Equal(a.CodeHash, b.CodeHash) &&
+		bytes.Equal(a.StorageRoot, b.StorageRoot)
+}
+
 var bigE18 = big.NewInt(1e18)
 
 // CalcEnergy calculates energy based on current block time.
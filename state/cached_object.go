@@ -15,6 +15,9 @@ type cachedObject struct {
 	kv   kv.GetPutter
 	data Account
 
+	snap     snapshotLayer // snapshot to read storage from, nil if not available
+	addrHash thor.Bytes32
+
 	cache struct {
 		code        []byte
 		storageTrie trieReader
@@ -54,6 +57,15 @@ func (co *cachedObject) GetStorage(key thor.Bytes32) ([]byte, error) {
 	}
 	// not found in cache
 
+	if co.snap != nil {
+		if v, err := co.snap.storage(co.addrHash, thor.Blake2b(key[:])); err == nil {
+			snapshotHitCounter.Inc()
+			cache.storage[key] = v
+			return v, nil
+		}
+		snapshotMissCounter.Inc()
+	}
+
 	trie, err := co.getOrCreateStorageTrie()
 	if err != nil {
 		return nil, err
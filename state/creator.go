@@ -6,21 +6,71 @@
 package state
 
 import (
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/vechain/thor/kv"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
 )
 
+var emptyRoot = thor.Blake2b(rlp.EmptyString)
+
 // Creator state creator to cut-off kv dependency.
 type Creator struct {
-	kv kv.GetPutter
+	kv    kv.GetPutter
+	snaps *Snapshots
+	sets  *WriteSets
 }
 
 // NewCreator create a new state creator.
 func NewCreator(kv kv.GetPutter) *Creator {
-	return &Creator{kv}
+	return &Creator{kv: kv}
+}
+
+// WithSnapshots returns a creator whose states read from snapshots if available, and update
+// snapshots when committed.
+func (c *Creator) WithSnapshots(snaps *Snapshots) *Creator {
+	cc := *c
+	cc.snaps = snaps
+	return &cc
+}
+
+// WithWriteSets returns a creator whose states record write-sets into sets when committed.
+func (c *Creator) WithWriteSets(sets *WriteSets) *Creator {
+	cc := *c
+	cc.sets = sets
+	return &cc
 }
 
 // NewState create a new state object.
 func (c *Creator) NewState(root thor.Bytes32) (*State, error) {
-	return New(root, c.kv)
+	return newState(root, c.kv, c.snaps, c.sets)
+}
+
+// WriteSet returns the write-set recorded of the commit from the state at parentRoot to the one at root,
+// or nil if not recorded.
+func (c *Creator) WriteSet(parentRoot, root thor.Bytes32) *WriteSet {
+	if c.sets == nil {
+		return nil
+	}
+	return c.sets.Get(parentRoot, root)
+}
+
+// HasRoot returns whether the state of given root is available.
+func (c *Creator) HasRoot(root thor.Bytes32) (bool, error) {
+	return HasRoot(root, c.kv)
+}
+
+// Prove writes trie nodes proving the account, and storage values of given keys, at the state root.
+// See Prove.
+func (c *Creator) Prove(root thor.Bytes32, addr thor.Address, keys []thor.Bytes32, proofDB trie.DatabaseWriter) error {
+	return Prove(root, c.kv, addr, keys, proofDB)
+}
+
+// HasRoot returns whether the trie node of given state root is stored in kv.
+// It only checks the root node, not the whole trie.
+func HasRoot(root thor.Bytes32, kv kv.Getter) (bool, error) {
+	if root.IsZero() || root == emptyRoot {
+		return true, nil
+	}
+	return kv.Has(root[:])
 }
@@ -0,0 +1,47 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+// Prove writes trie nodes proving the account, and storage values of given keys, into proofDB.
+// Nodes are keyed by their hashes, so that a State created upon proofDB with the same root
+// can read the account and the storage values, without trusting where nodes come from.
+func Prove(root thor.Bytes32, kv kv.GetPutter, addr thor.Address, keys []thor.Bytes32, proofDB trie.DatabaseWriter) error {
+	accountTrie, err := trie.NewSecure(root, kv, 0)
+	if err != nil {
+		return err
+	}
+	if err := accountTrie.Prove(addr[:], 0, proofDB); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	acc, err := loadAccount(accountTrie, addr)
+	if err != nil {
+		return err
+	}
+	if len(acc.StorageRoot) == 0 {
+		// storage is empty, which is proved by the account
+		return nil
+	}
+	storageTrie, err := trie.NewSecure(thor.BytesToBytes32(acc.StorageRoot), kv, 0)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := storageTrie.Prove(key[:], 0, proofDB); err != nil {
+			return err
+		}
+	}
+	return nil
+}
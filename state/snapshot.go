@@ -0,0 +1,229 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/inconshreveable/log15"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
+	"github.com/vechain/thor/thor"
+)
+
+// snapshotDiffLimit max number of diff layers kept over the disk layer. Deeper ones are flattened into disk.
+const snapshotDiffLimit = 128
+
+var log = log15.New("pkg", "state")
+
+var (
+	errSnapshotStale      = errors.New("snapshot stale")
+	errSnapshotNotCovered = errors.New("snapshot not covered")
+)
+
+var (
+	snapshotHitCounter  = metric.NewCounter("state", "snapshot_hits_total", "count of accounts and storage values read from snapshot")
+	snapshotMissCounter = metric.NewCounter("state", "snapshot_misses_total", "count of accounts and storage values read from trie since snapshot not available")
+)
+
+// snapshotLayer flat accounts and storage values at a state root, keyed by hashes as in tries.
+// A nil value means not existent.
+type snapshotLayer interface {
+	Root() thor.Bytes32
+	account(hash thor.Bytes32) ([]byte, error)
+	storage(accountHash, keyHash thor.Bytes32) ([]byte, error)
+}
+
+// snapshotDiff changes of a state commit, keyed by hashes.
+type snapshotDiff struct {
+	destructs map[thor.Bytes32]struct{}                // accounts deleted, or whose storage was reset
+	accounts  map[thor.Bytes32][]byte                  // nil for deleted accounts
+	storage   map[thor.Bytes32]map[thor.Bytes32][]byte // nil for deleted values
+}
+
+func newSnapshotDiff() *snapshotDiff {
+	return &snapshotDiff{
+		destructs: make(map[thor.Bytes32]struct{}),
+		accounts:  make(map[thor.Bytes32][]byte),
+		storage:   make(map[thor.Bytes32]map[thor.Bytes32][]byte),
+	}
+}
+
+// Snapshots maintains flat snapshots of recent states alongside tries, so that accounts and storage
+// values are read without trie traversal.
+//
+// It's a stack of in-memory diff layers, each for a state committed, over the disk layer persisted
+// in kv. Diff layers deeper than the limit are flattened into the disk layer, and the ones of other
+// branches are dropped then. The disk layer is generated from the trie in background if missing,
+// during which reads of accounts not yet generated fall back to tries.
+type Snapshots struct {
+	kv     kv.GetPutter
+	lock   sync.RWMutex
+	layers map[thor.Bytes32]snapshotLayer
+	disk   *diskLayer
+
+	genLock sync.Mutex // held while generating a batch, or replacing the disk layer
+	genStop chan struct{}
+	genDone chan struct{}
+}
+
+// NewSnapshots loads snapshots with the disk layer at the state root, e.g. of the best block.
+// The disk layer is regenerated if the persisted one is of another root, or incomplete.
+func NewSnapshots(kv kv.GetPutter, root thor.Bytes32) (*Snapshots, error) {
+	disk, err := loadDiskLayer(kv)
+	if err != nil {
+		return nil, err
+	}
+	if disk == nil || disk.root != root {
+		log.Info("snapshot not matched, regenerating", "root", root)
+		if disk, err = resetDiskLayer(kv, root); err != nil {
+			return nil, err
+		}
+	}
+	s := &Snapshots{
+		kv:      kv,
+		layers:  map[thor.Bytes32]snapshotLayer{root: disk},
+		disk:    disk,
+		genStop: make(chan struct{}),
+		genDone: make(chan struct{}),
+	}
+	if disk.done {
+		close(s.genDone)
+	} else {
+		go s.generate()
+	}
+	return s, nil
+}
+
+// layer returns the layer at the state root, or nil if not found.
+func (s *Snapshots) layer(root thor.Bytes32) snapshotLayer {
+	if s == nil {
+		return nil
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.layers[root]
+}
+
+// update adds a diff layer of the state root over the parent one, which is ignored if the parent
+// layer not found.
+func (s *Snapshots) update(root, parentRoot thor.Bytes32, diff *snapshotDiff) error {
+	if root == parentRoot {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.layers[root]; ok {
+		return nil
+	}
+	parent, ok := s.layers[parentRoot]
+	if !ok {
+		return nil
+	}
+	layer := newDiffLayer(parent, root, diff)
+	s.layers[root] = layer
+	return s.capDiffs(layer, snapshotDiffLimit)
+}
+
+// capDiffs flattens diff layers under the layer into disk, until no more than limit ones left.
+func (s *Snapshots) capDiffs(layer snapshotLayer, limit int) error {
+	var diffs []*diffLayer // from top to bottom
+	for {
+		diff, ok := layer.(*diffLayer)
+		if !ok {
+			break
+		}
+		diffs = append(diffs, diff)
+		layer = diff.getParent()
+	}
+	if len(diffs) <= limit {
+		return nil
+	}
+	for i := len(diffs) - 1; i >= limit; i-- {
+		if err := s.flatten(diffs[i]); err != nil {
+			return err
+		}
+	}
+	s.dropStale()
+	return nil
+}
+
+// flatten writes the bottom diff layer into disk, and the new disk layer takes place of it.
+func (s *Snapshots) flatten(bottom *diffLayer) error {
+	s.genLock.Lock()
+	defer s.genLock.Unlock()
+
+	disk, err := s.disk.apply(bottom)
+	if err != nil {
+		return err
+	}
+	bottom.markStale()
+	delete(s.layers, s.disk.root)
+	for _, layer := range s.layers {
+		if diff, ok := layer.(*diffLayer); ok && diff.getParent() == bottom {
+			diff.setParent(disk)
+		}
+	}
+	s.layers[disk.root] = disk
+	s.disk = disk
+	return nil
+}
+
+// dropStale drops diff layers not over the disk layer, i.e. of other branches.
+func (s *Snapshots) dropStale() {
+	for root, layer := range s.layers {
+		diff, ok := layer.(*diffLayer)
+		if !ok {
+			continue
+		}
+		bottom := snapshotLayer(diff)
+		for {
+			d, ok := bottom.(*diffLayer)
+			if !ok {
+				break
+			}
+			bottom = d.getParent()
+		}
+		if bottom != snapshotLayer(s.disk) {
+			diff.markStale()
+			delete(s.layers, root)
+		}
+	}
+}
+
+// Close stops generation, and flattens diff layers down to the state root into disk, so that the
+// snapshot is reused at the next start with the same root.
+func (s *Snapshots) Close(root thor.Bytes32) error {
+	close(s.genStop)
+	<-s.genDone
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.layers[root]; !ok {
+		return nil
+	}
+	return s.capDiffs(s.layers[root], 0)
+}
+
+// snapshotAccount reads the account from the snapshot layer.
+func snapshotAccount(layer snapshotLayer, addr thor.Address) (*Account, error) {
+	blob, err := layer.account(thor.Blake2b(addr[:]))
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) == 0 {
+		return emptyAccount(), nil
+	}
+	var a Account
+	if err := rlp.DecodeBytes(blob, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+// snapshotGenerateBatch number of accounts generated in a batch, during which the disk layer is not flattened into.
+const snapshotGenerateBatch = 256
+
+// generate generates the disk layer from the trie in batches, until completed or stopped.
+func (s *Snapshots) generate() {
+	defer close(s.genDone)
+
+	startTime := time.Now()
+	for {
+		select {
+		case <-s.genStop:
+			return
+		default:
+		}
+		done, err := s.generateBatch(snapshotGenerateBatch)
+		if err != nil {
+			// reads keep falling back to tries
+			log.Warn("failed to generate snapshot", "err", err)
+			return
+		}
+		if done {
+			log.Info("snapshot generated", "elapsed", time.Since(startTime))
+			return
+		}
+	}
+}
+
+// generateBatch generates accounts next to the marker, at the root of the current disk layer.
+// Entries left between generated accounts are deleted, since they are of other states.
+// It returns true if generation completed.
+func (s *Snapshots) generateBatch(n int) (bool, error) {
+	s.genLock.Lock()
+	defer s.genLock.Unlock()
+
+	disk := s.disk
+	disk.lock.RLock()
+	done, marker := disk.done, disk.marker
+	disk.lock.RUnlock()
+	if done {
+		return true, nil
+	}
+
+	tr, err := trie.New(disk.root, s.kv)
+	if err != nil {
+		return false, err
+	}
+	var start []byte
+	if marker != nil {
+		start = marker[:]
+	}
+	// the range after the last account generated
+	after := func(prefix []byte) []byte {
+		if marker == nil {
+			return prefix
+		}
+		return kv.NewRangeWithBytesPrefix(append(append([]byte(nil), prefix...), marker[:]...)).To
+	}
+
+	batch := s.kv.NewBatch()
+	it := trie.NewIterator(tr.NodeIterator(start))
+	generated := 0
+	for generated < n && it.Next() {
+		hash := thor.BytesToBytes32(it.Key)
+		if marker != nil && hash == *marker {
+			continue
+		}
+		// accounts and storage values left before this account, and storage values of this account
+		if err := deleteRange(s.kv, batch, kv.Range{From: after(snapshotAccountPrefix), To: snapshotAccountKey(hash)}, snapshotAccountKeyLen); err != nil {
+			return false, err
+		}
+		if err := deleteRange(s.kv, batch, kv.Range{From: after(snapshotStoragePrefix), To: kv.NewRangeWithBytesPrefix(snapshotAccountStorage(hash)).To}, snapshotStorageKeyLen); err != nil {
+			return false, err
+		}
+		if err := batch.Put(snapshotAccountKey(hash), it.Value); err != nil {
+			return false, err
+		}
+		var acc Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return false, err
+		}
+		if len(acc.StorageRoot) > 0 {
+			str, err := trie.New(thor.BytesToBytes32(acc.StorageRoot), s.kv)
+			if err != nil {
+				return false, err
+			}
+			sit := trie.NewIterator(str.NodeIterator(nil))
+			for sit.Next() {
+				if err := batch.Put(snapshotStorageKey(hash, thor.BytesToBytes32(sit.Key)), sit.Value); err != nil {
+					return false, err
+				}
+			}
+			if sit.Err != nil {
+				return false, sit.Err
+			}
+		}
+		marker = &hash
+		generated++
+	}
+	if it.Err != nil {
+		return false, it.Err
+	}
+
+	done = generated < n
+	if done {
+		// entries left after the last account
+		if err := deleteRange(s.kv, batch, kv.Range{From: after(snapshotAccountPrefix), To: kv.NewRangeWithBytesPrefix(snapshotAccountPrefix).To}, snapshotAccountKeyLen); err != nil {
+			return false, err
+		}
+		if err := deleteRange(s.kv, batch, kv.Range{From: after(snapshotStoragePrefix), To: kv.NewRangeWithBytesPrefix(snapshotStoragePrefix).To}, snapshotStorageKeyLen); err != nil {
+			return false, err
+		}
+		if err := batch.Delete(snapshotGeneratorKey); err != nil {
+			return false, err
+		}
+	} else if err := batch.Put(snapshotGeneratorKey, marker[:]); err != nil {
+		return false, err
+	}
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+
+	disk.lock.Lock()
+	disk.done, disk.marker = done, marker
+	disk.lock.Unlock()
+	return done, nil
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/thor"
+)
+
+var (
+	snapshotRootKey       = []byte("snapshotRoot")      // state root of the disk layer
+	snapshotGeneratorKey  = []byte("snapshotGenerator") // hash of the last account generated, absent if completed
+	snapshotAccountPrefix = []byte("sa")                // (prefix, account hash) -> account
+	snapshotStoragePrefix = []byte("ss")                // (prefix, account hash, key hash) -> storage value
+)
+
+// lengths of snapshot keys, to tell them from trie nodes and codes keyed by 32-byte hash in the same kv,
+// which may also start with the prefixes.
+const (
+	snapshotAccountKeyLen = 2 + 32
+	snapshotStorageKeyLen = 2 + 32 + 32
+)
+
+func snapshotAccountKey(hash thor.Bytes32) []byte {
+	return append(append([]byte(nil), snapshotAccountPrefix...), hash[:]...)
+}
+
+// snapshotAccountStorage returns prefix of storage values of the account.
+func snapshotAccountStorage(accountHash thor.Bytes32) []byte {
+	return append(append([]byte(nil), snapshotStoragePrefix...), accountHash[:]...)
+}
+
+func snapshotStorageKey(accountHash, keyHash thor.Bytes32) []byte {
+	return append(snapshotAccountStorage(accountHash), keyHash[:]...)
+}
+
+// diffLayer changes of a state commit over the parent layer.
+type diffLayer struct {
+	root thor.Bytes32
+	diff *snapshotDiff
+
+	lock   sync.RWMutex
+	parent snapshotLayer
+	stale  bool
+}
+
+func newDiffLayer(parent snapshotLayer, root thor.Bytes32, diff *snapshotDiff) *diffLayer {
+	return &diffLayer{
+		root:   root,
+		diff:   diff,
+		parent: parent,
+	}
+}
+
+func (dl *diffLayer) Root() thor.Bytes32 {
+	return dl.root
+}
+
+func (dl *diffLayer) getParent() snapshotLayer {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+	return dl.parent
+}
+
+func (dl *diffLayer) setParent(parent snapshotLayer) {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+	dl.parent = parent
+}
+
+func (dl *diffLayer) markStale() {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+	dl.stale = true
+}
+
+// lookup returns the parent layer to look up further, unless found in this layer.
+func (dl *diffLayer) lookup(find func() ([]byte, bool)) ([]byte, snapshotLayer, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale {
+		return nil, nil, errSnapshotStale
+	}
+	if v, ok := find(); ok {
+		return v, nil, nil
+	}
+	return nil, dl.parent, nil
+}
+
+func (dl *diffLayer) account(hash thor.Bytes32) ([]byte, error) {
+	v, parent, err := dl.lookup(func() ([]byte, bool) {
+		v, ok := dl.diff.accounts[hash]
+		return v, ok
+	})
+	if err != nil || parent == nil {
+		return v, err
+	}
+	return parent.account(hash)
+}
+
+func (dl *diffLayer) storage(accountHash, keyHash thor.Bytes32) ([]byte, error) {
+	v, parent, err := dl.lookup(func() ([]byte, bool) {
+		if v, ok := dl.diff.storage[accountHash][keyHash]; ok {
+			return v, true
+		}
+		// storage before destructed is dropped
+		_, ok := dl.diff.destructs[accountHash]
+		return nil, ok
+	})
+	if err != nil || parent == nil {
+		return v, err
+	}
+	return parent.storage(accountHash, keyHash)
+}
+
+// diskLayer the snapshot persisted in kv.
+type diskLayer struct {
+	kv   kv.GetPutter
+	root thor.Bytes32
+
+	lock   sync.RWMutex
+	done   bool          // whether generation completed
+	marker *thor.Bytes32 // hash of the last account generated, nil if none yet
+	stale  bool
+}
+
+// loadDiskLayer loads the disk layer persisted, or returns nil if not found.
+func loadDiskLayer(kv kv.GetPutter) (*diskLayer, error) {
+	root, err := kv.Get(snapshotRootKey)
+	if err != nil {
+		if kv.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	dl := &diskLayer{kv: kv, root: thor.BytesToBytes32(root)}
+	marker, err := kv.Get(snapshotGeneratorKey)
+	if err != nil {
+		if !kv.IsNotFound(err) {
+			return nil, err
+		}
+		dl.done = true
+	} else if len(marker) > 0 {
+		m := thor.BytesToBytes32(marker)
+		dl.marker = &m
+	}
+	return dl, nil
+}
+
+// resetDiskLayer resets the disk layer to the state root, which is to be generated.
+// Entries left are overwritten or deleted by generation.
+func resetDiskLayer(kv kv.GetPutter, root thor.Bytes32) (*diskLayer, error) {
+	batch := kv.NewBatch()
+	if err := batch.Put(snapshotRootKey, root[:]); err != nil {
+		return nil, err
+	}
+	if err := batch.Put(snapshotGeneratorKey, nil); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	return &diskLayer{kv: kv, root: root}, nil
+}
+
+func (dl *diskLayer) Root() thor.Bytes32 {
+	return dl.root
+}
+
+// covered returns whether the account was generated. It should be called with lock held.
+func (dl *diskLayer) covered(hash thor.Bytes32) bool {
+	return dl.done || (dl.marker != nil && bytes.Compare(hash[:], dl.marker[:]) <= 0)
+}
+
+func (dl *diskLayer) get(accountHash thor.Bytes32, key []byte) ([]byte, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale {
+		return nil, errSnapshotStale
+	}
+	if !dl.covered(accountHash) {
+		return nil, errSnapshotNotCovered
+	}
+	v, err := dl.kv.Get(key)
+	if err != nil {
+		if dl.kv.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func (dl *diskLayer) account(hash thor.Bytes32) ([]byte, error) {
+	return dl.get(hash, snapshotAccountKey(hash))
+}
+
+func (dl *diskLayer) storage(accountHash, keyHash thor.Bytes32) ([]byte, error) {
+	return dl.get(accountHash, snapshotStorageKey(accountHash, keyHash))
+}
+
+// apply writes changes of the diff layer over it into kv, and returns the new disk layer at the
+// root of the diff layer. Changes of accounts not generated yet are skipped, since they are
+// generated from the trie of the new root later. This layer becomes stale.
+func (dl *diskLayer) apply(diff *diffLayer) (*diskLayer, error) {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	batch := dl.kv.NewBatch()
+	for hash := range diff.diff.destructs {
+		if !dl.covered(hash) {
+			continue
+		}
+		if err := deleteRange(dl.kv, batch, *kv.NewRangeWithBytesPrefix(snapshotAccountStorage(hash)), snapshotStorageKeyLen); err != nil {
+			return nil, err
+		}
+	}
+	for hash, blob := range diff.diff.accounts {
+		if !dl.covered(hash) {
+			continue
+		}
+		if err := putOrDelete(batch, snapshotAccountKey(hash), blob); err != nil {
+			return nil, err
+		}
+	}
+	for accountHash, values := range diff.diff.storage {
+		if !dl.covered(accountHash) {
+			continue
+		}
+		for keyHash, v := range values {
+			if err := putOrDelete(batch, snapshotStorageKey(accountHash, keyHash), v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := batch.Put(snapshotRootKey, diff.root[:]); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	dl.stale = true
+	return &diskLayer{
+		kv:     dl.kv,
+		root:   diff.root,
+		done:   dl.done,
+		marker: dl.marker,
+	}, nil
+}
+
+func putOrDelete(w kv.Putter, key, value []byte) error {
+	if len(value) == 0 {
+		return w.Delete(key)
+	}
+	return w.Put(key, value)
+}
+
+// deleteRange deletes entries in the range with the batch. Entries with key length other than keyLen are skipped.
+func deleteRange(r kv.Getter, batch kv.Putter, rng kv.Range, keyLen int) error {
+	it := r.NewIterator(rng)
+	defer it.Release()
+	for it.Next() {
+		if len(it.Key()) != keyLen {
+			continue
+		}
+		if err := batch.Delete(append([]byte(nil), it.Key()...)); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
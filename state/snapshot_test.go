@@ -0,0 +1,192 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+var (
+	snapAddrs = []thor.Address{
+		thor.BytesToAddress([]byte("a1")),
+		thor.BytesToAddress([]byte("a2")),
+		thor.BytesToAddress([]byte("a3")),
+	}
+	snapKeys = []thor.Bytes32{
+		thor.BytesToBytes32([]byte("k1")),
+		thor.BytesToBytes32([]byte("k2")),
+	}
+)
+
+func commitState(t *testing.T, st *State) thor.Bytes32 {
+	root, err := st.Stage().Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// assertSnapshot asserts accounts and storage values read from snapshot equal those from trie.
+func assertSnapshot(t *testing.T, db kv.GetPutter, creator *Creator, root thor.Bytes32) {
+	snapState, err := creator.NewState(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.NotNil(t, snapState.snap, "snapshot should be available") {
+		return
+	}
+	trieState, _ := New(root, db)
+	for _, addr := range snapAddrs {
+		snapAcc, err := snapshotAccount(snapState.snap, addr)
+		assert.Nil(t, err)
+		trieAcc, _ := loadAccount(trieState.trie, addr)
+		assert.Equal(t, trieAcc, snapAcc)
+
+		for _, key := range snapKeys {
+			assert.Equal(t, trieState.GetStorage(addr, key), snapState.GetStorage(addr, key))
+		}
+	}
+	assert.Nil(t, snapState.Err())
+}
+
+func TestSnapshots(t *testing.T) {
+	db, _ := lvldb.NewMem()
+
+	st, _ := New(thor.Bytes32{}, db)
+	for i, addr := range snapAddrs {
+		st.SetBalance(addr, big.NewInt(int64(i+1)))
+		st.SetCode(addr, []byte("code"))
+		for _, key := range snapKeys {
+			st.SetStorage(addr, key, thor.BytesToBytes32([]byte{byte(i + 1)}))
+		}
+	}
+	root0 := commitState(t, st)
+
+	snaps, err := NewSnapshots(db, root0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-snaps.genDone
+	creator := NewCreator(db).WithSnapshots(snaps)
+	assertSnapshot(t, db, creator, root0)
+
+	// diff layers
+	st, _ = creator.NewState(root0)
+	st.Delete(snapAddrs[0])
+	st.SetStorage(snapAddrs[1], snapKeys[0], thor.Bytes32{})
+	st.SetBalance(snapAddrs[2], big.NewInt(10))
+	root1 := commitState(t, st)
+	assertSnapshot(t, db, creator, root1)
+
+	// account recreated after deleted, with storage reset
+	st, _ = creator.NewState(root1)
+	st.SetBalance(snapAddrs[0], big.NewInt(1))
+	st.SetStorage(snapAddrs[0], snapKeys[1], thor.BytesToBytes32([]byte("new")))
+	root2 := commitState(t, st)
+	assertSnapshot(t, db, creator, root2)
+
+	// flattened into disk
+	snaps.lock.Lock()
+	err = snaps.capDiffs(snaps.layers[root2], 1)
+	snaps.lock.Unlock()
+	assert.Nil(t, err)
+	assert.Equal(t, root1, snaps.disk.root)
+	assert.Nil(t, snaps.layer(root0), "flattened layer dropped")
+	assertSnapshot(t, db, creator, root1)
+	assertSnapshot(t, db, creator, root2)
+
+	assert.Nil(t, snaps.Close(root2))
+
+	// reused
+	snaps, err = NewSnapshots(db, root2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, snaps.disk.done)
+	assertSnapshot(t, db, NewCreator(db).WithSnapshots(snaps), root2)
+	snaps.Close(root2)
+
+	// regenerated at another root, with entries left deleted
+	snaps, err = NewSnapshots(db, root0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-snaps.genDone
+	assertSnapshot(t, db, NewCreator(db).WithSnapshots(snaps), root0)
+	snaps.Close(root0)
+}
+
+func TestSnapshotsNotCovered(t *testing.T) {
+	db, _ := lvldb.NewMem()
+
+	st, _ := New(thor.Bytes32{}, db)
+	for i, addr := range snapAddrs {
+		st.SetBalance(addr, big.NewInt(int64(i+1)))
+	}
+	root := commitState(t, st)
+
+	// generation not started
+	disk, err := resetDiskLayer(db, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = snapshotAccount(disk, snapAddrs[0])
+	assert.Equal(t, errSnapshotNotCovered, err)
+
+	// reads fall back to trie
+	snaps := &Snapshots{kv: db, layers: map[thor.Bytes32]snapshotLayer{root: disk}, disk: disk}
+	st, _ = NewCreator(db).WithSnapshots(snaps).NewState(root)
+	assert.Equal(t, big.NewInt(1), st.GetBalance(snapAddrs[0]))
+
+	done, err := snaps.generateBatch(1)
+	assert.Nil(t, err)
+	assert.False(t, done)
+	done, err = snaps.generateBatch(len(snapAddrs))
+	assert.Nil(t, err)
+	assert.True(t, done)
+	for _, addr := range snapAddrs {
+		_, err := snapshotAccount(disk, addr)
+		assert.Nil(t, err)
+	}
+}
+
+func TestSnapshotsGenerateKeepHashKeys(t *testing.T) {
+	db, _ := lvldb.NewMem()
+
+	st, _ := New(thor.Bytes32{}, db)
+	for i, addr := range snapAddrs {
+		st.SetBalance(addr, big.NewInt(int64(i+1)))
+	}
+	root := commitState(t, st)
+
+	// hash-keyed entries, e.g. trie nodes, falling in ranges of snapshot entries
+	var hashKeys [][]byte
+	for _, prefix := range [][]byte{snapshotAccountPrefix, snapshotStoragePrefix} {
+		var key thor.Bytes32
+		copy(key[:], prefix)
+		hashKeys = append(hashKeys, key[:])
+		db.Put(key[:], []byte("node"))
+	}
+
+	snaps, err := NewSnapshots(db, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-snaps.genDone
+	snaps.Close(root)
+
+	for _, key := range hashKeys {
+		has, err := db.Has(key)
+		assert.Nil(t, err)
+		assert.True(t, has, "hash-keyed entry should be kept")
+	}
+}
@@ -6,11 +6,19 @@
 package state
 
 import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/trie"
 )
 
+var commitDurationHistogram = metric.NewHistogram("state", "commit_duration_seconds", "duration of committing stage", metric.DurationBuckets)
+
 // Stage abstracts changes on the main accounts trie.
 type Stage struct {
 	err error
@@ -19,6 +27,13 @@ type Stage struct {
 	accountTrie  *trie.SecureTrie
 	storageTries []*trie.SecureTrie
 	codes        []codeWithHash
+
+	snaps      *Snapshots
+	parentRoot thor.Bytes32
+	snapDiff   *snapshotDiff
+
+	sets     *WriteSets
+	writeSet *WriteSet
 }
 
 type codeWithHash struct {
@@ -26,7 +41,7 @@ type codeWithHash struct {
 	hash []byte
 }
 
-func newStage(root thor.Bytes32, kv kv.GetPutter, changes map[thor.Address]*changedObject) *Stage {
+func newStage(root thor.Bytes32, kv kv.GetPutter, snaps *Snapshots, sets *WriteSets, changes map[thor.Address]*changedObject) *Stage {
 
 	accountTrie, err := trCache.Get(root, kv, true)
 	if err != nil {
@@ -35,9 +50,22 @@ func newStage(root thor.Bytes32, kv kv.GetPutter, changes map[thor.Address]*chan
 
 	storageTries := make([]*trie.SecureTrie, 0, len(changes))
 	codes := make([]codeWithHash, 0, len(changes))
+	var snapDiff *snapshotDiff
+	if snaps != nil {
+		snapDiff = newSnapshotDiff()
+	}
+	var writeSet *WriteSet
+	if sets != nil {
+		writeSet = &WriteSet{ParentRoot: root, Accounts: make(map[thor.Address]*AccountWrite)}
+	}
 
 	for addr, obj := range changes {
 		dataCpy := obj.data
+		addrHash := thor.Blake2b(addr[:])
+		if snapDiff != nil && (dataCpy.IsEmpty() || !bytes.Equal(obj.base.StorageRoot, dataCpy.StorageRoot)) {
+			// storage abandoned, as the account deleted or its storage root reset
+			snapDiff.destructs[addrHash] = struct{}{}
+		}
 
 		if len(obj.code) > 0 {
 			codes = append(codes, codeWithHash{
@@ -58,19 +86,54 @@ func newStage(root thor.Bytes32, kv kv.GetPutter, changes map[thor.Address]*chan
 						return &Stage{err: err}
 					}
 				}
+				if snapDiff != nil {
+					values := make(map[thor.Bytes32][]byte, len(obj.storage))
+					for k, v := range obj.storage {
+						values[thor.Blake2b(k[:])] = v
+					}
+					snapDiff.storage[addrHash] = values
+				}
 				dataCpy.StorageRoot = strie.Hash().Bytes()
 			}
 		}
 
+		if writeSet != nil {
+			var keys []thor.Bytes32
+			if !dataCpy.IsEmpty() {
+				for k := range obj.storage {
+					keys = append(keys, k)
+				}
+				sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+			}
+			if len(keys) > 0 || !obj.base.equal(&dataCpy) {
+				base, data := obj.base, dataCpy
+				writeSet.Accounts[addr] = &AccountWrite{&base, &data, keys}
+			}
+		}
+
 		if err := saveAccount(accountTrie, addr, &dataCpy); err != nil {
 			return &Stage{err: err}
 		}
+		if snapDiff != nil {
+			var blob []byte
+			if !dataCpy.IsEmpty() {
+				if blob, err = rlp.EncodeToBytes(&dataCpy); err != nil {
+					return &Stage{err: err}
+				}
+			}
+			snapDiff.accounts[addrHash] = blob
+		}
 	}
 	return &Stage{
 		kv:           kv,
 		accountTrie:  accountTrie,
 		storageTries: storageTries,
 		codes:        codes,
+		snaps:        snaps,
+		parentRoot:   root,
+		snapDiff:     snapDiff,
+		sets:         sets,
+		writeSet:     writeSet,
 	}
 }
 
@@ -87,6 +150,8 @@ func (s *Stage) Commit() (thor.Bytes32, error) {
 	if s.err != nil {
 		return thor.Bytes32{}, s.err
 	}
+	defer func(start time.Time) { commitDurationHistogram.Observe(time.Since(start).Seconds()) }(time.Now())
+
 	batch := s.kv.NewBatch()
 	// write codes
 	for _, code := range s.codes {
@@ -116,5 +181,15 @@ func (s *Stage) Commit() (thor.Bytes32, error) {
 
 	trCache.Add(root, s.accountTrie, s.kv)
 
+	if s.snaps != nil {
+		if err := s.snaps.update(root, s.parentRoot, s.snapDiff); err != nil {
+			// the trie is committed, and reads fall back to it if snapshot unavailable
+			log.Warn("failed to update snapshot", "err", err)
+		}
+	}
+	if s.sets != nil {
+		s.writeSet.Root = root
+		s.sets.add(s.writeSet)
+	}
 	return root, nil
 }
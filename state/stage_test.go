@@ -44,6 +44,14 @@ func TestStage(t *testing.T) {
 
 	assert.Equal(t, hash, root)
 
+	has, err := HasRoot(root, kv)
+	assert.Nil(t, err)
+	assert.True(t, has)
+
+	has, err = HasRoot(thor.BytesToBytes32([]byte("missing")), kv)
+	assert.Nil(t, err)
+	assert.False(t, has)
+
 	state, _ = New(root, kv)
 
 	assert.Equal(t, balance, state.GetBalance(addr))
@@ -13,12 +13,16 @@ import (
 	"github.com/vechain/thor/kv"
 	"github.com/vechain/thor/stackedmap"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
 )
 
 // State manages the main accounts trie.
 type State struct {
 	root     thor.Bytes32 // root of initial accounts trie
 	kv       kv.GetPutter
+	snaps    *Snapshots
+	sets     *WriteSets
+	snap     snapshotLayer                  // snapshot at root, nil if not available
 	trie     trieReader                     // the accounts trie reader
 	cache    map[thor.Address]*cachedObject // cache of accounts trie
 	sm       *stackedmap.StackedMap         // keeps revisions of accounts state
@@ -39,6 +43,10 @@ type trieWriter interface {
 
 // New create an state object.
 func New(root thor.Bytes32, kv kv.GetPutter) (*State, error) {
+	return newState(root, kv, nil, nil)
+}
+
+func newState(root thor.Bytes32, kv kv.GetPutter, snaps *Snapshots, sets *WriteSets) (*State, error) {
 	trie, err := trCache.Get(root, kv, false)
 	if err != nil {
 		return nil, err
@@ -47,6 +55,9 @@ func New(root thor.Bytes32, kv kv.GetPutter) (*State, error) {
 	state := State{
 		root:  root,
 		kv:    kv,
+		snaps: snaps,
+		sets:  sets,
+		snap:  snaps.layer(root),
 		trie:  trie,
 		cache: make(map[thor.Address]*cachedObject),
 	}
@@ -106,7 +117,8 @@ func (s *State) changes() map[thor.Address]*changedObject {
 		if obj, ok := changes[addr]; ok {
 			return obj
 		}
-		obj := &changedObject{data: s.getCachedObject(addr).data}
+		data := s.getCachedObject(addr).data
+		obj := &changedObject{data: data, base: data}
 		changes[addr] = obj
 		return obj
 	}
@@ -135,16 +147,31 @@ func (s *State) getCachedObject(addr thor.Address) *cachedObject {
 	if co, ok := s.cache[addr]; ok {
 		return co
 	}
-	a, err := loadAccount(s.trie, addr)
+	a, err := s.loadAccount(addr)
 	if err != nil {
 		s.setError(err)
 		return newCachedObject(s.kv, emptyAccount())
 	}
 	co := newCachedObject(s.kv, a)
+	if s.snap != nil {
+		co.snap, co.addrHash = s.snap, thor.Blake2b(addr[:])
+	}
 	s.cache[addr] = co
 	return co
 }
 
+// loadAccount loads the account from the snapshot if available, otherwise from the trie.
+func (s *State) loadAccount(addr thor.Address) (*Account, error) {
+	if s.snap != nil {
+		if a, err := snapshotAccount(s.snap, addr); err == nil {
+			snapshotHitCounter.Inc()
+			return a, nil
+		}
+		snapshotMissCounter.Inc()
+	}
+	return loadAccount(s.trie, addr)
+}
+
 // the returned account should not be modified
 func (s *State) getAccount(addr thor.Address) *Account {
 	v, _ := s.sm.Get(addr)
@@ -206,6 +233,41 @@ func (s *State) updateAccount(addr thor.Address, acc *Account) {
 // 	}
 // }
 
+// StorageEntry is an entry of account storage, keyed by the hashed storage key.
+type StorageEntry struct {
+	HashedKey thor.Bytes32
+	Value     []byte // rlp encoded raw value
+}
+
+// StorageRange returns at most maxResult storage entries of the given address, starting
+// from the hashed key 'start'. The hashed key of the next entry is returned if more remained.
+// It walks the committed storage trie of the account, so that uncommitted changes are not reflected.
+// It's for debug purpose.
+func (s *State) StorageRange(addr thor.Address, start thor.Bytes32, maxResult int) ([]StorageEntry, *thor.Bytes32, error) {
+	co := s.getCachedObject(addr)
+	strie, err := trCache.Get(thor.BytesToBytes32(co.data.StorageRoot), s.kv, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []StorageEntry
+	iter := trie.NewIterator(strie.NodeIterator(start[:]))
+	for iter.Next() {
+		key := thor.BytesToBytes32(iter.Key)
+		if len(entries) >= maxResult {
+			return entries, &key, nil
+		}
+		entries = append(entries, StorageEntry{
+			key,
+			append([]byte(nil), iter.Value...),
+		})
+	}
+	if iter.Err != nil {
+		return nil, nil, iter.Err
+	}
+	return entries, nil, nil
+}
+
 // Err returns first occurred error.
 func (s *State) Err() error {
 	return s.err
@@ -235,6 +297,12 @@ func (s *State) SetEnergy(addr thor.Address, energy *big.Int, blockTime uint64)
 	s.updateAccount(addr, &cpy)
 }
 
+// TouchEnergy settles energy grown since the last update for the given address, at block time specified.
+// It SHOULD be called before balance changed, otherwise the new balance is applied to the elapsed period.
+func (s *State) TouchEnergy(addr thor.Address, blockTime uint64) {
+	s.SetEnergy(addr, s.GetEnergy(addr, blockTime), blockTime)
+}
+
 // GetMaster get master for the given address.
 // Master can move energy, manage users...
 func (s *State) GetMaster(addr thor.Address) thor.Address {
@@ -384,7 +452,7 @@ func (s *State) Stage() *Stage {
 	if s.err != nil {
 		return &Stage{err: s.err}
 	}
-	return newStage(s.root, s.kv, changes)
+	return newStage(s.root, s.kv, s.snaps, s.sets, changes)
 }
 
 type (
@@ -395,6 +463,7 @@ type (
 	codeKey       thor.Address
 	changedObject struct {
 		data    Account
+		base    Account // account at the initial state
 		storage map[thor.Bytes32][]byte
 		code    []byte
 	}
@@ -6,10 +6,12 @@
 package state
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/lvldb"
 	"github.com/vechain/thor/thor"
@@ -121,3 +123,102 @@ func TestEnergy(t *testing.T) {
 
 	assert.Equal(t, x, bal1)
 }
+
+func TestTouchEnergy(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	st, _ := New(thor.Bytes32{}, kv)
+
+	acc := thor.BytesToAddress([]byte("a1"))
+	vetBal := big.NewInt(1e18)
+	st.SetBalance(acc, vetBal)
+	st.SetEnergy(acc, &big.Int{}, 10)
+
+	// balance doubled at 1000, after energy settled
+	st.TouchEnergy(acc, 1000)
+	st.SetBalance(acc, new(big.Int).Mul(vetBal, big.NewInt(2)))
+
+	grown := func(bal *big.Int, dt uint64) *big.Int {
+		x := new(big.Int).Mul(thor.EnergyGrowthRate, bal)
+		x.Mul(x, new(big.Int).SetUint64(dt))
+		return x.Div(x, big.NewInt(1e18))
+	}
+	expected := new(big.Int).Add(grown(vetBal, 990), grown(new(big.Int).Mul(vetBal, big.NewInt(2)), 1000))
+	assert.Equal(t, expected, st.GetEnergy(acc, 2000))
+
+	// touch is idempotent at the same time
+	st.TouchEnergy(acc, 2000)
+	st.TouchEnergy(acc, 2000)
+	assert.Equal(t, expected, st.GetEnergy(acc, 2000))
+}
+
+func TestStorageRange(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	st, _ := New(thor.Bytes32{}, kv)
+
+	addr := thor.BytesToAddress([]byte("account1"))
+	st.SetBalance(addr, big.NewInt(1))
+	for i := 1; i <= 3; i++ {
+		st.SetStorage(addr, thor.BytesToBytes32([]byte{byte(i)}), thor.BytesToBytes32([]byte{byte(i)}))
+	}
+	root, err := st.Stage().Commit()
+	assert.Nil(t, err)
+
+	st, _ = New(root, kv)
+	// uncommitted changes are not reflected
+	st.SetStorage(addr, thor.BytesToBytes32([]byte{4}), thor.BytesToBytes32([]byte{4}))
+
+	entries, next, err := st.StorageRange(addr, thor.Bytes32{}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(entries))
+	assert.NotNil(t, next)
+	assert.True(t, bytes.Compare(entries[0].HashedKey[:], entries[1].HashedKey[:]) < 0)
+
+	rest, next, err := st.StorageRange(addr, *next, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rest))
+	assert.Nil(t, next)
+
+	values := make(map[thor.Bytes32]bool)
+	for _, e := range append(entries, rest...) {
+		var v []byte
+		assert.Nil(t, rlp.DecodeBytes(e.Value, &v))
+		values[thor.BytesToBytes32(v)] = true
+	}
+	assert.Equal(t, 3, len(values))
+
+	entries, next, err = st.StorageRange(thor.BytesToAddress([]byte("account2")), thor.Bytes32{}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(entries))
+	assert.Nil(t, next)
+}
+
+func TestProve(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	st, _ := New(thor.Bytes32{}, kv)
+
+	addr := thor.BytesToAddress([]byte("account1"))
+	st.SetBalance(addr, big.NewInt(1))
+	for i := 1; i <= 3; i++ {
+		st.SetStorage(addr, thor.BytesToBytes32([]byte{byte(i)}), thor.BytesToBytes32([]byte{byte(i)}))
+	}
+	st.SetBalance(thor.BytesToAddress([]byte("account2")), big.NewInt(2))
+	root, err := st.Stage().Commit()
+	assert.Nil(t, err)
+
+	proofDB, _ := lvldb.NewMem()
+	keys := []thor.Bytes32{thor.BytesToBytes32([]byte{1}), thor.BytesToBytes32([]byte{4})}
+	assert.Nil(t, Prove(root, kv, addr, keys, proofDB))
+
+	st, err = New(root, proofDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, big.NewInt(1), st.GetBalance(addr))
+	assert.Equal(t, thor.BytesToBytes32([]byte{1}), st.GetStorage(addr, keys[0]))
+	assert.Equal(t, thor.Bytes32{}, st.GetStorage(addr, keys[1]))
+	assert.Nil(t, st.Err())
+
+	empty, _ := lvldb.NewMem()
+	_, err = New(root, empty)
+	assert.NotNil(t, err, "root node should be missing")
+}
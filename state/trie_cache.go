@@ -8,12 +8,27 @@ package state
 import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/trie"
 )
 
 var trCache = newTrieCache()
 
+var (
+	trieCacheHitCounter  = metric.NewCounter("state", "trie_cache_hits_total", "count of tries got from trie cache")
+	trieCacheMissCounter = metric.NewCounter("state", "trie_cache_misses_total", "count of tries loaded on trie cache miss")
+)
+
+func init() {
+	metric.NewCounterFunc("state", "trie_node_cache_misses_total", "count of trie nodes resolved from db", func() float64 {
+		return float64(trie.CacheMisses())
+	})
+	metric.NewCounterFunc("state", "trie_node_cache_unloads_total", "count of trie nodes unloaded from memory", func() float64 {
+		return float64(trie.CacheUnloads())
+	})
+}
+
 type trieCache struct {
 	cache *lru.Cache
 }
@@ -34,12 +49,14 @@ func (tc *trieCache) Get(root thor.Bytes32, kv kv.GetPutter, copy bool) (*trie.S
 	if v, ok := tc.cache.Get(root); ok {
 		entry := v.(*trieCacheEntry)
 		if entry.kv == kv {
+			trieCacheHitCounter.Inc()
 			if copy {
 				return entry.trie.Copy(), nil
 			}
 			return entry.trie, nil
 		}
 	}
+	trieCacheMissCounter.Inc()
 	tr, err := trie.NewSecure(root, kv, 16)
 	if err != nil {
 		return nil, err
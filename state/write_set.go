@@ -0,0 +1,59 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/vechain/thor/thor"
+)
+
+// writeSetsLimit max number of write-sets kept, about blocks subscribers can backtrace.
+const writeSetsLimit = 1024
+
+// AccountWrite an account written by a commit.
+type AccountWrite struct {
+	Old         *Account       // the account before
+	New         *Account       // the account after, empty if deleted
+	StorageKeys []thor.Bytes32 // keys of storage values written, sorted
+}
+
+// WriteSet accounts written by a commit, from the state at ParentRoot to the one at Root.
+// Accounts whose data and storage not written are excluded, even if touched.
+type WriteSet struct {
+	ParentRoot thor.Bytes32
+	Root       thor.Bytes32
+	Accounts   map[thor.Address]*AccountWrite
+}
+
+type writeSetKey struct {
+	parentRoot thor.Bytes32
+	root       thor.Bytes32
+}
+
+// WriteSets keeps write-sets of recent commits in memory, so that changes made by a block are
+// known from execution, without comparing states.
+type WriteSets struct {
+	cache *lru.Cache
+}
+
+// NewWriteSets create write-sets keeping a limited number of recent ones.
+func NewWriteSets() *WriteSets {
+	cache, _ := lru.New(writeSetsLimit)
+	return &WriteSets{cache}
+}
+
+func (ws *WriteSets) add(set *WriteSet) {
+	ws.cache.Add(writeSetKey{set.ParentRoot, set.Root}, set)
+}
+
+// Get returns the write-set of the commit from the state at parentRoot to the one at root,
+// or nil if not kept. The returned write-set should not be modified.
+func (ws *WriteSets) Get(parentRoot, root thor.Bytes32) *WriteSet {
+	if v, ok := ws.cache.Get(writeSetKey{parentRoot, root}); ok {
+		return v.(*WriteSet)
+	}
+	return nil
+}
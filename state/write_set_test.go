@@ -0,0 +1,65 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/thor"
+)
+
+func TestWriteSets(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	creator := NewCreator(db).WithWriteSets(NewWriteSets())
+
+	addr1 := thor.BytesToAddress([]byte("addr1"))
+	addr2 := thor.BytesToAddress([]byte("addr2"))
+	addr3 := thor.BytesToAddress([]byte("addr3"))
+	k1 := thor.BytesToBytes32([]byte("k1"))
+	k2 := thor.BytesToBytes32([]byte("k2"))
+
+	st, _ := creator.NewState(thor.Bytes32{})
+	st.SetBalance(addr1, big.NewInt(1))
+	st.SetBalance(addr2, big.NewInt(2))
+	root0, err := st.Stage().Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, _ = creator.NewState(root0)
+	st.SetBalance(addr1, big.NewInt(10))
+	st.SetStorage(addr2, k2, thor.BytesToBytes32([]byte("v2")))
+	st.SetStorage(addr2, k1, thor.BytesToBytes32([]byte("v1")))
+	// touched but not changed
+	st.SetBalance(addr3, big.NewInt(0))
+	root1, err := st.Stage().Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws := creator.WriteSet(root0, root1)
+	if !assert.NotNil(t, ws) {
+		return
+	}
+	assert.Equal(t, root0, ws.ParentRoot)
+	assert.Equal(t, root1, ws.Root)
+	assert.Equal(t, 2, len(ws.Accounts))
+
+	w1 := ws.Accounts[addr1]
+	assert.Equal(t, big.NewInt(1), w1.Old.Balance)
+	assert.Equal(t, big.NewInt(10), w1.New.Balance)
+	assert.Empty(t, w1.StorageKeys)
+
+	w2 := ws.Accounts[addr2]
+	assert.Equal(t, w2.Old.Balance, w2.New.Balance)
+	assert.Equal(t, []thor.Bytes32{k1, k2}, w2.StorageKeys)
+
+	assert.Nil(t, creator.WriteSet(root1, root0))
+	assert.Nil(t, NewCreator(db).WriteSet(root0, root1))
+}
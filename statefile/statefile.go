@@ -0,0 +1,311 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package statefile defines a portable snapshot format of the full state at a block.
+//
+// A snapshot is a stream of RLP values, a header carrying the block header, whose state root
+// the snapshot is verified against, followed by checksummed chunks of entries. Entries are
+// accounts, storage slots and codes. Accounts and storage slots are keyed by hashed keys as
+// they are in tries, and storage slots and the code follow the account they belong to.
+package statefile
+
+import (
+	"context"
+	"io"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/block"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+// Version version of snapshot format.
+const Version = 1
+
+const (
+	chunkSize       = 1024 // max number of entries in a chunk
+	importBatchSize = 4096 // number of kvs written in a batch while importing
+)
+
+const (
+	entryAccount = iota
+	entryStorage
+	entryCode
+)
+
+var emptyRoot = thor.Blake2b(rlp.EmptyString)
+
+// Header header of snapshot.
+type Header struct {
+	Version   uint32
+	GenesisID thor.Bytes32
+	Block     *block.Header
+}
+
+type entry struct {
+	Kind  uint8
+	Key   []byte // hashed key of account or storage slot, empty for code
+	Value []byte
+}
+
+type chunk struct {
+	Entries  []*entry
+	Checksum thor.Bytes32 // blake2b hash of encoded entries
+}
+
+func checksum(entries []*entry) (thor.Bytes32, error) {
+	data, err := rlp.EncodeToBytes(entries)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return thor.Blake2b(data), nil
+}
+
+type chunkWriter struct {
+	w       io.Writer
+	entries []*entry
+}
+
+func (cw *chunkWriter) add(kind uint8, key, value []byte) error {
+	cw.entries = append(cw.entries, &entry{
+		kind,
+		append([]byte(nil), key...),
+		append([]byte(nil), value...),
+	})
+	if len(cw.entries) >= chunkSize {
+		return cw.flush()
+	}
+	return nil
+}
+
+func (cw *chunkWriter) flush() error {
+	if len(cw.entries) == 0 {
+		return nil
+	}
+	sum, err := checksum(cw.entries)
+	if err != nil {
+		return err
+	}
+	if err := rlp.Encode(cw.w, &chunk{cw.entries, sum}); err != nil {
+		return err
+	}
+	cw.entries = nil
+	return nil
+}
+
+// Export writes snapshot of the state at the block. progress is called after each account written.
+func Export(ctx context.Context, kv kv.GetPutter, w io.Writer, genesisID thor.Bytes32, header *block.Header, progress func(accounts int)) error {
+	if err := rlp.Encode(w, &Header{Version, genesisID, header}); err != nil {
+		return err
+	}
+
+	accountTrie, err := trie.New(header.StateRoot(), kv)
+	if err != nil {
+		return err
+	}
+	cw := &chunkWriter{w: w}
+	codes := make(map[thor.Bytes32]bool)
+	accounts := 0
+
+	it := trie.NewIterator(accountTrie.NodeIterator(nil))
+	for it.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var acc state.Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return errors.Wrap(err, "decode account")
+		}
+		if err := cw.add(entryAccount, it.Key, it.Value); err != nil {
+			return err
+		}
+
+		// codes shared by accounts are written once
+		if codeHash := thor.BytesToBytes32(acc.CodeHash); len(acc.CodeHash) > 0 && !codes[codeHash] {
+			code, err := kv.Get(acc.CodeHash)
+			if err != nil {
+				return errors.Wrap(err, "load code")
+			}
+			if err := cw.add(entryCode, nil, code); err != nil {
+				return err
+			}
+			codes[codeHash] = true
+		}
+
+		if len(acc.StorageRoot) > 0 {
+			storageTrie, err := trie.New(thor.BytesToBytes32(acc.StorageRoot), kv)
+			if err != nil {
+				return err
+			}
+			sit := trie.NewIterator(storageTrie.NodeIterator(nil))
+			for sit.Next() {
+				if err := cw.add(entryStorage, sit.Key, sit.Value); err != nil {
+					return err
+				}
+			}
+			if sit.Err != nil {
+				return sit.Err
+			}
+		}
+
+		accounts++
+		if progress != nil {
+			progress(accounts)
+		}
+	}
+	if it.Err != nil {
+		return it.Err
+	}
+	return cw.flush()
+}
+
+// Reader reads snapshot.
+type Reader struct {
+	s      *rlp.Stream
+	header Header
+}
+
+// NewReader create a reader, and reads the snapshot header.
+func NewReader(r io.Reader) (*Reader, error) {
+	s := rlp.NewStream(r, 0)
+
+	var header Header
+	if err := s.Decode(&header); err != nil {
+		return nil, errors.Wrap(err, "read header")
+	}
+	if header.Version != Version {
+		return nil, errors.Errorf("unsupported version %v", header.Version)
+	}
+	return &Reader{s, header}, nil
+}
+
+// Header returns the snapshot header.
+func (r *Reader) Header() *Header {
+	return &r.header
+}
+
+// Import rebuilds tries from entries into kv, and verifies the state root against the block header.
+// The account trie is written last, so the state root is not found in kv unless the snapshot
+// is completely imported. progress is called after each chunk.
+func (r *Reader) Import(ctx context.Context, kv kv.GetPutter, progress func(accounts int)) error {
+	accountTrie, err := trie.New(thor.Bytes32{}, kv)
+	if err != nil {
+		return err
+	}
+
+	var (
+		batch       = kv.NewBatch()
+		codes       = make(map[thor.Bytes32]bool)
+		accounts    int
+		acc         *state.Account
+		storageTrie *trie.Trie
+	)
+	// finishes the account, whose storage and code should be all read
+	finish := func() error {
+		if acc == nil {
+			return nil
+		}
+		want := emptyRoot
+		if len(acc.StorageRoot) > 0 {
+			want = thor.BytesToBytes32(acc.StorageRoot)
+		}
+		root, err := storageTrie.CommitTo(batch)
+		if err != nil {
+			return err
+		}
+		if root != want {
+			return errors.Errorf("storage root mismatch: want %v, have %v", want, root)
+		}
+		if len(acc.CodeHash) > 0 && !codes[thor.BytesToBytes32(acc.CodeHash)] {
+			return errors.New("code missing")
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var c chunk
+		if err := r.s.Decode(&c); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.Wrap(err, "read chunk")
+		}
+		if sum, err := checksum(c.Entries); err != nil {
+			return err
+		} else if sum != c.Checksum {
+			return errors.New("chunk checksum mismatch")
+		}
+
+		for _, e := range c.Entries {
+			switch e.Kind {
+			case entryAccount:
+				if err := finish(); err != nil {
+					return err
+				}
+				acc = new(state.Account)
+				if err := rlp.DecodeBytes(e.Value, acc); err != nil {
+					return errors.Wrap(err, "decode account")
+				}
+				if err := accountTrie.TryUpdate(e.Key, e.Value); err != nil {
+					return err
+				}
+				if storageTrie, err = trie.New(thor.Bytes32{}, kv); err != nil {
+					return err
+				}
+				accounts++
+			case entryStorage:
+				if acc == nil {
+					return errors.New("storage entry without account")
+				}
+				if err := storageTrie.TryUpdate(e.Key, e.Value); err != nil {
+					return err
+				}
+			case entryCode:
+				hash := thor.BytesToBytes32(crypto.Keccak256(e.Value))
+				if err := batch.Put(hash[:], e.Value); err != nil {
+					return err
+				}
+				codes[hash] = true
+			default:
+				return errors.Errorf("unknown entry kind %v", e.Kind)
+			}
+		}
+
+		if batch.Len() >= importBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch = kv.NewBatch()
+		}
+		if progress != nil {
+			progress(accounts)
+		}
+	}
+	if err := finish(); err != nil {
+		return err
+	}
+
+	root, err := accountTrie.CommitTo(batch)
+	if err != nil {
+		return err
+	}
+	if want := r.header.Block.StateRoot(); root != want {
+		return errors.Errorf("state root mismatch: want %v, have %v", want, root)
+	}
+	return batch.Write()
+}
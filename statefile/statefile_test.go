@@ -0,0 +1,100 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package statefile_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/statefile"
+	"github.com/vechain/thor/thor"
+)
+
+func TestExportImport(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b0, _, err := gene.Build(state.NewCreator(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := b0.Header()
+
+	var buf bytes.Buffer
+	var exported int
+	assert.Nil(t, statefile.Export(context.Background(), db, &buf, gene.ID(), header, func(accounts int) {
+		exported = accounts
+	}))
+	assert.True(t, exported > 0)
+
+	r, err := statefile.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, gene.ID(), r.Header().GenesisID)
+	assert.Equal(t, header.ID(), r.Header().Block.ID())
+
+	db2, _ := lvldb.NewMem()
+	var imported int
+	assert.Nil(t, r.Import(context.Background(), db2, func(accounts int) {
+		imported = accounts
+	}))
+	assert.Equal(t, exported, imported)
+
+	has, err := state.HasRoot(header.StateRoot(), db2)
+	assert.Nil(t, err)
+	assert.True(t, has)
+
+	st1, _ := state.New(header.StateRoot(), db)
+	st2, err := state.New(header.StateRoot(), db2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, acc := range genesis.DevAccounts() {
+		assert.Equal(t, st1.GetBalance(acc.Address), st2.GetBalance(acc.Address))
+	}
+	assert.Equal(t, st1.GetCode(builtin.Params.Address), st2.GetCode(builtin.Params.Address))
+	assert.Equal(t,
+		st1.GetStorage(builtin.Params.Address, thor.KeyBaseGasPrice),
+		st2.GetStorage(builtin.Params.Address, thor.KeyBaseGasPrice))
+	assert.Nil(t, st2.Err())
+}
+
+func TestImportCorrupted(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b0, _, err := gene.Build(state.NewCreator(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, statefile.Export(context.Background(), db, &buf, gene.ID(), b0.Header(), nil))
+
+	data := buf.Bytes()
+	data[len(data)-40] ^= 0xff
+
+	r, err := statefile.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db2, _ := lvldb.NewMem()
+	assert.NotNil(t, r.Import(context.Background(), db2, nil))
+
+	has, _ := state.HasRoot(b0.Header().StateRoot(), db2)
+	assert.False(t, has)
+}
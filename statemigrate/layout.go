@@ -0,0 +1,278 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package statemigrate
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+var emptyRoot = thor.Blake2b(rlp.EmptyString)
+
+// Layout a trie layout the state is stored in.
+type Layout interface {
+	Name() string
+	// NewBuilder returns a builder writing the state in the layout into kv.
+	NewBuilder(kv kv.GetPutter) (Builder, error)
+	// Open opens the state in the layout at the root.
+	Open(kv kv.GetPutter, root thor.Bytes32) (Reader, error)
+}
+
+// Builder builds the state account by account. Storage slots put belong to the last account put.
+// Accounts and storage slots are keyed by hashed keys, and put in order of keys.
+type Builder interface {
+	PutAccount(addrHash thor.Bytes32, acc *state.Account) error
+	PutStorage(keyHash thor.Bytes32, value []byte) error
+	// Commit writes all built into kv and returns the state root.
+	Commit() (thor.Bytes32, error)
+}
+
+// Reader reads the state. A nil account or value means not existent.
+// StorageRoot of accounts read is layout specific.
+type Reader interface {
+	Account(addrHash thor.Bytes32) (*state.Account, error)
+	Storage(addrHash, keyHash thor.Bytes32) ([]byte, error)
+}
+
+// Layouts returns all known layouts.
+func Layouts() []Layout {
+	return []Layout{Legacy, Unified}
+}
+
+// LayoutByName returns the layout of the name, or nil if not found.
+func LayoutByName(name string) Layout {
+	for _, l := range Layouts() {
+		if l.Name() == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// Legacy the current layout, an account trie with a storage trie per account.
+// Migrating into it rebuilds the state, and results in the same root.
+var Legacy Layout = legacyLayout{}
+
+type legacyLayout struct{}
+
+func (legacyLayout) Name() string { return "legacy" }
+
+func (legacyLayout) NewBuilder(kv kv.GetPutter) (Builder, error) {
+	accountTrie, err := trie.New(thor.Bytes32{}, kv)
+	if err != nil {
+		return nil, err
+	}
+	return &legacyBuilder{kv: kv, batch: kv.NewBatch(), accountTrie: accountTrie}, nil
+}
+
+func (legacyLayout) Open(kv kv.GetPutter, root thor.Bytes32) (Reader, error) {
+	accountTrie, err := trie.New(root, kv)
+	if err != nil {
+		return nil, err
+	}
+	return &legacyReader{kv, accountTrie}, nil
+}
+
+type legacyBuilder struct {
+	kv          kv.GetPutter
+	batch       kv.Batch
+	accountTrie *trie.Trie
+
+	addrHash    thor.Bytes32
+	acc         *state.Account
+	storageTrie *trie.Trie
+}
+
+// finish saves the last account put, with the storage root rebuilt.
+func (b *legacyBuilder) finish() error {
+	if b.acc == nil {
+		return nil
+	}
+	root, err := b.storageTrie.CommitTo(b.batch)
+	if err != nil {
+		return err
+	}
+	acc := *b.acc
+	if root == emptyRoot {
+		acc.StorageRoot = nil
+	} else {
+		acc.StorageRoot = root[:]
+	}
+	data, err := rlp.EncodeToBytes(&acc)
+	if err != nil {
+		return err
+	}
+	if err := b.accountTrie.TryUpdate(b.addrHash[:], data); err != nil {
+		return err
+	}
+	b.acc = nil
+	if b.batch.Len() >= batchSize {
+		if err := b.batch.Write(); err != nil {
+			return err
+		}
+		b.batch = b.kv.NewBatch()
+	}
+	return nil
+}
+
+func (b *legacyBuilder) PutAccount(addrHash thor.Bytes32, acc *state.Account) (err error) {
+	if err := b.finish(); err != nil {
+		return err
+	}
+	if b.storageTrie, err = trie.New(thor.Bytes32{}, b.kv); err != nil {
+		return err
+	}
+	b.addrHash, b.acc = addrHash, acc
+	return nil
+}
+
+func (b *legacyBuilder) PutStorage(keyHash thor.Bytes32, value []byte) error {
+	if b.acc == nil {
+		return errors.New("storage put without account")
+	}
+	return b.storageTrie.TryUpdate(keyHash[:], value)
+}
+
+func (b *legacyBuilder) Commit() (thor.Bytes32, error) {
+	if err := b.finish(); err != nil {
+		return thor.Bytes32{}, err
+	}
+	root, err := b.accountTrie.CommitTo(b.batch)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return root, b.batch.Write()
+}
+
+type legacyReader struct {
+	kv          kv.GetPutter
+	accountTrie *trie.Trie
+}
+
+func (r *legacyReader) Account(addrHash thor.Bytes32) (*state.Account, error) {
+	data, err := r.accountTrie.TryGet(addrHash[:])
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	var acc state.Account
+	if err := rlp.DecodeBytes(data, &acc); err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
+
+func (r *legacyReader) Storage(addrHash, keyHash thor.Bytes32) ([]byte, error) {
+	acc, err := r.Account(addrHash)
+	if err != nil || acc == nil || len(acc.StorageRoot) == 0 {
+		return nil, err
+	}
+	storageTrie, err := trie.New(thor.BytesToBytes32(acc.StorageRoot), r.kv)
+	if err != nil {
+		return nil, err
+	}
+	return storageTrie.TryGet(keyHash[:])
+}
+
+// Unified a single trie for all accounts and storage slots, as a step toward single-tree
+// schemes. Accounts are keyed by address hashes, and storage slots by address hashes
+// followed by key hashes. Accounts are re-encoded without storage roots.
+var Unified Layout = unifiedLayout{}
+
+// unifiedAccount account encoding of the unified layout.
+type unifiedAccount struct {
+	Balance   *big.Int
+	Energy    *big.Int
+	BlockTime uint64
+	Master    []byte
+	CodeHash  []byte
+}
+
+type unifiedLayout struct{}
+
+func (unifiedLayout) Name() string { return "unified" }
+
+func (unifiedLayout) NewBuilder(kv kv.GetPutter) (Builder, error) {
+	tr, err := trie.New(thor.Bytes32{}, kv)
+	if err != nil {
+		return nil, err
+	}
+	return &unifiedBuilder{tr: tr}, nil
+}
+
+func (unifiedLayout) Open(kv kv.GetPutter, root thor.Bytes32) (Reader, error) {
+	tr, err := trie.New(root, kv)
+	if err != nil {
+		return nil, err
+	}
+	return &unifiedReader{tr}, nil
+}
+
+func unifiedStorageKey(addrHash, keyHash thor.Bytes32) []byte {
+	return append(append([]byte(nil), addrHash[:]...), keyHash[:]...)
+}
+
+type unifiedBuilder struct {
+	tr       *trie.Trie
+	addrHash *thor.Bytes32
+}
+
+func (b *unifiedBuilder) PutAccount(addrHash thor.Bytes32, acc *state.Account) error {
+	data, err := rlp.EncodeToBytes(&unifiedAccount{
+		acc.Balance,
+		acc.Energy,
+		acc.BlockTime,
+		acc.Master,
+		acc.CodeHash,
+	})
+	if err != nil {
+		return err
+	}
+	b.addrHash = &addrHash
+	return b.tr.TryUpdate(addrHash[:], data)
+}
+
+func (b *unifiedBuilder) PutStorage(keyHash thor.Bytes32, value []byte) error {
+	if b.addrHash == nil {
+		return errors.New("storage put without account")
+	}
+	return b.tr.TryUpdate(unifiedStorageKey(*b.addrHash, keyHash), value)
+}
+
+func (b *unifiedBuilder) Commit() (thor.Bytes32, error) {
+	return b.tr.Commit()
+}
+
+type unifiedReader struct {
+	tr *trie.Trie
+}
+
+func (r *unifiedReader) Account(addrHash thor.Bytes32) (*state.Account, error) {
+	data, err := r.tr.TryGet(addrHash[:])
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	var ua unifiedAccount
+	if err := rlp.DecodeBytes(data, &ua); err != nil {
+		return nil, err
+	}
+	return &state.Account{
+		Balance:   ua.Balance,
+		Energy:    ua.Energy,
+		BlockTime: ua.BlockTime,
+		Master:    ua.Master,
+		CodeHash:  ua.CodeHash,
+	}, nil
+}
+
+func (r *unifiedReader) Storage(addrHash, keyHash thor.Bytes32) ([]byte, error) {
+	return r.tr.TryGet(unifiedStorageKey(addrHash, keyHash))
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package statemigrate rewrites the full state at a root into another trie layout offline,
+// to support protocol migrations of the state format.
+//
+// The state is read account by account from the account trie and storage tries, and put into
+// the builder of the target layout, which produces the new root. The migrated state is then
+// verified against the source, account by account and slot by slot.
+package statemigrate
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/kv"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/trie"
+)
+
+const batchSize = 4096 // number of kvs written in a batch
+
+// iterate iterates accounts of the state at the root, and storage slots of each account.
+func iterate(ctx context.Context, src kv.GetPutter, root thor.Bytes32,
+	onAccount func(addrHash thor.Bytes32, acc *state.Account) error,
+	onStorage func(keyHash thor.Bytes32, value []byte) error,
+) error {
+	accountTrie, err := trie.New(root, src)
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(accountTrie.NodeIterator(nil))
+	for it.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var acc state.Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return errors.Wrap(err, "decode account")
+		}
+		if err := onAccount(thor.BytesToBytes32(it.Key), &acc); err != nil {
+			return err
+		}
+		if len(acc.StorageRoot) == 0 {
+			continue
+		}
+		storageTrie, err := trie.New(thor.BytesToBytes32(acc.StorageRoot), src)
+		if err != nil {
+			return err
+		}
+		sit := trie.NewIterator(storageTrie.NodeIterator(nil))
+		for sit.Next() {
+			if err := onStorage(thor.BytesToBytes32(sit.Key), sit.Value); err != nil {
+				return err
+			}
+		}
+		if sit.Err != nil {
+			return sit.Err
+		}
+	}
+	return it.Err
+}
+
+// Migrate rewrites the state at the root in src into the layout in dst, and returns the new root.
+// Codes are copied into dst if it's not src. progress is called after each account migrated.
+func Migrate(ctx context.Context, src, dst kv.GetPutter, root thor.Bytes32, layout Layout, progress func(accounts int)) (thor.Bytes32, error) {
+	builder, err := layout.NewBuilder(dst)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	codes := make(map[thor.Bytes32]bool)
+	accounts := 0
+
+	if err := iterate(ctx, src, root,
+		func(addrHash thor.Bytes32, acc *state.Account) error {
+			if codeHash := thor.BytesToBytes32(acc.CodeHash); src != dst && len(acc.CodeHash) > 0 && !codes[codeHash] {
+				code, err := src.Get(acc.CodeHash)
+				if err != nil {
+					return errors.Wrap(err, "load code")
+				}
+				if err := dst.Put(acc.CodeHash, code); err != nil {
+					return err
+				}
+				codes[codeHash] = true
+			}
+			if err := builder.PutAccount(addrHash, acc); err != nil {
+				return err
+			}
+			accounts++
+			if progress != nil {
+				progress(accounts)
+			}
+			return nil
+		},
+		builder.PutStorage,
+	); err != nil {
+		return thor.Bytes32{}, err
+	}
+	return builder.Commit()
+}
+
+// Verify verifies the state at newRoot in the layout is equivalent to the one at root, i.e. every
+// account, storage slot and code of the source is found equal in the migrated state.
+// progress is called after each account verified.
+func Verify(ctx context.Context, src, dst kv.GetPutter, root thor.Bytes32, layout Layout, newRoot thor.Bytes32, progress func(accounts int)) error {
+	reader, err := layout.Open(dst, newRoot)
+	if err != nil {
+		return err
+	}
+	var (
+		addrHash thor.Bytes32
+		accounts int
+	)
+	return iterate(ctx, src, root,
+		func(h thor.Bytes32, acc *state.Account) error {
+			addrHash = h
+			migrated, err := reader.Account(addrHash)
+			if err != nil {
+				return err
+			}
+			if migrated == nil {
+				return errors.Errorf("account %v missing", addrHash)
+			}
+			if !equalAccounts(acc, migrated) {
+				return errors.Errorf("account %v mismatch", addrHash)
+			}
+			if len(acc.CodeHash) > 0 {
+				if has, err := dst.Has(acc.CodeHash); err != nil {
+					return err
+				} else if !has {
+					return errors.Errorf("code of account %v missing", addrHash)
+				}
+			}
+			accounts++
+			if progress != nil {
+				progress(accounts)
+			}
+			return nil
+		},
+		func(keyHash thor.Bytes32, value []byte) error {
+			migrated, err := reader.Storage(addrHash, keyHash)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(value, migrated) {
+				return errors.Errorf("storage %v of account %v mismatch", keyHash, addrHash)
+			}
+			return nil
+		},
+	)
+}
+
+// equalAccounts returns whether accounts are equal, regardless of storage roots which are layout specific.
+func equalAccounts(a, b *state.Account) bool {
+	return a.Balance.Cmp(b.Balance) == 0 &&
+		a.Energy.Cmp(b.Energy) == 0 &&
+		a.BlockTime == b.BlockTime &&
+		bytes.Equal(a.Master, b.Master) &&
+		bytes.Equal(a.CodeHash, b.CodeHash)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package statemigrate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/builtin"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/statemigrate"
+	"github.com/vechain/thor/thor"
+)
+
+func TestMigrate(t *testing.T) {
+	db, _ := lvldb.NewMem()
+	gene, err := genesis.NewDevnet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b0, _, err := gene.Build(state.NewCreator(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := b0.Header().StateRoot()
+	ctx := context.Background()
+
+	// rebuilt into the same root
+	dst, _ := lvldb.NewMem()
+	var migrated int
+	legacyRoot, err := statemigrate.Migrate(ctx, db, dst, root, statemigrate.Legacy, func(accounts int) {
+		migrated = accounts
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, root, legacyRoot)
+	assert.True(t, migrated > 0)
+	assert.Nil(t, statemigrate.Verify(ctx, db, dst, root, statemigrate.Legacy, legacyRoot, nil))
+
+	dst, _ = lvldb.NewMem()
+	unifiedRoot, err := statemigrate.Migrate(ctx, db, dst, root, statemigrate.Unified, nil)
+	assert.Nil(t, err)
+	assert.NotEqual(t, root, unifiedRoot)
+	var verified int
+	assert.Nil(t, statemigrate.Verify(ctx, db, dst, root, statemigrate.Unified, unifiedRoot, func(accounts int) {
+		verified = accounts
+	}))
+	assert.Equal(t, migrated, verified)
+
+	reader, err := statemigrate.Unified.Open(dst, unifiedRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, _ := state.New(root, db)
+	acc, err := reader.Account(thor.Blake2b(builtin.Params.Address[:]))
+	assert.Nil(t, err)
+	assert.Equal(t, st.GetBalance(builtin.Params.Address), acc.Balance)
+	value, err := reader.Storage(thor.Blake2b(builtin.Params.Address[:]), thor.Blake2b(thor.KeyBaseGasPrice[:]))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, value)
+
+	// not equivalent
+	assert.NotNil(t, statemigrate.Verify(ctx, db, dst, root, statemigrate.Unified, thor.Bytes32{}, nil))
+	assert.Nil(t, statemigrate.LayoutByName("unknown"))
+	assert.Equal(t, statemigrate.Unified, statemigrate.LayoutByName("unified"))
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thor
+
+import (
+	"encoding/binary"
+)
+
+const (
+	// BloomByteLength length of bloom filter in bytes.
+	BloomByteLength = 256
+	// BloomBitLength length of bloom filter in bits.
+	BloomBitLength = BloomByteLength * 8
+
+	bloomHashCount = 3
+)
+
+// Bloom 2048-bits bloom filter.
+type Bloom [BloomByteLength]byte
+
+// Add add data into bloom filter.
+func (b *Bloom) Add(data []byte) {
+	hash := Blake2b(data)
+	for i := 0; i < bloomHashCount; i++ {
+		bit := bloomBit(hash, i)
+		b[BloomByteLength-1-bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test test if data is possibly in the bloom filter.
+// False positive is possible, but false negative is not.
+func (b *Bloom) Test(data []byte) bool {
+	hash := Blake2b(data)
+	for i := 0; i < bloomHashCount; i++ {
+		bit := bloomBit(hash, i)
+		if b[BloomByteLength-1-bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes returns byte slice form of bloom.
+func (b *Bloom) Bytes() []byte {
+	return b[:]
+}
+
+// BytesToBloom converts bytes slice into bloom.
+// If b is larger than bloom length, b will be cropped (from the left).
+// If b is smaller than bloom length, b will be extended (from the left).
+func BytesToBloom(b []byte) (bloom Bloom) {
+	if len(b) > BloomByteLength {
+		b = b[len(b)-BloomByteLength:]
+	}
+	copy(bloom[BloomByteLength-len(b):], b)
+	return
+}
+
+func bloomBit(hash Bytes32, i int) uint {
+	return uint(binary.BigEndian.Uint16(hash[i*2:])) % BloomBitLength
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package thor
+
+import (
+	"fmt"
+	"math"
+)
+
+// ForkConfig config for a fork.
+// Each field is the block number from which the feature is activated.
+//
+// It can be decoded from JSON onto a preset, where only present fields are overridden.
+type ForkConfig struct {
+	DYNFEE        uint32 // dynamic fee tx type
+	ETH_CONST     uint32 // ethereum constantinople opcodes (SHL, SHR, SAR)
+	SCHEDV2       uint32 // proposer scheduler v2, epoch shuffling with backoff
+	CLAUSEISO     uint32 // tx feature of clause revert isolation
+	VIP191        uint32 // tx feature of fee delegation
+	ENERGY_SETTLE uint32 // settle energy of receiver before tokens of suicided contract added
+}
+
+func (fc ForkConfig) String() string {
+	var strs []string
+	push := func(name string, blockNum uint32) {
+		if blockNum != math.MaxUint32 {
+			strs = append(strs, fmt.Sprintf("%v: #%v", name, blockNum))
+		}
+	}
+
+	push("DYNFEE", fc.DYNFEE)
+	push("ETH_CONST", fc.ETH_CONST)
+	push("SCHEDV2", fc.SCHEDV2)
+	push("CLAUSEISO", fc.CLAUSEISO)
+	push("VIP191", fc.VIP191)
+	push("ENERGY_SETTLE", fc.ENERGY_SETTLE)
+
+	if len(strs) == 0 {
+		return "-"
+	}
+	return fmt.Sprint(strs)
+}
+
+// NoFork a special config without any forks.
+var NoFork = ForkConfig{
+	DYNFEE:        math.MaxUint32,
+	ETH_CONST:     math.MaxUint32,
+	SCHEDV2:       math.MaxUint32,
+	CLAUSEISO:     math.MaxUint32,
+	VIP191:        math.MaxUint32,
+	ENERGY_SETTLE: math.MaxUint32,
+}
+
+// for well-known networks
+var forkConfigs = map[Bytes32]ForkConfig{}
+
+// GetForkConfig get fork config for given genesis ID.
+// NoFork returned for unknown networks.
+func GetForkConfig(genesisID Bytes32) ForkConfig {
+	if fc, ok := forkConfigs[genesisID]; ok {
+		return fc
+	}
+	return NoFork
+}
@@ -31,3 +31,25 @@ func TestAddress(t *testing.T) {
 	assert.Nil(t, json.Unmarshal(data, &dec))
 	assert.Equal(t, addr, dec)
 }
+
+func TestBloom(t *testing.T) {
+	var bloom Bloom
+	for i := 0; i < 16; i++ {
+		bloom.Add([]byte{byte(i)})
+	}
+	for i := 0; i < 16; i++ {
+		assert.True(t, bloom.Test([]byte{byte(i)}))
+	}
+	assert.Equal(t, bloom, BytesToBloom(bloom.Bytes()))
+
+	var empty Bloom
+	assert.False(t, empty.Test([]byte("data")))
+}
+
+func TestForkConfig(t *testing.T) {
+	fc := NoFork
+	assert.Nil(t, json.Unmarshal([]byte(`{"ETH_CONST": 100}`), &fc))
+	assert.Equal(t, ForkConfig{DYNFEE: NoFork.DYNFEE, ETH_CONST: 100, SCHEDV2: NoFork.SCHEDV2, CLAUSEISO: NoFork.CLAUSEISO, VIP191: NoFork.VIP191, ENERGY_SETTLE: NoFork.ENERGY_SETTLE}, fc, "only present fields overridden")
+	assert.Equal(t, "[ETH_CONST: #100]", fc.String())
+	assert.Equal(t, "-", NoFork.String())
+}
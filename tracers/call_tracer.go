@@ -0,0 +1,175 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tracers
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/vm"
+)
+
+var _ vm.Tracer = (*CallTracer)(nil)
+
+// CallFrame a call frame captured by CallTracer.
+type CallFrame struct {
+	Type    string
+	From    thor.Address
+	To      *thor.Address // nil if contract creation failed
+	Value   *big.Int
+	Gas     uint64
+	GasUsed uint64 // only available for the outermost frame
+	Input   []byte
+	Output  []byte
+	Error   string
+	Calls   []*CallFrame
+}
+
+// CallTracer captures hierarchy of call frames.
+// One outermost frame is captured for each clause executed.
+type CallTracer struct {
+	roots []*CallFrame
+	root  *CallFrame
+	stack []*CallFrame
+}
+
+// NewCallTracer create a CallTracer.
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// Result returns the outermost call frame of the last executed clause.
+func (t *CallTracer) Result() *CallFrame {
+	return t.root
+}
+
+// Results returns outermost call frames of all executed clauses.
+func (t *CallTracer) Results() []*CallFrame {
+	return t.roots
+}
+
+// CaptureStart implements vm.Tracer.
+func (t *CallTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := "CALL"
+	if create {
+		typ = "CREATE"
+	}
+	toAddr := thor.Address(to)
+	t.root = &CallFrame{
+		Type:  typ,
+		From:  thor.Address(from),
+		To:    &toAddr,
+		Value: new(big.Int).Set(value),
+		Gas:   gas,
+		Input: append([]byte(nil), input...),
+	}
+	t.roots = append(t.roots, t.root)
+	t.stack = []*CallFrame{t.root}
+	return nil
+}
+
+// CaptureState implements vm.Tracer.
+func (t *CallTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if len(t.stack) == 0 {
+		return nil
+	}
+	// returned from inner calls
+	t.exitTo(depth, stack)
+	if err != nil {
+		t.stack[len(t.stack)-1].Error = err.Error()
+		return nil
+	}
+
+	switch op {
+	case vm.CALL, vm.CALLCODE:
+		t.enter(op, contract, stack.Back(1), stack.Back(2), stack.Back(0), memory, stack.Back(3), stack.Back(4))
+	case vm.DELEGATECALL, vm.STATICCALL:
+		var value *big.Int
+		if op == vm.DELEGATECALL {
+			value = contract.Value()
+		}
+		t.enter(op, contract, stack.Back(1), value, stack.Back(0), memory, stack.Back(2), stack.Back(3))
+	case vm.CREATE:
+		t.enter(op, contract, nil, stack.Back(0), nil, memory, stack.Back(1), stack.Back(2))
+	}
+	return nil
+}
+
+// CaptureFault implements vm.Tracer.
+func (t *CallTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if len(t.stack) > 0 && err != nil {
+		t.stack[len(t.stack)-1].Error = err.Error()
+	}
+	return nil
+}
+
+// CaptureEnd implements vm.Tracer.
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if len(t.stack) == 0 {
+		return nil
+	}
+	t.root.Output = append([]byte(nil), output...)
+	t.root.GasUsed = gasUsed
+	if err != nil {
+		t.root.Error = err.Error()
+		if t.root.Type == "CREATE" {
+			t.root.To = nil
+		}
+	}
+	t.stack = nil
+	return nil
+}
+
+func (t *CallTracer) enter(op vm.OpCode, contract *vm.Contract, to, value, gas *big.Int, memory *vm.Memory, inOffset, inSize *big.Int) {
+	frame := &CallFrame{
+		Type:  op.String(),
+		From:  thor.Address(contract.Address()),
+		Value: new(big.Int),
+		Input: memory.Get(inOffset.Int64(), inSize.Int64()),
+	}
+	if to != nil {
+		addr := thor.BytesToAddress(to.Bytes())
+		frame.To = &addr
+	}
+	if value != nil {
+		frame.Value.Set(value)
+	}
+	if gas != nil && gas.IsUint64() {
+		frame.Gas = gas.Uint64()
+	}
+	parent := t.stack[len(t.stack)-1]
+	parent.Calls = append(parent.Calls, frame)
+	t.stack = append(t.stack, frame)
+}
+
+// exitTo pops frames deeper than depth.
+// The stack top of caller tells the result of the finished call.
+func (t *CallTracer) exitTo(depth int, stack *vm.Stack) {
+	for len(t.stack) > depth && len(t.stack) > 1 {
+		frame := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+
+		ret := new(big.Int)
+		if len(stack.Data()) > 0 {
+			ret = stack.Back(0)
+		}
+		if frame.Type == vm.CREATE.String() {
+			if ret.Sign() == 0 {
+				frame.To = nil
+				if frame.Error == "" {
+					frame.Error = "creation failed"
+				}
+			} else {
+				addr := thor.BytesToAddress(ret.Bytes())
+				frame.To = &addr
+			}
+		} else if ret.Sign() == 0 && frame.Error == "" {
+			frame.Error = "call failed"
+		}
+	}
+}
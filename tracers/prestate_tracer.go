@@ -0,0 +1,137 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tracers
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/vm"
+)
+
+var _ vm.Tracer = (*PrestateTracer)(nil)
+
+// Account the pre-execution state of an account.
+type Account struct {
+	Balance *big.Int
+	Code    []byte
+	Storage map[thor.Bytes32]thor.Bytes32
+}
+
+// PrestateTracer collects states of accounts touched by contract execution,
+// as they were before the execution.
+// Clauses without code execution (e.g. plain transfer) are not captured.
+type PrestateTracer struct {
+	prestate map[thor.Address]*Account
+
+	// outermost call of current clause
+	from, to thor.Address
+	value    *big.Int
+	create   bool
+	started  bool
+}
+
+// NewPrestateTracer create a PrestateTracer.
+func NewPrestateTracer() *PrestateTracer {
+	return &PrestateTracer{
+		prestate: make(map[thor.Address]*Account),
+	}
+}
+
+// Result returns collected accounts.
+func (t *PrestateTracer) Result() map[thor.Address]*Account {
+	return t.prestate
+}
+
+// CaptureStart implements vm.Tracer.
+func (t *PrestateTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.from = thor.Address(from)
+	t.to = thor.Address(to)
+	t.value = new(big.Int).Set(value)
+	t.create = create
+	t.started = false
+	return nil
+}
+
+// CaptureState implements vm.Tracer.
+func (t *PrestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if !t.started {
+		// value is already transferred when the first op executed
+		t.started = true
+		if t.lookupAccount(env.StateDB, t.from) && t.from != t.to {
+			acc := t.prestate[t.from]
+			acc.Balance = new(big.Int).Add(acc.Balance, t.value)
+		}
+		if t.lookupAccount(env.StateDB, t.to) {
+			acc := t.prestate[t.to]
+			if t.from != t.to {
+				acc.Balance = new(big.Int).Sub(acc.Balance, t.value)
+			}
+			if t.create {
+				// code not deployed yet
+				acc.Code = nil
+			}
+		}
+	}
+	if err != nil {
+		return nil
+	}
+
+	stackLen := len(stack.Data())
+	switch op {
+	case vm.SLOAD, vm.SSTORE:
+		if stackLen >= 1 {
+			t.lookupStorage(env.StateDB, thor.Address(contract.Address()), thor.BytesToBytes32(stack.Back(0).Bytes()))
+		}
+	case vm.BALANCE, vm.EXTCODESIZE, vm.EXTCODECOPY, vm.SELFDESTRUCT:
+		if stackLen >= 1 {
+			t.lookupAccount(env.StateDB, thor.BytesToAddress(stack.Back(0).Bytes()))
+		}
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		if stackLen >= 2 {
+			t.lookupAccount(env.StateDB, thor.BytesToAddress(stack.Back(1).Bytes()))
+		}
+	case vm.CREATE:
+		// the new contract address derives from caller's nonce, which is not maintained in thor,
+		// so only the creator is captured
+		t.lookupAccount(env.StateDB, thor.Address(contract.Address()))
+	}
+	return nil
+}
+
+// CaptureFault implements vm.Tracer.
+func (t *PrestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements vm.Tracer.
+func (t *PrestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// lookupAccount captures the account if not yet, and returns whether it's newly captured.
+func (t *PrestateTracer) lookupAccount(db vm.StateDB, addr thor.Address) bool {
+	if _, ok := t.prestate[addr]; ok {
+		return false
+	}
+	t.prestate[addr] = &Account{
+		Balance: db.GetBalance(common.Address(addr)),
+		Code:    db.GetCode(common.Address(addr)),
+		Storage: make(map[thor.Bytes32]thor.Bytes32),
+	}
+	return true
+}
+
+func (t *PrestateTracer) lookupStorage(db vm.StateDB, addr thor.Address, key thor.Bytes32) {
+	t.lookupAccount(db, addr)
+	acc := t.prestate[addr]
+	if _, ok := acc.Storage[key]; ok {
+		return
+	}
+	acc.Storage[key] = thor.Bytes32(db.GetState(common.Address(addr), common.Hash(key)))
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package tracers implements vm.Tracer to introspect contract execution.
+// A tracer is attached to runtime by
+//
+//	rt.SetVMConfig(vm.Config{Debug: true, Tracer: tracer})
+package tracers
+
+import (
+	"fmt"
+
+	"github.com/vechain/thor/vm"
+)
+
+// Names of builtin tracers.
+const (
+	StructLoggerName   = "structLogger"
+	CallTracerName     = "callTracer"
+	PrestateTracerName = "prestateTracer"
+)
+
+// New create a tracer by name.
+func New(name string) (vm.Tracer, error) {
+	switch name {
+	case StructLoggerName:
+		return vm.NewStructLogger(nil), nil
+	case CallTracerName:
+		return NewCallTracer(), nil
+	case PrestateTracerName:
+		return NewPrestateTracer(), nil
+	}
+	return nil, fmt.Errorf("unknown tracer %v", name)
+}
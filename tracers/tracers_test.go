@@ -0,0 +1,96 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tracers_test
+
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/chain"
+	"github.com/vechain/thor/genesis"
+	"github.com/vechain/thor/lvldb"
+	"github.com/vechain/thor/runtime"
+	"github.com/vechain/thor/state"
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tracers"
+	"github.com/vechain/thor/tx"
+	"github.com/vechain/thor/vm"
+	"github.com/vechain/thor/xenv"
+)
+
+func TestTracers(t *testing.T) {
+	kv, _ := lvldb.NewMem()
+	g, _ := genesis.NewDevnet()
+	stateCreator := state.NewCreator(kv)
+	b0, _, err := g.Build(stateCreator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, _ := chain.New(kv, b0)
+	st, _ := stateCreator.NewState(b0.Header().StateRoot())
+
+	callee := thor.BytesToAddress([]byte("callee"))
+	caller := thor.BytesToAddress([]byte("caller"))
+
+	// PUSH1 1 SLOAD POP STOP
+	st.SetCode(callee, []byte{0x60, 0x01, 0x54, 0x50, 0x00})
+
+	// CALL(GAS, callee, 0, 0, 0, 0, 0), then SSTORE the result to slot 0
+	code, _ := hex.DecodeString("60006000600060006000" + "73" + hex.EncodeToString(callee[:]) + "5af1" + "600055" + "00")
+	st.SetCode(caller, code)
+
+	newRuntime := func(tracer vm.Tracer) *runtime.Runtime {
+		return runtime.New(ch.NewSeeker(b0.Header().ID()), st, &xenv.BlockContext{Time: b0.Header().Timestamp()}, thor.NoFork).
+			SetVMConfig(vm.Config{Debug: true, Tracer: tracer})
+	}
+	origin := genesis.DevAccounts()[0].Address
+	balance := st.GetBalance(origin)
+	clause := tx.NewClause(&caller).WithValue(big.NewInt(1))
+
+	callTracer := tracers.NewCallTracer()
+	out := newRuntime(callTracer).ExecuteClause(clause, 0, math.MaxUint64, &xenv.TransactionContext{Origin: origin})
+	assert.Nil(t, out.VMErr)
+
+	root := callTracer.Result()
+	assert.Equal(t, "CALL", root.Type)
+	assert.Equal(t, origin, root.From)
+	assert.Equal(t, caller, *root.To)
+	assert.Equal(t, big.NewInt(1), root.Value)
+	assert.Equal(t, 1, len(root.Calls))
+	assert.Equal(t, []*tracers.CallFrame{root}, callTracer.Results())
+
+	inner := root.Calls[0]
+	assert.Equal(t, "CALL", inner.Type)
+	assert.Equal(t, caller, inner.From)
+	assert.Equal(t, callee, *inner.To)
+	assert.Equal(t, 0, inner.Value.Sign())
+	assert.Equal(t, "", inner.Error)
+
+	prestateTracer := tracers.NewPrestateTracer()
+	out = newRuntime(prestateTracer).ExecuteClause(clause, 0, math.MaxUint64, &xenv.TransactionContext{Origin: origin})
+	assert.Nil(t, out.VMErr)
+
+	prestate := prestateTracer.Result()
+	// balances before the second clause
+	assert.Equal(t, new(big.Int).Sub(balance, big.NewInt(1)), prestate[origin].Balance)
+	assert.Equal(t, big.NewInt(1), prestate[caller].Balance)
+	assert.Equal(t, code, prestate[caller].Code)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{{}: thor.BytesToBytes32([]byte{1})}, prestate[caller].Storage)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{thor.BytesToBytes32([]byte{1}): {}}, prestate[callee].Storage)
+}
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{tracers.StructLoggerName, tracers.CallTracerName, tracers.PrestateTracerName} {
+		tracer, err := tracers.New(name)
+		assert.Nil(t, err)
+		assert.NotNil(t, tracer)
+	}
+	_, err := tracers.New("foo")
+	assert.NotNil(t, err)
+}
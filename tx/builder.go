@@ -7,13 +7,16 @@ package tx
 
 import (
 	"encoding/binary"
+	"math/big"
 
 	"github.com/vechain/thor/thor"
 )
 
 // Builder to make it easy to build transaction.
 type Builder struct {
+	typ  byte
 	body body
+	fee  dynamicFee
 }
 
 // ChainTag set chain tag.
@@ -69,8 +72,27 @@ func (b *Builder) DependsOn(txID *thor.Bytes32) *Builder {
 	return b
 }
 
+// Features set features.
+func (b *Builder) Features(feat Features) *Builder {
+	b.body.Reserved.Features = feat
+	return b
+}
+
+// DynamicFee makes the tx dynamic fee typed, with fee caps set.
+func (b *Builder) DynamicFee(maxPriorityFeePerGas, maxFeePerGas *big.Int) *Builder {
+	b.typ = TypeDynamicFee
+	b.fee = dynamicFee{
+		new(big.Int).Set(maxPriorityFeePerGas),
+		new(big.Int).Set(maxFeePerGas),
+	}
+	return b
+}
+
 // Build build tx object.
 func (b *Builder) Build() *Transaction {
-	tx := Transaction{body: b.body}
+	tx := Transaction{typ: b.typ, body: b.body, fee: b.fee}
+	if tx.typ != TypeLegacy {
+		tx.body.GasPriceCoef = 0
+	}
 	return &tx
 }
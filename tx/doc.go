@@ -0,0 +1,16 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package tx defines the transaction format of VeChain Thor.
+// A transaction carries one or more clauses, which are executed in order and share
+// one gas budget. Each clause is a (To, Value, Data) tuple, so that multiple calls
+// can be made atomically without the help of a multicall contract.
+//
+//	[ ChainTag, BlockRef, Expiration, [ Clause... ], GasPriceCoef, Gas, DependsOn, Nonce, Reserved, Signature ]
+//
+// SigningHash is the blake2b hash of RLP encoded fields excludes the signature,
+// and the sender (origin) is recovered from the signature over it.
+// If any clause fails, all clauses are reverted.
+package tx
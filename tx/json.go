@@ -0,0 +1,365 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/thor"
+)
+
+// Canonical JSON form of tx objects.
+// Quantities are 0x-prefixed hex without leading zeros, byte arrays are 0x-prefixed hex,
+// and addresses are in EIP-55 checksum form. Mixed-case addresses with bad checksum are rejected.
+
+var (
+	_ json.Marshaler   = (*Transaction)(nil)
+	_ json.Unmarshaler = (*Transaction)(nil)
+	_ json.Marshaler   = (*Clause)(nil)
+	_ json.Unmarshaler = (*Clause)(nil)
+	_ json.Marshaler   = (*Receipt)(nil)
+	_ json.Unmarshaler = (*Receipt)(nil)
+)
+
+// jsonAddress address in checksum form.
+type jsonAddress thor.Address
+
+func (a jsonAddress) MarshalText() ([]byte, error) {
+	return []byte(common.Address(a).Hex()), nil
+}
+
+func (a *jsonAddress) UnmarshalText(text []byte) error {
+	s := string(text)
+	addr, err := thor.ParseAddress(s)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %v", s, err)
+	}
+	body := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if body != strings.ToLower(body) && body != strings.ToUpper(body) {
+		// mixed case, checksum must be valid
+		if common.Address(addr).Hex()[2:] != body {
+			return fmt.Errorf("invalid address %q: bad checksum", s)
+		}
+	}
+	*a = jsonAddress(addr)
+	return nil
+}
+
+type clauseJSON struct {
+	To    *jsonAddress  `json:"to"`
+	Value *hexutil.Big  `json:"value"`
+	Data  hexutil.Bytes `json:"data"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Clause) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&clauseJSON{
+		(*jsonAddress)(c.body.To),
+		(*hexutil.Big)(c.body.Value),
+		c.body.Data,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Clause) UnmarshalJSON(data []byte) error {
+	var cj clauseJSON
+	if err := strictUnmarshal(data, &cj); err != nil {
+		return fmt.Errorf("clause: %v", err)
+	}
+	if cj.Value == nil {
+		return errors.New("clause: missing 'value'")
+	}
+	if cj.Data == nil {
+		return errors.New("clause: missing 'data'")
+	}
+	if (*big.Int)(cj.Value).Sign() < 0 {
+		return errors.New("clause: negative 'value'")
+	}
+	*c = Clause{clauseBody{
+		(*thor.Address)(cj.To),
+		(*big.Int)(cj.Value),
+		cj.Data,
+	}}
+	return nil
+}
+
+type txJSON struct {
+	Type                 *hexutil.Uint64 `json:"type,omitempty"`
+	ChainTag             *hexutil.Uint64 `json:"chainTag"`
+	BlockRef             hexutil.Bytes   `json:"blockRef"`
+	Expiration           *hexutil.Uint64 `json:"expiration"`
+	Clauses              []*Clause       `json:"clauses"`
+	GasPriceCoef         *hexutil.Uint64 `json:"gasPriceCoef,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	Gas                  *hexutil.Uint64 `json:"gas"`
+	DependsOn            *thor.Bytes32   `json:"dependsOn"`
+	Nonce                *hexutil.Uint64 `json:"nonce"`
+	Features             *hexutil.Uint64 `json:"features,omitempty"`
+	Signature            hexutil.Bytes   `json:"signature,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// Tx with unknown reserved fields can't be presented in JSON.
+func (t *Transaction) MarshalJSON() ([]byte, error) {
+	if len(t.body.Reserved.Unused) > 0 {
+		return nil, errors.New("tx with unknown reserved fields")
+	}
+	br := t.BlockRef()
+	tj := txJSON{
+		ChainTag:   newHexUint64(uint64(t.body.ChainTag)),
+		BlockRef:   br[:],
+		Expiration: newHexUint64(uint64(t.body.Expiration)),
+		Clauses:    t.body.Clauses,
+		Gas:        newHexUint64(t.body.Gas),
+		DependsOn:  t.body.DependsOn,
+		Nonce:      newHexUint64(t.body.Nonce),
+		Signature:  t.body.Signature,
+	}
+	if tj.Clauses == nil {
+		tj.Clauses = []*Clause{}
+	}
+	switch t.typ {
+	case TypeLegacy:
+		tj.GasPriceCoef = newHexUint64(uint64(t.body.GasPriceCoef))
+	default:
+		tj.Type = newHexUint64(uint64(t.typ))
+		tj.MaxPriorityFeePerGas = (*hexutil.Big)(t.fee.MaxPriorityFeePerGas)
+		tj.MaxFeePerGas = (*hexutil.Big)(t.fee.MaxFeePerGas)
+	}
+	if t.body.Reserved.Features != 0 {
+		tj.Features = newHexUint64(uint64(t.body.Reserved.Features))
+	}
+	return json.Marshal(&tj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	var tj txJSON
+	if err := strictUnmarshal(data, &tj); err != nil {
+		return fmt.Errorf("tx: %v", err)
+	}
+
+	typ := TypeLegacy
+	if tj.Type != nil {
+		if uint64(*tj.Type) != uint64(TypeDynamicFee) {
+			return fmt.Errorf("tx: unsupported 'type' %v", *tj.Type)
+		}
+		typ = TypeDynamicFee
+	}
+
+	var missing []string
+	check := func(name string, present bool) {
+		if !present {
+			missing = append(missing, "'"+name+"'")
+		}
+	}
+	check("chainTag", tj.ChainTag != nil)
+	check("blockRef", tj.BlockRef != nil)
+	check("expiration", tj.Expiration != nil)
+	check("clauses", tj.Clauses != nil)
+	check("gas", tj.Gas != nil)
+	check("nonce", tj.Nonce != nil)
+	if typ == TypeLegacy {
+		check("gasPriceCoef", tj.GasPriceCoef != nil)
+	} else {
+		check("maxPriorityFeePerGas", tj.MaxPriorityFeePerGas != nil)
+		check("maxFeePerGas", tj.MaxFeePerGas != nil)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("tx: missing %v", strings.Join(missing, ", "))
+	}
+
+	if typ == TypeLegacy && (tj.MaxPriorityFeePerGas != nil || tj.MaxFeePerGas != nil) {
+		return errors.New("tx: fee caps not allowed for legacy tx")
+	}
+	if typ != TypeLegacy && tj.GasPriceCoef != nil {
+		return errors.New("tx: 'gasPriceCoef' not allowed for typed tx")
+	}
+	if *tj.ChainTag > math.MaxUint8 {
+		return errors.New("tx: 'chainTag' out of range")
+	}
+	if len(tj.BlockRef) != 8 {
+		return errors.New("tx: 'blockRef' should be 8 bytes")
+	}
+	if *tj.Expiration > math.MaxUint32 {
+		return errors.New("tx: 'expiration' out of range")
+	}
+	for i, c := range tj.Clauses {
+		if c == nil {
+			return fmt.Errorf("tx: null clause #%v", i)
+		}
+	}
+
+	newTx := Transaction{
+		typ: typ,
+		body: body{
+			ChainTag:   byte(*tj.ChainTag),
+			BlockRef:   binary.BigEndian.Uint64(tj.BlockRef),
+			Expiration: uint32(*tj.Expiration),
+			Clauses:    tj.Clauses,
+			Gas:        uint64(*tj.Gas),
+			DependsOn:  tj.DependsOn,
+			Nonce:      uint64(*tj.Nonce),
+			Signature:  tj.Signature,
+		},
+	}
+	if typ == TypeLegacy {
+		if *tj.GasPriceCoef > math.MaxUint8 {
+			return errors.New("tx: 'gasPriceCoef' out of range")
+		}
+		newTx.body.GasPriceCoef = uint8(*tj.GasPriceCoef)
+	} else {
+		newTx.fee = dynamicFee{(*big.Int)(tj.MaxPriorityFeePerGas), (*big.Int)(tj.MaxFeePerGas)}
+	}
+	if tj.Features != nil {
+		if *tj.Features > math.MaxUint32 {
+			return errors.New("tx: 'features' out of range")
+		}
+		newTx.body.Reserved.Features = Features(*tj.Features)
+	}
+	*t = newTx
+	return nil
+}
+
+type eventJSON struct {
+	Address jsonAddress    `json:"address"`
+	Topics  []thor.Bytes32 `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+type transferJSON struct {
+	Sender    jsonAddress  `json:"sender"`
+	Recipient jsonAddress  `json:"recipient"`
+	Amount    *hexutil.Big `json:"amount"`
+}
+
+type outputJSON struct {
+	Events    []*eventJSON    `json:"events"`
+	Transfers []*transferJSON `json:"transfers"`
+	GasUsed   hexutil.Uint64  `json:"gasUsed"`
+	Data      hexutil.Bytes   `json:"data"`
+	Reverted  bool            `json:"reverted"`
+}
+
+type receiptJSON struct {
+	GasUsed  *hexutil.Uint64 `json:"gasUsed"`
+	GasPayer *jsonAddress    `json:"gasPayer"`
+	Paid     *hexutil.Big    `json:"paid"`
+	Reward   *hexutil.Big    `json:"reward"`
+	Reverted bool            `json:"reverted"`
+	Outputs  []*outputJSON   `json:"outputs"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *Receipt) MarshalJSON() ([]byte, error) {
+	rj := receiptJSON{
+		GasUsed:  newHexUint64(r.GasUsed),
+		GasPayer: (*jsonAddress)(&r.GasPayer),
+		Paid:     (*hexutil.Big)(r.Paid),
+		Reward:   (*hexutil.Big)(r.Reward),
+		Reverted: r.Reverted,
+		Outputs:  make([]*outputJSON, len(r.Outputs)),
+	}
+	for i, o := range r.Outputs {
+		oj := &outputJSON{
+			make([]*eventJSON, len(o.Events)),
+			make([]*transferJSON, len(o.Transfers)),
+			hexutil.Uint64(o.GasUsed),
+			o.Data,
+			o.Reverted,
+		}
+		for j, ev := range o.Events {
+			topics := ev.Topics
+			if topics == nil {
+				topics = []thor.Bytes32{}
+			}
+			oj.Events[j] = &eventJSON{jsonAddress(ev.Address), topics, ev.Data}
+		}
+		for j, tr := range o.Transfers {
+			oj.Transfers[j] = &transferJSON{jsonAddress(tr.Sender), jsonAddress(tr.Recipient), (*hexutil.Big)(tr.Amount)}
+		}
+		rj.Outputs[i] = oj
+	}
+	return json.Marshal(&rj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Receipt) UnmarshalJSON(data []byte) error {
+	var rj receiptJSON
+	if err := strictUnmarshal(data, &rj); err != nil {
+		return fmt.Errorf("receipt: %v", err)
+	}
+	switch {
+	case rj.GasUsed == nil:
+		return errors.New("receipt: missing 'gasUsed'")
+	case rj.GasPayer == nil:
+		return errors.New("receipt: missing 'gasPayer'")
+	case rj.Paid == nil:
+		return errors.New("receipt: missing 'paid'")
+	case rj.Reward == nil:
+		return errors.New("receipt: missing 'reward'")
+	case rj.Outputs == nil:
+		return errors.New("receipt: missing 'outputs'")
+	}
+
+	newReceipt := Receipt{
+		GasUsed:  uint64(*rj.GasUsed),
+		GasPayer: thor.Address(*rj.GasPayer),
+		Paid:     (*big.Int)(rj.Paid),
+		Reward:   (*big.Int)(rj.Reward),
+		Reverted: rj.Reverted,
+		Outputs:  make([]*Output, len(rj.Outputs)),
+	}
+	for i, oj := range rj.Outputs {
+		if oj == nil {
+			return fmt.Errorf("receipt: null output #%v", i)
+		}
+		o := &Output{
+			Events:    make(Events, len(oj.Events)),
+			Transfers: make(Transfers, len(oj.Transfers)),
+			GasUsed:   uint64(oj.GasUsed),
+			Data:      oj.Data,
+			Reverted:  oj.Reverted,
+		}
+		for j, ej := range oj.Events {
+			if ej == nil {
+				return fmt.Errorf("receipt: null event #%v of output #%v", j, i)
+			}
+			o.Events[j] = &Event{thor.Address(ej.Address), ej.Topics, ej.Data}
+		}
+		for j, tj := range oj.Transfers {
+			if tj == nil || tj.Amount == nil {
+				return fmt.Errorf("receipt: bad transfer #%v of output #%v", j, i)
+			}
+			o.Transfers[j] = &Transfer{thor.Address(tj.Sender), thor.Address(tj.Recipient), (*big.Int)(tj.Amount)}
+		}
+		newReceipt.Outputs[i] = o
+	}
+	*r = newReceipt
+	return nil
+}
+
+func newHexUint64(v uint64) *hexutil.Uint64 {
+	h := hexutil.Uint64(v)
+	return &h
+}
+
+// strictUnmarshal decodes JSON with unknown fields disallowed.
+func strictUnmarshal(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
@@ -6,6 +6,7 @@
 package tx
 
 import (
+	"io"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -23,18 +24,58 @@ type Receipt struct {
 	Paid *big.Int
 	// energy reward given to block proposer
 	Reward *big.Int
-	// if the tx reverted
+	// if the tx reverted, or any clause reverted for tx with clause isolation
 	Reverted bool
 	// outputs of clauses in tx
 	Outputs []*Output
 }
 
 // Output output of clause execution.
+// Only events, transfers and the reverted flag are encoded into RLP, which is the
+// consensus layout committed by receipts root. Other fields are kept by the chain aside.
 type Output struct {
 	// events produced by the clause
 	Events Events
 	// transfer occurred in clause
 	Transfers Transfers
+	// gas used by the clause, before refunded
+	GasUsed uint64
+	// data returned by the clause
+	Data []byte
+	// if the clause reverted, only for tx with clause isolation
+	Reverted bool
+}
+
+// EncodeRLP implements rlp.Encoder.
+// The reverted flag is appended only if set, which happens only to txs with clause
+// isolation, i.e. after the fork. Outputs of other txs are encoded in the legacy layout.
+func (o *Output) EncodeRLP(w io.Writer) error {
+	if o.Reverted {
+		return rlp.Encode(w, []interface{}{o.Events, o.Transfers, o.Reverted})
+	}
+	return rlp.Encode(w, []interface{}{o.Events, o.Transfers})
+}
+
+// DecodeRLP implements rlp.Decoder, accepts both the legacy layout and the one with reverted flag.
+func (o *Output) DecodeRLP(s *rlp.Stream) error {
+	if _, err := s.List(); err != nil {
+		return err
+	}
+	var out Output
+	if err := s.Decode(&out.Events); err != nil {
+		return err
+	}
+	if err := s.Decode(&out.Transfers); err != nil {
+		return err
+	}
+	if err := s.Decode(&out.Reverted); err != nil && err != rlp.EOL {
+		return err
+	}
+	if err := s.ListEnd(); err != nil {
+		return err
+	}
+	*o = out
+	return nil
 }
 
 // Receipts slice of receipts.
@@ -62,3 +103,18 @@ func (rs derivableReceipts) GetRlp(i int) []byte {
 	}
 	return data
 }
+
+// Bloom returns bloom filter of event addresses and topics in receipts.
+func (rs Receipts) Bloom() (bloom thor.Bloom) {
+	for _, r := range rs {
+		for _, o := range r.Outputs {
+			for _, ev := range o.Events {
+				bloom.Add(ev.Address.Bytes())
+				for _, topic := range ev.Topics {
+					bloom.Add(topic.Bytes())
+				}
+			}
+		}
+	}
+	return
+}
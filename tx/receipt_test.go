@@ -7,8 +7,12 @@ package tx_test
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/thor"
 	. "github.com/vechain/thor/tx"
 )
 
@@ -19,3 +23,49 @@ func TestReceipt(t *testing.T) {
 	var txs Transactions
 	fmt.Println(txs.RootHash())
 }
+
+func TestOutputRLP(t *testing.T) {
+	transfers := Transfers{{Sender: thor.BytesToAddress([]byte("from")), Recipient: thor.BytesToAddress([]byte("to")), Amount: big.NewInt(1)}}
+
+	// consensus layout of events and transfers only
+	legacy, _ := rlp.EncodeToBytes([]interface{}{Events{}, transfers})
+	data, err := rlp.EncodeToBytes(&Output{Transfers: transfers, GasUsed: 100, Data: []byte{1}})
+	assert.Nil(t, err)
+	assert.Equal(t, legacy, data)
+
+	var o Output
+	assert.Nil(t, rlp.DecodeBytes(legacy, &o))
+	assert.Equal(t, transfers, o.Transfers)
+	assert.Equal(t, uint64(0), o.GasUsed)
+	assert.Nil(t, o.Data)
+
+	// receipts root not affected
+	r1 := &Receipt{Paid: big.NewInt(0), Reward: big.NewInt(0), Outputs: []*Output{{Transfers: transfers}}}
+	r2 := &Receipt{Paid: big.NewInt(0), Reward: big.NewInt(0), Outputs: []*Output{{Transfers: transfers, GasUsed: 100, Data: []byte{1}}}}
+	assert.Equal(t, Receipts{r1}.RootHash(), Receipts{r2}.RootHash())
+}
+
+func TestOutputRevertedRLP(t *testing.T) {
+	// receipt of tx before clause isolation fork
+	preFork, _ := rlp.EncodeToBytes([]interface{}{
+		uint64(21000), thor.Address{}, big.NewInt(1), big.NewInt(1), false,
+		[]interface{}{[]interface{}{Events{}, Transfers{}}},
+	})
+	var r Receipt
+	assert.Nil(t, rlp.DecodeBytes(preFork, &r))
+	assert.Equal(t, uint64(21000), r.GasUsed)
+	assert.Equal(t, 1, len(r.Outputs))
+	assert.False(t, r.Outputs[0].Reverted)
+
+	data, _ := rlp.EncodeToBytes(&r)
+	assert.Equal(t, preFork, data, "encoded in legacy layout")
+
+	// reverted clause of tx with clause isolation
+	r.Outputs[0].Reverted = true
+	data, _ = rlp.EncodeToBytes(&r)
+	assert.NotEqual(t, preFork, data)
+
+	var decoded Receipt
+	assert.Nil(t, rlp.DecodeBytes(data, &decoded))
+	assert.True(t, decoded.Outputs[0].Reverted)
+}
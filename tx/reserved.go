@@ -0,0 +1,70 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"errors"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// reserved is the reserved field of tx body.
+// It's encoded as a list, with trailing empty items trimmed, so that txs without
+// features keep the same encoding as before.
+type reserved struct {
+	Features Features
+	Unused   []rlp.RawValue
+}
+
+// EncodeRLP implements rlp.Encoder.
+func (r *reserved) EncodeRLP(w io.Writer) error {
+	var list []interface{}
+	if r.Features != 0 || len(r.Unused) > 0 {
+		list = append(list, r.Features)
+	}
+	for _, u := range r.Unused {
+		list = append(list, u)
+	}
+	// trim trailing empty values
+	for len(list) > 0 {
+		if raw, ok := list[len(list)-1].(rlp.RawValue); ok && isEmptyRLP(raw) {
+			list = list[:len(list)-1]
+			continue
+		}
+		break
+	}
+	if list == nil {
+		list = []interface{}{}
+	}
+	return rlp.Encode(w, list)
+}
+
+// DecodeRLP implements rlp.Decoder.
+func (r *reserved) DecodeRLP(s *rlp.Stream) error {
+	var raws []rlp.RawValue
+	if err := s.Decode(&raws); err != nil {
+		return err
+	}
+	if len(raws) == 0 {
+		*r = reserved{}
+		return nil
+	}
+	if isEmptyRLP(raws[len(raws)-1]) {
+		return errors.New("rlp: reserved fields not trimmed")
+	}
+
+	var features Features
+	if err := rlp.DecodeBytes(raws[0], &features); err != nil {
+		return err
+	}
+	*r = reserved{features, raws[1:]}
+	return nil
+}
+
+func isEmptyRLP(raw rlp.RawValue) bool {
+	return len(raw) == 1 && (raw[0] == 0x80 || raw[0] == 0xc0)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/vechain/thor/thor"
+)
+
+// Sign signs the tx with the private key of origin, and returns the signed tx.
+// The returned tx can be RLP encoded and submitted directly.
+func Sign(tx *Transaction, pk *ecdsa.PrivateKey) (*Transaction, error) {
+	if tx.Features().IsDelegated() {
+		return nil, errors.New("delegated tx requires delegator's signature")
+	}
+	sig, err := crypto.Sign(tx.SigningHash().Bytes(), pk)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(sig), nil
+}
+
+// SignDelegated signs the delegated tx with private keys of origin and delegator,
+// and returns the signed tx.
+func SignDelegated(tx *Transaction, originPK *ecdsa.PrivateKey, delegatorPK *ecdsa.PrivateKey) (*Transaction, error) {
+	if !tx.Features().IsDelegated() {
+		return nil, errors.New("tx is not delegated")
+	}
+	sig, err := crypto.Sign(tx.SigningHash().Bytes(), originPK)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := thor.Address(crypto.PubkeyToAddress(originPK.PublicKey))
+	dSig, err := crypto.Sign(tx.DelegatorSigningHash(origin).Bytes(), delegatorPK)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(append(sig, dSig...)), nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"github.com/ethereum/go-ethereum/crypto"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/vechain/thor/thor"
+)
+
+const signerCacheSize = 16384
+
+// signerCache keeps recently recovered signers across tx instances, so that a tx
+// recovered once, e.g. when it entered the pool, is not recovered again when it's
+// decoded from a block.
+var signerCache = func() *lru.Cache {
+	c, err := lru.New(signerCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}()
+
+// recoverSigner recovers the signer of hash from sig.
+func recoverSigner(hash thor.Bytes32, sig []byte) (thor.Address, error) {
+	key := thor.Blake2b(hash[:], sig)
+	if cached, ok := signerCache.Get(key); ok {
+		return cached.(thor.Address), nil
+	}
+	pub, err := crypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return thor.Address{}, err
+	}
+	signer := thor.Address(crypto.PubkeyToAddress(*pub))
+	signerCache.Add(key, signer)
+	return signer, nil
+}
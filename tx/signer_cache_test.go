@@ -0,0 +1,34 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/thor"
+)
+
+func TestSignerCache(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx1, _ := Sign(new(Builder).Nonce(1).Build(), key)
+
+	cacheKey := thor.Blake2b(tx1.SigningHash().Bytes(), tx1.Signature())
+	assert.False(t, signerCache.Contains(cacheKey))
+	signer, err := tx1.Signer()
+	assert.Nil(t, err)
+	assert.True(t, signerCache.Contains(cacheKey))
+
+	data, _ := rlp.EncodeToBytes(tx1)
+	var tx2 Transaction
+	assert.Nil(t, rlp.DecodeBytes(data, &tx2))
+	signer2, err := tx2.Signer()
+	assert.Nil(t, err)
+	assert.Equal(t, signer, signer2)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), signer2)
+}
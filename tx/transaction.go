@@ -15,7 +15,6 @@ import (
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/vechain/thor/metric"
@@ -23,16 +22,28 @@ import (
 )
 
 var (
-	errIntrinsicGasOverflow = errors.New("intrinsic gas overflow")
+	errIntrinsicGasOverflow  = errors.New("intrinsic gas overflow")
+	errDelegatorNotAvailable = errors.New("delegator not available")
+)
+
+const signatureLength = 65
+
+// Tx types.
+const (
+	TypeLegacy     byte = 0x00
+	TypeDynamicFee byte = 0x51
 )
 
 // Transaction is an immutable tx type.
 type Transaction struct {
+	typ  byte
 	body body
+	fee  dynamicFee // only for TypeDynamicFee
 
 	cache struct {
 		signingHash  atomic.Value
 		signer       atomic.Value
+		delegator    atomic.Value
 		id           atomic.Value
 		unprovedWork atomic.Value
 		size         atomic.Value
@@ -50,11 +61,63 @@ type body struct {
 	Gas          uint64
 	DependsOn    *thor.Bytes32 `rlp:"nil"`
 	Nonce        uint64
-	Reserved     []interface{}
+	Reserved     reserved
 	Signature    []byte
 }
 
+// dynamicFee fee fields of dynamic fee tx.
+// GasPriceCoef is not used by dynamic fee tx.
+type dynamicFee struct {
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+}
+
+// dynamicFeeBody is encoding layout of dynamic fee tx.
+type dynamicFeeBody struct {
+	ChainTag             byte
+	BlockRef             uint64
+	Expiration           uint32
+	Clauses              []*Clause
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	DependsOn            *thor.Bytes32 `rlp:"nil"`
+	Nonce                uint64
+	Reserved             reserved
+	Signature            []byte
+}
+
+// Type returns tx type.
+func (t *Transaction) Type() byte {
+	return t.typ
+}
+
+// IsTyped returns whether the tx is not of legacy type.
+func (t *Transaction) IsTyped() bool {
+	return t.typ != TypeLegacy
+}
+
+// MaxFeePerGas returns max fee per gas of dynamic fee tx.
+// Nil returned for legacy tx.
+func (t *Transaction) MaxFeePerGas() *big.Int {
+	if t.fee.MaxFeePerGas == nil {
+		return nil
+	}
+	return new(big.Int).Set(t.fee.MaxFeePerGas)
+}
+
+// MaxPriorityFeePerGas returns max priority fee per gas of dynamic fee tx.
+// Nil returned for legacy tx.
+func (t *Transaction) MaxPriorityFeePerGas() *big.Int {
+	if t.fee.MaxPriorityFeePerGas == nil {
+		return nil
+	}
+	return new(big.Int).Set(t.fee.MaxPriorityFeePerGas)
+}
+
 // ChainTag returns chain tag.
+// It's the last byte of genesis block ID, and committed in signing hash,
+// so that tx signed for one network can't be replayed on another.
 func (t *Transaction) ChainTag() byte {
 	return t.body.ChainTag
 }
@@ -122,17 +185,33 @@ func (t *Transaction) UnprovedWork() (w *big.Int) {
 // EvaluateWork try to compute work when tx signer assumed.
 func (t *Transaction) EvaluateWork(signer thor.Address) func(nonce uint64) *big.Int {
 	hw := thor.NewBlake2b()
-	rlp.Encode(hw, []interface{}{
-		t.body.ChainTag,
-		t.body.BlockRef,
-		t.body.Expiration,
-		t.body.Clauses,
-		t.body.GasPriceCoef,
-		t.body.Gas,
-		t.body.DependsOn,
-		t.body.Reserved,
-		signer,
-	})
+	if t.typ == TypeLegacy {
+		rlp.Encode(hw, []interface{}{
+			t.body.ChainTag,
+			t.body.BlockRef,
+			t.body.Expiration,
+			t.body.Clauses,
+			t.body.GasPriceCoef,
+			t.body.Gas,
+			t.body.DependsOn,
+			&t.body.Reserved,
+			signer,
+		})
+	} else {
+		hw.Write([]byte{t.typ})
+		rlp.Encode(hw, []interface{}{
+			t.body.ChainTag,
+			t.body.BlockRef,
+			t.body.Expiration,
+			t.body.Clauses,
+			t.fee.MaxPriorityFeePerGas,
+			t.fee.MaxFeePerGas,
+			t.body.Gas,
+			t.body.DependsOn,
+			&t.body.Reserved,
+			signer,
+		})
+	}
 
 	var hashWithoutNonce thor.Bytes32
 	hw.Sum(hashWithoutNonce[:0])
@@ -154,21 +233,49 @@ func (t *Transaction) SigningHash() (hash thor.Bytes32) {
 	defer func() { t.cache.signingHash.Store(hash) }()
 
 	hw := thor.NewBlake2b()
-	rlp.Encode(hw, []interface{}{
-		t.body.ChainTag,
-		t.body.BlockRef,
-		t.body.Expiration,
-		t.body.Clauses,
-		t.body.GasPriceCoef,
-		t.body.Gas,
-		t.body.DependsOn,
-		t.body.Nonce,
-		t.body.Reserved,
-	})
+	if t.typ == TypeLegacy {
+		rlp.Encode(hw, []interface{}{
+			t.body.ChainTag,
+			t.body.BlockRef,
+			t.body.Expiration,
+			t.body.Clauses,
+			t.body.GasPriceCoef,
+			t.body.Gas,
+			t.body.DependsOn,
+			t.body.Nonce,
+			&t.body.Reserved,
+		})
+	} else {
+		// type prefixed, to make signing hashes distinct between types
+		hw.Write([]byte{t.typ})
+		rlp.Encode(hw, []interface{}{
+			t.body.ChainTag,
+			t.body.BlockRef,
+			t.body.Expiration,
+			t.body.Clauses,
+			t.fee.MaxPriorityFeePerGas,
+			t.fee.MaxFeePerGas,
+			t.body.Gas,
+			t.body.DependsOn,
+			t.body.Nonce,
+			&t.body.Reserved,
+		})
+	}
 	hw.Sum(hash[:0])
 	return
 }
 
+// DelegatorSigningHash returns hash of tx components for delegator to sign, by assuming tx origin.
+// It's hash(signingHash, origin).
+func (t *Transaction) DelegatorSigningHash(origin thor.Address) (hash thor.Bytes32) {
+	return thor.Blake2b(t.SigningHash().Bytes(), origin.Bytes())
+}
+
+// Features returns features.
+func (t *Transaction) Features() Features {
+	return t.body.Reserved.Features
+}
+
 // GasPriceCoef returns gas price coef.
 // gas price = bgp + bgp * gpc / 255.
 func (t *Transaction) GasPriceCoef() uint8 {
@@ -210,45 +317,139 @@ func (t *Transaction) Signer() (signer thor.Address, err error) {
 		}
 	}()
 
-	pub, err := crypto.SigToPub(t.SigningHash().Bytes(), t.body.Signature)
+	sig := t.body.Signature
+	if t.Features().IsDelegated() {
+		if len(sig) != signatureLength*2 {
+			return thor.Address{}, errors.New("invalid signature length")
+		}
+		sig = sig[:signatureLength]
+	}
+
+	return recoverSigner(t.SigningHash(), sig)
+}
+
+// Delegator returns delegator(gas payer) of the delegated tx.
+// Nil returned if the tx is not delegated.
+func (t *Transaction) Delegator() (delegator *thor.Address, err error) {
+	if !t.Features().IsDelegated() {
+		return nil, nil
+	}
+	if cached := t.cache.delegator.Load(); cached != nil {
+		addr := cached.(thor.Address)
+		return &addr, nil
+	}
+	defer func() {
+		if err == nil {
+			t.cache.delegator.Store(*delegator)
+		}
+	}()
+
+	origin, err := t.Signer()
 	if err != nil {
-		return thor.Address{}, err
+		return nil, errDelegatorNotAvailable
 	}
-	signer = thor.Address(crypto.PubkeyToAddress(*pub))
-	return
+	addr, err := recoverSigner(t.DelegatorSigningHash(origin), t.body.Signature[signatureLength:])
+	if err != nil {
+		return nil, err
+	}
+	return &addr, nil
 }
 
 // WithSignature create a new tx with signature set.
 func (t *Transaction) WithSignature(sig []byte) *Transaction {
 	newTx := Transaction{
+		typ:  t.typ,
 		body: t.body,
+		fee:  t.fee,
 	}
 	// copy sig
 	newTx.body.Signature = append([]byte(nil), sig...)
 	return &newTx
 }
 
-// HasReservedFields returns if there're reserved fields.
+// HasReservedFields returns if there're reserved fields, or unsupported features.
 // Reserved fields are for backward compatibility purpose.
 func (t *Transaction) HasReservedFields() bool {
-	return len(t.body.Reserved) > 0
+	return len(t.body.Reserved.Unused) > 0 || !t.body.Reserved.Features.isSupported()
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder.
+// Legacy tx is encoded as RLP list, while typed tx is encoded as RLP string of
+// (type || RLP list).
 func (t *Transaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &t.body)
+	if t.typ == TypeLegacy {
+		return rlp.Encode(w, &t.body)
+	}
+	data, err := rlp.EncodeToBytes(&dynamicFeeBody{
+		t.body.ChainTag,
+		t.body.BlockRef,
+		t.body.Expiration,
+		t.body.Clauses,
+		t.fee.MaxPriorityFeePerGas,
+		t.fee.MaxFeePerGas,
+		t.body.Gas,
+		t.body.DependsOn,
+		t.body.Nonce,
+		t.body.Reserved,
+		t.body.Signature,
+	})
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, append([]byte{t.typ}, data...))
 }
 
 // DecodeRLP implements rlp.Decoder
 func (t *Transaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	var body body
-	if err := s.Decode(&body); err != nil {
+	kind, size, err := s.Kind()
+	if err != nil {
 		return err
 	}
-	*t = Transaction{body: body}
+	if kind == rlp.List {
+		var body body
+		if err := s.Decode(&body); err != nil {
+			return err
+		}
+		*t = Transaction{body: body}
+
+		t.cache.size.Store(metric.StorageSize(rlp.ListSize(size)))
+		return nil
+	}
 
-	t.cache.size.Store(metric.StorageSize(rlp.ListSize(size)))
+	data, err := s.Bytes()
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return errors.New("rlp: empty typed tx")
+	}
+	switch data[0] {
+	case TypeDynamicFee:
+		var dfb dynamicFeeBody
+		if err := rlp.DecodeBytes(data[1:], &dfb); err != nil {
+			return err
+		}
+		if dfb.MaxPriorityFeePerGas == nil || dfb.MaxFeePerGas == nil {
+			return errors.New("rlp: dynamic fee fields missing")
+		}
+		*t = Transaction{
+			typ: TypeDynamicFee,
+			body: body{
+				ChainTag:   dfb.ChainTag,
+				BlockRef:   dfb.BlockRef,
+				Expiration: dfb.Expiration,
+				Clauses:    dfb.Clauses,
+				Gas:        dfb.Gas,
+				DependsOn:  dfb.DependsOn,
+				Nonce:      dfb.Nonce,
+				Reserved:   dfb.Reserved,
+				Signature:  dfb.Signature,
+			},
+			fee: dynamicFee{dfb.MaxPriorityFeePerGas, dfb.MaxFeePerGas},
+		}
+	default:
+		return fmt.Errorf("rlp: unsupported tx type %v", data[0])
+	}
 	return nil
 }
 
@@ -265,46 +466,29 @@ func (t *Transaction) Size() metric.StorageSize {
 
 // IntrinsicGas returns intrinsic gas of tx.
 func (t *Transaction) IntrinsicGas() (uint64, error) {
-	if len(t.body.Clauses) == 0 {
-		return thor.TxGas + thor.ClauseGas, nil
-	}
-
 	if cached := t.cache.intrinsicGas.Load(); cached != nil {
 		return cached.(uint64), nil
 	}
 
-	var total = thor.TxGas
-	var overflow bool
-	for _, c := range t.body.Clauses {
-		gas, err := dataGas(c.body.Data)
-		if err != nil {
-			return 0, err
-		}
-		total, overflow = math.SafeAdd(total, gas)
-		if overflow {
-			return 0, errIntrinsicGasOverflow
-		}
-
-		var cgas uint64
-		if c.IsCreatingContract() {
-			// contract creation
-			cgas = thor.ClauseGasContractCreation
-		} else {
-			cgas = thor.ClauseGas
-		}
-
-		total, overflow = math.SafeAdd(total, cgas)
-		if overflow {
-			return 0, errIntrinsicGasOverflow
-		}
+	gas, err := IntrinsicGas(t.body.Clauses...)
+	if err != nil {
+		return 0, err
 	}
-	t.cache.intrinsicGas.Store(total)
-	return total, nil
+	t.cache.intrinsicGas.Store(gas)
+	return gas, nil
 }
 
 // GasPrice returns gas price.
-// gasPrice = baseGasPrice + baseGasPrice * gasPriceCoef / 255
+// For legacy tx, gasPrice = baseGasPrice + baseGasPrice * gasPriceCoef / 255.
+// For dynamic fee tx, gasPrice = min(maxFeePerGas, baseGasPrice + maxPriorityFeePerGas).
 func (t *Transaction) GasPrice(baseGasPrice *big.Int) *big.Int {
+	if t.typ == TypeDynamicFee {
+		x := new(big.Int).Add(baseGasPrice, t.fee.MaxPriorityFeePerGas)
+		if x.Cmp(t.fee.MaxFeePerGas) > 0 {
+			return new(big.Int).Set(t.fee.MaxFeePerGas)
+		}
+		return x
+	}
 	x := big.NewInt(int64(t.body.GasPriceCoef))
 	x.Mul(x, baseGasPrice)
 	x.Div(x, big.NewInt(math.MaxUint8))
@@ -359,6 +543,7 @@ func (t *Transaction) OverallGasPrice(baseGasPrice *big.Int, headBlockNum uint32
 func (t *Transaction) String() string {
 	var (
 		from      string
+		delegator string
 		br        BlockRef
 		dependsOn string
 	)
@@ -369,6 +554,14 @@ func (t *Transaction) String() string {
 		from = signer.String()
 	}
 
+	if d, err := t.Delegator(); err != nil {
+		delegator = "N/A"
+	} else if d == nil {
+		delegator = "nil"
+	} else {
+		delegator = d.String()
+	}
+
 	binary.BigEndian.PutUint64(br[:], t.body.BlockRef)
 	if t.body.DependsOn == nil {
 		dependsOn = "nil"
@@ -378,7 +571,9 @@ func (t *Transaction) String() string {
 
 	return fmt.Sprintf(`
 	Tx(%v, %v)
+	Type:           %v
 	From:           %v
+	Delegator:      %v
 	Clauses:        %v
 	GasPriceCoef:   %v
 	Gas:            %v
@@ -389,7 +584,7 @@ func (t *Transaction) String() string {
 	Nonce:          %v
 	UnprovedWork:   %v	
 	Signature:      0x%x
-`, t.ID(), t.Size(), from, t.body.Clauses, t.body.GasPriceCoef, t.body.Gas,
+`, t.ID(), t.Size(), t.typ, from, delegator, t.body.Clauses, t.body.GasPriceCoef, t.body.Gas,
 		t.body.ChainTag, br.Number(), br[4:], t.body.Expiration, dependsOn, t.body.Nonce, t.UnprovedWork(), t.body.Signature)
 }
 
@@ -421,3 +616,38 @@ func dataGas(data []byte) (uint64, error) {
 	}
 	return gas, nil
 }
+
+// IntrinsicGas calculate intrinsic gas cost for tx with such clauses.
+// It's the sum of base tx cost, per-clause cost and data cost (zero/non-zero bytes).
+func IntrinsicGas(clauses ...*Clause) (uint64, error) {
+	if len(clauses) == 0 {
+		return thor.TxGas + thor.ClauseGas, nil
+	}
+
+	var total = thor.TxGas
+	var overflow bool
+	for _, c := range clauses {
+		gas, err := dataGas(c.body.Data)
+		if err != nil {
+			return 0, err
+		}
+		total, overflow = math.SafeAdd(total, gas)
+		if overflow {
+			return 0, errIntrinsicGasOverflow
+		}
+
+		var cgas uint64
+		if c.IsCreatingContract() {
+			// contract creation
+			cgas = thor.ClauseGasContractCreation
+		} else {
+			cgas = thor.ClauseGas
+		}
+
+		total, overflow = math.SafeAdd(total, cgas)
+		if overflow {
+			return 0, errIntrinsicGasOverflow
+		}
+	}
+	return total, nil
+}
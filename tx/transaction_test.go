@@ -6,10 +6,13 @@
 package tx_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/thor"
@@ -54,3 +57,258 @@ func TestClause(t *testing.T) {
 	tx := new(tx.Builder).Clause(c1).Clause(c1).Build()
 	fmt.Println(tx)
 }
+
+func TestChainTag(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx1, _ := Sign(new(Builder).ChainTag(1).Gas(21000).Build(), key)
+	tx2, _ := Sign(new(Builder).ChainTag(2).Gas(21000).Build(), key)
+
+	// signing hash commits to chain tag
+	assert.NotEqual(t, tx1.SigningHash(), tx2.SigningHash())
+
+	// signature is not reusable on another chain
+	replayed := new(Builder).ChainTag(2).Gas(21000).Build().WithSignature(tx1.Signature())
+	signer1, _ := tx1.Signer()
+	signer2, _ := replayed.Signer()
+	assert.NotEqual(t, signer1, signer2)
+}
+
+func TestMultiClauseTx(t *testing.T) {
+	to := thor.BytesToAddress([]byte("to"))
+	key, _ := crypto.GenerateKey()
+
+	tx1 := new(Builder).
+		ChainTag(1).
+		Clause(NewClause(&to).WithValue(big.NewInt(1))).
+		Clause(NewClause(&to).WithData([]byte{1, 2, 3})).
+		Clause(NewClause(nil)).
+		Gas(100000).
+		Build()
+	sig, _ := crypto.Sign(tx1.SigningHash().Bytes(), key)
+	tx1 = tx1.WithSignature(sig)
+
+	data, err := rlp.EncodeToBytes(tx1)
+	assert.Nil(t, err)
+
+	var tx2 Transaction
+	assert.Nil(t, rlp.DecodeBytes(data, &tx2))
+	assert.Equal(t, tx1.ID(), tx2.ID())
+	assert.Equal(t, 3, len(tx2.Clauses()))
+	assert.True(t, tx2.Clauses()[2].IsCreatingContract())
+
+	signer, err := tx2.Signer()
+	assert.Nil(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), signer)
+}
+
+func TestDelegatedTx(t *testing.T) {
+	originKey, _ := crypto.GenerateKey()
+	delegatorKey, _ := crypto.GenerateKey()
+	origin := thor.Address(crypto.PubkeyToAddress(originKey.PublicKey))
+	delegator := thor.Address(crypto.PubkeyToAddress(delegatorKey.PublicKey))
+
+	var features Features
+	features.SetDelegated(true)
+	tx1 := new(Builder).Features(features).Gas(21000).Build()
+	assert.False(t, tx1.HasReservedFields())
+
+	_, err := Sign(tx1, originKey)
+	assert.NotNil(t, err)
+
+	tx1, err = SignDelegated(tx1, originKey, delegatorKey)
+	assert.Nil(t, err)
+
+	data, _ := rlp.EncodeToBytes(tx1)
+	var tx2 Transaction
+	assert.Nil(t, rlp.DecodeBytes(data, &tx2))
+	assert.True(t, tx2.Features().IsDelegated())
+
+	signer, err := tx2.Signer()
+	assert.Nil(t, err)
+	assert.Equal(t, origin, signer)
+
+	d, err := tx2.Delegator()
+	assert.Nil(t, err)
+	assert.Equal(t, delegator, *d)
+
+	// tx without features keeps the legacy encoding
+	plain := new(Builder).Build()
+	data, _ = rlp.EncodeToBytes(plain)
+	var legacy struct {
+		ChainTag     byte
+		BlockRef     uint64
+		Expiration   uint32
+		Clauses      []*Clause
+		GasPriceCoef uint8
+		Gas          uint64
+		DependsOn    *thor.Bytes32 `rlp:"nil"`
+		Nonce        uint64
+		Reserved     []interface{}
+		Signature    []byte
+	}
+	assert.Nil(t, rlp.DecodeBytes(data, &legacy))
+	assert.Equal(t, 0, len(legacy.Reserved))
+}
+
+func TestTxExpiration(t *testing.T) {
+	tx := new(Builder).BlockRef(NewBlockRef(100)).Expiration(10).Build()
+	assert.Equal(t, uint32(100), tx.BlockRef().Number())
+
+	assert.False(t, tx.IsExpired(99))
+	assert.False(t, tx.IsExpired(110))
+	assert.True(t, tx.IsExpired(111))
+
+	// no overflow
+	tx = new(Builder).BlockRef(NewBlockRef(math.MaxUint32)).Expiration(math.MaxUint32).Build()
+	assert.False(t, tx.IsExpired(math.MaxUint32))
+}
+
+func TestIntrinsicGas(t *testing.T) {
+	gas, err := IntrinsicGas()
+	assert.Nil(t, err)
+	assert.Equal(t, thor.TxGas+thor.ClauseGas, gas)
+
+	gas, err = IntrinsicGas(NewClause(&thor.Address{}))
+	assert.Nil(t, err)
+	assert.Equal(t, thor.TxGas+thor.ClauseGas, gas)
+
+	gas, err = IntrinsicGas(NewClause(nil))
+	assert.Nil(t, err)
+	assert.Equal(t, thor.TxGas+thor.ClauseGasContractCreation, gas)
+
+	// 4 per zero byte, 68 per non-zero byte
+	gas, err = IntrinsicGas(NewClause(&thor.Address{}).WithData([]byte{0, 1}))
+	assert.Nil(t, err)
+	assert.Equal(t, thor.TxGas+thor.ClauseGas+4+68, gas)
+
+	tx := new(Builder).Clause(NewClause(nil)).Clause(NewClause(&thor.Address{})).Build()
+	gas, err = tx.IntrinsicGas()
+	assert.Nil(t, err)
+	assert.Equal(t, thor.TxGas+thor.ClauseGasContractCreation+thor.ClauseGas, gas)
+}
+
+func TestDynamicFeeTx(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	origin := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+
+	builder := new(Builder).ChainTag(1).Gas(21000).Nonce(1).Clause(NewClause(nil))
+	legacyTx := builder.Build()
+	tx1 := builder.DynamicFee(big.NewInt(10), big.NewInt(100)).Build()
+
+	assert.Equal(t, TypeLegacy, legacyTx.Type())
+	assert.Equal(t, TypeDynamicFee, tx1.Type())
+	assert.True(t, tx1.IsTyped())
+	assert.NotEqual(t, legacyTx.SigningHash(), tx1.SigningHash())
+
+	tx1, err := Sign(tx1, key)
+	assert.Nil(t, err)
+
+	data, _ := rlp.EncodeToBytes(tx1)
+	var tx2 Transaction
+	assert.Nil(t, rlp.DecodeBytes(data, &tx2))
+	assert.Equal(t, tx1.ID(), tx2.ID())
+	assert.Equal(t, TypeDynamicFee, tx2.Type())
+	assert.Equal(t, big.NewInt(10), tx2.MaxPriorityFeePerGas())
+	assert.Equal(t, big.NewInt(100), tx2.MaxFeePerGas())
+
+	signer, err := tx2.Signer()
+	assert.Nil(t, err)
+	assert.Equal(t, origin, signer)
+
+	// capped by max fee
+	assert.Equal(t, big.NewInt(60), tx2.GasPrice(big.NewInt(50)))
+	assert.Equal(t, big.NewInt(100), tx2.GasPrice(big.NewInt(95)))
+
+	// legacy tx in block body still decodes
+	txs := Transactions{legacyTx, tx1}
+	data, _ = rlp.EncodeToBytes(txs)
+	var txs2 Transactions
+	assert.Nil(t, rlp.DecodeBytes(data, &txs2))
+	assert.Equal(t, TypeLegacy, txs2[0].Type())
+	assert.Equal(t, TypeDynamicFee, txs2[1].Type())
+}
+
+func TestTxJSON(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	to := thor.BytesToAddress([]byte("to"))
+
+	tx1, _ := Sign(new(Builder).
+		ChainTag(1).
+		BlockRef(NewBlockRef(100)).
+		Expiration(32).
+		Clause(NewClause(&to).WithValue(big.NewInt(10000)).WithData([]byte{1, 2})).
+		Clause(NewClause(nil)).
+		GasPriceCoef(128).
+		Gas(50000).
+		Nonce(12345678).
+		Build(), key)
+
+	data, err := json.Marshal(tx1)
+	assert.Nil(t, err)
+
+	var tx2 *Transaction
+	assert.Nil(t, json.Unmarshal(data, &tx2))
+	assert.Equal(t, tx1.ID(), tx2.ID())
+
+	tx3, _ := Sign(new(Builder).Gas(21000).DynamicFee(big.NewInt(1), big.NewInt(2)).Build(), key)
+	data, err = json.Marshal(tx3)
+	assert.Nil(t, err)
+	var tx4 *Transaction
+	assert.Nil(t, json.Unmarshal(data, &tx4))
+	assert.Equal(t, tx3.ID(), tx4.ID())
+	assert.Equal(t, TypeDynamicFee, tx4.Type())
+
+	// strict decoding
+	var v Transaction
+	assert.NotNil(t, json.Unmarshal([]byte(`{"chainTag":"0x1"}`), &v))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"chainTag":"0x1","blockRef":"0x0000000000000000","expiration":"0x0","clauses":[],"gasPriceCoef":"0x0","gas":"0x0","dependsOn":null,"nonce":"0x0","foo":1}`), &v))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"chainTag":"0x01","blockRef":"0x0000000000000000","expiration":"0x0","clauses":[],"gasPriceCoef":"0x0","gas":"0x0","dependsOn":null,"nonce":"0x0"}`), &v))
+	assert.Nil(t, json.Unmarshal([]byte(`{"chainTag":"0x1","blockRef":"0x0000000000000000","expiration":"0x0","clauses":[],"gasPriceCoef":"0x0","gas":"0x0","dependsOn":null,"nonce":"0x0"}`), &v))
+
+	// address checksum
+	var c Clause
+	assert.Nil(t, json.Unmarshal([]byte(`{"to":"0x7567d83b7b8d80addcb281a71d54fc7b3364ffed","value":"0x0","data":"0x"}`), &c))
+	assert.Nil(t, json.Unmarshal([]byte(`{"to":"0x7567D83b7b8d80ADdCb281A71d54Fc7B3364ffed","value":"0x0","data":"0x"}`), &c))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"to":"0x7567D83b7b8d80ADdCb281A71d54Fc7B3364ffeD","value":"0x0","data":"0x"}`), &c))
+}
+
+func TestProvedWork(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := thor.Address(crypto.PubkeyToAddress(key.PublicKey))
+
+	ref := NewBlockRef(10)
+	var refID thor.Bytes32
+	copy(refID[:], ref[:])
+	getID := func(uint32) thor.Bytes32 { return refID }
+
+	builder := new(Builder).BlockRef(ref).Gas(21000)
+
+	// grind a nonce with noticeable work
+	eval := builder.Build().EvaluateWork(signer)
+	var (
+		nonce   uint64
+		maxWork = &big.Int{}
+	)
+	for i := uint64(0); i < 10000; i++ {
+		if work := eval(i); work.Cmp(maxWork) > 0 {
+			nonce, maxWork = i, work
+		}
+	}
+	tx1, _ := Sign(builder.Nonce(nonce).Build(), key)
+	assert.Equal(t, maxWork, tx1.UnprovedWork())
+
+	baseGasPrice := big.NewInt(1e15)
+	gasPrice := tx1.GasPrice(baseGasPrice)
+
+	// boosted, but capped
+	overall := tx1.OverallGasPrice(baseGasPrice, 11, getID)
+	assert.True(t, overall.Cmp(gasPrice) > 0)
+	assert.True(t, overall.Cmp(new(big.Int).Add(gasPrice, baseGasPrice)) <= 0)
+
+	// block ref not on chain
+	assert.Equal(t, gasPrice, tx1.OverallGasPrice(baseGasPrice, 11, func(uint32) thor.Bytes32 { return thor.Bytes32{} }))
+	// ref block not yet in the past
+	assert.Equal(t, gasPrice, tx1.OverallGasPrice(baseGasPrice, 10, getID))
+	// work delayed too long
+	assert.Equal(t, gasPrice, tx1.OverallGasPrice(baseGasPrice, 11+thor.MaxTxWorkDelay, getID))
+}
@@ -8,7 +8,7 @@ package txpool
 import Cache "github.com/vechain/thor/cache"
 
 type cache interface {
-	Set(key, value interface{})
+	Set(key, value interface{}) (evicted *Cache.Entry)
 	Get(key interface{}) (interface{}, bool)
 	Remove(key interface{}) bool
 	Len() int
@@ -0,0 +1,149 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"math/big"
+
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// TxSummary brief of tx in pool.
+type TxSummary struct {
+	ID              thor.Bytes32
+	Origin          thor.Address
+	Gas             uint64
+	OverallGasPrice *big.Int // zero for queued txs
+	BlockRef        uint32   // number of referenced block
+	Expiration      uint32
+	Local           bool
+	ArrivalTime     int64  // unix time when tx entered the pool
+	QueuedReason    string // why the tx is not executable yet, empty for pending txs
+}
+
+// Content returns pending and queued txs in pool, grouped by signer.
+func (pool *TxPool) Content() (pending, queued map[thor.Address]tx.Transactions) {
+	pending = make(map[thor.Address]tx.Transactions)
+	queued = make(map[thor.Address]tx.Transactions)
+
+	for _, obj := range pool.dumpAll() {
+		if obj.status == Pending {
+			pending[obj.signer] = append(pending[obj.signer], obj.tx)
+		} else {
+			queued[obj.signer] = append(queued[obj.signer], obj.tx)
+		}
+	}
+	return
+}
+
+// Inspect returns summaries of pending and queued txs in pool, grouped by signer.
+func (pool *TxPool) Inspect() (pending, queued map[thor.Address][]*TxSummary) {
+	pending = make(map[thor.Address][]*TxSummary)
+	queued = make(map[thor.Address][]*TxSummary)
+
+	bestNum := pool.chain.BestBlock().Header().Number()
+	for _, obj := range pool.dumpAll() {
+		summary := pool.summarize(obj, bestNum)
+		if obj.status == Pending {
+			pending[obj.signer] = append(pending[obj.signer], summary)
+		} else {
+			queued[obj.signer] = append(queued[obj.signer], summary)
+		}
+	}
+	return
+}
+
+// Lookup returns the summary of tx in pool by ID, and whether it's pending.
+// Nil summary returned if not found.
+func (pool *TxPool) Lookup(id thor.Bytes32) (summary *TxSummary, pending bool) {
+	if pool.entry.isDirty() {
+		pool.updateData(pool.chain.BestBlock())
+	}
+	obj := pool.entry.find(id)
+	if obj == nil {
+		return nil, false
+	}
+	return pool.summarize(obj, pool.chain.BestBlock().Header().Number()), obj.status == Pending
+}
+
+func (pool *TxPool) summarize(obj *txObject, bestNum uint32) *TxSummary {
+	summary := &TxSummary{
+		ID:              obj.tx.ID(),
+		Origin:          obj.signer,
+		Gas:             obj.tx.Gas(),
+		OverallGasPrice: new(big.Int).Set(obj.overallGP),
+		BlockRef:        obj.tx.BlockRef().Number(),
+		Expiration:      obj.tx.Expiration(),
+		Local:           obj.local,
+		ArrivalTime:     obj.creationTime,
+	}
+	if obj.status != Pending {
+		summary.QueuedReason = obj.queuedReason(pool.chain, bestNum)
+	}
+	return summary
+}
+
+// Stats counts of txs in pool.
+type Stats struct {
+	Pending int
+	Queued  int
+	Local   int
+	Signers int
+}
+
+// Stats returns counts of txs in pool.
+func (pool *TxPool) Stats() *Stats {
+	var stats Stats
+	signers := make(map[thor.Address]bool)
+	for _, obj := range pool.dumpAll() {
+		if obj.status == Pending {
+			stats.Pending++
+		} else {
+			stats.Queued++
+		}
+		if obj.local {
+			stats.Local++
+		}
+		signers[obj.signer] = true
+	}
+	stats.Signers = len(signers)
+	return &stats
+}
+
+// dumpAll returns all tx objects with status refreshed.
+func (pool *TxPool) dumpAll() txObjects {
+	if pool.entry.isDirty() {
+		pool.updateData(pool.chain.BestBlock())
+	}
+	return pool.entry.dumpAll()
+}
+
+// PendingTx pending tx with its pool metadata.
+type PendingTx struct {
+	Tx              *tx.Transaction
+	Origin          thor.Address
+	OverallGasPrice *big.Int
+	ArrivalTime     int64 // unix time when tx entered the pool
+}
+
+// PendingTxs returns pending txs with metadata, in no particular order.
+func (pool *TxPool) PendingTxs() []*PendingTx {
+	if pool.entry.isDirty() {
+		pool.updateData(pool.chain.BestBlock())
+	}
+	objs := pool.entry.dumpPending(false)
+	txs := make([]*PendingTx, 0, len(objs))
+	for _, obj := range objs {
+		txs = append(txs, &PendingTx{
+			Tx:              obj.tx,
+			Origin:          obj.signer,
+			OverallGasPrice: new(big.Int).Set(obj.overallGP),
+			ArrivalTime:     obj.creationTime,
+		})
+	}
+	return txs
+}
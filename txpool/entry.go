@@ -6,11 +6,14 @@
 package txpool
 
 import (
+	"bytes"
 	Sort "sort"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/rlp"
 	Cache "github.com/vechain/thor/cache"
 	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
 )
 
 type mechanism int
@@ -27,12 +30,18 @@ type entry struct {
 	pending txObjects
 	sorted  bool
 	quota   quota
+	// max txs per signer
+	quotaLimit uint
+	// tx objects indexed by signer
+	bySigner map[thor.Address]map[thor.Bytes32]*txObject
 }
 
-func newEntry(size int) *entry {
+func newEntry(size int, quotaLimit uint) *entry {
 	e := &entry{
-		all:   newPriorCache(size),
-		quota: make(quota),
+		all:        newPriorCache(size),
+		quota:      make(quota),
+		quotaLimit: quotaLimit,
+		bySigner:   make(map[thor.Address]map[thor.Bytes32]*txObject),
 	}
 	switch cacheMechanism {
 	case random:
@@ -55,35 +64,132 @@ func (e *entry) find(id thor.Bytes32) *txObject {
 	return nil
 }
 
-func (e *entry) delete(id thor.Bytes32) {
+// delete deletes the tx object and returns it.
+// Nil returned if not found.
+func (e *entry) delete(id thor.Bytes32) *txObject {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
 	if value, ok := e.all.Get(id); ok {
 		if obj, ok := value.(*txObject); ok {
-			e.quota.dec(obj.signer)
 			e.all.Remove(id)
-			obj.deleted = true
+			e.discard(obj)
+			return obj
 		}
 	}
+	return nil
 }
 
-func (e *entry) save(obj *txObject) error {
+// save saves the tx object, and returns those dropped, i.e. evicted if the cache is full.
+func (e *entry) save(obj *txObject) (txObjects, error) {
+	return e.replace(nil, obj)
+}
+
+// replace saves the tx object in place of the old one of the same signer.
+// The old one is deleted only after the new one saved, and kept with an error returned if
+// the new one evicted at once. Objects dropped are returned.
+func (e *entry) replace(old, obj *txObject) (txObjects, error) {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
+	if old != nil {
+		if _, ok := e.all.Get(old.tx.ID()); !ok {
+			old = nil
+		}
+	}
+
 	if _, ok := e.all.Get(obj.tx.ID()); !ok {
-		if e.quota.quota(obj.signer) >= quotaSignerTx {
-			return rejectedTxErr{"quota exceeds limit"}
+		// the quota taken by the old one is to be released
+		if old == nil && e.quota.quota(obj.signer) >= e.quotaLimit {
+			return nil, rejectedTxErr{"quota exceeds limit"}
 		}
 		e.quota.inc(obj.signer)
+		objs := e.bySigner[obj.signer]
+		if objs == nil {
+			objs = make(map[thor.Bytes32]*txObject)
+			e.bySigner[obj.signer] = objs
+		}
+		objs[obj.tx.ID()] = obj
 	}
 
-	e.all.Set(obj.tx.ID(), obj)
+	var dropped txObjects
+	if evicted := e.all.Set(obj.tx.ID(), obj); evicted != nil {
+		if evictedObj, ok := evicted.Value.(*txObject); ok {
+			e.discard(evictedObj)
+			if evictedObj == obj && old != nil {
+				// the old one kept
+				return nil, rejectedTxErr{"replacement tx evicted by full pool"}
+			}
+			dropped = append(dropped, evictedObj)
+			if evictedObj == obj || evictedObj == old {
+				old = nil
+			}
+		}
+	}
+	if old != nil {
+		e.all.Remove(old.tx.ID())
+		e.discard(old)
+		dropped = append(dropped, old)
+	}
 	e.dirty = true
+	return dropped, nil
+}
+
+// discard releases quota and index of the object removed from cache.
+func (e *entry) discard(obj *txObject) {
+	e.quota.dec(obj.signer)
+	e.unindex(obj)
+	obj.deleted = true
+}
+
+// findReplaceable finds tx object of the signer, which differs from trx only in price, gas or expiration.
+// Txs of same nonce but different clauses or dependency are distinct ones, and not replaceable.
+func (e *entry) findReplaceable(signer thor.Address, trx *tx.Transaction) *txObject {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for id, obj := range e.bySigner[signer] {
+		if _, ok := e.all.Get(id); !ok {
+			// evicted by cache
+			e.unindex(obj)
+			continue
+		}
+		if isReplaceable(obj.tx, trx) {
+			return obj
+		}
+	}
 	return nil
 }
 
+// isReplaceable returns whether the two txs have same block ref, nonce, dependency and clauses.
+func isReplaceable(a, b *tx.Transaction) bool {
+	if a.BlockRef() != b.BlockRef() || a.Nonce() != b.Nonce() {
+		return false
+	}
+	depA, depB := a.DependsOn(), b.DependsOn()
+	if (depA == nil) != (depB == nil) || (depA != nil && *depA != *depB) {
+		return false
+	}
+	clausesA, err := rlp.EncodeToBytes(a.Clauses())
+	if err != nil {
+		return false
+	}
+	clausesB, err := rlp.EncodeToBytes(b.Clauses())
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(clausesA, clausesB)
+}
+
+func (e *entry) unindex(obj *txObject) {
+	if objs := e.bySigner[obj.signer]; objs != nil {
+		delete(objs, obj.tx.ID())
+		if len(objs) == 0 {
+			delete(e.bySigner, obj.signer)
+		}
+	}
+}
+
 func (e *entry) dumpPending(sort bool) txObjects {
 	e.lock.Lock()
 	defer e.lock.Unlock()
@@ -0,0 +1,36 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import "github.com/vechain/thor/tx"
+
+// TxEventKind kind of tx event.
+type TxEventKind int
+
+// kinds of tx event
+const (
+	TxExecutable TxEventKind = iota // tx becomes executable
+	TxDropped                       // tx dropped out of pool before being included
+	TxIncluded                      // tx included in trunk
+)
+
+func (k TxEventKind) String() string {
+	switch k {
+	case TxExecutable:
+		return "executable"
+	case TxDropped:
+		return "dropped"
+	case TxIncluded:
+		return "included"
+	}
+	return "unknown"
+}
+
+// TxEvent emitted when state of tx in pool changed.
+type TxEvent struct {
+	Tx   *tx.Transaction
+	Kind TxEventKind
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/vechain/thor/thor"
+	"github.com/vechain/thor/tx"
+)
+
+// Filter is admission policy evaluated before tx accepted by pool.
+// The tx is rejected if non-nil error returned.
+type Filter func(trx *tx.Transaction, origin thor.Address, baseGasPrice *big.Int) error
+
+// MinGasPrice rejects txs with gas price lower than the given price.
+func MinGasPrice(price *big.Int) Filter {
+	price = new(big.Int).Set(price)
+	return func(trx *tx.Transaction, _ thor.Address, baseGasPrice *big.Int) error {
+		if trx.GasPrice(baseGasPrice).Cmp(price) < 0 {
+			return fmt.Errorf("gas price lower than %v", price)
+		}
+		return nil
+	}
+}
+
+// AllowSenders only accepts txs from the given origins.
+func AllowSenders(origins ...thor.Address) Filter {
+	set := make(map[thor.Address]bool, len(origins))
+	for _, origin := range origins {
+		set[origin] = true
+	}
+	return func(_ *tx.Transaction, origin thor.Address, _ *big.Int) error {
+		if !set[origin] {
+			return errors.New("sender not allowed")
+		}
+		return nil
+	}
+}
+
+// DenySenders rejects txs from the given origins.
+func DenySenders(origins ...thor.Address) Filter {
+	set := make(map[thor.Address]bool, len(origins))
+	for _, origin := range origins {
+		set[origin] = true
+	}
+	return func(_ *tx.Transaction, origin thor.Address, _ *big.Int) error {
+		if set[origin] {
+			return errors.New("sender denied")
+		}
+		return nil
+	}
+}
+
+// MaxClauseDataSize rejects txs with any clause data larger than size.
+func MaxClauseDataSize(size int) Filter {
+	return func(trx *tx.Transaction, _ thor.Address, _ *big.Int) error {
+		for _, clause := range trx.Clauses() {
+			if len(clause.Data()) > size {
+				return fmt.Errorf("clause data exceeds %v bytes", size)
+			}
+		}
+		return nil
+	}
+}
+
+// ThrottleContractCreation accepts at most limit contract-creating txs in each interval.
+func ThrottleContractCreation(limit int, interval time.Duration) Filter {
+	var (
+		lock        sync.Mutex
+		windowStart time.Time
+		count       int
+	)
+	return func(trx *tx.Transaction, _ thor.Address, _ *big.Int) error {
+		creating := false
+		for _, clause := range trx.Clauses() {
+			if clause.IsCreatingContract() {
+				creating = true
+				break
+			}
+		}
+		if !creating {
+			return nil
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		now := time.Now()
+		if now.Sub(windowStart) >= interval {
+			windowStart = now
+			count = 0
+		}
+		if count >= limit {
+			return errors.New("too many contract creations")
+		}
+		count++
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2018 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package txpool
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/tx"
+)
+
+var errNoActiveJournal = errors.New("no active journal")
+
+// journal persists local txs as a stream of RLP encoded txs,
+// so that they survive node restarts.
+type journal struct {
+	path   string
+	lock   sync.Mutex
+	writer *os.File
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load parses txs from journal file, and feeds them to add.
+// Errors returned by add are ignored, since txs may have been included or expired.
+func (j *journal) load(add func(*tx.Transaction) error) (total int, dropped int, err error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	file, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	stream := rlp.NewStream(file, 0)
+	for {
+		var trx *tx.Transaction
+		if err := stream.Decode(&trx); err != nil {
+			if err == io.EOF {
+				return total, dropped, nil
+			}
+			// tail may be partially written when crashed
+			return total, dropped, err
+		}
+		total++
+		if err := add(trx); err != nil {
+			dropped++
+		}
+	}
+}
+
+// insert appends tx to journal.
+func (j *journal) insert(trx *tx.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return errNoActiveJournal
+	}
+	return rlp.Encode(j.writer, trx)
+}
+
+// rotate regenerates journal file with given txs, and reopens it for appending.
+func (j *journal) rotate(txs tx.Transactions) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+		j.writer = nil
+	}
+
+	newPath := j.path + ".new"
+	file, err := os.OpenFile(newPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	for _, trx := range txs {
+		if err := rlp.Encode(file, trx); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(newPath, j.path); err != nil {
+		return err
+	}
+
+	writer, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	j.writer = writer
+	return nil
+}
+
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.writer == nil {
+		return nil
+	}
+	err := j.writer.Close()
+	j.writer = nil
+	return err
+}
@@ -19,10 +19,13 @@ func newPriorCache(limit int) *priorCache {
 	}
 }
 
-func (pc *priorCache) Set(key, value interface{}) {
+func (pc *priorCache) Set(key, value interface{}) *Cache.Entry {
 	if obj, ok := value.(*txObject); ok {
-		pc.cache.Set(obj.tx.ID(), obj, float64(obj.overallGP.Uint64()))
+		if evicted := pc.cache.Set(obj.tx.ID(), obj, float64(obj.overallGP.Uint64())); evicted != nil {
+			return &evicted.Entry
+		}
 	}
+	return nil
 }
 
 func (pc *priorCache) Get(key interface{}) (interface{}, bool) {
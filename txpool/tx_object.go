@@ -8,7 +8,6 @@ package txpool
 import (
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/log"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/thor"
 	"github.com/vechain/thor/tx"
@@ -29,24 +28,48 @@ type txObject struct {
 	overallGP    *big.Int
 	creationTime int64
 	deleted      bool
+	local        bool // submitted locally
 }
 
-func (txObjs *txObject) currentState(chain *chain.Chain, bestBlockNum uint32) objectStatus {
+// currentState returns the status of tx according to trunk.
+// An error returned if the tx will never be executable, e.g. the depended tx reverted.
+func (txObjs *txObject) currentState(chain *chain.Chain, bestBlockNum uint32) (objectStatus, error) {
 	dependsOn := txObjs.tx.DependsOn()
 	if dependsOn != nil {
-		if _, err := chain.GetTrunkTransactionMeta(*dependsOn); err != nil {
+		meta, err := chain.GetTrunkTransactionMeta(*dependsOn)
+		if err != nil {
 			if !chain.IsNotFound(err) {
-				log.Error("err", err)
+				log.Error("failed to get depended tx", "err", err)
 			}
-			return Queued
+			return Queued, nil
+		}
+		if meta.Reverted {
+			return Queued, rejectedTxErr{"depended tx reverted"}
 		}
 	}
 
 	if txObjs.tx.BlockRef().Number() > bestBlockNum+1 {
-		return Queued
+		return Queued, nil
 	}
 
-	return Pending
+	return Pending, nil
+}
+
+// queuedReason explains why the tx is not executable according to trunk.
+func (txObjs *txObject) queuedReason(chain *chain.Chain, bestBlockNum uint32) string {
+	if dependsOn := txObjs.tx.DependsOn(); dependsOn != nil {
+		meta, err := chain.GetTrunkTransactionMeta(*dependsOn)
+		if err != nil {
+			return "depended tx not packed yet"
+		}
+		if meta.Reverted {
+			return "depended tx reverted"
+		}
+	}
+	if txObjs.tx.BlockRef().Number() > bestBlockNum+1 {
+		return "block ref ahead of best block"
+	}
+	return "waiting for pool update"
 }
 
 type txObjects []*txObject
@@ -7,11 +7,14 @@ package txpool
 
 import (
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/inconshreveable/log15"
 	"github.com/vechain/thor/chain"
 	"github.com/vechain/thor/co"
+	"github.com/vechain/thor/metric"
 	"github.com/vechain/thor/runtime"
 	"github.com/vechain/thor/state"
 	"github.com/vechain/thor/thor"
@@ -19,44 +22,72 @@ import (
 )
 
 const (
-	maxTxSize      = 32 * 1024 // Reject transactions over 32KB to prevent DOS attacks
-	quotaSignerTx  = 100       // Each signer saves up to 100 txs
-	cacheMechanism = prior
+	maxTxSize            = 32 * 1024 // Reject transactions over 32KB to prevent DOS attacks
+	replacementPriceBump = 10        // Minimum price bump (percentage) to replace pending tx of same content but price
+	cacheMechanism       = prior
+)
+
+var log = log15.New("pkg", "txpool")
+
+var (
+	txCounter       = metric.NewCounterVec("txpool", "txs_total", "count of txs submitted to pool", "result")
+	txAddedCounter  = txCounter.WithLabelValues("added")
+	txRejectCounter = txCounter.WithLabelValues("rejected")
+	pendingGauge    = metric.NewGauge("txpool", "pending", "count of executable txs in pool")
+	queuedGauge     = metric.NewGauge("txpool", "queued", "count of non-executable txs in pool")
 )
 
 //PoolConfig PoolConfig
 type PoolConfig struct {
-	PoolSize int           // Maximum number of executable transaction slots for all accounts
-	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+	PoolSize        int           // Maximum number of executable transaction slots for all accounts
+	LimitPerAccount uint          // Maximum number of transactions each signer can hold in pool
+	Lifetime        time.Duration // Maximum amount of time non-executable transaction are queued
+	Journal         string        // Path of journal file to persist local txs, empty to disable
+	Filters         []Filter      // Admission filters registered at construction
 }
 
-//DefaultTxPoolConfig DefaultTxPoolConfig
-var defaultTxPoolConfig = PoolConfig{
-	PoolSize: 20000,
-	Lifetime: 1000,
+// DefaultPoolConfig default pool config.
+// When pool is full, txs with the lowest overall gas price are evicted first.
+var DefaultPoolConfig = PoolConfig{
+	PoolSize:        20000,
+	LimitPerAccount: 100,
+	Lifetime:        1000 * time.Second,
 }
 
 //TxPool TxPool
 type TxPool struct {
-	config PoolConfig
-	chain  *chain.Chain
-	stateC *state.Creator
-	goes   co.Goes
-	done   chan struct{}
-	txFeed event.Feed
-	scope  event.SubscriptionScope
-	entry  *entry
+	config    PoolConfig
+	chain     *chain.Chain
+	stateC    *state.Creator
+	goes      co.Goes
+	done      chan struct{}
+	txFeed    event.Feed
+	eventFeed event.Feed
+	scope     event.SubscriptionScope
+	entry     *entry
+	journal   *journal
+
+	filtersLock sync.RWMutex
+	filters     []Filter
+
+	forkConfig thor.ForkConfig
 }
 
 //New construct a new txpool
-func New(chain *chain.Chain, stateC *state.Creator) *TxPool {
+func New(chain *chain.Chain, stateC *state.Creator, config PoolConfig, forkConfig thor.ForkConfig) *TxPool {
 	pool := &TxPool{
-		config: defaultTxPoolConfig,
-		chain:  chain,
-		stateC: stateC,
-		done:   make(chan struct{}),
+		config:     config,
+		chain:      chain,
+		stateC:     stateC,
+		done:       make(chan struct{}),
+		forkConfig: forkConfig,
+		filters:    append([]Filter(nil), config.Filters...),
+	}
+	pool.entry = newEntry(pool.config.PoolSize, pool.config.LimitPerAccount)
+	if config.Journal != "" {
+		pool.journal = newJournal(config.Journal)
+		pool.loadJournal()
 	}
-	pool.entry = newEntry(pool.config.PoolSize)
 	pool.goes.Go(pool.updateLoop)
 	return pool
 }
@@ -66,53 +97,133 @@ func (pool *TxPool) Close() {
 	close(pool.done)
 	pool.scope.Close()
 	pool.goes.Wait()
+	if pool.journal != nil {
+		pool.journal.close()
+	}
 }
 
-//Add transaction
-func (pool *TxPool) Add(txs ...*tx.Transaction) error {
-	for _, tx := range txs {
-		tx := tx // it's for closure
-		txID := tx.ID()
+// AddFilter registers admission filter.
+// Filters are evaluated in registration order, after basic validation passed.
+func (pool *TxPool) AddFilter(filter Filter) {
+	pool.filtersLock.Lock()
+	defer pool.filtersLock.Unlock()
 
-		repeatedTx, err := pool.isAlreadyInChain(txID)
-		if err != nil {
-			return err
-		}
-		if repeatedTx {
-			return rejectedTxErr{"transaction already packed"}
-		}
+	pool.filters = append(pool.filters, filter)
+}
+
+func (pool *TxPool) filter(tx *tx.Transaction, origin thor.Address, baseGasPrice *big.Int) error {
+	pool.filtersLock.RLock()
+	defer pool.filtersLock.RUnlock()
 
-		if obj := pool.entry.find(txID); obj != nil {
-			return rejectedTxErr{"known transaction"}
+	for _, filter := range pool.filters {
+		if err := filter(tx, origin, baseGasPrice); err != nil {
+			return rejectedTxErr{"policy: " + err.Error()}
 		}
+	}
+	return nil
+}
 
-		// If the transaction fails basic validation, discard it
-		signer, err := pool.validateTx(tx)
-		if err != nil {
+//Add transaction
+func (pool *TxPool) Add(txs ...*tx.Transaction) error {
+	for _, tx := range txs {
+		if err := pool.add(tx, false); err != nil {
+			txRejectCounter.Inc()
 			return err
 		}
+		txAddedCounter.Inc()
+	}
+	return nil
+}
 
-		if err := pool.entry.save(&txObject{
-			tx:           tx,
-			signer:       signer,
-			overallGP:    new(big.Int),
-			creationTime: time.Now().Unix(),
-			status:       Queued,
-		}); err != nil {
+// AddLocal adds txs submitted locally.
+// Local txs are journaled if journal enabled, and re-injected after restart.
+func (pool *TxPool) AddLocal(txs ...*tx.Transaction) error {
+	for _, tx := range txs {
+		if err := pool.add(tx, true); err != nil {
+			txRejectCounter.Inc()
 			return err
 		}
+		txAddedCounter.Inc()
+	}
+	return nil
+}
+
+func (pool *TxPool) add(tx *tx.Transaction, local bool) error {
+	txID := tx.ID()
+
+	repeatedTx, err := pool.isAlreadyInChain(txID)
+	if err != nil {
+		return err
+	}
+	if repeatedTx {
+		return rejectedTxErr{"transaction already packed"}
+	}
+
+	if obj := pool.entry.find(txID); obj != nil {
+		return rejectedTxErr{"known transaction"}
+	}
+
+	// If the transaction fails basic validation, discard it
+	signer, baseGasPrice, err := pool.validateTx(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := pool.filter(tx, signer, baseGasPrice); err != nil {
+		return err
+	}
+
+	// tx with same signer, block ref, nonce, dependency and clauses can be replaced by one with higher price
+	old := pool.entry.findReplaceable(signer, tx)
+	if old != nil && !isPriceBumped(old.tx.GasPrice(baseGasPrice), tx.GasPrice(baseGasPrice)) {
+		return rejectedTxErr{"replacement tx underpriced"}
+	}
+
+	dropped, err := pool.entry.replace(old, &txObject{
+		tx:           tx,
+		signer:       signer,
+		overallGP:    new(big.Int),
+		creationTime: time.Now().Unix(),
+		status:       Queued,
+		local:        local,
+	})
+	if err != nil {
+		return err
+	}
+	for _, obj := range dropped {
+		pool.emit(obj.tx, TxDropped)
+	}
 
-		pool.goes.Go(func() { pool.txFeed.Send(tx) })
+	if local && pool.journal != nil {
+		if err := pool.journal.insert(tx); err != nil {
+			log.Warn("failed to journal local tx", "err", err)
+		}
 	}
 
+	pool.goes.Go(func() { pool.txFeed.Send(tx) })
 	return nil
 }
 
 //Remove remove transaction by txID with TransactionCategory
+//TxIncluded event emitted for those already in trunk, and TxDropped for others.
 func (pool *TxPool) Remove(txIDs ...thor.Bytes32) {
 	for _, txID := range txIDs {
-		pool.entry.delete(txID)
+		if obj := pool.entry.delete(txID); obj != nil {
+			kind := TxDropped
+			if included, _ := pool.isAlreadyInChain(txID); included {
+				kind = TxIncluded
+			}
+			pool.emit(obj.tx, kind)
+		}
+	}
+}
+
+// Get returns the tx in pool by ID, or nil if not found.
+func (pool *TxPool) Get(id thor.Bytes32) *tx.Transaction {
+	if obj := pool.entry.find(id); obj != nil {
+		return obj.tx
 	}
+	return nil
 }
 
 //SubscribeNewTransaction receivers will receive a tx
@@ -120,6 +231,22 @@ func (pool *TxPool) SubscribeNewTransaction(ch chan *tx.Transaction) event.Subsc
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
+// SubscribeTxEvent receivers will receive events of tx state changes.
+func (pool *TxPool) SubscribeTxEvent(ch chan *TxEvent) event.Subscription {
+	return pool.scope.Track(pool.eventFeed.Subscribe(ch))
+}
+
+func (pool *TxPool) emit(tx *tx.Transaction, kind TxEventKind) {
+	pool.goes.Go(func() { pool.eventFeed.Send(&TxEvent{tx, kind}) })
+}
+
+// drop deletes tx from pool, and emits TxDropped event if deleted.
+func (pool *TxPool) drop(txID thor.Bytes32) {
+	if obj := pool.entry.delete(txID); obj != nil {
+		pool.emit(obj.tx, TxDropped)
+	}
+}
+
 //Pending return all pending txs
 func (pool *TxPool) Pending(sort bool) tx.Transactions {
 	if pool.entry.isDirty() {
@@ -128,51 +255,107 @@ func (pool *TxPool) Pending(sort bool) tx.Transactions {
 	return pool.entry.dumpPending(sort).parseTxs()
 }
 
-func (pool *TxPool) validateTx(tx *tx.Transaction) (thor.Address, error) {
+// validateTx validates tx, and returns its signer and current base gas price.
+func (pool *TxPool) validateTx(tx *tx.Transaction) (thor.Address, *big.Int, error) {
 	if tx.Size() > maxTxSize {
-		return thor.Address{}, rejectedTxErr{"tx too large"}
+		return thor.Address{}, nil, rejectedTxErr{"tx too large"}
 	}
 
 	if tx.ChainTag() != pool.chain.Tag() {
-		return thor.Address{}, badTxErr{"chain tag mismatched"}
+		return thor.Address{}, nil, badTxErr{"chain tag mismatched"}
 	}
 
 	if tx.HasReservedFields() {
-		return thor.Address{}, badTxErr{"reserved fields not empty"}
+		return thor.Address{}, nil, badTxErr{"reserved fields not empty"}
 	}
 
 	bestBlock := pool.chain.BestBlock()
 
+	// typed tx can't be packed before fork activated
+	if tx.IsTyped() && bestBlock.Header().Number()+1 < pool.forkConfig.DYNFEE {
+		return thor.Address{}, nil, badTxErr{"tx type not activated"}
+	}
+
+	if tx.Features().IsIsolated() && bestBlock.Header().Number()+1 < pool.forkConfig.CLAUSEISO {
+		return thor.Address{}, nil, badTxErr{"tx clause isolation not activated"}
+	}
+
+	if tx.Features().IsDelegated() && bestBlock.Header().Number()+1 < pool.forkConfig.VIP191 {
+		return thor.Address{}, nil, badTxErr{"tx delegation not activated"}
+	}
+
 	if tx.Gas() > bestBlock.Header().GasLimit() {
-		return thor.Address{}, badTxErr{"tx gas exceeded"}
+		return thor.Address{}, nil, badTxErr{"tx gas exceeded"}
 	}
 
 	if tx.IsExpired(bestBlock.Header().Number()) {
-		return thor.Address{}, rejectedTxErr{"tx expired"}
+		return thor.Address{}, nil, rejectedTxErr{"tx expired"}
+	}
+
+	// queued txs are evicted after lifetime, so reject those can't be pending before eviction
+	maxRefAhead := uint64(pool.config.Lifetime/time.Second) / thor.BlockInterval
+	if uint64(tx.BlockRef().Number()) > uint64(bestBlock.Header().Number())+maxRefAhead {
+		return thor.Address{}, nil, rejectedTxErr{"tx block ref too far ahead"}
 	}
 
 	st, err := pool.stateC.NewState(bestBlock.Header().StateRoot())
 	if err != nil {
-		return thor.Address{}, err
+		return thor.Address{}, nil, err
 	}
 
 	resolvedTx, err := runtime.ResolveTransaction(tx)
 	if err != nil {
-		return thor.Address{}, badTxErr{err.Error()}
+		return thor.Address{}, nil, badTxErr{err.Error()}
 	}
 
-	_, _, _, _, err = resolvedTx.BuyGas(st, bestBlock.Header().Timestamp()+thor.BlockInterval)
+	baseGasPrice, _, _, _, err := resolvedTx.BuyGas(st, bestBlock.Header().Timestamp()+thor.BlockInterval)
 	if err != nil {
-		return thor.Address{}, rejectedTxErr{"insufficient energy"}
+		return thor.Address{}, nil, rejectedTxErr{"insufficient energy"}
 	}
 
 	for _, clause := range resolvedTx.Clauses {
 		if clause.Value().Sign() < 0 {
-			return thor.Address{}, badTxErr{"negative clause value"}
+			return thor.Address{}, nil, badTxErr{"negative clause value"}
 		}
 	}
 
-	return resolvedTx.Origin, nil
+	return resolvedTx.Origin, baseGasPrice, nil
+}
+
+// isPriceBumped returns whether new price exceeds old one by at least replacementPriceBump percents.
+func isPriceBumped(oldPrice, newPrice *big.Int) bool {
+	threshold := new(big.Int).Mul(oldPrice, big.NewInt(100+replacementPriceBump))
+	return new(big.Int).Mul(newPrice, big.NewInt(100)).Cmp(threshold) >= 0
+}
+
+// loadJournal re-injects journaled txs, and rewrites journal with those still valid.
+func (pool *TxPool) loadJournal() {
+	total, dropped, err := pool.journal.load(func(trx *tx.Transaction) error {
+		return pool.add(trx, false)
+	})
+	if err != nil {
+		log.Warn("failed to load tx journal", "err", err)
+	}
+	if total > 0 {
+		log.Info("loaded local txs from journal", "total", total, "dropped", dropped)
+	}
+	for _, obj := range pool.entry.dumpAll() {
+		obj.local = true
+	}
+	pool.rotateJournal()
+}
+
+// rotateJournal rewrites journal with local txs currently in pool.
+func (pool *TxPool) rotateJournal() {
+	var locals tx.Transactions
+	for _, obj := range pool.entry.dumpAll() {
+		if obj.local {
+			locals = append(locals, obj.tx)
+		}
+	}
+	if err := pool.journal.rotate(locals); err != nil {
+		log.Warn("failed to rotate tx journal", "err", err)
+	}
 }
 
 func (pool *TxPool) isAlreadyInChain(txID thor.Bytes32) (bool, error) {
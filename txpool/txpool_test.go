@@ -6,9 +6,14 @@
 package txpool
 
 import (
+	"io/ioutil"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/block"
@@ -44,6 +49,7 @@ func TestTxPool(t *testing.T) {
 		t.Fatal(err)
 	}
 	testPending(t, pool, count)
+	assert.Equal(t, txs[0], pool.Get(txID))
 
 	// test pool quota
 	err := pool.Add(generateTxs(t, 1)...)
@@ -52,6 +58,7 @@ func TestTxPool(t *testing.T) {
 	// test remove tx
 	pool.Remove(txID)
 	testPending(t, pool, count-1)
+	assert.Nil(t, pool.Get(txID))
 
 	// test pool quota
 	if err := pool.Add(generateTxs(t, 1)...); err != nil {
@@ -60,6 +67,350 @@ func TestTxPool(t *testing.T) {
 	testPending(t, pool, count)
 }
 
+func TestPoolConfig(t *testing.T) {
+	config := DefaultPoolConfig
+	config.LimitPerAccount = 5
+	pool := initPoolWithConfig(t, config)
+	defer pool.Close()
+
+	if err := pool.Add(generateTxs(t, 5)...); err != nil {
+		t.Fatal(err)
+	}
+	err := pool.Add(generateTxs(t, 1)...)
+	assert.Equal(t, rejectedTxErr{"quota exceeds limit"}, err)
+
+	// intrinsic gas not covered
+	tx1, _ := tx.Sign(new(tx.Builder).Gas(100).Expiration(100).ChainTag(c.Tag()).Build(), genesis.DevAccounts()[1].PrivateKey)
+	assert.True(t, IsBadTx(pool.Add(tx1)))
+}
+
+func TestLifetime(t *testing.T) {
+	config := DefaultPoolConfig
+	config.Lifetime = 100 * time.Second
+	pool := initPoolWithConfig(t, config)
+	defer pool.Close()
+
+	// can't be pending within lifetime
+	tx1, _ := tx.Sign(new(tx.Builder).Gas(21000).Expiration(100).BlockRef(tx.NewBlockRef(100)).ChainTag(c.Tag()).Build(), genesis.DevAccounts()[1].PrivateKey)
+	assert.Equal(t, rejectedTxErr{"tx block ref too far ahead"}, pool.Add(tx1))
+
+	tx2, _ := tx.Sign(new(tx.Builder).Gas(21000).Expiration(100).BlockRef(tx.NewBlockRef(10)).ChainTag(c.Tag()).Build(), genesis.DevAccounts()[1].PrivateKey)
+	assert.Nil(t, pool.Add(tx2))
+}
+
+func TestTxEvent(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	ch := make(chan *TxEvent, 10)
+	sub := pool.SubscribeTxEvent(ch)
+	defer sub.Unsubscribe()
+
+	txs := generateTxs(t, 1)
+	if err := pool.Add(txs...); err != nil {
+		t.Fatal(err)
+	}
+	testPending(t, pool, 1)
+
+	ev := <-ch
+	assert.Equal(t, TxExecutable, ev.Kind)
+	assert.Equal(t, txs[0].ID(), ev.Tx.ID())
+
+	pool.Remove(txs[0].ID())
+	ev = <-ch
+	assert.Equal(t, TxDropped, ev.Kind)
+	assert.Equal(t, txs[0].ID(), ev.Tx.ID())
+}
+
+func TestTxEventEvicted(t *testing.T) {
+	config := DefaultPoolConfig
+	config.PoolSize = 1
+	pool := initPoolWithConfig(t, config)
+	defer pool.Close()
+
+	ch := make(chan *TxEvent, 10)
+	sub := pool.SubscribeTxEvent(ch)
+	defer sub.Unsubscribe()
+
+	txs := generateTxs(t, 2)
+	if err := pool.Add(txs[0]); err != nil {
+		t.Fatal(err)
+	}
+	testPending(t, pool, 1)
+	assert.Equal(t, TxExecutable, (<-ch).Kind)
+
+	// the new one is of the lowest price before being executable
+	if err := pool.Add(txs[1]); err != nil {
+		t.Fatal(err)
+	}
+	ev := <-ch
+	assert.Equal(t, TxDropped, ev.Kind)
+	assert.Equal(t, txs[1].ID(), ev.Tx.ID())
+	assert.Nil(t, pool.Get(txs[1].ID()))
+	assert.Equal(t, txs[0], pool.Get(txs[0].ID()))
+}
+
+func TestReplacement(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	build := func(coef uint8, value int64) *tx.Transaction {
+		to := thor.BytesToAddress([]byte("to"))
+		trx, err := tx.Sign(new(tx.Builder).
+			GasPriceCoef(coef).
+			Gas(21000).
+			Expiration(100).
+			Clause(tx.NewClause(&to).WithValue(big.NewInt(value))).
+			Nonce(100).
+			ChainTag(c.Tag()).
+			Build(), genesis.DevAccounts()[0].PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trx
+	}
+
+	tx1 := build(0, 1)
+	if err := pool.Add(tx1); err != nil {
+		t.Fatal(err)
+	}
+	testPending(t, pool, 1)
+
+	// not enough price bump
+	assert.Equal(t, rejectedTxErr{"replacement tx underpriced"}, pool.Add(build(10, 1)))
+
+	tx2 := build(255, 1)
+	assert.Nil(t, pool.Add(tx2))
+	testPending(t, pool, 1)
+	assert.Equal(t, tx2.ID(), pool.Pending(false)[0].ID())
+	assert.Nil(t, pool.Get(tx1.ID()))
+
+	// same nonce but different clauses, a distinct tx
+	tx3 := build(0, 2)
+	assert.Nil(t, pool.Add(tx3))
+	testPending(t, pool, 2)
+	assert.Equal(t, tx2, pool.Get(tx2.ID()))
+}
+
+func TestReplacementSavedFirst(t *testing.T) {
+	build := func(coef uint8) *tx.Transaction {
+		trx, err := tx.Sign(new(tx.Builder).
+			GasPriceCoef(coef).
+			Gas(21000).
+			Expiration(100).
+			Nonce(100).
+			ChainTag(c.Tag()).
+			Build(), genesis.DevAccounts()[0].PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trx
+	}
+
+	// quota taken by the replaced one is released
+	config := DefaultPoolConfig
+	config.LimitPerAccount = 1
+	pool := initPoolWithConfig(t, config)
+	defer pool.Close()
+
+	tx1 := build(0)
+	if err := pool.Add(tx1); err != nil {
+		t.Fatal(err)
+	}
+	tx2 := build(128)
+	assert.Nil(t, pool.Add(tx2))
+	assert.Nil(t, pool.Get(tx1.ID()))
+	assert.Equal(t, tx2, pool.Get(tx2.ID()))
+
+	// evicted at once by the full pool as not executable yet, the replaced one is kept
+	config = DefaultPoolConfig
+	config.PoolSize = 1
+	pool = initPoolWithConfig(t, config)
+	defer pool.Close()
+
+	tx1 = build(0)
+	if err := pool.Add(tx1); err != nil {
+		t.Fatal(err)
+	}
+	testPending(t, pool, 1)
+	tx2 = build(128)
+	assert.Equal(t, rejectedTxErr{"replacement tx evicted by full pool"}, pool.Add(tx2))
+	assert.Nil(t, pool.Get(tx2.ID()))
+	assert.Equal(t, tx1, pool.Get(tx1.ID()))
+}
+
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "txpool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := DefaultPoolConfig
+	config.Journal = filepath.Join(dir, "journal")
+	pool := initPoolWithConfig(t, config)
+
+	locals := generateTxs(t, 2)
+	if err := pool.AddLocal(locals...); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Add(generateTxs(t, 1)...); err != nil {
+		t.Fatal(err)
+	}
+	pool.Close()
+
+	// restart
+	pool = New(c, pool.stateC, config, thor.NoFork)
+	defer pool.Close()
+
+	testPending(t, pool, 2)
+	for _, trx := range locals {
+		assert.NotNil(t, pool.entry.find(trx.ID()))
+	}
+}
+
+func TestContent(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	txs := generateTxs(t, 3)
+	if err := pool.Add(txs...); err != nil {
+		t.Fatal(err)
+	}
+	signer := thor.Address(crypto.PubkeyToAddress(genesis.DevAccounts()[0].PrivateKey.PublicKey))
+
+	pending, queued := pool.Content()
+	assert.Equal(t, 3, len(pending[signer]))
+	assert.Equal(t, 0, len(queued))
+
+	assert.Equal(t, &Stats{Pending: 3, Signers: 1}, pool.Stats())
+
+	pendingSummaries, _ := pool.Inspect()
+	assert.Equal(t, 3, len(pendingSummaries[signer]))
+	for _, s := range pendingSummaries[signer] {
+		assert.Equal(t, uint64(1000000), s.Gas)
+		assert.Equal(t, uint32(100), s.Expiration)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	pendingTx := generateTxs(t, 1)[0]
+	address := thor.BytesToAddress([]byte("addr"))
+	queuedTx, _ := tx.Sign(new(tx.Builder).
+		Gas(1000000).
+		Expiration(100).
+		Clause(tx.NewClause(&address)).
+		Nonce(uint64(nonce)).
+		DependsOn(&thor.Bytes32{1}).
+		ChainTag(c.Tag()).
+		Build(), genesis.DevAccounts()[0].PrivateKey)
+	nonce++
+	if err := pool.Add(pendingTx, queuedTx); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, pending := pool.Lookup(pendingTx.ID())
+	assert.True(t, pending)
+	assert.Equal(t, pendingTx.ID(), summary.ID)
+	assert.Equal(t, "", summary.QueuedReason)
+	assert.NotZero(t, summary.ArrivalTime)
+
+	summary, pending = pool.Lookup(queuedTx.ID())
+	assert.False(t, pending)
+	assert.Equal(t, "depended tx not packed yet", summary.QueuedReason)
+
+	summary, _ = pool.Lookup(thor.Bytes32{2})
+	assert.Nil(t, summary)
+}
+
+func TestFilters(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	signer := thor.Address(crypto.PubkeyToAddress(genesis.DevAccounts()[0].PrivateKey.PublicKey))
+
+	pool.AddFilter(DenySenders(signer))
+	err := pool.Add(generateTxs(t, 1)...)
+	assert.True(t, IsRejectedTx(err))
+	assert.Equal(t, rejectedTxErr{"policy: sender denied"}, err)
+
+	// registered by config
+	config := DefaultPoolConfig
+	config.Filters = []Filter{MinGasPrice(math.MaxBig256)}
+	pool = initPoolWithConfig(t, config)
+	defer pool.Close()
+	assert.True(t, IsRejectedTx(pool.Add(generateTxs(t, 1)...)))
+
+	pool = initPool(t)
+	defer pool.Close()
+	pool.AddFilter(AllowSenders(signer))
+	pool.AddFilter(MaxClauseDataSize(0))
+	assert.Nil(t, pool.Add(generateTxs(t, 1)...))
+
+	creation := func() *tx.Transaction {
+		trx, _ := tx.Sign(new(tx.Builder).
+			Gas(1000000).
+			Expiration(100).
+			Clause(tx.NewClause(nil)).
+			Nonce(uint64(nonce)).
+			ChainTag(c.Tag()).
+			Build(), genesis.DevAccounts()[0].PrivateKey)
+		nonce++
+		return trx
+	}
+	pool.AddFilter(ThrottleContractCreation(1, time.Hour))
+	assert.Nil(t, pool.Add(creation()))
+	assert.True(t, IsRejectedTx(pool.Add(creation())))
+}
+
+func TestChainTagMismatch(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	tx1 := new(tx.Builder).
+		Gas(21000).
+		Expiration(100).
+		ChainTag(c.Tag() + 1).
+		Build()
+	tx1, err := tx.Sign(tx1, genesis.DevAccounts()[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = pool.Add(tx1)
+	assert.True(t, IsBadTx(err))
+	assert.Equal(t, badTxErr{"chain tag mismatched"}, err)
+}
+
+func TestDelegationFork(t *testing.T) {
+	pool := initPool(t)
+	defer pool.Close()
+
+	var features tx.Features
+	features.SetDelegated(true)
+	tx1 := new(tx.Builder).
+		Gas(21000).
+		Expiration(100).
+		ChainTag(c.Tag()).
+		Features(features).
+		Build()
+	tx1, err := tx.SignDelegated(tx1, genesis.DevAccounts()[0].PrivateKey, genesis.DevAccounts()[1].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the block added by initPool has no score, so the best block is still genesis,
+	// and txs are to be packed into #1
+	assert.Equal(t, uint32(0), c.BestBlock().Header().Number())
+	pool.forkConfig.VIP191 = 2
+	assert.Equal(t, badTxErr{"tx delegation not activated"}, pool.Add(tx1))
+
+	pool.forkConfig.VIP191 = 1
+	assert.Nil(t, pool.Add(tx1))
+}
+
 func testPending(t *testing.T, pool *TxPool, count int) {
 	txs := pool.Pending(true)
 	assert.Equal(t, len(txs), count)
@@ -89,6 +440,10 @@ func generateTxs(t *testing.T, count int) tx.Transactions {
 }
 
 func initPool(t *testing.T) *TxPool {
+	return initPoolWithConfig(t, DefaultPoolConfig)
+}
+
+func initPoolWithConfig(t *testing.T, config PoolConfig) *TxPool {
 	db, _ := lvldb.NewMem()
 	stateC := state.NewCreator(db)
 	gen, err := genesis.NewDevnet()
@@ -108,5 +463,5 @@ func initPool(t *testing.T) *TxPool {
 	if _, err := c.AddBlock(blk, nil); err != nil {
 		t.Fatal(err)
 	}
-	return New(c, stateC)
+	return New(c, stateC, config, thor.NoFork)
 }
@@ -8,22 +8,31 @@ package txpool
 import (
 	"time"
 
-	"github.com/inconshreveable/log15"
 	"github.com/vechain/thor/block"
 	"github.com/vechain/thor/builtin"
 	"github.com/vechain/thor/thor"
 )
 
+// journal is regenerated periodically to drop txs no longer in pool
+const journalRotateInterval = time.Hour
+
 func (pool *TxPool) updateLoop() {
 	var (
-		bestBlock = pool.chain.BestBlock()
-		ticker    = time.NewTicker(time.Second)
+		bestBlock     = pool.chain.BestBlock()
+		ticker        = time.NewTicker(time.Second)
+		journalTicker = time.NewTicker(journalRotateInterval)
 	)
+	defer ticker.Stop()
+	defer journalTicker.Stop()
 
 	for {
 		select {
 		case <-pool.done:
 			return
+		case <-journalTicker.C:
+			if pool.journal != nil {
+				pool.rotateJournal()
+			}
 		case <-ticker.C:
 			currentBestBlock := pool.chain.BestBlock()
 			if currentBestBlock.Header().ID() == bestBlock.Header().ID() {
@@ -36,13 +45,12 @@ func (pool *TxPool) updateLoop() {
 }
 
 func (pool *TxPool) updateData(bestBlock *block.Block) {
-	log := log15.New("txpool", pool)
 	allObjs := pool.entry.dumpAll()
 	pending := make(txObjects, 0, len(allObjs))
 
 	st, err := pool.stateC.NewState(bestBlock.Header().StateRoot())
 	if err != nil {
-		log.Error("err", err)
+		log.Error("failed to create state", "err", err)
 		return
 	}
 
@@ -50,32 +58,44 @@ func (pool *TxPool) updateData(bestBlock *block.Block) {
 	bestBlockNum := bestBlock.Header().Number()
 	bestBlockID := bestBlock.Header().ID()
 
+	var queued int
 	//can be pendinged txObjects
 	for _, obj := range allObjs {
-		if obj.tx.IsExpired(bestBlockNum) || time.Now().Unix()-obj.creationTime > int64(pool.config.Lifetime) {
-			pool.entry.delete(obj.tx.ID())
+		if obj.tx.IsExpired(bestBlockNum) || time.Now().Unix()-obj.creationTime > int64(pool.config.Lifetime/time.Second) {
+			pool.drop(obj.tx.ID())
 			continue
 		}
 
 		repeatedTx, err := pool.isAlreadyInChain(obj.tx.ID())
 		if err != nil {
-			log.Error("err", err)
+			log.Error("failed to check tx in chain", "err", err)
 			continue
 		}
 		if repeatedTx {
-			pool.entry.delete(obj.tx.ID())
+			if pool.entry.delete(obj.tx.ID()) != nil {
+				pool.emit(obj.tx, TxIncluded)
+			}
 			continue
 		}
 
 		if obj.status == Queued {
-			state := obj.currentState(pool.chain, bestBlockNum)
+			state, err := obj.currentState(pool.chain, bestBlockNum)
+			if err != nil {
+				pool.drop(obj.tx.ID())
+				continue
+			}
 			if state != Pending {
+				queued++
 				continue
 			}
 
 			obj.status = state
 			obj.overallGP = obj.tx.OverallGasPrice(baseGasPrice, bestBlockNum, pool.chain.NewSeeker(bestBlockID).GetID)
-			pool.entry.save(obj)
+			dropped, _ := pool.entry.save(obj)
+			for _, obj := range dropped {
+				pool.emit(obj.tx, TxDropped)
+			}
+			pool.emit(obj.tx, TxExecutable)
 		}
 
 		if obj.status == Pending {
@@ -84,4 +104,6 @@ func (pool *TxPool) updateData(bestBlock *block.Block) {
 	}
 
 	pool.entry.cachePending(pending)
+	pendingGauge.Set(float64(len(pending)))
+	queuedGauge.Set(float64(queued))
 }
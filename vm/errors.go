@@ -25,4 +25,7 @@ var (
 	ErrTraceLimitReached        = errors.New("the number of logs reached the specified limit")
 	ErrInsufficientBalance      = errors.New("insufficient balance for transfer")
 	ErrContractAddressCollision = errors.New("contract address collision")
+
+	// ErrExecutionReverted returned when REVERT executed, and remaining gas is kept.
+	ErrExecutionReverted = errExecutionReverted
 )
@@ -47,16 +47,31 @@ type (
 
 	// OnSuicideContractFunc callback when suicide contract.
 	OnSuicideContractFunc func(evm *EVM, contractAddr common.Address, tokenReceiver common.Address)
+
+	// GetPrecompiledContractFunc returns additional precompiled contract at the given address, or nil if none.
+	GetPrecompiledContractFunc func(evm *EVM, addr common.Address) PrecompiledContract
 )
 
+// precompile returns the precompiled contract at addr.
+// Extra precompiled contracts are looked up if it's not a standard one.
+func (evm *EVM) precompile(addr common.Address) PrecompiledContract {
+	precompiles := PrecompiledContractsHomestead
+	if evm.ChainConfig().IsByzantium(evm.BlockNumber) {
+		precompiles = PrecompiledContractsByzantium
+	}
+	if p := precompiles[addr]; p != nil {
+		return p
+	}
+	if evm.GetPrecompiledContract != nil {
+		return evm.GetPrecompiledContract(evm, addr)
+	}
+	return nil
+}
+
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(evm *EVM, contract *Contract, input []byte) ([]byte, error) {
 	if contract.CodeAddr != nil {
-		precompiles := PrecompiledContractsHomestead
-		if evm.ChainConfig().IsByzantium(evm.BlockNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if p := precompiles[*contract.CodeAddr]; p != nil {
+		if p := evm.precompile(*contract.CodeAddr); p != nil {
 			return RunPrecompiledContract(p, input, contract)
 		}
 	}
@@ -79,6 +94,8 @@ type Context struct {
 	OnCreateContract      OnCreateContractFunc
 	OnSuicideContract     OnSuicideContractFunc
 
+	GetPrecompiledContract GetPrecompiledContractFunc
+
 	// Message information
 	Origin   common.Address // Provides information for ORIGIN
 	GasPrice *big.Int       // Provides information for GASPRICE
@@ -180,11 +197,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		snapshot = evm.StateDB.Snapshot()
 	)
 	if !evm.StateDB.Exist(addr) {
-		precompiles := PrecompiledContractsHomestead
-		if evm.ChainConfig().IsByzantium(evm.BlockNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if precompiles[addr] == nil && evm.ChainConfig().IsEIP158(evm.BlockNumber) && value.Sign() == 0 {
+		if evm.precompile(addr) == nil && evm.ChainConfig().IsEIP158(evm.BlockNumber) && value.Sign() == 0 {
 			// Calling a non existing account, don't do antything, but ping the tracer
 			if evm.vmConfig.Debug && evm.depth == 0 {
 				evm.vmConfig.Tracer.CaptureStart(caller.Address(), addr, false, input, gas, value)